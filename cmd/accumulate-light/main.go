@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"os/user"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/internal/logging"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/lightclient"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage/badger"
+)
+
+var cmd = &cobra.Command{
+	Use:   "accumulate-light",
+	Short: "Accumulate light client daemon",
+	Long: `Follows the directory network's anchors and validator set updates, and
+answers whether receipts are rooted in a trusted anchor over JSON-RPC.
+
+Directory anchors are only trusted if they are signed by the threshold of
+active directory validators. The first run requires a validator set you trust,
+either a checkpoint exported by another light client with --checkpoint, or a
+network definition and network globals with --network and --globals. The
+validator set is not queried from the server.`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var flag = struct {
+	Server       string
+	WorkDir      string
+	Listen       string
+	Network      string
+	Globals      string
+	Checkpoint   string
+	Export       bool
+	PollInterval time.Duration
+	Log          string
+}{}
+
+func init() {
+	usr, err := user.Current()
+	check(err)
+
+	cmd.Flags().StringVarP(&flag.Server, "server", "s", "", "The Accumulate API server to follow")
+	cmd.Flags().StringVarP(&flag.WorkDir, "work-dir", "w", filepath.Join(usr.HomeDir, ".accumulate", "light"), "Directory the anchor history is stored in")
+	cmd.Flags().StringVarP(&flag.Listen, "listen", "l", "127.0.0.1:26700", "Address to serve the JSON-RPC API on")
+	cmd.Flags().StringVar(&flag.Network, "network", "", "A JSON file containing the trusted network definition")
+	cmd.Flags().StringVar(&flag.Globals, "globals", "", "A JSON file containing the trusted network globals")
+	cmd.Flags().StringVar(&flag.Checkpoint, "checkpoint", "", "A JSON file containing a trusted checkpoint")
+	cmd.Flags().BoolVar(&flag.Export, "export-checkpoint", false, "Print a checkpoint of the stored validator set and latest anchor, and exit")
+	cmd.Flags().DurationVar(&flag.PollInterval, "poll-interval", 5*time.Second, "How often to check for new directory blocks")
+	cmd.Flags().StringVar(&flag.Log, "log", "info", "Log levels")
+}
+
+func main() { _ = cmd.Execute() }
+
+func run(*cobra.Command, []string) {
+	logw, err := logging.NewConsoleWriter("plain")
+	check(err)
+	level, writer, err := logging.ParseLogLevel(flag.Log, logw)
+	checkf(err, "--log")
+	logger, err := logging.NewTendermintLogger(zerolog.New(writer), level, false)
+	check(err)
+
+	node, err := client.New(flag.Server)
+	checkf(err, "--server")
+
+	check(os.MkdirAll(flag.WorkDir, 0700))
+	db, err := badger.New(filepath.Join(flag.WorkDir, "anchors.db"), logger.With("module", "storage"))
+	check(err)
+	defer func() { _ = db.Close() }()
+
+	if flag.Export {
+		checkpoint, err := lightclient.NewStore(db).Checkpoint()
+		check(err)
+		if checkpoint == nil {
+			fatalf("the store is empty")
+		}
+		data, err := json.MarshalIndent(checkpoint, "", "  ")
+		check(err)
+		fmt.Println(string(data))
+		return
+	}
+
+	lc := lightclient.New()
+	syncer := &lightclient.Syncer{
+		Client:       lc,
+		Store:        lightclient.NewStore(db),
+		Node:         node,
+		Logger:       logger.With("module", "sync"),
+		PollInterval: flag.PollInterval,
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// The trusted checkpoint is only used if the store is empty
+	var checkpoint *lightclient.Checkpoint
+	switch {
+	case flag.Checkpoint != "":
+		checkpoint = new(lightclient.Checkpoint)
+		checkf(readJSON(flag.Checkpoint, checkpoint), "--checkpoint")
+	case flag.Network != "" || flag.Globals != "":
+		checkpoint = new(lightclient.Checkpoint)
+		checkpoint.Network = new(protocol.NetworkDefinition)
+		checkpoint.Globals = new(protocol.NetworkGlobals)
+		checkf(readJSON(flag.Network, checkpoint.Network), "--network")
+		checkf(readJSON(flag.Globals, checkpoint.Globals), "--globals")
+	}
+	err = syncer.Bootstrap(checkpoint)
+	if errors.Is(err, lightclient.ErrNoCheckpoint) {
+		fatalf("%v, specify --checkpoint or --network and --globals", err)
+	}
+	check(err)
+
+	l, err := net.Listen("tcp", flag.Listen)
+	checkf(err, "--listen")
+	server := &http.Server{Handler: lightclient.NewHandler(lc)}
+	go func() {
+		err := server.Serve(l)
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("JSON-RPC server stopped", "error", err)
+			cancel()
+		}
+	}()
+	logger.Info("Serving the JSON-RPC API", "address", l.Addr())
+
+	syncer.Run(ctx)
+	_ = server.Shutdown(context.Background())
+}
+
+func readJSON(file string, v interface{}) error {
+	if file == "" {
+		return fmt.Errorf("--network and --globals must be specified together")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
+	os.Exit(1)
+}
+
+func check(err error) {
+	if err != nil {
+		fatalf("%v", err)
+	}
+}
+
+func checkf(err error, format string, otherArgs ...interface{}) {
+	if err != nil {
+		fatalf(format+": %v", append(otherArgs, err)...)
+	}
+}
@@ -394,7 +394,7 @@ func ListAccounts() (string, error) {
 	}
 	for i, v := range b.KeyValueList {
 		k := new(walletd.Key)
-		err = k.LoadByLabel(string(v.Value))
+		err = k.LoadByLabel(walletd.GetWallet(), string(v.Value))
 		if err != nil {
 			return "", err
 		}
@@ -507,7 +507,7 @@ func ExportAccounts(filePath string) error {
 	//var res []*KeyResponse
 	for i := range b.KeyValueList {
 		k := new(walletd.Key)
-		err = k.LoadByPublicKey(b.KeyValueList[i].Value)
+		err = k.LoadByPublicKey(walletd.GetWallet(), b.KeyValueList[i].Value)
 		if err != nil {
 			log.Printf("cannot load key by label %s with public key %x, %v", b.KeyValueList[i].Key, b.KeyValueList[i].Value, err)
 			continue
@@ -551,7 +551,7 @@ func ExportAccounts(filePath string) error {
 		}
 	}
 
-	kl, err := walletd.GetKeyList()
+	kl, err := walletd.GetKeyList(walletd.GetWallet())
 	if err != nil {
 		log.Println(err)
 	}
@@ -585,8 +585,8 @@ func ExportAccounts(filePath string) error {
 				//these are the sig types
 				dc := api2.DerivationCount{}
 				dc.Count = uint64(binary.LittleEndian.Uint32(v.Value))
-				var found bool
-				dc.Type, found = protocol.SignatureTypeByName(string(v.Key))
+				typ, account, found := walletd.ParseDerivationCounterKey(v.Key)
+				dc.Type, dc.Account = typ, uint64(account)
 				if found {
 					res.SeedInfo.Derivations = append(res.SeedInfo.Derivations, dc)
 				}
@@ -654,7 +654,7 @@ func ImportAccounts(filePath string) error {
 	for _, v := range req.SeedInfo.Derivations {
 		var b [4]byte
 		binary.LittleEndian.PutUint32(b[:], uint32(v.Count))
-		err = walletd.GetWallet().Put(walletd.BucketMnemonic, []byte(v.Type.String()), b[:])
+		err = walletd.GetWallet().Put(walletd.BucketMnemonic, walletd.DerivationCounterKey(v.Type, uint32(v.Account)), b[:])
 		if err != nil {
 			log.Printf("failed to set derivation counter for %s", v.Type.String())
 		}
@@ -703,7 +703,7 @@ func ImportAccounts(filePath string) error {
 			continue
 		}
 
-		k, err := walletd.LookupByLabel(adi.Pages[0].KeyNames[0])
+		k, err := walletd.LookupByLabel(walletd.GetWallet(), adi.Pages[0].KeyNames[0])
 		if err != nil {
 			log.Printf("skipping adi import, %s, cannot find key for name %s", adi.Url.String(), adi.Pages[0].KeyNames[0])
 			continue
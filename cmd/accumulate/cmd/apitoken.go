@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+var walletApiTokenCmd = &cobra.Command{
+	Use:   "api-token",
+	Short: "Manage the tokens that authorize access to the wallet daemon API",
+}
+
+var walletApiTokenCreateCmd = &cobra.Command{
+	Use:   "create [name] --permission read|sign|admin --signer [url]... --principal [url]... --limit [token url]=[amount]...",
+	Short: "Create an API token, the secret is only printed once",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCmdFunc(CreateApiToken),
+}
+
+var walletApiTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the API tokens",
+	Args:  cobra.NoArgs,
+	Run:   runCmdFunc(ListApiTokens),
+}
+
+var walletApiTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke [name]",
+	Short: "Revoke an API token",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCmdFunc(RevokeApiToken),
+}
+
+var flagApiToken = struct {
+	Permission string
+	Signers    []string
+	Principals []string
+	Limits     []string
+}{}
+
+func init() {
+	walletApiTokenCreateCmd.Flags().StringVar(&flagApiToken.Permission, "permission", "read", "The highest permission of the token: read, sign, or admin")
+	walletApiTokenCreateCmd.Flags().StringSliceVar(&flagApiToken.Signers, "signer", nil, "Restrict signing to the given signer")
+	walletApiTokenCreateCmd.Flags().StringSliceVar(&flagApiToken.Principals, "principal", nil, "Restrict transactions to the given principal")
+	walletApiTokenCreateCmd.Flags().StringSliceVar(&flagApiToken.Limits, "limit", nil, "Limit the amount of a token that can be spent per day, e.g. ACME=100")
+	walletApiTokenCmd.AddCommand(walletApiTokenCreateCmd, walletApiTokenListCmd, walletApiTokenRevokeCmd)
+	walletCmd.AddCommand(walletApiTokenCmd)
+}
+
+func CreateApiToken(args []string) (string, error) {
+	token := new(api.ApiToken)
+	token.Name = args[0]
+
+	var ok bool
+	token.Permission, ok = api.ApiPermissionByName(flagApiToken.Permission)
+	if !ok {
+		return "", fmt.Errorf("invalid permission %q", flagApiToken.Permission)
+	}
+
+	for _, s := range flagApiToken.Signers {
+		u, err := resolveAddress(s)
+		if err != nil {
+			return "", err
+		}
+		token.Signers = append(token.Signers, u)
+	}
+	for _, s := range flagApiToken.Principals {
+		u, err := resolveAddress(s)
+		if err != nil {
+			return "", err
+		}
+		token.Principals = append(token.Principals, u)
+	}
+	for _, s := range flagApiToken.Limits {
+		limit, err := parseTokenAmount(s)
+		if err != nil {
+			return "", err
+		}
+		token.DailyLimits = append(token.DailyLimits, limit)
+	}
+
+	secret, err := walletd.CreateApiToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	if WantJsonOutput {
+		data, err := json.Marshal(map[string]string{"name": token.Name, "secret": secret})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return fmt.Sprintf("Created API token %q with %v permission\n\tsecret:\t%s\n\nThe secret cannot be shown again. Send it as \"Authorization: Bearer <secret>\".\n", token.Name, token.Permission, secret), nil
+}
+
+func ListApiTokens([]string) (string, error) {
+	tokens, err := walletd.ListApiTokens()
+	if err != nil {
+		return "", err
+	}
+
+	if WantJsonOutput {
+		data, err := json.Marshal(tokens)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var out string
+	for _, token := range tokens {
+		out += fmt.Sprintf("\t%s\t%v\n", token.Name, token.Permission)
+		for _, u := range token.Signers {
+			out += fmt.Sprintf("\t\tsigner:\t%v\n", u)
+		}
+		for _, u := range token.Principals {
+			out += fmt.Sprintf("\t\tprincipal:\t%v\n", u)
+		}
+		for _, limit := range token.DailyLimits {
+			out += fmt.Sprintf("\t\tdaily limit:\t%s %v\n", limit.Amount.String(), limit.Token)
+		}
+	}
+	return out, nil
+}
+
+func RevokeApiToken(args []string) (string, error) {
+	err := walletd.RevokeApiToken(args[0])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Revoked API token %q\n", args[0]), nil
+}
+
+// parseTokenAmount parses [token url]=[amount] into a token amount, using the
+// precision of the token.
+func parseTokenAmount(s string) (*api.TokenAmount, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid limit %q, want [token url]=[amount]", s)
+	}
+
+	token, err := url.Parse(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	limit := &api.TokenAmount{Token: token}
+	if protocol.AcmeUrl().Equal(token) {
+		amount, err := parseAmount(parts[1], protocol.AcmePrecisionPower)
+		if err != nil {
+			return nil, err
+		}
+		limit.Amount = *amount
+		return limit, nil
+	}
+
+	amount, err := amountToBigInt(token.String(), parts[1])
+	if err != nil {
+		return nil, err
+	}
+	limit.Amount = *amount
+	return limit, nil
+}
@@ -0,0 +1,465 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch [plan file]",
+	Short: "Execute a YAML or JSON plan of commands",
+	Long: `Execute a YAML or JSON plan of commands in a single process. For example:
+
+  vars:
+    lite: acc://<lite token account>
+    adi: acc://example.acme
+  wait: 1m
+  steps:
+    - id: credits
+      command: credits
+      args: ["${lite}", "${lite}", "1000"]
+    - id: adi
+      command: adi create
+      args: ["${lite}", "${adi}", "key1"]
+      needs: [credits]
+    - id: tokens
+      command: account create token
+      args: ["${adi}", "key1", "${adi}/tokens", "acc://ACME"]
+      needs: [adi]
+
+Each step runs a command of this CLI. Arguments may reference variables with
+${name} and the JSON output of an earlier step with ${steps.<id>.<field>},
+which makes the step depend on the earlier step. Steps run in dependency
+order, and each step waits for its transaction and the synthetic transactions
+it produces. A step is skipped if a step it depends on fails.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runCmdFunc2(RunBatch),
+}
+
+// batchPlan is a plan of commands to execute.
+type batchPlan struct {
+	Vars  map[string]string `json:"vars,omitempty"`
+	Wait  string            `json:"wait,omitempty"`
+	Steps []*batchStep      `json:"steps"`
+}
+
+// batchStep is a command of a plan.
+type batchStep struct {
+	ID      string   `json:"id"`
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	Needs   []string `json:"needs,omitempty"`
+	Wait    string   `json:"wait,omitempty"`
+}
+
+// batchResult is the result of a step.
+type batchResult struct {
+	ID      string        `json:"id"`
+	Command string        `json:"command"`
+	Args    []string      `json:"args,omitempty"`
+	Status  string        `json:"status"`
+	Output  interface{}   `json:"output,omitempty"`
+	Results []interface{} `json:"results,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+const (
+	batchStatusOk      = "ok"
+	batchStatusFailed  = "failed"
+	batchStatusSkipped = "skipped"
+)
+
+var batchReference = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+func RunBatch(cmd *cobra.Command, args []string) (string, error) {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	plan := new(batchPlan)
+	err = yaml.Unmarshal(data, plan)
+	if err != nil {
+		return "", fmt.Errorf("invalid plan: %w", err)
+	}
+
+	steps, err := plan.order()
+	if err != nil {
+		return "", err
+	}
+
+	defaultWait := time.Minute
+	if plan.Wait != "" {
+		defaultWait, err = time.ParseDuration(plan.Wait)
+		if err != nil {
+			return "", fmt.Errorf("invalid wait: %w", err)
+		}
+	}
+
+	// Query each signer once, and invalidate the cache when a step submits a
+	// transaction, since the transaction may change a key page
+	signerCache = map[string]*cachedSigner{}
+	defer func() { signerCache = nil }()
+	batchSubmitted = false
+
+	wantJson := WantJsonOutput
+	results := map[string]*batchResult{}
+	var ordered []*batchResult
+	var failed bool
+	for _, step := range steps {
+		result := plan.run(cmd.Root(), step, results, defaultWait)
+		results[step.ID] = result
+		ordered = append(ordered, result)
+
+		if result.Status != batchStatusOk {
+			failed = true
+		}
+		if result.Status == batchStatusFailed || batchSubmitted {
+			signerCache = map[string]*cachedSigner{}
+			batchSubmitted = false
+		}
+		if !wantJson {
+			cmd.Printf("\t%s\t%s\n", result.ID, result.Status)
+			if result.Error != "" {
+				cmd.Printf("\t\t%s\n", result.Error)
+			}
+		}
+	}
+
+	var out string
+	if wantJson {
+		data, err := json.Marshal(ordered)
+		if err != nil {
+			return "", err
+		}
+		out = string(data)
+	}
+	if failed {
+		return out, fmt.Errorf("one or more steps failed")
+	}
+	return out, nil
+}
+
+// order validates the steps and sorts them in dependency order. Steps that do
+// not depend on each other keep the order of the plan.
+func (p *batchPlan) order() ([]*batchStep, error) {
+	index := map[string]int{}
+	for i, step := range p.Steps {
+		if step.ID == "" {
+			step.ID = fmt.Sprintf("step%d", i+1)
+		}
+		if _, ok := index[step.ID]; ok {
+			return nil, fmt.Errorf("duplicate step %q", step.ID)
+		}
+		if step.Command == "" {
+			return nil, fmt.Errorf("step %q is missing the command", step.ID)
+		}
+		index[step.ID] = i
+	}
+
+	deps := make([][]int, len(p.Steps))
+	for i, step := range p.Steps {
+		needs := append([]string{}, step.Needs...)
+		for _, arg := range step.Args {
+			for _, match := range batchReference.FindAllStringSubmatch(arg, -1) {
+				parts := strings.SplitN(match[1], ".", 3)
+				if parts[0] == "steps" && len(parts) > 1 {
+					needs = append(needs, parts[1])
+				}
+			}
+		}
+		for _, id := range needs {
+			j, ok := index[id]
+			if !ok {
+				return nil, fmt.Errorf("step %q depends on unknown step %q", step.ID, id)
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+
+	done := make([]bool, len(p.Steps))
+	var steps []*batchStep
+	for len(steps) < len(p.Steps) {
+		next := -1
+		for i := range p.Steps {
+			if done[i] {
+				continue
+			}
+			ready := true
+			for _, j := range deps[i] {
+				ready = ready && done[j]
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("the steps have a dependency cycle")
+		}
+		done[next] = true
+		steps = append(steps, p.Steps[next])
+	}
+	return steps, nil
+}
+
+// run executes a step, unless a step it depends on did not succeed.
+func (p *batchPlan) run(root *cobra.Command, step *batchStep, results map[string]*batchResult, defaultWait time.Duration) *batchResult {
+	result := &batchResult{ID: step.ID, Command: step.Command}
+	fail := func(err error) *batchResult {
+		result.Status = batchStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+	skip := func(id string) *batchResult {
+		result.Status = batchStatusSkipped
+		result.Error = fmt.Sprintf("step %q did not succeed", id)
+		return result
+	}
+
+	for _, id := range step.Needs {
+		if results[id].Status != batchStatusOk {
+			return skip(id)
+		}
+	}
+
+	for _, arg := range step.Args {
+		arg, err := p.expand(arg, results)
+		if err != nil {
+			var skipped *batchSkipError
+			if errors.As(err, &skipped) {
+				return skip(skipped.step)
+			}
+			return fail(err)
+		}
+		result.Args = append(result.Args, arg)
+	}
+
+	wait := defaultWait
+	if step.Wait != "" {
+		var err error
+		wait, err = time.ParseDuration(step.Wait)
+		if err != nil {
+			return fail(fmt.Errorf("invalid wait: %w", err))
+		}
+	}
+
+	out, err := executeBatchCommand(root, append(strings.Fields(step.Command), result.Args...), wait)
+	if err != nil {
+		return fail(err)
+	}
+
+	// Commands print their result followed by the results of the
+	// transactions they waited for
+	dec := json.NewDecoder(strings.NewReader(out))
+	for {
+		var v interface{}
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			result.Output = out
+			break
+		}
+		if result.Output == nil {
+			result.Output = v
+		} else {
+			result.Results = append(result.Results, v)
+		}
+	}
+
+	result.Status = batchStatusOk
+	return result
+}
+
+// batchSkipError is returned when an argument references the output of a
+// step that did not succeed.
+type batchSkipError struct{ step string }
+
+func (e *batchSkipError) Error() string { return fmt.Sprintf("step %q did not succeed", e.step) }
+
+// expand replaces the references of the argument with the values of
+// variables and the outputs of steps.
+func (p *batchPlan) expand(arg string, results map[string]*batchResult) (string, error) {
+	var err error
+	expanded := batchReference.ReplaceAllStringFunc(arg, func(match string) string {
+		name := match[2 : len(match)-1]
+		parts := strings.Split(name, ".")
+		if parts[0] != "steps" {
+			v, ok := p.Vars[name]
+			if !ok && err == nil {
+				err = fmt.Errorf("unknown variable %q", name)
+			}
+			return v
+		}
+
+		if len(parts) < 3 {
+			if err == nil {
+				err = fmt.Errorf("invalid reference %q, want steps.<id>.<field>", name)
+			}
+			return ""
+		}
+		result := results[parts[1]]
+		if result.Status != batchStatusOk {
+			if err == nil {
+				err = &batchSkipError{parts[1]}
+			}
+			return ""
+		}
+
+		var v interface{} = result.Output
+		for _, field := range parts[2:] {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				v = nil
+				break
+			}
+			v = m[field]
+		}
+		switch v := v.(type) {
+		case nil:
+			if err == nil {
+				err = fmt.Errorf("step %q has no output %q", parts[1], strings.Join(parts[2:], "."))
+			}
+			return ""
+		case string:
+			return v
+		default:
+			data, _ := json.Marshal(v)
+			return string(data)
+		}
+	})
+	return expanded, err
+}
+
+// executeBatchCommand runs a command of the CLI in this process with JSON
+// output, and returns its output.
+func executeBatchCommand(root *cobra.Command, args []string, wait time.Duration) (string, error) {
+	cmd, args, err := root.Find(args)
+	if err != nil {
+		return "", err
+	}
+	if cmd == root || cmd.Parent() == root && cmd.Name() == "batch" {
+		return "", fmt.Errorf("%q is not a command that can be batched", cmd.CommandPath())
+	}
+	if cmd.Run == nil {
+		return "", fmt.Errorf("%q is not runnable", cmd.CommandPath())
+	}
+
+	// Merge the persistent flags so they are included in the snapshot
+	cmd.InheritedFlags()
+	defer restoreFlags(cmd.Flags(), snapshotFlags(cmd.Flags()))
+	err = cmd.ParseFlags(args)
+	if err != nil {
+		return "", err
+	}
+	args = cmd.Flags().Args()
+	err = cmd.ValidateArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	defer cmd.SetOut(nil)
+	defer cmd.SetErr(nil)
+
+	wantJson, txWait := WantJsonOutput, TxWait
+	defer func() { WantJsonOutput, TxWait, DidError = wantJson, txWait, nil }()
+	WantJsonOutput, TxWait, DidError = true, wait, nil
+
+	err = runBatchHooks(cmd, args)
+	if err != nil {
+		return "", err
+	}
+	if DidError != nil {
+		return "", DidError
+	}
+	return buf.String(), nil
+}
+
+// runBatchHooks runs the command and its pre- and post-run hooks the way
+// cobra does. Persistent post-run hooks are not run since the root command's
+// exits the process if the command failed.
+func runBatchHooks(cmd *cobra.Command, args []string) error {
+	for p := cmd; p != nil; p = p.Parent() {
+		if p.PersistentPreRunE != nil {
+			err := p.PersistentPreRunE(cmd, args)
+			if err != nil {
+				return err
+			}
+			break
+		} else if p.PersistentPreRun != nil {
+			p.PersistentPreRun(cmd, args)
+			break
+		}
+	}
+	if cmd.PreRunE != nil {
+		err := cmd.PreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+	} else if cmd.PreRun != nil {
+		cmd.PreRun(cmd, args)
+	}
+
+	cmd.Run(cmd, args)
+	if DidError != nil {
+		return nil
+	}
+
+	if cmd.PostRunE != nil {
+		return cmd.PostRunE(cmd, args)
+	} else if cmd.PostRun != nil {
+		cmd.PostRun(cmd, args)
+	}
+	return nil
+}
+
+type flagSnapshot struct {
+	value   string
+	slice   []string
+	changed bool
+}
+
+// snapshotFlags records the values of the flags, so flags set by a step can
+// be restored and do not carry over to the next step.
+func snapshotFlags(flags *pflag.FlagSet) map[*pflag.Flag]flagSnapshot {
+	snapshot := map[*pflag.Flag]flagSnapshot{}
+	flags.VisitAll(func(f *pflag.Flag) {
+		s := flagSnapshot{value: f.Value.String(), changed: f.Changed}
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			s.slice = v.GetSlice()
+		}
+		snapshot[f] = s
+	})
+	return snapshot
+}
+
+func restoreFlags(flags *pflag.FlagSet, snapshot map[*pflag.Flag]flagSnapshot) {
+	flags.Visit(func(f *pflag.Flag) {
+		s, ok := snapshot[f]
+		if !ok || s.changed && f.Value.String() == s.value {
+			return
+		}
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			_ = v.Replace(s.slice)
+		} else {
+			_ = f.Value.Set(s.value)
+		}
+		f.Changed = s.changed
+	})
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchOrder(t *testing.T) {
+	cases := map[string]struct {
+		Steps []*batchStep
+		Order []string
+		Error string
+	}{
+		"plan order": {
+			Steps: []*batchStep{{ID: "a", Command: "x"}, {ID: "b", Command: "x"}},
+			Order: []string{"a", "b"},
+		},
+		"needs": {
+			Steps: []*batchStep{{ID: "a", Command: "x", Needs: []string{"b"}}, {ID: "b", Command: "x"}},
+			Order: []string{"b", "a"},
+		},
+		"reference": {
+			Steps: []*batchStep{{ID: "a", Command: "x", Args: []string{"${steps.b.txid}"}}, {ID: "b", Command: "x"}},
+			Order: []string{"b", "a"},
+		},
+		"default ids": {
+			Steps: []*batchStep{{Command: "x", Args: []string{"${steps.step2.txid}"}}, {Command: "x"}},
+			Order: []string{"step2", "step1"},
+		},
+		"cycle": {
+			Steps: []*batchStep{{ID: "a", Command: "x", Needs: []string{"b"}}, {ID: "b", Command: "x", Args: []string{"${steps.a.txid}"}}},
+			Error: "dependency cycle",
+		},
+		"unknown step": {
+			Steps: []*batchStep{{ID: "a", Command: "x", Needs: []string{"b"}}},
+			Error: `unknown step "b"`,
+		},
+		"unknown reference": {
+			Steps: []*batchStep{{ID: "a", Command: "x", Args: []string{"${steps.b.txid}"}}},
+			Error: `unknown step "b"`,
+		},
+		"duplicate step": {
+			Steps: []*batchStep{{ID: "a", Command: "x"}, {ID: "a", Command: "x"}},
+			Error: `duplicate step "a"`,
+		},
+		"missing command": {
+			Steps: []*batchStep{{ID: "a"}},
+			Error: "missing the command",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			plan := &batchPlan{Steps: c.Steps}
+			steps, err := plan.order()
+			if c.Error != "" {
+				require.ErrorContains(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+			var ids []string
+			for _, step := range steps {
+				ids = append(ids, step.ID)
+			}
+			require.Equal(t, c.Order, ids)
+		})
+	}
+}
+
+func TestBatchExpand(t *testing.T) {
+	plan := &batchPlan{Vars: map[string]string{"adi": "acc://example.acme"}}
+	results := map[string]*batchResult{
+		"ok": {Status: batchStatusOk, Output: map[string]interface{}{
+			"txid":   "acc://abc@example.acme",
+			"result": map[string]interface{}{"nested": map[string]interface{}{"value": "deep"}, "code": 5.0},
+		}},
+		"failed":  {Status: batchStatusFailed},
+		"skipped": {Status: batchStatusSkipped},
+	}
+
+	cases := map[string]struct {
+		Arg   string
+		Value string
+		Error string
+		Skip  string
+	}{
+		"literal":         {Arg: "acc://ACME", Value: "acc://ACME"},
+		"variable":        {Arg: "${adi}/tokens", Value: "acc://example.acme/tokens"},
+		"unknown":         {Arg: "${other}", Error: `unknown variable "other"`},
+		"output":          {Arg: "${steps.ok.txid}", Value: "acc://abc@example.acme"},
+		"nested output":   {Arg: "${steps.ok.result.nested.value}", Value: "deep"},
+		"non-string":      {Arg: "${steps.ok.result.code}", Value: "5"},
+		"object":          {Arg: "${steps.ok.result.nested}", Value: `{"value":"deep"}`},
+		"missing output":  {Arg: "${steps.ok.result.other}", Error: `no output "result.other"`},
+		"through a value": {Arg: "${steps.ok.txid.value}", Error: `no output "txid.value"`},
+		"invalid":         {Arg: "${steps.ok}", Error: "invalid reference"},
+		"failed step":     {Arg: "${steps.failed.txid}", Skip: "failed"},
+		"skipped step":    {Arg: "${steps.skipped.txid}", Skip: "skipped"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := plan.expand(c.Arg, results)
+			switch {
+			case c.Skip != "":
+				var skipped *batchSkipError
+				require.ErrorAs(t, err, &skipped)
+				require.Equal(t, c.Skip, skipped.step)
+			case c.Error != "":
+				require.ErrorContains(t, err, c.Error)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, c.Value, v)
+			}
+		})
+	}
+}
+
+// newBatchTestRoot returns a command tree with commands that echo their
+// arguments or fail, and records the hooks that run.
+func newBatchTestRoot(hooks *[]string) *cobra.Command {
+	root := &cobra.Command{Use: "root"}
+	root.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		*hooks = append(*hooks, "persistent-pre-run "+cmd.Name())
+		return nil
+	}
+	root.PersistentPostRun = func(*cobra.Command, []string) {
+		*hooks = append(*hooks, "persistent-post-run")
+	}
+
+	var flag string
+	echo := &cobra.Command{
+		Use: "echo",
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			*hooks = append(*hooks, "pre-run "+cmd.Name())
+		},
+		Run: runCmdFunc(func(args []string) (string, error) {
+			return fmt.Sprintf(`{"args":%q,"flag":%q,"nested":{"value":"deep"}}`, strings.Join(args, " "), flag), nil
+		}),
+	}
+	echo.Flags().StringVar(&flag, "flag", "default", "")
+
+	fail := &cobra.Command{
+		Use: "fail",
+		Run: runCmdFunc(func([]string) (string, error) { return "", fmt.Errorf("failed") }),
+	}
+	// Submit simulates a command that caches its signer and submits a
+	// transaction, and cached reports the size of the signer cache
+	submit := &cobra.Command{
+		Use: "submit",
+		Run: runCmdFunc(func([]string) (string, error) {
+			signerCache["signer"] = new(cachedSigner)
+			batchSubmitted = true
+			return "{}", nil
+		}),
+	}
+	cached := &cobra.Command{
+		Use: "cached",
+		Run: runCmdFunc(func(args []string) (string, error) {
+			signerCache[args[0]] = new(cachedSigner)
+			return fmt.Sprintf(`{"count":%d}`, len(signerCache)), nil
+		}),
+	}
+
+	group := &cobra.Command{Use: "group"}
+	root.AddCommand(echo, fail, submit, cached, group, &cobra.Command{Use: "batch", Run: func(*cobra.Command, []string) {}})
+	return root
+}
+
+func TestExecuteBatchCommand(t *testing.T) {
+	var hooks []string
+	root := newBatchTestRoot(&hooks)
+
+	cases := map[string]struct {
+		Args   []string
+		Output string
+		Error  string
+		Hooks  []string
+	}{
+		"run": {
+			Args:   []string{"echo", "a", "b"},
+			Output: `{"args":"a b","flag":"default","nested":{"value":"deep"}}`,
+			Hooks:  []string{"persistent-pre-run echo", "pre-run echo"},
+		},
+		"flag": {
+			Args:   []string{"echo", "--flag", "set"},
+			Output: `{"args":"","flag":"set","nested":{"value":"deep"}}`,
+			Hooks:  []string{"persistent-pre-run echo", "pre-run echo"},
+		},
+		"flag does not carry over": {
+			Args:   []string{"echo"},
+			Output: `{"args":"","flag":"default","nested":{"value":"deep"}}`,
+			Hooks:  []string{"persistent-pre-run echo", "pre-run echo"},
+		},
+		"failure": {
+			Args:  []string{"fail"},
+			Error: "failed",
+			Hooks: []string{"persistent-pre-run fail"},
+		},
+		"unknown command": {
+			Args:  []string{"other"},
+			Error: "unknown command",
+		},
+		"not runnable": {
+			Args:  []string{"group"},
+			Error: "not runnable",
+		},
+		"batch": {
+			Args:  []string{"batch"},
+			Error: "is not a command that can be batched",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			hooks = nil
+			out, err := executeBatchCommand(root, c.Args, time.Second)
+			if c.Error != "" {
+				require.ErrorContains(t, err, c.Error)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, c.Output, strings.TrimSpace(out))
+			}
+			require.Equal(t, c.Hooks, hooks)
+		})
+	}
+}
+
+func TestBatchRun(t *testing.T) {
+	var hooks []string
+	root := newBatchTestRoot(&hooks)
+	plan := &batchPlan{Steps: []*batchStep{
+		{ID: "echo", Command: "echo", Args: []string{"x"}},
+		{ID: "fail", Command: "fail"},
+		{ID: "after-fail", Command: "echo", Needs: []string{"fail"}},
+		{ID: "uses-fail", Command: "echo", Args: []string{"${steps.fail.args}"}},
+		{ID: "uses-echo", Command: "echo", Args: []string{"${steps.echo.nested.value}"}},
+		{ID: "after-skip", Command: "echo", Needs: []string{"after-fail"}},
+	}}
+	steps, err := plan.order()
+	require.NoError(t, err)
+
+	results := map[string]*batchResult{}
+	status := map[string]string{}
+	for _, step := range steps {
+		result := plan.run(root, step, results, time.Second)
+		results[step.ID] = result
+		status[step.ID] = result.Status
+	}
+
+	require.Equal(t, map[string]string{
+		"echo":       batchStatusOk,
+		"fail":       batchStatusFailed,
+		"after-fail": batchStatusSkipped,
+		"uses-fail":  batchStatusSkipped,
+		"uses-echo":  batchStatusOk,
+		"after-skip": batchStatusSkipped,
+	}, status)
+	require.Equal(t, []string{"deep"}, results["uses-echo"].Args)
+	require.Equal(t, "deep", results["uses-echo"].Output.(map[string]interface{})["args"])
+}
+
+func TestBatchSignerCache(t *testing.T) {
+	var hooks []string
+	root := newBatchTestRoot(&hooks)
+	batch, _, err := root.Find([]string{"batch"})
+	require.NoError(t, err)
+
+	// The cache is cleared after a step submits a transaction, and kept
+	// otherwise
+	file := filepath.Join(t.TempDir(), "plan.yml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+steps:
+  - id: before
+    command: cached
+    args: [a]
+  - id: kept
+    command: cached
+    args: [b]
+  - id: submit
+    command: submit
+  - id: after
+    command: cached
+    args: [c]
+`), 0600))
+
+	wantJson := WantJsonOutput
+	defer func() { WantJsonOutput = wantJson }()
+	WantJsonOutput = true
+	out, err := RunBatch(batch, []string{file})
+	require.NoError(t, err)
+	require.Nil(t, signerCache)
+
+	var results []*batchResult
+	require.NoError(t, json.Unmarshal([]byte(out), &results))
+	require.Len(t, results, 4)
+	require.Equal(t, 1.0, results[0].Output.(map[string]interface{})["count"])
+	require.Equal(t, 2.0, results[1].Output.(map[string]interface{})["count"])
+	require.Equal(t, 1.0, results[3].Output.(map[string]interface{})["count"])
+}
@@ -29,8 +29,8 @@ var creditsCmd = &cobra.Command{
 }
 
 func PrintCredits() {
-	fmt.Println("  accumulate credits [origin lite token account] [lite identity url or key page url] [credits desired] [max amount in acme (optional)] 		Purchase credits using a lite token account or adi key page to another lite token account or adi key page")
-	fmt.Println("  accumulate credits [adi token account] [key name[@key book or page]] [key page or lite identity url] [credits desired] [max amount in acme (optional)]		Purchase credits to send to another lite identity or adi key page")
+	fmt.Println("  accumulate credits [origin lite token account] [lite identity url, key page url, or address book label] [credits desired] [max amount in acme (optional)] 		Purchase credits using a lite token account or adi key page to another lite token account or adi key page")
+	fmt.Println("  accumulate credits [adi token account] [key name[@key book or page]] [key page url, lite identity url, or address book label] [credits desired] [max amount in acme (optional)]		Purchase credits to send to another lite identity or adi key page")
 	fmt.Println("\tnote: If the max amount in ACME parameter is provided and the oracle price falls below what\n" +
 		"\tthat value can cover, the transaction will fail. The minimum of the computed credit purchase and the maximum\n" +
 		"\tvalue to spend will be used to satisfy the purchase.")
@@ -52,7 +52,7 @@ func AddCredits(origin string, args []string) (string, error) {
 		return "", err
 	}
 
-	u2, err := url2.Parse(args[0])
+	u2, err := resolveAddress(args[0])
 	if err != nil {
 		return "", err
 	}
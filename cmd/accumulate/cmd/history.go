@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+)
+
+var walletHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Cache and export the transaction history of the wallet's accounts",
+}
+
+var walletHistorySyncCmd = &cobra.Command{
+	Use:   "sync [account url (optional)]...",
+	Short: "Fetch new transactions of the wallet's token accounts into the local cache",
+	Run:   runCmdFunc(SyncHistory),
+}
+
+var walletHistoryExportCmd = &cobra.Command{
+	Use:   "export [account url (optional)] --format csv|json --output [file (optional)]",
+	Short: "Export the cached transaction history as a ledger",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runCmdFunc(ExportHistory),
+}
+
+var flagHistory = struct {
+	Format string
+	Output string
+}{}
+
+func init() {
+	walletHistoryExportCmd.Flags().StringVar(&flagHistory.Format, "format", "csv", "The export format, csv or json")
+	walletHistoryExportCmd.Flags().StringVar(&flagHistory.Output, "output", "", "Write the export to a file")
+	walletHistoryCmd.AddCommand(walletHistorySyncCmd, walletHistoryExportCmd)
+	walletCmd.AddCommand(walletHistoryCmd)
+}
+
+func SyncHistory(args []string) (string, error) {
+	var accounts []*url.URL
+	for _, arg := range args {
+		u, err := resolveAddress(arg)
+		if err != nil {
+			return "", err
+		}
+		accounts = append(accounts, u)
+	}
+
+	if len(accounts) == 0 {
+		var err error
+		accounts, err = walletd.HistoryAccounts(context.Background(), walletd.GetWallet(), Client)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	states, err := walletd.SyncHistory(context.Background(), walletd.GetWallet(), Client, accounts)
+	if err != nil {
+		return "", err
+	}
+
+	if WantJsonOutput {
+		data, err := json.Marshal(states)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var out string
+	for _, state := range states {
+		out += fmt.Sprintf("\t%v\tsynced to height %d\n", state.Account, state.Height)
+	}
+	return out, nil
+}
+
+func ExportHistory(args []string) (string, error) {
+	var account *url.URL
+	if len(args) > 0 {
+		var err error
+		account, err = resolveAddress(args[0])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	entries, err := walletd.ListLedger(walletd.GetWallet(), account)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	switch flagHistory.Format {
+	case "csv":
+		err = walletd.WriteLedgerCSV(buf, entries)
+	case "json":
+		enc := json.NewEncoder(buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(entries)
+	default:
+		return "", fmt.Errorf("unknown format %q, want csv or json", flagHistory.Format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if flagHistory.Output == "" {
+		return buf.String(), nil
+	}
+	err = os.WriteFile(flagHistory.Output, buf.Bytes(), 0600)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Exported %d entries to %s\n", len(entries), flagHistory.Output), nil
+}
@@ -0,0 +1,472 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2/query"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+var interactiveCmd = &cobra.Command{
+	Use:     "interactive",
+	Aliases: []string{"tui"},
+	Short:   "Browse accounts, sign pending transactions, and follow new blocks interactively",
+	Args:    cobra.NoArgs,
+	Run: runCmdFunc2(func(cmd *cobra.Command, _ []string) (string, error) {
+		return "", (&interactive{cmd: cmd}).run()
+	}),
+}
+
+var flagInteractive = struct {
+	Partition    string
+	PollInterval time.Duration
+}{}
+
+func init() {
+	interactiveCmd.Flags().StringVar(&flagInteractive.Partition, "partition", protocol.DnUrl().String(), "The partition to follow blocks of")
+	interactiveCmd.Flags().DurationVar(&flagInteractive.PollInterval, "poll-interval", 2*time.Second, "How often to check for new blocks")
+}
+
+// interactive is a menu-driven terminal UI built on the query helpers used
+// by the other commands.
+type interactive struct {
+	cmd *cobra.Command
+
+	// choose shows a menu and returns the index of the item the user chose. If
+	// choose is nil, the menu is shown with promptui.
+	choose func(label string, items []string) (int, error)
+
+	// txnTypes caches the body types of pending transactions, which do not
+	// change, so redrawing a menu does not query every transaction again.
+	txnTypes map[[32]byte]protocol.TransactionType
+}
+
+// menuItem is an entry of a menu. If run returns errBack, the menu returns to
+// its parent.
+type menuItem struct {
+	label string
+	run   func() error
+}
+
+var errBack = errors.New("back")
+
+func (t *interactive) run() error {
+	return t.menu("Accumulate", func() ([]menuItem, error) {
+		return []menuItem{
+			{"Wallet accounts", t.walletAccounts},
+			{"Browse an account", t.promptAccount},
+			{"Follow blocks", t.followBlocks},
+			{"Quit", back},
+		}, nil
+	})
+}
+
+// menu repeatedly shows the items returned by load until the user goes back.
+// Errors from an item are printed and the menu is shown again.
+func (t *interactive) menu(label string, load func() ([]menuItem, error)) error {
+	for {
+		items, err := load()
+		if err != nil {
+			return err
+		}
+
+		labels := make([]string, len(items))
+		for i, item := range items {
+			labels[i] = item.label
+		}
+
+		choose := t.choose
+		if choose == nil {
+			choose = promptChoose
+		}
+		index, err := choose(label, labels)
+		switch {
+		case errors.Is(err, promptui.ErrInterrupt), errors.Is(err, promptui.ErrEOF):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		err = items[index].run()
+		switch {
+		case errors.Is(err, errBack):
+			return nil
+		case err != nil:
+			t.cmd.PrintErrln("Error:", err)
+		}
+	}
+}
+
+// promptChoose shows a menu with promptui.
+func promptChoose(label string, items []string) (int, error) {
+	prompt := promptui.Select{Label: label, Items: items, Size: 15}
+	index, _, err := prompt.Run()
+	return index, err
+}
+
+func (t *interactive) walletAccounts() error {
+	return t.menu("Wallet accounts", func() ([]menuItem, error) {
+		accounts, err := ownedAccounts()
+		if err != nil {
+			return nil, err
+		}
+		watched, err := walletd.ListWatchedAccounts()
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range watched {
+			accounts = append(accounts, account.Url)
+		}
+
+		var items []menuItem
+		for _, u := range accounts {
+			items = append(items, t.accountItem(u.String(), u))
+		}
+		return append(items, menuItem{"Back", back}), nil
+	})
+}
+
+func (t *interactive) promptAccount() error {
+	prompt := promptui.Prompt{
+		Label: "Account URL or address book name",
+		Validate: func(s string) error {
+			_, err := resolveAddress(s)
+			return err
+		},
+	}
+	s, err := prompt.Run()
+	if err != nil {
+		return nil
+	}
+
+	u, err := resolveAddress(s)
+	if err != nil {
+		return err
+	}
+	return t.account(u)
+}
+
+// account shows the account and the actions for its type.
+func (t *interactive) account(u *url.URL) error {
+	return t.menu(u.String(), func() ([]menuItem, error) {
+		qr, err := GetUrl(u.String())
+		if err != nil {
+			return nil, err
+		}
+		out, err := PrintChainQueryResponseV2(qr)
+		if err != nil {
+			return nil, err
+		}
+		t.cmd.Println(out)
+
+		data, err := json.Marshal(qr.Data)
+		if err != nil {
+			return nil, err
+		}
+		account, err := protocol.UnmarshalAccountJSON(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []menuItem
+		switch account := account.(type) {
+		case *protocol.ADI, *protocol.LiteIdentity:
+			items = append(items, menuItem{"Directory", func() error { return t.directory(u) }})
+		case *protocol.KeyBook:
+			items = append(items, menuItem{"Key pages", func() error { return t.keyPages(account) }})
+		}
+		items = append(items,
+			menuItem{"Pending transactions", func() error { return t.pending(u) }},
+			menuItem{"Refresh", func() error { return nil }},
+			menuItem{"Back", back},
+		)
+		return items, nil
+	})
+}
+
+func (t *interactive) accountItem(label string, u *url.URL) menuItem {
+	return menuItem{label, func() error { return t.account(u) }}
+}
+
+func (t *interactive) directory(u *url.URL) error {
+	return t.menu("Directory of "+u.String(), func() ([]menuItem, error) {
+		entries, err := queryDirectory(u)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []menuItem
+		for _, entry := range entries {
+			if !entry.Equal(u) {
+				items = append(items, t.accountItem(entry.String(), entry))
+			}
+		}
+		return append(items, menuItem{"Back", back}), nil
+	})
+}
+
+func (t *interactive) keyPages(book *protocol.KeyBook) error {
+	return t.menu("Pages of "+book.Url.String(), func() ([]menuItem, error) {
+		var items []menuItem
+		for i := uint64(0); i < book.PageCount; i++ {
+			page := protocol.FormatKeyPageUrl(book.Url, i)
+			items = append(items, t.accountItem(page.String(), page))
+		}
+		return append(items, menuItem{"Back", back}), nil
+	})
+}
+
+// pending lists the account's pending transactions.
+func (t *interactive) pending(u *url.URL) error {
+	return t.menu("Pending transactions of "+u.String(), func() ([]menuItem, error) {
+		pending, err := t.pendingTransactions(u)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []menuItem
+		for _, txn := range pending {
+			hash := txn.hash
+			label := fmt.Sprintf("%x\t%v", hash[:8], txn.typ)
+			items = append(items, menuItem{label, func() error { return t.pendingTransaction(hash[:]) }})
+		}
+		if len(items) == 0 {
+			t.cmd.Println("There are no pending transactions")
+		}
+		return append(items, menuItem{"Back", back}), nil
+	})
+}
+
+// pendingTxn is an entry of an account's pending transactions.
+type pendingTxn struct {
+	hash [32]byte
+	typ  protocol.TransactionType
+}
+
+// pendingTransactions queries the account's pending transactions. Only
+// transactions that have not been seen before are queried for their type.
+func (t *interactive) pendingTransactions(u *url.URL) ([]pendingTxn, error) {
+	params := api.UrlQuery{Url: u.WithFragment("pending")}
+	res := api.MultiResponse{}
+	err := queryAs("query", &params, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.txnTypes == nil {
+		t.txnTypes = map[[32]byte]protocol.TransactionType{}
+	}
+
+	pending := make([]pendingTxn, 0, len(res.Items))
+	for _, item := range res.Items {
+		hash, err := parsePendingItem(item)
+		if err != nil {
+			return nil, err
+		}
+
+		typ, ok := t.txnTypes[hash]
+		if !ok {
+			txn, err := getTxUsingHash(hash[:], 0, false)
+			if err != nil {
+				return nil, err
+			}
+			typ = txn.Transaction.Body.Type()
+			t.txnTypes[hash] = typ
+		}
+		pending = append(pending, pendingTxn{hash, typ})
+	}
+	return pending, nil
+}
+
+// parsePendingItem parses an item of a pending query response, which is the
+// hex-encoded hash of the transaction.
+func parsePendingItem(item interface{}) ([32]byte, error) {
+	s, ok := item.(string)
+	if !ok {
+		return [32]byte{}, fmt.Errorf("invalid pending transaction %v", item)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid pending transaction %q: %w", s, err)
+	}
+	if len(b) != 32 {
+		return [32]byte{}, fmt.Errorf("invalid pending transaction %q: want 32 bytes, got %d", s, len(b))
+	}
+	return *(*[32]byte)(b), nil
+}
+
+// pendingTransaction shows a pending transaction and lets the user sign it.
+func (t *interactive) pendingTransaction(hash []byte) error {
+	return t.menu(fmt.Sprintf("Transaction %x", hash[:8]), func() ([]menuItem, error) {
+		txn, err := getTxUsingHash(hash, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		out, err := PrintTransactionQueryResponseV2(txn)
+		if err != nil {
+			return nil, err
+		}
+		t.cmd.Println(out)
+
+		var items []menuItem
+		if txn.Status == nil || !txn.Status.Delivered() {
+			items = append(items, menuItem{"Sign", func() error { return t.sign(txn.Transaction.Header.Principal, hash) }})
+		}
+		return append(items, menuItem{"Refresh", func() error { return nil }}, menuItem{"Back", back}), nil
+	})
+}
+
+// sign signs a pending transaction with a key from the wallet, the same way
+// as `accumulate tx sign`.
+func (t *interactive) sign(principal *url.URL, hash []byte) error {
+	b, err := walletd.GetWallet().GetBucket(walletd.BucketLabel)
+	if err != nil {
+		return fmt.Errorf("the wallet has no keys")
+	}
+	var labels []string
+	for _, v := range b.KeyValueList {
+		labels = append(labels, string(v.Key))
+	}
+	sort.Strings(labels)
+
+	prompt := promptui.SelectWithAdd{
+		Label:    "Key name[@key book or page]",
+		Items:    labels,
+		AddLabel: "Other",
+	}
+	_, key, err := prompt.Run()
+	if err != nil {
+		return nil
+	}
+
+	_, signers, err := prepareSigner(principal, []string{key})
+	if err != nil {
+		return fmt.Errorf("unable to prepare signer, %v", err)
+	}
+	out, err := dispatchTxAndPrintResponse(hash, principal, signers)
+	if err != nil {
+		return err
+	}
+	t.cmd.Println(out)
+	return nil
+}
+
+// followBlocks prints new minor blocks of the partition until interrupted.
+func (t *interactive) followBlocks() error {
+	u, err := url.Parse(flagInteractive.Partition)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	t.cmd.Printf("Following blocks of %v, press Ctrl+C to stop\n", u)
+
+	follower, err := newBlockFollower(ctx, u)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(flagInteractive.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		blocks, err := follower.next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		for _, block := range blocks {
+			var when string
+			if block.BlockTime != nil {
+				when = block.BlockTime.Format(time.RFC3339)
+			}
+			t.cmd.Printf("\tblock %d\t%s\t%d transactions\n", block.BlockIndex, when, block.TxCount)
+			for _, id := range block.TxIds {
+				t.cmd.Printf("\t\t%x\n", id)
+			}
+		}
+	}
+}
+
+// blockFollower queries the minor blocks of a partition that come after the
+// last block it returned.
+type blockFollower struct {
+	params *api.MinorBlocksQuery
+}
+
+// newBlockFollower returns a blockFollower that starts after the partition's
+// latest block.
+func newBlockFollower(ctx context.Context, partition *url.URL) (*blockFollower, error) {
+	params := new(api.MinorBlocksQuery)
+	params.Url = partition
+	params.Count = 1
+	params.TxFetchMode = query.TxFetchModeOmit
+	params.BlockFilterMode = query.BlockFilterModeExcludeNone
+
+	res, err := Client.QueryMinorBlocks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Total is the index of the latest block
+	params.Start = res.Total + 1
+	params.Count = 50
+	params.TxFetchMode = query.TxFetchModeIds
+	return &blockFollower{params}, nil
+}
+
+// next returns the blocks that have been added since the last call.
+func (f *blockFollower) next(ctx context.Context) ([]*api.MinorQueryResponse, error) {
+	// The query fails with not found if there are no new blocks
+	res, err := Client.QueryMinorBlocks(ctx, f.params)
+	var rpcErr jsonrpc2.Error
+	switch {
+	case err == nil:
+	case errors.As(err, &rpcErr) && rpcErr.Code == api.ErrCodeNotFound:
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	blocks := make([]*api.MinorQueryResponse, 0, len(res.Items))
+	for _, item := range res.Items {
+		block := new(api.MinorQueryResponse)
+		err = Remarshal(item, block)
+		if err != nil {
+			return nil, err
+		}
+
+		// Skip blocks that have already been returned
+		if block.BlockIndex < f.params.Start {
+			continue
+		}
+		blocks = append(blocks, block)
+		f.params.Start = block.BlockIndex + 1
+	}
+	return blocks, nil
+}
+
+func back() error { return errBack }
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/block/simulator"
+	acctesting "gitlab.com/accumulatenetwork/accumulate/internal/testing"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// useSimulator points the client at the simulator's API and returns a function
+// that counts the requests made for a method.
+func useSimulator(t *testing.T, sim *simulator.Simulator) func(method string) int {
+	counts := map[string]int{}
+	handler := sim.NewServer()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		for _, method := range []string{"query", "query-tx", "query-minor-blocks"} {
+			if strings.Contains(string(body), `"method":"`+method+`"`) {
+				counts[method]++
+			}
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(server.URL)
+	require.NoError(t, err)
+	old := Client
+	Client = c
+	t.Cleanup(func() { Client = old })
+
+	return func(method string) int { return counts[method] }
+}
+
+func TestInteractiveMenu(t *testing.T) {
+	out := new(bytes.Buffer)
+	cmd := new(cobra.Command)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+
+	var shown []string
+	choices := []int{0, 0, 1, 2}
+	ti := &interactive{cmd: cmd, choose: func(label string, _ []string) (int, error) {
+		shown = append(shown, label)
+		if len(choices) == 0 {
+			return 0, promptui.ErrInterrupt
+		}
+		choice := choices[0]
+		choices = choices[1:]
+		return choice, nil
+	}}
+
+	sub := func() error {
+		return ti.menu("sub", func() ([]menuItem, error) {
+			return []menuItem{{"Back", back}}, nil
+		})
+	}
+	fail := func() error { return errors.New("boom") }
+
+	// Going back from a sub-menu and failing an item both show the menu again
+	err := ti.menu("root", func() ([]menuItem, error) {
+		return []menuItem{{"Sub", sub}, {"Fail", fail}, {"Quit", back}}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"root", "sub", "root", "root"}, shown)
+	require.Contains(t, out.String(), "Error: boom")
+
+	// Interrupting the prompt leaves the menu
+	shown = nil
+	require.NoError(t, ti.menu("root", func() ([]menuItem, error) {
+		return []menuItem{{"Quit", back}}, nil
+	}))
+	require.Equal(t, []string{"root"}, shown)
+}
+
+func TestParsePendingItem(t *testing.T) {
+	hash, err := parsePendingItem(strings.Repeat("ab", 32))
+	require.NoError(t, err)
+	require.Equal(t, byte(0xab), hash[31])
+
+	for _, item := range []interface{}{1.0, "xyz", "abcd"} {
+		_, err = parsePendingItem(item)
+		require.Error(t, err)
+	}
+}
+
+func TestInteractivePending(t *testing.T) {
+	var timestamp uint64
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+	count := useSimulator(t, sim)
+
+	// Require two signatures so the transaction stays pending
+	alice := url.MustParse("alice")
+	aliceKey1, aliceKey2 := acctesting.GenerateKey(alice, 1), acctesting.GenerateKey(alice, 2)
+	sim.CreateIdentity(alice, aliceKey1[32:], aliceKey2[32:])
+	sim.UpdateAccount(alice.JoinPath("book", "1"), func(account protocol.Account) {
+		page := account.(*protocol.KeyPage)
+		page.CreditBalance = 1e9
+		page.AcceptThreshold = 2
+	})
+
+	env := acctesting.NewTransaction().
+		WithPrincipal(alice).
+		WithSigner(alice.JoinPath("book", "1"), 1).
+		WithTimestampVar(&timestamp).
+		WithBody(&protocol.CreateTokenAccount{Url: alice.JoinPath("tokens"), TokenUrl: protocol.AcmeUrl()}).
+		Initiate(protocol.SignatureTypeED25519, aliceKey1).
+		Build()
+	sim.WaitForTransactions(func(s *protocol.TransactionStatus) bool { return s.Pending() }, sim.MustSubmitAndExecuteBlock(env)...)
+
+	ti := &interactive{cmd: new(cobra.Command)}
+	pending, err := ti.pendingTransactions(alice)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, env.Transaction[0].GetHash(), pending[0].hash[:])
+	require.Equal(t, protocol.TransactionTypeCreateTokenAccount, pending[0].typ)
+	require.Equal(t, 1, count("query-tx"))
+
+	// Showing the list again does not query the transaction again
+	pending, err = ti.pendingTransactions(alice)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, 2, count("query"))
+	require.Equal(t, 1, count("query-tx"))
+}
+
+func TestBlockFollower(t *testing.T) {
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+	useSimulator(t, sim)
+
+	ctx := context.Background()
+	follower, err := newBlockFollower(ctx, protocol.DnUrl())
+	require.NoError(t, err)
+	start := follower.params.Start
+
+	// No blocks have been added
+	blocks, err := follower.next(ctx)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+
+	// Each new block is returned once, in order
+	sim.ExecuteBlocks(3)
+	blocks, err = follower.next(ctx)
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	for i, block := range blocks {
+		require.Equal(t, start+uint64(i), block.BlockIndex)
+	}
+
+	sim.ExecuteBlocks(1)
+	blocks, err = follower.next(ctx)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, start+3, blocks[0].BlockIndex)
+	require.Equal(t, start+4, follower.params.Start)
+}
@@ -40,12 +40,26 @@ func init() {
 	keyImportLiteCmd.Flags().StringVar(&SigType, "sigtype", "ed25519", "Specify the signature type use rcd1 for RCD1 type ; ed25519 for accumulate ED25519 ; btc for Bitcoin ; btclegacy for Legacy Bitcoin  ; eth for Ethereum ")
 	keyGenerateCmd.Flags().StringVar(&SigType, "sigtype", "ed25519", "Specify the signature type use rcd1 for RCD1 type ; ed25519 for accumulate ED25519 ; btc for Bitcoin ; btclegacy for Legacy Bitcoin  ; eth for Ethereum ")
 	keyImportPrivateCmd.Flags().BoolVarP(&flagKeyImport.Force, "force", "f", false, "If there is an existing external key, overwrite it")
+	keyExportSeedCmd.Flags().IntVar(&flagKeyExportSeed.Shares, "shares", 0, "Split the seed into this many SLIP-39 shares")
+	keyExportSeedCmd.Flags().IntVar(&flagKeyExportSeed.Threshold, "threshold", 0, "The number of shares required to restore the seed")
+	keyExportSeedCmd.Flags().BoolVar(&flagKeyExportSeed.Passphrase, "passphrase", false, "Prompt for a passphrase to encrypt the shares with")
+	keyGenerateCmd.Flags().UintVar(&flagKeyGenerate.Account, "account", 0, "Derive the key from the given BIP-44 account")
 }
 
 var flagKeyImport = struct {
 	Force bool
 }{}
 
+var flagKeyExportSeed = struct {
+	Shares     int
+	Threshold  int
+	Passphrase bool
+}{}
+
+var flagKeyGenerate = struct {
+	Account uint
+}{}
+
 var keyImportCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import private key from hex or factoid secret address",
@@ -139,11 +153,17 @@ var keyExportMnemonicCmd = &cobra.Command{
 }
 
 var keyExportSeedCmd = &cobra.Command{
-	Use:   "seed",
-	Short: "export key seed",
+	Use:   "seed --shares [count] --threshold [count] --passphrase",
+	Short: "export key seed, or split it into SLIP-39 Shamir shares",
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		out, err := ExportSeed()
+		var out string
+		var err error
+		if flagKeyExportSeed.Shares > 0 {
+			out, err = ExportSeedShares(cmd)
+		} else {
+			out, err = ExportSeed()
+		}
 		printOutput(cmd, out, err)
 	},
 }
@@ -237,7 +257,7 @@ func resolvePrivateKey(s string) (*walletd.Key, error) {
 		return k, nil
 	}
 
-	return walletd.LookupByPubKey(k.PublicKey)
+	return walletd.LookupByPubKey(walletd.GetWallet(), k.PublicKey)
 }
 
 func resolvePublicKey(s string) (*walletd.Key, error) {
@@ -260,7 +280,7 @@ func parseKey(s string) (*walletd.Key, error) {
 		return k, nil
 	}
 
-	k, err = walletd.LookupByLabel(s)
+	k, err = walletd.LookupByLabel(walletd.GetWallet(), s)
 	if err == nil {
 		return k, nil
 	}
@@ -381,14 +401,14 @@ func ImportKey(token []byte, label string, signatureType protocol.SignatureType)
 	//here will change the label if it is a lite account specified, otherwise just use the label
 	label, _ = walletd.LabelForLiteTokenAccount(label)
 
-	existing, err := walletd.LookupByLabel(label)
+	existing, err := walletd.LookupByLabel(walletd.GetWallet(), label)
 	if err == nil {
 		if !flagKeyImport.Force || existing.KeyInfo.Derivation != "external" {
 			return "", fmt.Errorf("key name is already being used")
 		}
 	}
 
-	_, err = walletd.LookupByPubKey(pk.PublicKey)
+	_, err = walletd.LookupByPubKey(walletd.GetWallet(), pk.PublicKey)
 	lab := "not found"
 	if err == nil {
 		b, _ := walletd.GetWallet().GetBucket(walletd.BucketLabel)
@@ -426,13 +446,13 @@ func ImportKey(token []byte, label string, signatureType protocol.SignatureType)
 }
 
 func ExportKey(label string) (string, error) {
-	k, err := walletd.LookupByLabel(label)
+	k, err := walletd.LookupByLabel(walletd.GetWallet(), label)
 	if err != nil {
 		k, err := pubKeyFromString(label)
 		if err != nil {
 			return "", fmt.Errorf("no private key found for key name %s", label)
 		}
-		_, err = walletd.LookupByPubKey(k.PublicKey)
+		_, err = walletd.LookupByPubKey(walletd.GetWallet(), k.PublicKey)
 		if err != nil {
 			return "", fmt.Errorf("no private key found for key name %s", label)
 		}
@@ -481,7 +501,7 @@ func GenerateKey(label string) (string, error) {
 		return "", err
 	}
 
-	key, err := walletd.GenerateKey(sigtype)
+	key, err := walletd.GenerateKeyForAccount(sigtype, uint32(flagKeyGenerate.Account))
 	if err != nil {
 		return "", err
 	}
@@ -506,7 +526,7 @@ func GenerateKey(label string) (string, error) {
 	label, _ = walletd.LabelForLiteTokenAccount(label)
 
 	//make sure it doesn't exist
-	_, err = walletd.LookupByLabel(label)
+	_, err = walletd.LookupByLabel(walletd.GetWallet(), label)
 	if err == nil {
 		return "", fmt.Errorf("key already exists for key name %s", label)
 	}
@@ -595,6 +615,38 @@ func ExportSeed() (string, error) {
 	}
 }
 
+// ExportSeedShares splits the wallet's mnemonic into SLIP-39 shares to be
+// distributed to different custodians.
+func ExportSeedShares(cmd *cobra.Command) (string, error) {
+	var passphrase string
+	if flagKeyExportSeed.Passphrase {
+		var err error
+		passphrase, err = getPasswdPrompt(cmd, "Share passphrase : ", true)
+		if err != nil {
+			return "", db.ErrInvalidPassword
+		}
+	}
+
+	shares, err := walletd.ExportSeedShares(flagKeyExportSeed.Threshold, flagKeyExportSeed.Shares, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	if WantJsonOutput {
+		dump, err := json.Marshal(map[string]interface{}{"threshold": flagKeyExportSeed.Threshold, "shares": shares})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s\n", string(dump)), nil
+	}
+
+	out := fmt.Sprintf("Any %d of the following %d shares can restore the wallet:\n", flagKeyExportSeed.Threshold, len(shares))
+	for i, share := range shares {
+		out += fmt.Sprintf(" share %d: %s\n", i+1, share)
+	}
+	return out, nil
+}
+
 func ExportMnemonic() (string, error) {
 	phrase, err := walletd.GetWallet().Get(walletd.BucketMnemonic, []byte("phrase"))
 	if err != nil {
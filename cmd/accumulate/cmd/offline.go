@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	wapi "gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/client/signing"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// writeOfflineEnvelope writes the unsigned transaction and the details of its
+// signers to a partially signed transaction file instead of signing and
+// submitting it. See docs/OfflineSigning.md.
+func writeOfflineEnvelope(payload interface{}, origin *url.URL, signers []*signing.Builder) (string, error) {
+	if TxFile == "" {
+		return "", fmt.Errorf("--offline requires --file")
+	}
+
+	env, err := prepareEnvelope(payload, origin, signers)
+	if err != nil {
+		return PrintJsonRpcError(err)
+	}
+	if len(env.Transaction) != 1 {
+		return "", fmt.Errorf("expected exactly one transaction, got %d", len(env.Transaction))
+	}
+
+	pst := walletd.NewPST(env.Transaction[0])
+	for _, signer := range signers {
+		s := new(wapi.PstSigner)
+		s.Url = signer.Url
+		s.Version = signer.Version
+		s.Delegators = signer.Delegators
+		s.Threshold = 1
+
+		// Record the threshold of key pages so the file can be inspected
+		if key, _ := protocol.ParseLiteIdentity(signer.Url); key == nil {
+			var page *protocol.KeyPage
+			_, err = getRecord(signer.Url.String(), &page)
+			if err != nil {
+				return "", fmt.Errorf("failed to get %q : %v", signer.Url, err)
+			}
+			s.Authority = page.KeyBook()
+			s.Threshold = page.AcceptThreshold
+		}
+		pst.Signers = append(pst.Signers, s)
+	}
+
+	err = walletd.WritePST(TxFile, pst)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Wrote transaction %X to %s", pst.Transaction.GetHash(), TxFile), nil
+}
+
+// SignOfflineTX signs the transaction in the partially signed transaction file
+// with a key from the wallet, without querying the network, and adds the
+// signature to the file.
+func SignOfflineTX(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected a key name")
+	}
+
+	pst, err := walletd.ReadPST(TxFile)
+	if err != nil {
+		return "", err
+	}
+
+	// The key may be specified as key@signer to select the signer
+	keyName := args[0]
+	var signerUrl *url.URL
+	if u, err := url.Parse(args[0]); err == nil && u.UserInfo != "" {
+		keyName = u.UserInfo
+		signerUrl = u.WithUserInfo("")
+	}
+
+	key, err := resolvePrivateKey(keyName)
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := walletd.SignPST(pst, key, signerUrl)
+	if err != nil {
+		return "", err
+	}
+
+	err = walletd.WritePST(TxFile, pst)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Signed transaction %X as %v, the file has %d signature(s)", pst.Transaction.GetHash(), sig.GetSigner(), len(pst.Signatures)), nil
+}
+
+// SubmitOfflineTX submits the transaction and signatures in the partially
+// signed transaction file.
+func SubmitOfflineTX() (string, error) {
+	pst, err := walletd.ReadPST(TxFile)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := walletd.SubmitPST(context.Background(), Client, pst)
+	if err != nil {
+		return PrintJsonRpcError(err)
+	}
+
+	return printExecuteResponse(res)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+)
+
+var walletPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Manage the policies that restrict the transactions the wallet will sign",
+}
+
+var walletPolicySetCmd = &cobra.Command{
+	Use:   "set [policy file]",
+	Short: "Add or replace a signing policy, defined in a JSON or YAML file",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCmdFunc(SetSigningPolicy),
+}
+
+var walletPolicyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the signing policies",
+	Args:  cobra.NoArgs,
+	Run:   runCmdFunc(ListSigningPolicies),
+}
+
+var walletPolicyRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Remove a signing policy",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCmdFunc(RemoveSigningPolicy),
+}
+
+func init() {
+	walletPolicyCmd.AddCommand(walletPolicySetCmd, walletPolicyListCmd, walletPolicyRemoveCmd)
+	walletCmd.AddCommand(walletPolicyCmd)
+}
+
+func SetSigningPolicy(args []string) (string, error) {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	policy := new(api.SigningPolicy)
+	err = yaml.Unmarshal(data, policy)
+	if err != nil {
+		return "", fmt.Errorf("invalid policy: %w", err)
+	}
+
+	err = walletd.SaveSigningPolicy(policy)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Saved signing policy %q\n", policy.Name), nil
+}
+
+func ListSigningPolicies([]string) (string, error) {
+	policies, err := walletd.ListSigningPolicies(walletd.GetWallet())
+	if err != nil {
+		return "", err
+	}
+
+	if WantJsonOutput {
+		data, err := json.Marshal(policies)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var out string
+	for _, policy := range policies {
+		out += fmt.Sprintf("\t%s\n", policy.Name)
+		for _, u := range policy.Signers {
+			out += fmt.Sprintf("\t\tsigner:\t\t%v\n", u)
+		}
+		if len(policy.TransactionTypes) > 0 {
+			var types []string
+			for _, typ := range policy.TransactionTypes {
+				types = append(types, typ.String())
+			}
+			out += fmt.Sprintf("\t\ttypes:\t\t%s\n", strings.Join(types, ", "))
+		}
+		for _, u := range policy.Principals {
+			out += fmt.Sprintf("\t\tprincipal:\t%v\n", u)
+		}
+		for _, limit := range policy.Recipients {
+			recipient := "any recipient"
+			if limit.Recipient != nil {
+				recipient = limit.Recipient.String()
+			}
+			out += fmt.Sprintf("\t\trecipient:\t%s, at most %s %v\n", recipient, limit.Amount.String(), limit.Token)
+		}
+		if policy.MemoPattern != "" {
+			out += fmt.Sprintf("\t\tmemo:\t\t%s\n", policy.MemoPattern)
+		}
+	}
+	return out, nil
+}
+
+func RemoveSigningPolicy(args []string) (string, error) {
+	err := walletd.DeleteSigningPolicy(args[0])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Removed signing policy %q\n", args[0]), nil
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	wapi "gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+var txPstCmd = &cobra.Command{
+	Use:   "pst",
+	Short: "Create, sign, merge, and submit partially signed transactions",
+}
+
+var txPstCreateCmd = &cobra.Command{
+	Use:   "create [origin url] [txid or payload] [file]",
+	Short: "Create a partially signed transaction from a pending transaction or a payload",
+	Args:  cobra.ExactArgs(3),
+	Run:   runCmdFunc(CreatePST),
+}
+
+var txPstSignCmd = &cobra.Command{
+	Use:   "sign [file] [key name[@key page]]",
+	Short: "Sign a partially signed transaction",
+	Args:  cobra.ExactArgs(2),
+	Run:   runCmdFunc(SignPST),
+}
+
+var txPstMergeCmd = &cobra.Command{
+	Use:   "merge [output file] [input file] ...",
+	Short: "Merge the signatures of partially signed transactions from multiple co-signers",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runCmdFunc(MergePST),
+}
+
+var txPstInspectCmd = &cobra.Command{
+	Use:   "inspect [file]",
+	Short: "Show the signatures collected for a partially signed transaction",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCmdFunc(InspectPST),
+}
+
+var txPstSubmitCmd = &cobra.Command{
+	Use:   "submit [file]",
+	Short: "Submit a partially signed transaction",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCmdFunc(SubmitPST),
+}
+
+var txPstImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a partially signed transaction into the wallet",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCmdFunc(ImportPST),
+}
+
+var txPstExportCmd = &cobra.Command{
+	Use:   "export [txid] [file]",
+	Short: "Export a partially signed transaction from the wallet",
+	Args:  cobra.ExactArgs(2),
+	Run:   runCmdFunc(ExportPST),
+}
+
+var txPstListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the partially signed transactions in the wallet",
+	Args:  cobra.NoArgs,
+	Run:   runCmdFunc(ListPSTs),
+}
+
+func init() {
+	txPstCmd.AddCommand(txPstCreateCmd, txPstSignCmd, txPstMergeCmd, txPstInspectCmd, txPstSubmitCmd, txPstImportCmd, txPstExportCmd, txPstListCmd)
+	txPstSubmitCmd.Flags().DurationVarP(&TxWait, "wait", "w", 0, "Wait for the transaction to complete")
+	txCmd.AddCommand(txPstCmd)
+}
+
+// CreatePST creates a partially signed transaction for a pending transaction
+// or for a new transaction, and lists the signers of the principal.
+func CreatePST(args []string) (string, error) {
+	principal, err := url.Parse(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	var txn *protocol.Transaction
+	if hash, err := hex.DecodeString(args[1]); err == nil && len(hash) == 32 {
+		res, err := getTxUsingHash(hash, 0, false)
+		if err != nil {
+			return PrintJsonRpcError(err)
+		}
+		if !res.Transaction.Header.Principal.Equal(principal) {
+			return "", fmt.Errorf("transaction %X belongs to %v", hash, res.Transaction.Header.Principal)
+		}
+		txn = res.Transaction
+	} else {
+		var typ struct {
+			Type protocol.TransactionType
+		}
+		err = yaml.Unmarshal([]byte(args[1]), &typ)
+		if err != nil {
+			return "", fmt.Errorf("invalid payload: %v", err)
+		}
+
+		body, err := protocol.NewTransactionBody(typ.Type)
+		if err != nil {
+			return "", fmt.Errorf("invalid payload: %v", err)
+		}
+
+		err = yaml.Unmarshal([]byte(args[1]), body)
+		if err != nil {
+			return "", fmt.Errorf("invalid payload: %v", err)
+		}
+
+		txn = new(protocol.Transaction)
+		txn.Header.Principal = principal
+		txn.Body = body
+	}
+
+	signers, err := getPstSigners(principal)
+	if err != nil {
+		return "", err
+	}
+
+	pst := walletd.NewPST(txn, signers...)
+	err = walletd.WritePST(args[2], pst)
+	if err != nil {
+		return "", err
+	}
+
+	return printPstStatus(pst)
+}
+
+// getPstSigners returns the key pages of every authority of the principal, or
+// the lite identity if the principal is a lite account.
+func getPstSigners(principal *url.URL) ([]*wapi.PstSigner, error) {
+	if key, _, _ := protocol.ParseLiteTokenAddress(principal); key != nil {
+		return []*wapi.PstSigner{{Url: principal.RootIdentity(), Version: 1, Threshold: 1}}, nil
+	}
+
+	account, err := getAccount(principal.String())
+	if err != nil {
+		return nil, err
+	}
+	full, ok := account.(protocol.FullAccount)
+	if !ok {
+		return nil, fmt.Errorf("%v does not have authorities", principal)
+	}
+
+	var signers []*wapi.PstSigner
+	for _, authority := range full.GetAuth().Authorities {
+		if authority.Disabled {
+			continue
+		}
+		_, book, err := GetKeyBook(authority.Url.String())
+		if err != nil {
+			return nil, fmt.Errorf("authority %v: %v", authority.Url, err)
+		}
+		for i := uint64(0); i < book.PageCount; i++ {
+			pageUrl := protocol.FormatKeyPageUrl(book.Url, i)
+			_, page, err := GetKeyPage(pageUrl.String())
+			if err != nil {
+				return nil, fmt.Errorf("key page %v: %v", pageUrl, err)
+			}
+			signers = append(signers, &wapi.PstSigner{
+				Url:       pageUrl,
+				Authority: book.Url,
+				Version:   page.Version,
+				Threshold: page.AcceptThreshold,
+			})
+		}
+	}
+	return signers, nil
+}
+
+// SignPST signs a partially signed transaction with a key from the wallet
+// without querying the network.
+func SignPST(args []string) (string, error) {
+	pst, err := walletd.ReadPST(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	// The key may be specified as key@signer to select the signer
+	keyName := args[1]
+	var signerUrl *url.URL
+	if u, err := url.Parse(args[1]); err == nil && u.UserInfo != "" {
+		keyName = u.UserInfo
+		signerUrl = u.WithUserInfo("")
+	}
+
+	key, err := resolvePrivateKey(keyName)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = walletd.SignPST(pst, key, signerUrl)
+	if err != nil {
+		return "", err
+	}
+
+	err = walletd.WritePST(args[0], pst)
+	if err != nil {
+		return "", err
+	}
+
+	return printPstStatus(pst)
+}
+
+// MergePST merges the signatures of the input files and writes the result to
+// the output file.
+func MergePST(args []string) (string, error) {
+	pst, err := walletd.ReadPST(args[1])
+	if err != nil {
+		return "", err
+	}
+
+	for _, filename := range args[2:] {
+		src, err := walletd.ReadPST(filename)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", filename, err)
+		}
+		err = walletd.MergePST(pst, src)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", filename, err)
+		}
+	}
+
+	err = walletd.WritePST(args[0], pst)
+	if err != nil {
+		return "", err
+	}
+
+	return printPstStatus(pst)
+}
+
+func InspectPST(args []string) (string, error) {
+	pst, err := walletd.ReadPST(args[0])
+	if err != nil {
+		return "", err
+	}
+	return printPstStatus(pst)
+}
+
+func SubmitPST(args []string) (string, error) {
+	pst, err := walletd.ReadPST(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	res, err := walletd.SubmitPST(context.Background(), Client, pst)
+	if err != nil {
+		return PrintJsonRpcError(err)
+	}
+	return printExecuteResponse(res)
+}
+
+func ImportPST(args []string) (string, error) {
+	pst, err := walletd.ReadPST(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	err = walletd.ImportPST(walletd.GetWallet(), pst)
+	if err != nil {
+		return "", err
+	}
+	return printPstStatus(pst)
+}
+
+func ExportPST(args []string) (string, error) {
+	hash, err := hex.DecodeString(args[0])
+	if err != nil {
+		return "", fmt.Errorf("unable to parse transaction hash: %v", err)
+	}
+
+	pst, err := walletd.LoadPST(walletd.GetWallet(), hash)
+	if err != nil {
+		return "", fmt.Errorf("partially signed transaction %X: %v", hash, err)
+	}
+
+	err = walletd.WritePST(args[1], pst)
+	if err != nil {
+		return "", err
+	}
+	return printPstStatus(pst)
+}
+
+func ListPSTs([]string) (string, error) {
+	psts, err := walletd.ListPSTs(walletd.GetWallet())
+	if err != nil {
+		return "", err
+	}
+
+	var out string
+	for _, pst := range psts {
+		str, err := printPstStatus(pst)
+		if err != nil {
+			return "", err
+		}
+		out += str
+	}
+	return out, nil
+}
+
+func printPstStatus(pst *wapi.PartiallySignedTransaction) (string, error) {
+	status := walletd.InspectPST(pst)
+	if WantJsonOutput {
+		data, err := json.Marshal(status)
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+
+	out := fmt.Sprintf("Transaction %X\n", status.TransactionHash)
+	out += fmt.Sprintf("\tPrincipal:\t%v\n", status.Principal)
+	out += fmt.Sprintf("\tType:\t\t%v\n", status.TransactionType)
+	out += fmt.Sprintf("\tInitiated:\t%v\n", status.Initiated)
+	out += fmt.Sprintf("\tComplete:\t%v\n", status.Complete)
+	for _, signer := range status.Signers {
+		out += fmt.Sprintf("\tSigner %v:\t%d of %d signature(s)\n", signer.Url, signer.Signatures, signer.Threshold)
+	}
+	return out, nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/receipt"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+var receiptCmd = &cobra.Command{
+	Use:   "receipt",
+	Short: "Export receipts",
+}
+
+var receiptExportCmd = &cobra.Command{
+	Use:   "export [txid | tx url]",
+	Short: "Export a receipt proving a transaction to a directory anchor",
+	Long: `Export a receipt proving a transaction to a directory anchor, for verification
+by external tools. The chainpoint format is a Chainpoint v3 style JSON proof.
+The abi format is the Ethereum ABI encoding of
+(bytes32 start, bytes32 anchor, (bytes32 hash, bool right)[] entries).`,
+	Args: cobra.ExactArgs(1),
+	Run:  runCmdFunc(exportReceipt),
+}
+
+var receiptFlag = struct {
+	Format string
+	Local  bool
+}{}
+
+func init() {
+	receiptCmd.AddCommand(receiptExportCmd)
+	receiptExportCmd.Flags().StringVar(&receiptFlag.Format, "format", "chainpoint", "The format of the receipt: chainpoint or abi")
+	receiptExportCmd.Flags().BoolVar(&receiptFlag.Local, "local", false, "Export the receipt to the partition's anchor instead of the directory anchor")
+}
+
+func exportReceipt(args []string) (string, error) {
+	params := new(api.TxnQuery)
+	params.Prove = true
+	txid, err := url.ParseTxID(args[0])
+	if err == nil {
+		params.TxIdUrl = txid
+	} else if len(args[0]) == 64 {
+		params.Txid, err = hex.DecodeString(args[0])
+		if err != nil {
+			return "", err
+		}
+	} else {
+		return "", fmt.Errorf("transaction ID could not be parsed from the txID URL %s, reason: %v", args[0], err)
+	}
+
+	res, err := getTX(0, params)
+	if err != nil {
+		var rpcErr jsonrpc2.Error
+		if errors.As(err, &rpcErr) {
+			return PrintJsonRpcError(err)
+		}
+		return "", err
+	}
+
+	// Start with the proof from the principal's chain
+	var receipts []*managed.Receipt
+	for _, r := range res.Receipts {
+		if !r.Account.Equal(res.Transaction.Header.Principal) {
+			continue
+		}
+		if r.Error != "" {
+			return "", fmt.Errorf("get proof of %x: %s", res.TransactionHash[:4], r.Error)
+		}
+		receipts = append(receipts, &r.Proof)
+		break
+	}
+	if len(receipts) == 0 {
+		return "", fmt.Errorf("missing proof for %x from %v", res.TransactionHash[:4], res.Transaction.Header.Principal)
+	}
+
+	// Add the proof of the partition's anchor
+	if !receiptFlag.Local {
+		req := new(api.GeneralQuery)
+		req.Url = protocol.DnUrl().JoinPath(protocol.AnchorPool).WithFragment(fmt.Sprintf("anchor/%x", receipts[0].Anchor))
+		resp := new(api.ChainQueryResponse)
+		err = Client.RequestAPIv2(context.Background(), "query", req, resp)
+		if err != nil {
+			return "", fmt.Errorf("get proof of anchor %x: %w", receipts[0].Anchor[:4], err)
+		}
+		if resp.Receipt.Error != "" {
+			return "", fmt.Errorf("get proof of anchor %x: %s", receipts[0].Anchor[:4], resp.Receipt.Error)
+		}
+		receipts = append(receipts, &resp.Receipt.Proof)
+	}
+
+	r, err := managed.CombineReceipts(receipts...)
+	if err != nil {
+		return "", err
+	}
+	if !r.Validate() {
+		return "", fmt.Errorf("receipt for %x is invalid", res.TransactionHash[:4])
+	}
+
+	switch receiptFlag.Format {
+	case "chainpoint":
+		c, err := receipt.NewChainpoint(r)
+		if err != nil {
+			return "", err
+		}
+		b, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+
+	case "abi":
+		b, err := receipt.EncodeABI(r)
+		if err != nil {
+			return "", err
+		}
+		out := "0x" + hex.EncodeToString(b)
+		if !WantJsonOutput {
+			return out, nil
+		}
+		b, err = json.Marshal(map[string]string{"abi": out})
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+
+	default:
+		return "", fmt.Errorf("unknown format %q, expected chainpoint or abi", receiptFlag.Format)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/receipt"
+)
+
+func init() {
+	testMatrix.addTest(testCase6_1)
+}
+
+//testCase6_1 Export the receipt of a transaction
+func testCase6_1(t *testing.T, tc *testCmd) {
+	r, err := tc.execute(t, "faucet "+liteAccounts[0])
+	require.NoError(t, err)
+	var res ActionResponse
+	require.NoError(t, json.Unmarshal([]byte(r), &res))
+	_, err = waitForTxnUsingHash(res.TransactionHash, 10*time.Second, true)
+	require.NoError(t, err)
+	txid := hex.EncodeToString(res.TransactionHash)
+
+	// Export a Chainpoint proof to the partition's anchor
+	r, err = tc.execute(t, fmt.Sprintf("receipt export %s --local --format chainpoint", txid))
+	require.NoError(t, err)
+	local := new(receipt.Chainpoint)
+	require.NoError(t, json.Unmarshal([]byte(r), local))
+	require.Equal(t, txid, local.Hash)
+	localReceipt, err := local.Receipt()
+	require.NoError(t, err)
+	require.True(t, localReceipt.Validate())
+
+	// Export the same proof ABI-encoded
+	r, err = tc.execute(t, fmt.Sprintf("receipt export %s --local --format abi", txid))
+	require.NoError(t, err)
+	var abi struct{ Abi string }
+	require.NoError(t, json.Unmarshal([]byte(r), &abi))
+	require.True(t, strings.HasPrefix(abi.Abi, "0x"))
+	b, err := hex.DecodeString(abi.Abi[2:])
+	require.NoError(t, err)
+	abiReceipt, err := receipt.DecodeABI(b)
+	require.NoError(t, err)
+	require.True(t, abiReceipt.Validate())
+	require.True(t, abiReceipt.Equal(localReceipt))
+
+	// Export a proof to the directory anchor, once the partition's anchor has
+	// reached the directory
+	for start := time.Now(); time.Since(start) < 30*time.Second; time.Sleep(time.Second) {
+		r, err = tc.execute(t, fmt.Sprintf("receipt export %s --format chainpoint", txid))
+		if err == nil {
+			break
+		}
+	}
+	require.NoError(t, err)
+	dn := new(receipt.Chainpoint)
+	require.NoError(t, json.Unmarshal([]byte(r), dn))
+	dnReceipt, err := dn.Receipt()
+	require.NoError(t, err)
+	require.True(t, dnReceipt.Validate())
+	require.Equal(t, localReceipt.Start, dnReceipt.Start)
+	require.NotEqual(t, localReceipt.Anchor, dnReceipt.Anchor)
+	require.True(t, dnReceipt.Contains(localReceipt))
+
+	// An unknown format is rejected
+	_, err = tc.execute(t, fmt.Sprintf("receipt export %s --local --format other", txid))
+	require.ErrorContains(t, err, `unknown format "other"`)
+}
@@ -45,6 +45,8 @@ var (
 	Delegators        []string
 	AdditionalSigners []string
 	SignerVersion     uint
+	TxOffline         bool
+	TxFile            string
 )
 
 var currentUser = func() *user.User {
@@ -86,10 +88,13 @@ func InitRootCmd() *cobra.Command {
 	flags.StringSliceVar(&Delegators, "delegator", nil, "Specifies the delegator when creating a delegated signature")
 	flags.StringSliceVar(&AdditionalSigners, "sign-with", nil, "Specifies additional keys to sign the transaction with")
 	flags.UintVar(&SignerVersion, "signer-version", uint(0), "Specify the signer version. Overrides the default behavior of fetching the signer version.")
+	flags.BoolVar(&TxOffline, "offline", false, "Write the unsigned transaction to the envelope file instead of signing and submitting it")
+	flags.StringVar(&TxFile, "file", "", "The envelope file used for offline signing")
 
 	//TODO: to be moved to walletd configuration
 	flags.UintVar(&walletd.Entropy, "entropy", uint(128), "Specifies the size of the mnemonic entropy.")
 	flags.StringVar(&walletd.DatabaseDir, "database", filepath.Join(currentUser.HomeDir, ".accumulate"), "Directory the database is stored in")
+	flags.StringVar(&walletd.WalletName, "wallet", "", "Use the named wallet instead of the default wallet")
 	flags.BoolVar(&walletd.UseUnencryptedWallet, "use-unencrypted-wallet", false, "Use unencrypted wallet (strongly discouraged) stored at ~/.accumulate/wallet.db")
 	flags.BoolVar(&walletd.NoWalletVersionCheck, "no-wallet-version-check", false, "Bypass the check to prevent updating the wallet to the format supported by the cli")
 
@@ -111,8 +116,10 @@ func InitRootCmd() *cobra.Command {
 	cmd.AddCommand(operatorCmd, validatorCmd)
 	cmd.AddCommand(followerCmd)
 	cmd.AddCommand(versionCmd, describeCmd)
+	cmd.AddCommand(receiptCmd)
 	cmd.AddCommand(walletCmd)
 	cmd.AddCommand(resubmitCmd)
+	cmd.AddCommand(interactiveCmd, batchCmd)
 
 	//for the testnet integration
 	cmd.AddCommand(faucetCmd)
@@ -141,6 +148,11 @@ func InitRootCmd() *cobra.Command {
 			TxWait = 0
 		}
 
+		err = walletd.ValidateWalletName(walletd.WalletName)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	}
 
@@ -174,6 +174,8 @@ func executeCmd(cmd *cobra.Command, args []string, input string) (string, error)
 	TxWaitSynth = 0
 	TxIgnorePending = false
 	flagAccount.Lite = false
+	receiptFlag.Format = "chainpoint"
+	receiptFlag.Local = false
 
 	walletd.UseUnencryptedWallet = true
 
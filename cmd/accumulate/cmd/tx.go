@@ -27,7 +27,9 @@ var txCmd = &cobra.Command{
 		if len(args) > 0 {
 			switch arg := args[0]; arg {
 			case "submit":
-				if len(args) > 2 {
+				if TxFile != "" {
+					out, err = SubmitOfflineTX()
+				} else if len(args) > 2 {
 					out, err = submit(args[1:])
 				} else {
 					fmt.Println("Usage:")
@@ -69,7 +71,9 @@ var txCmd = &cobra.Command{
 					PrintTXExecute()
 				}
 			case "sign":
-				if len(args) > 2 {
+				if TxFile != "" && !TxOffline {
+					out, err = SignOfflineTX(args[1:])
+				} else if len(args) > 2 {
 					out, err = SignTX(args[1], args[2:])
 				} else {
 					fmt.Println("Usage:")
@@ -113,6 +117,8 @@ func PrintTXPendingGet() {
 func PrintTXCreate() {
 	fmt.Println("  accumulate tx create [token account url] [signing key ] [to] [amount]	Create new token tx")
 	fmt.Println("  accumulate tx create [lite token account url] [to] [amount]	Create new token tx")
+	fmt.Println("\tnote: [to] may be a label from the wallet address book")
+	fmt.Println("  accumulate tx create [token account url] [key page url] [to] [amount] --offline --file [envelope file]	Create an unsigned token tx for offline signing")
 }
 
 func PrintTXExecute() {
@@ -122,6 +128,7 @@ func PrintTXExecute() {
 
 func PrintTxSign() {
 	fmt.Println("  accumulate tx sign [origin url] [key name[@key book or page]] [txid]	Sign a pending transaction")
+	fmt.Println("  accumulate tx sign --file [envelope file] [key name[@key page]]	Sign a transaction offline")
 }
 
 func PrintTXHistoryGet() {
@@ -130,6 +137,7 @@ func PrintTXHistoryGet() {
 
 func PrintTXSubmit() {
 	fmt.Println("  accumulate tx submit [transaction] [[signature 0] ...] Submit an arbitrary transaction + signature(s)")
+	fmt.Println("  accumulate tx submit --file [envelope file]	Submit a transaction that was signed offline")
 }
 
 func PrintTX() {
@@ -380,7 +388,7 @@ func CreateTX(sender string, args []string) (string, error) {
 		return "", fmt.Errorf("invalid token url was obtained from %s, %v", u.String(), err)
 	}
 
-	u2, err := url.Parse(args[0])
+	u2, err := resolveAddress(args[0])
 	if err != nil {
 		return "", fmt.Errorf("invalid receiver url %s, %v", args[0], err)
 	}
@@ -495,26 +503,20 @@ func submit(args []string) (string, error) {
 		sigs = append(sigs, sig)
 	}
 
-	req := new(api.ExecuteRequest)
-	req.Envelope = &protocol.Envelope{Transaction: []*protocol.Transaction{txn}, Signatures: sigs}
-	if TxPretend {
-		req.CheckOnly = true
-	}
-
-	res, err := Client.ExecuteDirect(context.Background(), req)
+	res, err := executeEnvelope(&protocol.Envelope{Transaction: []*protocol.Transaction{txn}, Signatures: sigs})
 	if err != nil {
 		return PrintJsonRpcError(err)
 	}
-	if res.Code != 0 {
-		result := new(protocol.TransactionStatus)
-		if Remarshal(res.Result, result) != nil {
-			return "", errors.New(errors.StatusEncodingError, res.Message)
-		}
-		return "", result.Error
-	}
 
+	return printExecuteResponse(res)
+}
+
+// printExecuteResponse prints the response and, if --wait is specified, waits
+// for the transaction and prints the results.
+func printExecuteResponse(res *api.TxResponse) (string, error) {
 	var resps []*api.TransactionQueryResponse
-	if TxWait != 0 {
+	var err error
+	if TxWait != 0 && res.Code == 0 {
 		resps, err = waitForTxnUsingHash(res.TransactionHash, TxWait, TxIgnorePending)
 		if err != nil {
 			return PrintJsonRpcError(err)
@@ -525,14 +527,12 @@ func submit(args []string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if res.Code == 0 {
-		for _, response := range resps {
-			str, err := PrintTransactionQueryResponseV2(response)
-			if err != nil {
-				return PrintJsonRpcError(err)
-			}
-			result = fmt.Sprint(result, str, "\n")
+	for _, response := range resps {
+		str, err := PrintTransactionQueryResponseV2(response)
+		if err != nil {
+			return PrintJsonRpcError(err)
 		}
+		result = fmt.Sprint(result, str, "\n")
 	}
 	return result, nil
 }
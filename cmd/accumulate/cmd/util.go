@@ -149,8 +149,20 @@ func prepareSignerLite(signer *signing.Builder, str string) (bool, error) {
 
 	var key *walletd.Key
 	isLiteTokenAccount, _ := IsLiteTokenAccount(str)
+	if TxOffline {
+		// The key is not needed until the transaction is signed
+		if !isLiteTokenAccount {
+			isLiteIdentity, err := IsLiteIdentity(str)
+			if err != nil || !isLiteIdentity {
+				return false, err
+			}
+		}
+		signer.Url = u.RootIdentity()
+		signer.Version = 1
+		return true, nil
+	}
 	if isLiteTokenAccount {
-		key, err = walletd.LookupByLiteTokenUrl(str)
+		key, err = walletd.LookupByLiteTokenUrl(walletd.GetWallet(), str)
 		if err != nil {
 			return false, fmt.Errorf("unable to find private key for lite token account %s %v", str, err)
 		}
@@ -163,7 +175,7 @@ func prepareSignerLite(signer *signing.Builder, str string) (bool, error) {
 		if !isLiteIdentity {
 			return false, nil
 		}
-		key, err = walletd.LookupByLiteIdentityUrl(str)
+		key, err = walletd.LookupByLiteIdentityUrl(walletd.GetWallet(), str)
 		if err != nil {
 			return false, fmt.Errorf("unable to find private key for lite identity account %s %v", str, err)
 		}
@@ -176,36 +188,67 @@ func prepareSignerLite(signer *signing.Builder, str string) (bool, error) {
 	return true, nil
 }
 
+// signerCache caches the key page and version of signers while a batch is
+// executed, so every step does not query them again. It is nil otherwise.
+var signerCache map[string]*cachedSigner
+
+// batchSubmitted is set when a transaction is submitted while a batch is
+// executed, so the batch can invalidate the signer cache.
+var batchSubmitted bool
+
+type cachedSigner struct {
+	Url     *url.URL
+	Version uint64
+}
+
 func prepareSignerPage(signer *signing.Builder, origin *url.URL, signingKey string) error {
-	var keyName string
+	var keyName, cacheKey string
 	keyHolder, err := url.Parse(signingKey)
-	if err == nil && keyHolder.UserInfo != "" {
-		keyName = keyHolder.UserInfo
-		keyHolder = keyHolder.WithUserInfo("")
-	} else {
-		keyHolder = origin
-		keyName = signingKey
-	}
+	switch {
+	case TxOffline && err == nil && keyHolder.UserInfo == "" && isKeyPageUrl(keyHolder):
+		// When preparing a transaction for offline signing, the signer may be
+		// specified as a key page URL since the key may not be available
+		signer.Url = keyHolder
 
-	key, err := resolvePrivateKey(keyName)
-	if err != nil {
-		return err
-	}
-	signer.SetPrivateKey(key.PrivateKey)
+	default:
+		if err == nil && keyHolder.UserInfo != "" {
+			keyName = keyHolder.UserInfo
+			keyHolder = keyHolder.WithUserInfo("")
+		} else {
+			keyHolder = origin
+			keyName = signingKey
+		}
 
-	signer.Type = key.KeyInfo.Type
+		key, err := resolvePrivateKey(keyName)
+		if err != nil {
+			return err
+		}
+		signer.SetPrivateKey(key.PrivateKey)
 
-	keyInfo, err := getKey(keyHolder.String(), key.PublicKeyHash())
-	if err != nil {
-		return fmt.Errorf("failed to get key for %q : %v", origin, err)
-	}
+		signer.Type = key.KeyInfo.Type
 
-	signer.Url = keyInfo.Signer
+		cacheKey = fmt.Sprintf("%v#%x", keyHolder, key.PublicKeyHash())
+		if cached, ok := signerCache[cacheKey]; ok {
+			signer.Url = cached.Url
+			signer.Version = cached.Version
+			if SignerVersion != 0 {
+				signer.Version = uint64(SignerVersion)
+			}
+			return nil
+		}
+
+		keyInfo, err := getKey(keyHolder.String(), key.PublicKeyHash())
+		if err != nil {
+			return fmt.Errorf("failed to get key for %q : %v", origin, err)
+		}
+
+		signer.Url = keyInfo.Signer
+	}
 
 	var page *protocol.KeyPage
 	_, err = getRecord(signer.Url.String(), &page)
 	if err != nil {
-		return fmt.Errorf("failed to get %q : %v", keyInfo.Signer, err)
+		return fmt.Errorf("failed to get %q : %v", signer.Url, err)
 	}
 	if SignerVersion != 0 {
 		signer.Version = uint64(SignerVersion)
@@ -213,9 +256,17 @@ func prepareSignerPage(signer *signing.Builder, origin *url.URL, signingKey stri
 		signer.Version = page.Version
 	}
 
+	if signerCache != nil && cacheKey != "" {
+		signerCache[cacheKey] = &cachedSigner{Url: signer.Url, Version: page.Version}
+	}
 	return nil
 }
 
+func isKeyPageUrl(u *url.URL) bool {
+	_, _, ok := protocol.ParseKeyPageUrl(u)
+	return ok
+}
+
 func parseArgsAndPrepareSigner(args []string) ([]string, *url.URL, []*signing.Builder, error) {
 	principal, err := url.Parse(args[0])
 	if err != nil {
@@ -329,6 +380,31 @@ func queryAs(method string, input, output interface{}) error {
 }
 
 func dispatchTxRequest(payload interface{}, origin *url.URL, signers []*signing.Builder) (*api.TxResponse, error) {
+	env, err := prepareEnvelope(payload, origin, signers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign
+	for _, signer := range signers {
+		var sig protocol.Signature
+		if env.Transaction[0].Header.Initiator == ([32]byte{}) {
+			sig, err = signer.Initiate(env.Transaction[0])
+		} else {
+			sig, err = signer.Sign(env.Transaction[0].GetHash())
+		}
+		if err != nil {
+			return nil, err
+		}
+		env.Signatures = append(env.Signatures, sig)
+	}
+
+	return executeEnvelope(env)
+}
+
+// prepareEnvelope converts the payload to an envelope and resolves the
+// transaction if the payload is a transaction hash.
+func prepareEnvelope(payload interface{}, origin *url.URL, signers []*signing.Builder) (*protocol.Envelope, error) {
 	// Convert the payload to an envelope
 	var env *protocol.Envelope
 	var err error
@@ -383,26 +459,20 @@ func dispatchTxRequest(payload interface{}, origin *url.URL, signers []*signing.
 		}
 	}
 
-	// Sign
-	for _, signer := range signers {
-		var sig protocol.Signature
-		if env.Transaction[0].Header.Initiator == ([32]byte{}) {
-			sig, err = signer.Initiate(env.Transaction[0])
-		} else {
-			sig, err = signer.Sign(env.Transaction[0].GetHash())
-		}
-		if err != nil {
-			return nil, err
-		}
-		env.Signatures = append(env.Signatures, sig)
-	}
+	return env, nil
+}
 
+// executeEnvelope submits a signed envelope.
+func executeEnvelope(env *protocol.Envelope) (*api.TxResponse, error) {
 	req := new(api.ExecuteRequest)
 	req.Envelope = env
 	if TxPretend {
 		req.CheckOnly = true
 	}
 
+	if signerCache != nil {
+		batchSubmitted = true
+	}
 	res, err := Client.ExecuteDirect(context.Background(), req)
 	if err != nil {
 		_, err := PrintJsonRpcError(err)
@@ -438,6 +508,10 @@ func dispatchTxAndWait(payload interface{}, origin *url.URL, signers []*signing.
 }
 
 func dispatchTxAndPrintResponse(payload interface{}, origin *url.URL, signers []*signing.Builder) (string, error) {
+	if TxOffline {
+		return writeOfflineEnvelope(payload, origin, signers)
+	}
+
 	res, resps, err := dispatchTxAndWait(payload, origin, signers)
 	if err != nil {
 		return PrintJsonRpcError(err)
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"syscall"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/tyler-smith/go-bip39"
 	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
 	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/slip39"
 )
 
 var walletCmd = &cobra.Command{
@@ -57,6 +59,19 @@ var walletInitImportKeystoreCmd = &cobra.Command{
 	},
 }
 
+var walletInitImportSharesCmd = &cobra.Command{
+	Use:   "shares [share file (optional)]... --passphrase",
+	Short: "restore a wallet seed from SLIP-39 shares via command prompt or share files",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := InitDBImportShares(cmd, args)
+		printOutput(cmd, "", err)
+	},
+}
+
+var flagImportShares = struct {
+	Passphrase bool
+}{}
+
 var walletInitScriptCmd = &cobra.Command{
 	Use:   "script",
 	Short: "create a wallet from a script (used for testing only)",
@@ -91,13 +106,29 @@ var walletExportCmd = &cobra.Command{
 	},
 }
 
+var walletListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the named wallets",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		names, err := walletd.ListWallets()
+		var out string
+		for _, name := range names {
+			out += name + "\n"
+		}
+		printOutput(cmd, out, err)
+	},
+}
+
 func init() {
 	initRunFlags(walletCmd, false)
 	walletInitCmd.AddCommand(walletInitCreateCmd, walletInitImportCmd, walletInitImportCmd, walletInitScriptCmd)
-	walletInitImportCmd.AddCommand(walletInitImportMnemonicCmd, walletInitImportKeystoreCmd)
+	walletInitImportCmd.AddCommand(walletInitImportMnemonicCmd, walletInitImportKeystoreCmd, walletInitImportSharesCmd)
+	walletInitImportSharesCmd.Flags().BoolVar(&flagImportShares.Passphrase, "passphrase", false, "Prompt for the passphrase the shares were encrypted with")
 	walletCmd.AddCommand(walletInitCmd)
 	walletCmd.AddCommand(walletServeCmd)
 	walletCmd.AddCommand(walletExportCmd)
+	walletCmd.AddCommand(walletListCmd)
 }
 
 var walletdConfig = &service.Config{
@@ -108,10 +139,11 @@ var walletdConfig = &service.Config{
 }
 
 var flagRunWalletd = struct {
-	ListenAddress string
-	CiStopAfter   time.Duration
-	LogFile       string
-	JsonLogFile   string
+	ListenAddress   string
+	CiStopAfter     time.Duration
+	LogFile         string
+	JsonLogFile     string
+	Unauthenticated bool
 }{}
 
 func initRunFlags(cmd *cobra.Command, forService bool) {
@@ -119,6 +151,7 @@ func initRunFlags(cmd *cobra.Command, forService bool) {
 	cmd.PersistentFlags().StringVar(&flagRunWalletd.ListenAddress, "listen", "http://localhost:26661", "listen address for daemon")
 	cmd.PersistentFlags().StringVar(&flagRunWalletd.LogFile, "log-file", "", "Write logs to a file as plain text")
 	cmd.PersistentFlags().StringVar(&flagRunWalletd.JsonLogFile, "json-log-file", "", "Write logs to a file as JSON")
+	cmd.PersistentFlags().BoolVar(&flagRunWalletd.Unauthenticated, "unauthenticated", false, "Permit requests without an API token while the wallet has no tokens. Only use this if the daemon is not reachable by untrusted clients")
 
 	if !forService {
 		cmd.Flags().DurationVar(&flagRunWalletd.CiStopAfter, "ci-stop-after", 0, "FOR CI ONLY - stop the node after some time")
@@ -129,7 +162,7 @@ func initRunFlags(cmd *cobra.Command, forService bool) {
 func runWalletd(cmd *cobra.Command, _ []string) (string, error) {
 	//this will be reworked when wallet database accessed via GetWallet() is moved to the backend.
 	prog, err := walletd.NewProgram(cmd, &walletd.ServiceOptions{WorkDir: walletd.DatabaseDir,
-		LogFilename: flagRunWalletd.LogFile, JsonLogFilename: flagRunWalletd.JsonLogFile}, flagRunWalletd.ListenAddress)
+		LogFilename: flagRunWalletd.LogFile, JsonLogFilename: flagRunWalletd.JsonLogFile, Unauthenticated: flagRunWalletd.Unauthenticated}, flagRunWalletd.ListenAddress, Client)
 	if err != nil {
 		return "", err
 	}
@@ -182,6 +215,50 @@ func InitDBImport(cmd *cobra.Command, memDb bool) error {
 	return nil
 }
 
+// InitDBImportShares restores the wallet seed from SLIP-39 shares. Each file
+// contains one share. If no files are given, shares are read from the prompt
+// until the threshold is reached.
+func InitDBImportShares(cmd *cobra.Command, files []string) error {
+	var shares []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		shares = append(shares, strings.TrimSpace(string(data)))
+	}
+
+	if len(files) == 0 {
+		var threshold int
+		for len(shares) == 0 || len(shares) < threshold {
+			share, err := getPasswdPrompt(cmd, fmt.Sprintf("Enter share %d : ", len(shares)+1), true)
+			if err != nil {
+				return db.ErrInvalidPassword
+			}
+
+			// Check the integrity of each share as it is entered
+			s, err := slip39.ParseShare(share)
+			if err != nil {
+				return fmt.Errorf("share %d: %w", len(shares)+1, err)
+			}
+			threshold = int(s.MemberThreshold)
+			shares = append(shares, share)
+		}
+	}
+
+	var passphrase string
+	if flagImportShares.Passphrase {
+		var err error
+		passphrase, err = getPasswdPrompt(cmd, "Share passphrase : ", true)
+		if err != nil {
+			return db.ErrInvalidPassword
+		}
+	}
+
+	_, err := walletd.ImportSeedShares(shares, passphrase)
+	return err
+}
+
 func InitDBCreate(memDb bool) error {
 	root, _ := walletd.GetWallet().Get(walletd.BucketMnemonic, []byte("seed"))
 	if len(root) != 0 {
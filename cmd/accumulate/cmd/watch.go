@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd"
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+var walletWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Manage watch-only accounts and keys",
+}
+
+var walletWatchAddCmd = &cobra.Command{
+	Use:   "add [account url] [label (optional)]",
+	Short: "Watch an account",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: runCmdFunc(func(args []string) (string, error) {
+		u, err := url.Parse(args[0])
+		if err != nil {
+			return "", err
+		}
+		var label string
+		if len(args) > 1 {
+			label = args[1]
+		}
+		err = walletd.WatchAccount(u, label)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Watching %v\n", u), nil
+	}),
+}
+
+var walletWatchKeyCmd = &cobra.Command{
+	Use:   "key [public key hex] [label (optional)] --sigtype (optional)",
+	Short: "Watch a public key",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: runCmdFunc(func(args []string) (string, error) {
+		pubKey, err := hex.DecodeString(args[0])
+		if err != nil {
+			return "", fmt.Errorf("invalid public key: %v", err)
+		}
+		typ, err := ValidateSigType(SigType)
+		if err != nil {
+			return "", err
+		}
+		var label string
+		if len(args) > 1 {
+			label = args[1]
+		}
+		err = walletd.WatchKey(pubKey, typ, label)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Watching key %x\n", pubKey), nil
+	}),
+}
+
+var walletWatchRemoveCmd = &cobra.Command{
+	Use:   "remove [account url or public key hex]",
+	Short: "Stop watching an account or a public key",
+	Args:  cobra.ExactArgs(1),
+	Run: runCmdFunc(func(args []string) (string, error) {
+		if pubKey, err := hex.DecodeString(args[0]); err == nil {
+			err = walletd.UnwatchKey(pubKey)
+			if err == nil {
+				return fmt.Sprintf("Stopped watching key %x\n", pubKey), nil
+			}
+		}
+
+		u, err := url.Parse(args[0])
+		if err != nil {
+			return "", err
+		}
+		err = walletd.UnwatchAccount(u)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Stopped watching %v\n", u), nil
+	}),
+}
+
+var walletWatchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List watched accounts and keys",
+	Args:  cobra.NoArgs,
+	Run:   runCmdFunc(func([]string) (string, error) { return ListWatched() }),
+}
+
+var walletAddressCmd = &cobra.Command{
+	Use:   "address",
+	Short: "Manage the address book",
+}
+
+var walletAddressAddCmd = &cobra.Command{
+	Use:   "add [label] [url]",
+	Short: "Add a labeled address to the address book",
+	Args:  cobra.ExactArgs(2),
+	Run: runCmdFunc(func(args []string) (string, error) {
+		u, err := url.Parse(args[1])
+		if err != nil {
+			return "", err
+		}
+		err = walletd.AddAddress(args[0], u)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Added %s = %v\n", args[0], u), nil
+	}),
+}
+
+var walletAddressRemoveCmd = &cobra.Command{
+	Use:   "remove [label]",
+	Short: "Remove a labeled address from the address book",
+	Args:  cobra.ExactArgs(1),
+	Run: runCmdFunc(func(args []string) (string, error) {
+		err := walletd.RemoveAddress(args[0])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Removed %s\n", args[0]), nil
+	}),
+}
+
+var walletAddressListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the address book",
+	Args:  cobra.NoArgs,
+	Run:   runCmdFunc(func([]string) (string, error) { return ListAddresses() }),
+}
+
+var walletBalanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Show the balances of all owned and watched token accounts",
+	Args:  cobra.NoArgs,
+	Run:   runCmdFunc(func([]string) (string, error) { return WalletBalance() }),
+}
+
+func init() {
+	walletWatchKeyCmd.Flags().StringVar(&SigType, "sigtype", "ed25519", "Specify the type of the public key")
+	walletWatchCmd.AddCommand(walletWatchAddCmd, walletWatchKeyCmd, walletWatchRemoveCmd, walletWatchListCmd)
+	walletAddressCmd.AddCommand(walletAddressAddCmd, walletAddressRemoveCmd, walletAddressListCmd)
+	walletCmd.AddCommand(walletWatchCmd, walletAddressCmd, walletBalanceCmd)
+}
+
+func ListWatched() (string, error) {
+	accounts, err := walletd.ListWatchedAccounts()
+	if err != nil {
+		return "", err
+	}
+	keys, err := walletd.ListWatchedKeys()
+	if err != nil {
+		return "", err
+	}
+
+	if WantJsonOutput {
+		data, err := json.Marshal(map[string]interface{}{"accounts": accounts, "keys": keys})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var out string
+	for _, account := range accounts {
+		out += fmt.Sprintf("\t%v\t%s\n", account.Url, account.Label)
+	}
+	for _, key := range keys {
+		lta, err := walletd.WatchedKeyLiteTokenAccount(key)
+		if err != nil {
+			return "", err
+		}
+		out += fmt.Sprintf("\t%x\t%v\t%v\t%s\n", key.PublicKey, key.Type, lta, key.Label)
+	}
+	return out, nil
+}
+
+func ListAddresses() (string, error) {
+	entries, err := walletd.ListAddresses()
+	if err != nil {
+		return "", err
+	}
+
+	if WantJsonOutput {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var out string
+	for _, entry := range entries {
+		out += fmt.Sprintf("\t%s\t%v\n", entry.Label, entry.Url)
+	}
+	return out, nil
+}
+
+// resolveAddress resolves an address book label or parses a URL.
+func resolveAddress(s string) (*url.URL, error) {
+	return walletd.ResolveAddress(s)
+}
+
+type tokenBalance struct {
+	Url      *url.URL `json:"url"`
+	TokenUrl *url.URL `json:"tokenUrl"`
+	Balance  *big.Int `json:"balance"`
+	Watched  bool     `json:"watched"`
+}
+
+// WalletBalance queries every token account owned or watched by the wallet
+// and reports each balance and the total of each token.
+func WalletBalance() (string, error) {
+	owned, err := ownedAccounts()
+	if err != nil {
+		return "", err
+	}
+
+	// Watched accounts and keys
+	var watched []*url.URL
+	accounts, err := walletd.ListWatchedAccounts()
+	if err != nil {
+		return "", err
+	}
+	for _, account := range accounts {
+		watched = append(watched, account.Url)
+	}
+	keys, err := walletd.ListWatchedKeys()
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		lta, err := walletd.WatchedKeyLiteTokenAccount(key)
+		if err != nil {
+			return "", err
+		}
+		watched = append(watched, lta)
+	}
+
+	seen := map[[32]byte]bool{}
+	var balances []*tokenBalance
+	for i, list := range [][]*url.URL{owned, watched} {
+		for _, u := range list {
+			b, err := collectTokenBalances(u, seen)
+			if err != nil {
+				return "", err
+			}
+			for _, b := range b {
+				b.Watched = i == 1
+			}
+			balances = append(balances, b...)
+		}
+	}
+
+	totals := map[string]*big.Int{}
+	for _, b := range balances {
+		total, ok := totals[b.TokenUrl.String()]
+		if !ok {
+			total = new(big.Int)
+			totals[b.TokenUrl.String()] = total
+		}
+		total.Add(total, b.Balance)
+	}
+
+	if WantJsonOutput {
+		data, err := json.Marshal(map[string]interface{}{"accounts": balances, "totals": totals})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var out string
+	for _, b := range balances {
+		amount, err := formatAmount(b.TokenUrl.String(), b.Balance)
+		if err != nil {
+			return "", err
+		}
+		var watched string
+		if b.Watched {
+			watched = "\t(watched)"
+		}
+		out += fmt.Sprintf("\t%v\t%s%s\n", b.Url, amount, watched)
+	}
+
+	tokens := make([]string, 0, len(totals))
+	for token := range totals {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	out += "\nTotal:\n"
+	for _, token := range tokens {
+		amount, err := formatAmount(token, totals[token])
+		if err != nil {
+			return "", err
+		}
+		out += fmt.Sprintf("\t%s\n", amount)
+	}
+	return out, nil
+}
+
+// ownedAccounts returns the lite token accounts of the wallet's keys and the
+// wallet's ADIs.
+func ownedAccounts() ([]*url.URL, error) {
+	var owned []*url.URL
+
+	// Owned lite token accounts
+	b, err := walletd.GetWallet().GetBucket(walletd.BucketLite)
+	if err == nil {
+		for _, v := range b.KeyValueList {
+			k := new(walletd.Key)
+			err = k.LoadByLabel(walletd.GetWallet(), string(v.Value))
+			if err != nil {
+				return nil, err
+			}
+			lta, err := protocol.LiteTokenAddressFromHash(k.PublicKeyHash(), protocol.ACME)
+			if err != nil {
+				return nil, err
+			}
+			owned = append(owned, lta)
+		}
+	}
+
+	// Owned ADIs
+	b, err = walletd.GetWallet().GetBucket(walletd.BucketAdi)
+	if err == nil {
+		for _, v := range b.KeyValueList {
+			u, err := url.Parse(string(v.Key))
+			if err != nil {
+				return nil, err
+			}
+			owned = append(owned, u)
+		}
+	}
+
+	return owned, nil
+}
+
+// collectTokenBalances returns the balance of the account if it is a token
+// account, or of every token account in its directory if it is an identity.
+// Accounts that do not exist are skipped.
+func collectTokenBalances(u *url.URL, seen map[[32]byte]bool) ([]*tokenBalance, error) {
+	if seen[u.AccountID32()] {
+		return nil, nil
+	}
+	seen[u.AccountID32()] = true
+
+	account, err := getAccount(u.String())
+	if err != nil {
+		var jerr *JsonRpcError
+		if errors.As(err, &jerr) && jerr.Err.Code == api.ErrCodeNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("query %v: %v", u, err)
+	}
+
+	switch account := account.(type) {
+	case *protocol.TokenAccount:
+		return []*tokenBalance{{Url: account.Url, TokenUrl: account.TokenUrl, Balance: &account.Balance}}, nil
+	case *protocol.LiteTokenAccount:
+		return []*tokenBalance{{Url: account.Url, TokenUrl: account.TokenUrl, Balance: &account.Balance}}, nil
+	case *protocol.ADI, *protocol.LiteIdentity:
+		entries, err := queryDirectory(u)
+		if err != nil {
+			return nil, err
+		}
+		var balances []*tokenBalance
+		for _, entry := range entries {
+			if entry.Equal(u) {
+				continue
+			}
+			b, err := collectTokenBalances(entry, seen)
+			if err != nil {
+				return nil, err
+			}
+			balances = append(balances, b...)
+		}
+		return balances, nil
+	default:
+		return nil, nil
+	}
+}
+
+// queryDirectory returns every entry of an identity's directory.
+func queryDirectory(u *url.URL) ([]*url.URL, error) {
+	var entries []*url.URL
+	params := api.DirectoryQuery{}
+	params.Url = u
+	params.Count = 100
+	for {
+		var res api.MultiResponse
+		err := Client.RequestAPIv2(context.Background(), "query-directory", &params, &res)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range res.Items {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid directory entry %v", item)
+			}
+			entry, err := url.Parse(s)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+
+		params.Start += uint64(len(res.Items))
+		if len(res.Items) == 0 || params.Start >= res.Total {
+			return entries, nil
+		}
+	}
+}
@@ -3,6 +3,7 @@ package walletd
 import (
 	"fmt"
 
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
 	url2 "gitlab.com/accumulatenetwork/accumulate/pkg/url"
 )
 
@@ -30,8 +31,8 @@ func ListADIs() (string, error) {
 	return out, nil
 }
 
-func getAdiList() (urls []url2.URL, err error) {
-	b, err := GetWallet().GetBucket(BucketAdi)
+func getAdiList(w db.DB) (urls []url2.URL, err error) {
+	b, err := w.GetBucket(BucketAdi)
 	if err != nil {
 		return nil, err
 	}
@@ -7,3 +7,14 @@ type ErrorCode jsonrpc2.ErrorCode
 func (e ErrorCode) Code() jsonrpc2.ErrorCode {
 	return jsonrpc2.ErrorCode(e)
 }
+
+// ApiPermission is the level of access granted by an API token.
+type ApiPermission uint64
+
+// Permits returns true if the permission includes the required permission.
+func (p ApiPermission) Permits(required ApiPermission) bool {
+	return p >= required
+}
+
+// PolicyRule is a rule of a signing policy.
+type PolicyRule uint64
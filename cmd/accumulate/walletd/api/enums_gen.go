@@ -8,6 +8,24 @@ import (
 	"strings"
 )
 
+// ApiPermissionRead permits methods that do not use the wallet's keys or change its state.
+const ApiPermissionRead ApiPermission = 1
+
+// ApiPermissionSign permits signing and building transactions, in addition to Read.
+const ApiPermissionSign ApiPermission = 2
+
+// ApiPermissionAdmin permits every method.
+const ApiPermissionAdmin ApiPermission = 3
+
+// ErrorCodePolicyViolation indicates the transaction does not satisfy the wallet's signing policies.
+const ErrorCodePolicyViolation ErrorCode = -33005
+
+// ErrorCodePermissionDenied indicates the API token does not permit the request.
+const ErrorCodePermissionDenied ErrorCode = -33004
+
+// ErrorCodeUnauthorized indicates the request did not include a valid API token.
+const ErrorCodeUnauthorized ErrorCode = -33003
+
 // ErrorCodeGeneralError .
 const ErrorCodeGeneralError ErrorCode = -33002
 
@@ -17,6 +35,85 @@ const ErrorCodeAlreadyExists ErrorCode = -33001
 // ErrorCodeNotFound .
 const ErrorCodeNotFound ErrorCode = -33000
 
+// PolicyRuleTransactionType .
+const PolicyRuleTransactionType PolicyRule = 1
+
+// PolicyRulePrincipal .
+const PolicyRulePrincipal PolicyRule = 2
+
+// PolicyRuleRecipient .
+const PolicyRuleRecipient PolicyRule = 3
+
+// PolicyRuleAmount .
+const PolicyRuleAmount PolicyRule = 4
+
+// PolicyRuleMemo .
+const PolicyRuleMemo PolicyRule = 5
+
+// GetEnumValue returns the value of the Api Permission
+func (v ApiPermission) GetEnumValue() uint64 { return uint64(v) }
+
+// SetEnumValue sets the value. SetEnumValue returns false if the value is invalid.
+func (v *ApiPermission) SetEnumValue(id uint64) bool {
+	u := ApiPermission(id)
+	switch u {
+	case ApiPermissionRead, ApiPermissionSign, ApiPermissionAdmin:
+		*v = u
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the name of the Api Permission.
+func (v ApiPermission) String() string {
+	switch v {
+	case ApiPermissionRead:
+		return "read"
+	case ApiPermissionSign:
+		return "sign"
+	case ApiPermissionAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("ApiPermission:%d", v)
+	}
+}
+
+// ApiPermissionByName returns the named Api Permission.
+func ApiPermissionByName(name string) (ApiPermission, bool) {
+	switch strings.ToLower(name) {
+	case "read":
+		return ApiPermissionRead, true
+	case "sign":
+		return ApiPermissionSign, true
+	case "admin":
+		return ApiPermissionAdmin, true
+	default:
+		return 0, false
+	}
+}
+
+// MarshalJSON marshals the Api Permission to JSON as a string.
+func (v ApiPermission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON unmarshals the Api Permission from JSON as a string.
+func (v *ApiPermission) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	*v, ok = ApiPermissionByName(s)
+	if !ok || strings.ContainsRune(v.String(), ':') {
+		return fmt.Errorf("invalid Api Permission %q", s)
+	}
+	return nil
+}
+
 // GetEnumValue returns the value of the Error Code
 func (v ErrorCode) GetEnumValue() uint64 { return uint64(v) }
 
@@ -24,7 +121,7 @@ func (v ErrorCode) GetEnumValue() uint64 { return uint64(v) }
 func (v *ErrorCode) SetEnumValue(id uint64) bool {
 	u := ErrorCode(id)
 	switch u {
-	case ErrorCodeGeneralError, ErrorCodeAlreadyExists, ErrorCodeNotFound:
+	case ErrorCodePolicyViolation, ErrorCodePermissionDenied, ErrorCodeUnauthorized, ErrorCodeGeneralError, ErrorCodeAlreadyExists, ErrorCodeNotFound:
 		*v = u
 		return true
 	default:
@@ -35,6 +132,12 @@ func (v *ErrorCode) SetEnumValue(id uint64) bool {
 // String returns the name of the Error Code.
 func (v ErrorCode) String() string {
 	switch v {
+	case ErrorCodePolicyViolation:
+		return "policyViolation"
+	case ErrorCodePermissionDenied:
+		return "permissionDenied"
+	case ErrorCodeUnauthorized:
+		return "unauthorized"
 	case ErrorCodeGeneralError:
 		return "generalError"
 	case ErrorCodeAlreadyExists:
@@ -49,6 +152,12 @@ func (v ErrorCode) String() string {
 // ErrorCodeByName returns the named Error Code.
 func ErrorCodeByName(name string) (ErrorCode, bool) {
 	switch strings.ToLower(name) {
+	case "policyviolation":
+		return ErrorCodePolicyViolation, true
+	case "permissiondenied":
+		return ErrorCodePermissionDenied, true
+	case "unauthorized":
+		return ErrorCodeUnauthorized, true
 	case "generalerror":
 		return ErrorCodeGeneralError, true
 	case "alreadyexists":
@@ -80,3 +189,75 @@ func (v *ErrorCode) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// GetEnumValue returns the value of the Policy Rule
+func (v PolicyRule) GetEnumValue() uint64 { return uint64(v) }
+
+// SetEnumValue sets the value. SetEnumValue returns false if the value is invalid.
+func (v *PolicyRule) SetEnumValue(id uint64) bool {
+	u := PolicyRule(id)
+	switch u {
+	case PolicyRuleTransactionType, PolicyRulePrincipal, PolicyRuleRecipient, PolicyRuleAmount, PolicyRuleMemo:
+		*v = u
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the name of the Policy Rule.
+func (v PolicyRule) String() string {
+	switch v {
+	case PolicyRuleTransactionType:
+		return "transactionType"
+	case PolicyRulePrincipal:
+		return "principal"
+	case PolicyRuleRecipient:
+		return "recipient"
+	case PolicyRuleAmount:
+		return "amount"
+	case PolicyRuleMemo:
+		return "memo"
+	default:
+		return fmt.Sprintf("PolicyRule:%d", v)
+	}
+}
+
+// PolicyRuleByName returns the named Policy Rule.
+func PolicyRuleByName(name string) (PolicyRule, bool) {
+	switch strings.ToLower(name) {
+	case "transactiontype":
+		return PolicyRuleTransactionType, true
+	case "principal":
+		return PolicyRulePrincipal, true
+	case "recipient":
+		return PolicyRuleRecipient, true
+	case "amount":
+		return PolicyRuleAmount, true
+	case "memo":
+		return PolicyRuleMemo, true
+	default:
+		return 0, false
+	}
+}
+
+// MarshalJSON marshals the Policy Rule to JSON as a string.
+func (v PolicyRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON unmarshals the Policy Rule from JSON as a string.
+func (v *PolicyRule) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	*v, ok = PolicyRuleByName(s)
+	if !ok || strings.ContainsRune(v.String(), ':') {
+		return fmt.Errorf("invalid Policy Rule %q", s)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package api
+
+import "fmt"
+
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("policy %q: %s", v.Policy, v.Message)
+}
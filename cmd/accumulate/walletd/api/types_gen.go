@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"gitlab.com/accumulatenetwork/accumulate/internal/encoding"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
 )
 
@@ -113,6 +114,27 @@ type FinalizeEnvelopeRequest struct {
 	Name string `json:"name,omitempty" form:"name" query:"name" validate:"required"`
 }
 
+type HistoryExportRequest struct {
+	Account *url.URL `json:"account,omitempty" form:"account" query:"account"`
+	// Format is json or csv.
+	Format string `json:"format,omitempty" form:"format" query:"format"`
+}
+
+type HistoryExportResponse struct {
+	Entries []*LedgerEntry `json:"entries,omitempty" form:"entries" query:"entries" validate:"required"`
+	Csv     string         `json:"csv,omitempty" form:"csv" query:"csv"`
+}
+
+type HistorySyncRequest struct {
+
+	// Accounts lists the accounts to sync, defaulting to every token account owned by the wallet.
+	Accounts []*url.URL `json:"accounts,omitempty" form:"accounts" query:"accounts"`
+}
+
+type HistorySyncResponse struct {
+	Accounts []*HistorySyncState `json:"accounts,omitempty" form:"accounts" query:"accounts" validate:"required"`
+}
+
 type KeyData struct {
 	Name      string  `json:"name,omitempty" form:"name" query:"name" validate:"required"`
 	PublicKey []byte  `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
@@ -127,11 +149,55 @@ type NewTransactionRequest struct {
 	TxName string `json:"txName,omitempty" form:"txName" query:"txName" validate:"required"`
 }
 
+// PolicyViolation describes the signing policy rule a transaction does not satisfy.
+type PolicyViolation struct {
+	Policy  string     `json:"policy,omitempty" form:"policy" query:"policy" validate:"required"`
+	Rule    PolicyRule `json:"rule,omitempty" form:"rule" query:"rule" validate:"required"`
+	Message string     `json:"message,omitempty" form:"message" query:"message" validate:"required"`
+}
+
 type ProveReceiptRequest struct {
 	DataJson    string `json:"dataJson,omitempty" form:"dataJson" query:"dataJson" validate:"required"`
 	ReceiptJson string `json:"receiptJson,omitempty" form:"receiptJson" query:"receiptJson" validate:"required"`
 }
 
+type PstHashRequest struct {
+	TransactionHash []byte `json:"transactionHash,omitempty" form:"transactionHash" query:"transactionHash" validate:"required"`
+}
+
+type PstListResponse struct {
+	Transactions []*PstStatus `json:"transactions,omitempty" form:"transactions" query:"transactions" validate:"required"`
+}
+
+type PstRequest struct {
+	Pst *PartiallySignedTransaction `json:"pst,omitempty" form:"pst" query:"pst" validate:"required"`
+}
+
+type PstResponse struct {
+	Pst *PartiallySignedTransaction `json:"pst,omitempty" form:"pst" query:"pst" validate:"required"`
+}
+
+type PstSignRequest struct {
+	TransactionHash []byte   `json:"transactionHash,omitempty" form:"transactionHash" query:"transactionHash" validate:"required"`
+	KeyName         string   `json:"keyName,omitempty" form:"keyName" query:"keyName" validate:"required"`
+	Signer          *url.URL `json:"signer,omitempty" form:"signer" query:"signer"`
+}
+
+type PstSignerStatus struct {
+	Url        *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	Threshold  uint64   `json:"threshold,omitempty" form:"threshold" query:"threshold" validate:"required"`
+	Signatures uint64   `json:"signatures,omitempty" form:"signatures" query:"signatures" validate:"required"`
+}
+
+type PstStatus struct {
+	TransactionHash []byte                   `json:"transactionHash,omitempty" form:"transactionHash" query:"transactionHash" validate:"required"`
+	Principal       *url.URL                 `json:"principal,omitempty" form:"principal" query:"principal" validate:"required"`
+	TransactionType protocol.TransactionType `json:"transactionType,omitempty" form:"transactionType" query:"transactionType" validate:"required"`
+	Initiated       bool                     `json:"initiated,omitempty" form:"initiated" query:"initiated" validate:"required"`
+	Complete        bool                     `json:"complete,omitempty" form:"complete" query:"complete" validate:"required"`
+	Signers         []*PstSignerStatus       `json:"signers,omitempty" form:"signers" query:"signers" validate:"required"`
+}
+
 type ResolveKeyRequest struct {
 	KeyNameOrLiteAddress string `json:"keyNameOrLiteAddress,omitempty" form:"keyNameOrLiteAddress" query:"keyNameOrLiteAddress" validate:"required"`
 }
@@ -143,11 +209,17 @@ type ResolveKeyResponse struct {
 type SignRequest struct {
 	Name    int64  `json:"name,omitempty" form:"name" query:"name" validate:"required"`
 	KeyName string `json:"keyName,omitempty" form:"keyName" query:"keyName"`
+	// Transaction is the transaction to sign. If it has not been initiated, the signature initiates it.
+	Transaction   *protocol.Transaction `json:"transaction,omitempty" form:"transaction" query:"transaction"`
+	Signer        *url.URL              `json:"signer,omitempty" form:"signer" query:"signer"`
+	SignerVersion uint64                `json:"signerVersion,omitempty" form:"signerVersion" query:"signerVersion"`
 }
 
 type SignResponse struct {
 	Signature []byte `json:"signature,omitempty" form:"signature" query:"signature" validate:"required"`
 	PublicKey []byte `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	// Transaction is the signed transaction, which includes the initiator if the signature initiated it.
+	Transaction *protocol.Transaction `json:"transaction,omitempty" form:"transaction" query:"transaction"`
 }
 
 type VersionResponse struct {
@@ -157,6 +229,20 @@ type VersionResponse struct {
 	extraData []byte
 }
 
+type WalletCloseRequest struct {
+	Name string `json:"name,omitempty" form:"name" query:"name" validate:"required"`
+}
+
+type WalletListResponse struct {
+	Wallets []string `json:"wallets,omitempty" form:"wallets" query:"wallets" validate:"required"`
+	Open    []string `json:"open,omitempty" form:"open" query:"open" validate:"required"`
+}
+
+type WalletOpenRequest struct {
+	Name     string `json:"name,omitempty" form:"name" query:"name" validate:"required"`
+	Password string `json:"password,omitempty" form:"password" query:"password"`
+}
+
 func (v *AddSendTokensOutputRequest) Copy() *AddSendTokensOutputRequest {
 	u := new(AddSendTokensOutputRequest)
 
@@ -380,6 +466,65 @@ func (v *FinalizeEnvelopeRequest) Copy() *FinalizeEnvelopeRequest {
 
 func (v *FinalizeEnvelopeRequest) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *HistoryExportRequest) Copy() *HistoryExportRequest {
+	u := new(HistoryExportRequest)
+
+	if v.Account != nil {
+		u.Account = v.Account
+	}
+	u.Format = v.Format
+
+	return u
+}
+
+func (v *HistoryExportRequest) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *HistoryExportResponse) Copy() *HistoryExportResponse {
+	u := new(HistoryExportResponse)
+
+	u.Entries = make([]*LedgerEntry, len(v.Entries))
+	for i, v := range v.Entries {
+		if v != nil {
+			u.Entries[i] = (v).Copy()
+		}
+	}
+	u.Csv = v.Csv
+
+	return u
+}
+
+func (v *HistoryExportResponse) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *HistorySyncRequest) Copy() *HistorySyncRequest {
+	u := new(HistorySyncRequest)
+
+	u.Accounts = make([]*url.URL, len(v.Accounts))
+	for i, v := range v.Accounts {
+		if v != nil {
+			u.Accounts[i] = v
+		}
+	}
+
+	return u
+}
+
+func (v *HistorySyncRequest) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *HistorySyncResponse) Copy() *HistorySyncResponse {
+	u := new(HistorySyncResponse)
+
+	u.Accounts = make([]*HistorySyncState, len(v.Accounts))
+	for i, v := range v.Accounts {
+		if v != nil {
+			u.Accounts[i] = (v).Copy()
+		}
+	}
+
+	return u
+}
+
+func (v *HistorySyncResponse) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *KeyData) Copy() *KeyData {
 	u := new(KeyData)
 
@@ -415,6 +560,18 @@ func (v *NewTransactionRequest) Copy() *NewTransactionRequest {
 
 func (v *NewTransactionRequest) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *PolicyViolation) Copy() *PolicyViolation {
+	u := new(PolicyViolation)
+
+	u.Policy = v.Policy
+	u.Rule = v.Rule
+	u.Message = v.Message
+
+	return u
+}
+
+func (v *PolicyViolation) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *ProveReceiptRequest) Copy() *ProveReceiptRequest {
 	u := new(ProveReceiptRequest)
 
@@ -426,6 +583,105 @@ func (v *ProveReceiptRequest) Copy() *ProveReceiptRequest {
 
 func (v *ProveReceiptRequest) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *PstHashRequest) Copy() *PstHashRequest {
+	u := new(PstHashRequest)
+
+	u.TransactionHash = encoding.BytesCopy(v.TransactionHash)
+
+	return u
+}
+
+func (v *PstHashRequest) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *PstListResponse) Copy() *PstListResponse {
+	u := new(PstListResponse)
+
+	u.Transactions = make([]*PstStatus, len(v.Transactions))
+	for i, v := range v.Transactions {
+		if v != nil {
+			u.Transactions[i] = (v).Copy()
+		}
+	}
+
+	return u
+}
+
+func (v *PstListResponse) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *PstRequest) Copy() *PstRequest {
+	u := new(PstRequest)
+
+	if v.Pst != nil {
+		u.Pst = (v.Pst).Copy()
+	}
+
+	return u
+}
+
+func (v *PstRequest) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *PstResponse) Copy() *PstResponse {
+	u := new(PstResponse)
+
+	if v.Pst != nil {
+		u.Pst = (v.Pst).Copy()
+	}
+
+	return u
+}
+
+func (v *PstResponse) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *PstSignRequest) Copy() *PstSignRequest {
+	u := new(PstSignRequest)
+
+	u.TransactionHash = encoding.BytesCopy(v.TransactionHash)
+	u.KeyName = v.KeyName
+	if v.Signer != nil {
+		u.Signer = v.Signer
+	}
+
+	return u
+}
+
+func (v *PstSignRequest) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *PstSignerStatus) Copy() *PstSignerStatus {
+	u := new(PstSignerStatus)
+
+	if v.Url != nil {
+		u.Url = v.Url
+	}
+	u.Threshold = v.Threshold
+	u.Signatures = v.Signatures
+
+	return u
+}
+
+func (v *PstSignerStatus) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *PstStatus) Copy() *PstStatus {
+	u := new(PstStatus)
+
+	u.TransactionHash = encoding.BytesCopy(v.TransactionHash)
+	if v.Principal != nil {
+		u.Principal = v.Principal
+	}
+	u.TransactionType = v.TransactionType
+	u.Initiated = v.Initiated
+	u.Complete = v.Complete
+	u.Signers = make([]*PstSignerStatus, len(v.Signers))
+	for i, v := range v.Signers {
+		if v != nil {
+			u.Signers[i] = (v).Copy()
+		}
+	}
+
+	return u
+}
+
+func (v *PstStatus) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *ResolveKeyRequest) Copy() *ResolveKeyRequest {
 	u := new(ResolveKeyRequest)
 
@@ -451,6 +707,13 @@ func (v *SignRequest) Copy() *SignRequest {
 
 	u.Name = v.Name
 	u.KeyName = v.KeyName
+	if v.Transaction != nil {
+		u.Transaction = (v.Transaction).Copy()
+	}
+	if v.Signer != nil {
+		u.Signer = v.Signer
+	}
+	u.SignerVersion = v.SignerVersion
 
 	return u
 }
@@ -462,6 +725,9 @@ func (v *SignResponse) Copy() *SignResponse {
 
 	u.Signature = encoding.BytesCopy(v.Signature)
 	u.PublicKey = encoding.BytesCopy(v.PublicKey)
+	if v.Transaction != nil {
+		u.Transaction = (v.Transaction).Copy()
+	}
 
 	return u
 }
@@ -479,6 +745,44 @@ func (v *VersionResponse) Copy() *VersionResponse {
 
 func (v *VersionResponse) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *WalletCloseRequest) Copy() *WalletCloseRequest {
+	u := new(WalletCloseRequest)
+
+	u.Name = v.Name
+
+	return u
+}
+
+func (v *WalletCloseRequest) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *WalletListResponse) Copy() *WalletListResponse {
+	u := new(WalletListResponse)
+
+	u.Wallets = make([]string, len(v.Wallets))
+	for i, v := range v.Wallets {
+		u.Wallets[i] = v
+	}
+	u.Open = make([]string, len(v.Open))
+	for i, v := range v.Open {
+		u.Open[i] = v
+	}
+
+	return u
+}
+
+func (v *WalletListResponse) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *WalletOpenRequest) Copy() *WalletOpenRequest {
+	u := new(WalletOpenRequest)
+
+	u.Name = v.Name
+	u.Password = v.Password
+
+	return u
+}
+
+func (v *WalletOpenRequest) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *AddSendTokensOutputRequest) Equal(u *AddSendTokensOutputRequest) bool {
 	if !(v.TxName == u.TxName) {
 		return false
@@ -682,6 +986,64 @@ func (v *FinalizeEnvelopeRequest) Equal(u *FinalizeEnvelopeRequest) bool {
 	return true
 }
 
+func (v *HistoryExportRequest) Equal(u *HistoryExportRequest) bool {
+	switch {
+	case v.Account == u.Account:
+		// equal
+	case v.Account == nil || u.Account == nil:
+		return false
+	case !((v.Account).Equal(u.Account)):
+		return false
+	}
+	if !(v.Format == u.Format) {
+		return false
+	}
+
+	return true
+}
+
+func (v *HistoryExportResponse) Equal(u *HistoryExportResponse) bool {
+	if len(v.Entries) != len(u.Entries) {
+		return false
+	}
+	for i := range v.Entries {
+		if !((v.Entries[i]).Equal(u.Entries[i])) {
+			return false
+		}
+	}
+	if !(v.Csv == u.Csv) {
+		return false
+	}
+
+	return true
+}
+
+func (v *HistorySyncRequest) Equal(u *HistorySyncRequest) bool {
+	if len(v.Accounts) != len(u.Accounts) {
+		return false
+	}
+	for i := range v.Accounts {
+		if !((v.Accounts[i]).Equal(u.Accounts[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *HistorySyncResponse) Equal(u *HistorySyncResponse) bool {
+	if len(v.Accounts) != len(u.Accounts) {
+		return false
+	}
+	for i := range v.Accounts {
+		if !((v.Accounts[i]).Equal(u.Accounts[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (v *KeyData) Equal(u *KeyData) bool {
 	if !(v.Name == u.Name) {
 		return false
@@ -717,6 +1079,20 @@ func (v *NewTransactionRequest) Equal(u *NewTransactionRequest) bool {
 	return true
 }
 
+func (v *PolicyViolation) Equal(u *PolicyViolation) bool {
+	if !(v.Policy == u.Policy) {
+		return false
+	}
+	if !(v.Rule == u.Rule) {
+		return false
+	}
+	if !(v.Message == u.Message) {
+		return false
+	}
+
+	return true
+}
+
 func (v *ProveReceiptRequest) Equal(u *ProveReceiptRequest) bool {
 	if !(v.DataJson == u.DataJson) {
 		return false
@@ -728,6 +1104,124 @@ func (v *ProveReceiptRequest) Equal(u *ProveReceiptRequest) bool {
 	return true
 }
 
+func (v *PstHashRequest) Equal(u *PstHashRequest) bool {
+	if !(bytes.Equal(v.TransactionHash, u.TransactionHash)) {
+		return false
+	}
+
+	return true
+}
+
+func (v *PstListResponse) Equal(u *PstListResponse) bool {
+	if len(v.Transactions) != len(u.Transactions) {
+		return false
+	}
+	for i := range v.Transactions {
+		if !((v.Transactions[i]).Equal(u.Transactions[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *PstRequest) Equal(u *PstRequest) bool {
+	switch {
+	case v.Pst == u.Pst:
+		// equal
+	case v.Pst == nil || u.Pst == nil:
+		return false
+	case !((v.Pst).Equal(u.Pst)):
+		return false
+	}
+
+	return true
+}
+
+func (v *PstResponse) Equal(u *PstResponse) bool {
+	switch {
+	case v.Pst == u.Pst:
+		// equal
+	case v.Pst == nil || u.Pst == nil:
+		return false
+	case !((v.Pst).Equal(u.Pst)):
+		return false
+	}
+
+	return true
+}
+
+func (v *PstSignRequest) Equal(u *PstSignRequest) bool {
+	if !(bytes.Equal(v.TransactionHash, u.TransactionHash)) {
+		return false
+	}
+	if !(v.KeyName == u.KeyName) {
+		return false
+	}
+	switch {
+	case v.Signer == u.Signer:
+		// equal
+	case v.Signer == nil || u.Signer == nil:
+		return false
+	case !((v.Signer).Equal(u.Signer)):
+		return false
+	}
+
+	return true
+}
+
+func (v *PstSignerStatus) Equal(u *PstSignerStatus) bool {
+	switch {
+	case v.Url == u.Url:
+		// equal
+	case v.Url == nil || u.Url == nil:
+		return false
+	case !((v.Url).Equal(u.Url)):
+		return false
+	}
+	if !(v.Threshold == u.Threshold) {
+		return false
+	}
+	if !(v.Signatures == u.Signatures) {
+		return false
+	}
+
+	return true
+}
+
+func (v *PstStatus) Equal(u *PstStatus) bool {
+	if !(bytes.Equal(v.TransactionHash, u.TransactionHash)) {
+		return false
+	}
+	switch {
+	case v.Principal == u.Principal:
+		// equal
+	case v.Principal == nil || u.Principal == nil:
+		return false
+	case !((v.Principal).Equal(u.Principal)):
+		return false
+	}
+	if !(v.TransactionType == u.TransactionType) {
+		return false
+	}
+	if !(v.Initiated == u.Initiated) {
+		return false
+	}
+	if !(v.Complete == u.Complete) {
+		return false
+	}
+	if len(v.Signers) != len(u.Signers) {
+		return false
+	}
+	for i := range v.Signers {
+		if !((v.Signers[i]).Equal(u.Signers[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (v *ResolveKeyRequest) Equal(u *ResolveKeyRequest) bool {
 	if !(v.KeyNameOrLiteAddress == u.KeyNameOrLiteAddress) {
 		return false
@@ -751,6 +1245,25 @@ func (v *SignRequest) Equal(u *SignRequest) bool {
 	if !(v.KeyName == u.KeyName) {
 		return false
 	}
+	switch {
+	case v.Transaction == u.Transaction:
+		// equal
+	case v.Transaction == nil || u.Transaction == nil:
+		return false
+	case !((v.Transaction).Equal(u.Transaction)):
+		return false
+	}
+	switch {
+	case v.Signer == u.Signer:
+		// equal
+	case v.Signer == nil || u.Signer == nil:
+		return false
+	case !((v.Signer).Equal(u.Signer)):
+		return false
+	}
+	if !(v.SignerVersion == u.SignerVersion) {
+		return false
+	}
 
 	return true
 }
@@ -762,6 +1275,14 @@ func (v *SignResponse) Equal(u *SignResponse) bool {
 	if !(bytes.Equal(v.PublicKey, u.PublicKey)) {
 		return false
 	}
+	switch {
+	case v.Transaction == u.Transaction:
+		// equal
+	case v.Transaction == nil || u.Transaction == nil:
+		return false
+	case !((v.Transaction).Equal(u.Transaction)):
+		return false
+	}
 
 	return true
 }
@@ -777,6 +1298,46 @@ func (v *VersionResponse) Equal(u *VersionResponse) bool {
 	return true
 }
 
+func (v *WalletCloseRequest) Equal(u *WalletCloseRequest) bool {
+	if !(v.Name == u.Name) {
+		return false
+	}
+
+	return true
+}
+
+func (v *WalletListResponse) Equal(u *WalletListResponse) bool {
+	if len(v.Wallets) != len(u.Wallets) {
+		return false
+	}
+	for i := range v.Wallets {
+		if !(v.Wallets[i] == u.Wallets[i]) {
+			return false
+		}
+	}
+	if len(v.Open) != len(u.Open) {
+		return false
+	}
+	for i := range v.Open {
+		if !(v.Open[i] == u.Open[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *WalletOpenRequest) Equal(u *WalletOpenRequest) bool {
+	if !(v.Name == u.Name) {
+		return false
+	}
+	if !(v.Password == u.Password) {
+		return false
+	}
+
+	return true
+}
+
 var fieldNames_AuthorizationRequired = []string{
 	1: "Key",
 	2: "Version",
@@ -1015,6 +1576,32 @@ func (v *EncodeTransactionResponse) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *HistoryExportResponse) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Entries encoding.JsonList[*LedgerEntry] `json:"entries,omitempty"`
+		Csv     string                          `json:"csv,omitempty"`
+	}{}
+	u.Entries = v.Entries
+	u.Csv = v.Csv
+	return json.Marshal(&u)
+}
+
+func (v *HistorySyncRequest) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Accounts encoding.JsonList[*url.URL] `json:"accounts,omitempty"`
+	}{}
+	u.Accounts = v.Accounts
+	return json.Marshal(&u)
+}
+
+func (v *HistorySyncResponse) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Accounts encoding.JsonList[*HistorySyncState] `json:"accounts,omitempty"`
+	}{}
+	u.Accounts = v.Accounts
+	return json.Marshal(&u)
+}
+
 func (v *KeyData) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Name      string  `json:"name,omitempty"`
@@ -1035,13 +1622,71 @@ func (v *KeyListResponse) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *PstHashRequest) MarshalJSON() ([]byte, error) {
+	u := struct {
+		TransactionHash *string `json:"transactionHash,omitempty"`
+	}{}
+	u.TransactionHash = encoding.BytesToJSON(v.TransactionHash)
+	return json.Marshal(&u)
+}
+
+func (v *PstListResponse) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Transactions encoding.JsonList[*PstStatus] `json:"transactions,omitempty"`
+	}{}
+	u.Transactions = v.Transactions
+	return json.Marshal(&u)
+}
+
+func (v *PstSignRequest) MarshalJSON() ([]byte, error) {
+	u := struct {
+		TransactionHash *string  `json:"transactionHash,omitempty"`
+		KeyName         string   `json:"keyName,omitempty"`
+		Signer          *url.URL `json:"signer,omitempty"`
+	}{}
+	u.TransactionHash = encoding.BytesToJSON(v.TransactionHash)
+	u.KeyName = v.KeyName
+	u.Signer = v.Signer
+	return json.Marshal(&u)
+}
+
+func (v *PstStatus) MarshalJSON() ([]byte, error) {
+	u := struct {
+		TransactionHash *string                             `json:"transactionHash,omitempty"`
+		Principal       *url.URL                            `json:"principal,omitempty"`
+		TransactionType protocol.TransactionType            `json:"transactionType,omitempty"`
+		Initiated       bool                                `json:"initiated,omitempty"`
+		Complete        bool                                `json:"complete,omitempty"`
+		Signers         encoding.JsonList[*PstSignerStatus] `json:"signers,omitempty"`
+	}{}
+	u.TransactionHash = encoding.BytesToJSON(v.TransactionHash)
+	u.Principal = v.Principal
+	u.TransactionType = v.TransactionType
+	u.Initiated = v.Initiated
+	u.Complete = v.Complete
+	u.Signers = v.Signers
+	return json.Marshal(&u)
+}
+
 func (v *SignResponse) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Signature *string `json:"signature,omitempty"`
-		PublicKey *string `json:"publicKey,omitempty"`
+		Signature   *string               `json:"signature,omitempty"`
+		PublicKey   *string               `json:"publicKey,omitempty"`
+		Transaction *protocol.Transaction `json:"transaction,omitempty"`
 	}{}
 	u.Signature = encoding.BytesToJSON(v.Signature)
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.Transaction = v.Transaction
+	return json.Marshal(&u)
+}
+
+func (v *WalletListResponse) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Wallets encoding.JsonList[string] `json:"wallets,omitempty"`
+		Open    encoding.JsonList[string] `json:"open,omitempty"`
+	}{}
+	u.Wallets = v.Wallets
+	u.Open = v.Open
 	return json.Marshal(&u)
 }
 
@@ -1212,6 +1857,45 @@ func (v *EncodeTransactionResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *HistoryExportResponse) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Entries encoding.JsonList[*LedgerEntry] `json:"entries,omitempty"`
+		Csv     string                          `json:"csv,omitempty"`
+	}{}
+	u.Entries = v.Entries
+	u.Csv = v.Csv
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Entries = u.Entries
+	v.Csv = u.Csv
+	return nil
+}
+
+func (v *HistorySyncRequest) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Accounts encoding.JsonList[*url.URL] `json:"accounts,omitempty"`
+	}{}
+	u.Accounts = v.Accounts
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Accounts = u.Accounts
+	return nil
+}
+
+func (v *HistorySyncResponse) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Accounts encoding.JsonList[*HistorySyncState] `json:"accounts,omitempty"`
+	}{}
+	u.Accounts = v.Accounts
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Accounts = u.Accounts
+	return nil
+}
+
 func (v *KeyData) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Name      string  `json:"name,omitempty"`
@@ -1246,13 +1930,96 @@ func (v *KeyListResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *PstHashRequest) UnmarshalJSON(data []byte) error {
+	u := struct {
+		TransactionHash *string `json:"transactionHash,omitempty"`
+	}{}
+	u.TransactionHash = encoding.BytesToJSON(v.TransactionHash)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.BytesFromJSON(u.TransactionHash); err != nil {
+		return fmt.Errorf("error decoding TransactionHash: %w", err)
+	} else {
+		v.TransactionHash = x
+	}
+	return nil
+}
+
+func (v *PstListResponse) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Transactions encoding.JsonList[*PstStatus] `json:"transactions,omitempty"`
+	}{}
+	u.Transactions = v.Transactions
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Transactions = u.Transactions
+	return nil
+}
+
+func (v *PstSignRequest) UnmarshalJSON(data []byte) error {
+	u := struct {
+		TransactionHash *string  `json:"transactionHash,omitempty"`
+		KeyName         string   `json:"keyName,omitempty"`
+		Signer          *url.URL `json:"signer,omitempty"`
+	}{}
+	u.TransactionHash = encoding.BytesToJSON(v.TransactionHash)
+	u.KeyName = v.KeyName
+	u.Signer = v.Signer
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.BytesFromJSON(u.TransactionHash); err != nil {
+		return fmt.Errorf("error decoding TransactionHash: %w", err)
+	} else {
+		v.TransactionHash = x
+	}
+	v.KeyName = u.KeyName
+	v.Signer = u.Signer
+	return nil
+}
+
+func (v *PstStatus) UnmarshalJSON(data []byte) error {
+	u := struct {
+		TransactionHash *string                             `json:"transactionHash,omitempty"`
+		Principal       *url.URL                            `json:"principal,omitempty"`
+		TransactionType protocol.TransactionType            `json:"transactionType,omitempty"`
+		Initiated       bool                                `json:"initiated,omitempty"`
+		Complete        bool                                `json:"complete,omitempty"`
+		Signers         encoding.JsonList[*PstSignerStatus] `json:"signers,omitempty"`
+	}{}
+	u.TransactionHash = encoding.BytesToJSON(v.TransactionHash)
+	u.Principal = v.Principal
+	u.TransactionType = v.TransactionType
+	u.Initiated = v.Initiated
+	u.Complete = v.Complete
+	u.Signers = v.Signers
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.BytesFromJSON(u.TransactionHash); err != nil {
+		return fmt.Errorf("error decoding TransactionHash: %w", err)
+	} else {
+		v.TransactionHash = x
+	}
+	v.Principal = u.Principal
+	v.TransactionType = u.TransactionType
+	v.Initiated = u.Initiated
+	v.Complete = u.Complete
+	v.Signers = u.Signers
+	return nil
+}
+
 func (v *SignResponse) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Signature *string `json:"signature,omitempty"`
-		PublicKey *string `json:"publicKey,omitempty"`
+		Signature   *string               `json:"signature,omitempty"`
+		PublicKey   *string               `json:"publicKey,omitempty"`
+		Transaction *protocol.Transaction `json:"transaction,omitempty"`
 	}{}
 	u.Signature = encoding.BytesToJSON(v.Signature)
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.Transaction = v.Transaction
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -1266,5 +2033,21 @@ func (v *SignResponse) UnmarshalJSON(data []byte) error {
 	} else {
 		v.PublicKey = x
 	}
+	v.Transaction = u.Transaction
+	return nil
+}
+
+func (v *WalletListResponse) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Wallets encoding.JsonList[string] `json:"wallets,omitempty"`
+		Open    encoding.JsonList[string] `json:"open,omitempty"`
+	}{}
+	u.Wallets = v.Wallets
+	u.Open = v.Open
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Wallets = u.Wallets
+	v.Open = u.Open
 	return nil
 }
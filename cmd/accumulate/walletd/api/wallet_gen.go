@@ -10,13 +10,22 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"strings"
+	"time"
 
 	"gitlab.com/accumulatenetwork/accumulate/internal/encoding"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
 )
 
+type AddressBookEntry struct {
+	fieldsSet []bool
+	Label     string   `json:"label,omitempty" form:"label" query:"label" validate:"required"`
+	Url       *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	extraData []byte
+}
+
 type Adi struct {
 	fieldsSet []bool
 	Url       url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
@@ -24,9 +33,47 @@ type Adi struct {
 	extraData []byte
 }
 
+// ApiToken is a token that authorizes requests to the wallet daemon.
+type ApiToken struct {
+	fieldsSet []bool
+	Name      string `json:"name,omitempty" form:"name" query:"name" validate:"required"`
+	// Hash is the SHA-256 hash of the token's secret.
+	Hash       []byte        `json:"hash,omitempty" form:"hash" query:"hash" validate:"required"`
+	Permission ApiPermission `json:"permission,omitempty" form:"permission" query:"permission" validate:"required"`
+	// Signers restricts signing to the listed signers.
+	Signers []*url.URL `json:"signers,omitempty" form:"signers" query:"signers"`
+	// Principals restricts signing and submitting to transactions for the listed principals.
+	Principals []*url.URL `json:"principals,omitempty" form:"principals" query:"principals"`
+	// DailyLimits limits the amount of each token that transactions authorized by the token may spend per day. If any limits are set, spending a token that is not listed is not permitted.
+	DailyLimits []*TokenAmount `json:"dailyLimits,omitempty" form:"dailyLimits" query:"dailyLimits"`
+	extraData   []byte
+}
+
+// ApiTokenUsage records the spending authorized by an API token on a given day.
+type ApiTokenUsage struct {
+	fieldsSet []bool
+	// Day is the number of days since the Unix epoch, in UTC.
+	Day   uint64         `json:"day,omitempty" form:"day" query:"day" validate:"required"`
+	Spent []*TokenAmount `json:"spent,omitempty" form:"spent" query:"spent" validate:"required"`
+	// Transactions lists the hashes of the transactions that have been counted.
+	Transactions [][32]byte `json:"transactions,omitempty" form:"transactions" query:"transactions" validate:"required"`
+	extraData    []byte
+}
+
 type DerivationCount struct {
 	Type  protocol.SignatureType `json:"type,omitempty" form:"type" query:"type" validate:"required"`
 	Count uint64                 `json:"count,omitempty" form:"count" query:"count" validate:"required"`
+	// Account is the BIP-44 account the count applies to.
+	Account uint64 `json:"account,omitempty" form:"account" query:"account"`
+}
+
+// HistorySyncState records how much of an account's transaction history has been cached.
+type HistorySyncState struct {
+	fieldsSet []bool
+	Account   *url.URL `json:"account,omitempty" form:"account" query:"account" validate:"required"`
+	// Height is the number of main chain entries that have been synced.
+	Height    uint64 `json:"height,omitempty" form:"height" query:"height" validate:"required"`
+	extraData []byte
 }
 
 type Key struct {
@@ -51,6 +98,30 @@ type KeyName struct {
 	extraData []byte
 }
 
+// LedgerEntry is a transfer recorded in the transaction history cache.
+type LedgerEntry struct {
+	fieldsSet []bool
+	Account   *url.URL `json:"account,omitempty" form:"account" query:"account" validate:"required"`
+	// Index is the index of the transaction on the account's main chain.
+	Index uint64    `json:"index,omitempty" form:"index" query:"index" validate:"required"`
+	TxID  *url.TxID `json:"txID,omitempty" form:"txID" query:"txID" validate:"required"`
+	// Time is the time of the block the transaction was recorded in, or the timestamp of the initiator signature if the block is not known.
+	Time         time.Time                `json:"time,omitempty" form:"time" query:"time"`
+	Type         protocol.TransactionType `json:"type,omitempty" form:"type" query:"type" validate:"required"`
+	Counterparty *url.URL                 `json:"counterparty,omitempty" form:"counterparty" query:"counterparty"`
+	Token        *url.URL                 `json:"token,omitempty" form:"token" query:"token"`
+	Amount       big.Int                  `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
+	Incoming     bool                     `json:"incoming,omitempty" form:"incoming" query:"incoming" validate:"required"`
+	// FeeCredits is the fee paid for the transaction and its signatures, in credits.
+	FeeCredits uint64 `json:"feeCredits,omitempty" form:"feeCredits" query:"feeCredits" validate:"required"`
+	// FeeAcme is the fee converted to ACME at the oracle price in effect when the transaction was recorded.
+	FeeAcme big.Int `json:"feeAcme,omitempty" form:"feeAcme" query:"feeAcme" validate:"required"`
+	Memo    string  `json:"memo,omitempty" form:"memo" query:"memo"`
+	// Precision is the precision of the token.
+	Precision uint64 `json:"precision,omitempty" form:"precision" query:"precision" validate:"required"`
+	extraData []byte
+}
+
 type LiteLabel struct {
 	LiteName string `json:"liteName,omitempty" form:"liteName" query:"liteName" validate:"required"`
 	KeyName  string `json:"keyName,omitempty" form:"keyName" query:"keyName" validate:"required"`
@@ -63,12 +134,70 @@ type Page struct {
 	extraData []byte
 }
 
+// PartiallySignedTransaction is a transaction along with the signatures that have been collected for it and the signers that are expected to sign it.
+type PartiallySignedTransaction struct {
+	fieldsSet   []bool
+	Version     uint64                `json:"version,omitempty" form:"version" query:"version" validate:"required"`
+	Transaction *protocol.Transaction `json:"transaction,omitempty" form:"transaction" query:"transaction" validate:"required"`
+	Signers     []*PstSigner          `json:"signers,omitempty" form:"signers" query:"signers"`
+	Signatures  []protocol.Signature  `json:"signatures,omitempty" form:"signatures" query:"signatures"`
+	extraData   []byte
+}
+
+// PstSigner is a signer that is expected to sign a partially signed transaction.
+type PstSigner struct {
+	fieldsSet []bool
+	Url       *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	// Authority is the key book the signer belongs to.
+	Authority *url.URL `json:"authority,omitempty" form:"authority" query:"authority"`
+	Version   uint64   `json:"version,omitempty" form:"version" query:"version" validate:"required"`
+	// Threshold is the number of signatures required from the signer.
+	Threshold uint64 `json:"threshold,omitempty" form:"threshold" query:"threshold" validate:"required"`
+	// Delegators is the delegation path, if the signer signs on behalf of another authority.
+	Delegators []*url.URL `json:"delegators,omitempty" form:"delegators" query:"delegators"`
+	extraData  []byte
+}
+
+type RecipientLimit struct {
+	fieldsSet []bool
+	// Recipient is the recipient the limit applies to. If unset, the limit applies to every recipient.
+	Recipient *url.URL `json:"recipient,omitempty" form:"recipient" query:"recipient"`
+	Token     *url.URL `json:"token,omitempty" form:"token" query:"token" validate:"required"`
+	// Amount is the maximum amount of the token the recipient may receive.
+	Amount    big.Int `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
+	extraData []byte
+}
+
 type SeedInfo struct {
 	Mnemonic    string            `json:"mnemonic,omitempty" form:"mnemonic" query:"mnemonic" validate:"required"`
 	Seed        []byte            `json:"seed,omitempty" form:"seed" query:"seed" validate:"required"`
 	Derivations []DerivationCount `json:"derivations,omitempty" form:"derivations" query:"derivations"`
 }
 
+// SigningPolicy restricts the transactions the wallet will sign. Every policy that applies to a signer must be satisfied before the wallet signs with it.
+type SigningPolicy struct {
+	fieldsSet []bool
+	Name      string `json:"name,omitempty" form:"name" query:"name" validate:"required"`
+	// Signers lists the signers the policy applies to. If empty, the policy applies to every signer.
+	Signers []*url.URL `json:"signers,omitempty" form:"signers" query:"signers"`
+	// TransactionTypes lists the transaction types that may be signed. Transactions of any other type are not permitted.
+	TransactionTypes []protocol.TransactionType `json:"transactionTypes,omitempty" form:"transactionTypes" query:"transactionTypes" validate:"required"`
+	// Principals lists the principals that transactions may be for.
+	Principals []*url.URL `json:"principals,omitempty" form:"principals" query:"principals"`
+	// Recipients limits the amount each recipient may receive in a single transaction. Burned tokens are received by the token issuer and ACME spent on credits by the credit recipient. If any limits are set, moving tokens to a recipient that is not listed is not permitted.
+	Recipients []*RecipientLimit `json:"recipients,omitempty" form:"recipients" query:"recipients"`
+	// MemoPattern is a regular expression the entire memo must match.
+	MemoPattern string `json:"memoPattern,omitempty" form:"memoPattern" query:"memoPattern"`
+	extraData   []byte
+}
+
+type TokenAmount struct {
+	fieldsSet []bool
+	Token     *url.URL `json:"token,omitempty" form:"token" query:"token" validate:"required"`
+	Amount    big.Int  `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
+	extraData []byte
+}
+
 type Version struct {
 	Major    uint64 `json:"major,omitempty" form:"major" query:"major" validate:"required"`
 	Minor    uint64 `json:"minor,omitempty" form:"minor" query:"minor" validate:"required"`
@@ -85,6 +214,36 @@ type Wallet struct {
 	Adis       []Adi       `json:"adis,omitempty" form:"adis" query:"adis"`
 }
 
+// WatchedAccount is an account the user does not control that is tracked by the wallet.
+type WatchedAccount struct {
+	fieldsSet []bool
+	Url       *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	Label     string   `json:"label,omitempty" form:"label" query:"label"`
+	extraData []byte
+}
+
+// WatchedKey is a public key the user does not control that is tracked by the wallet.
+type WatchedKey struct {
+	fieldsSet []bool
+	PublicKey []byte                 `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	Type      protocol.SignatureType `json:"type,omitempty" form:"type" query:"type" validate:"required"`
+	Label     string                 `json:"label,omitempty" form:"label" query:"label"`
+	extraData []byte
+}
+
+func (v *AddressBookEntry) Copy() *AddressBookEntry {
+	u := new(AddressBookEntry)
+
+	u.Label = v.Label
+	if v.Url != nil {
+		u.Url = v.Url
+	}
+
+	return u
+}
+
+func (v *AddressBookEntry) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *Adi) Copy() *Adi {
 	u := new(Adi)
 
@@ -99,17 +258,81 @@ func (v *Adi) Copy() *Adi {
 
 func (v *Adi) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *ApiToken) Copy() *ApiToken {
+	u := new(ApiToken)
+
+	u.Name = v.Name
+	u.Hash = encoding.BytesCopy(v.Hash)
+	u.Permission = v.Permission
+	u.Signers = make([]*url.URL, len(v.Signers))
+	for i, v := range v.Signers {
+		if v != nil {
+			u.Signers[i] = v
+		}
+	}
+	u.Principals = make([]*url.URL, len(v.Principals))
+	for i, v := range v.Principals {
+		if v != nil {
+			u.Principals[i] = v
+		}
+	}
+	u.DailyLimits = make([]*TokenAmount, len(v.DailyLimits))
+	for i, v := range v.DailyLimits {
+		if v != nil {
+			u.DailyLimits[i] = (v).Copy()
+		}
+	}
+
+	return u
+}
+
+func (v *ApiToken) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *ApiTokenUsage) Copy() *ApiTokenUsage {
+	u := new(ApiTokenUsage)
+
+	u.Day = v.Day
+	u.Spent = make([]*TokenAmount, len(v.Spent))
+	for i, v := range v.Spent {
+		if v != nil {
+			u.Spent[i] = (v).Copy()
+		}
+	}
+	u.Transactions = make([][32]byte, len(v.Transactions))
+	for i, v := range v.Transactions {
+		u.Transactions[i] = v
+	}
+
+	return u
+}
+
+func (v *ApiTokenUsage) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *DerivationCount) Copy() *DerivationCount {
 	u := new(DerivationCount)
 
 	u.Type = v.Type
 	u.Count = v.Count
+	u.Account = v.Account
 
 	return u
 }
 
 func (v *DerivationCount) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *HistorySyncState) Copy() *HistorySyncState {
+	u := new(HistorySyncState)
+
+	if v.Account != nil {
+		u.Account = v.Account
+	}
+	u.Height = v.Height
+
+	return u
+}
+
+func (v *HistorySyncState) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *Key) Copy() *Key {
 	u := new(Key)
 
@@ -144,6 +367,36 @@ func (v *KeyName) Copy() *KeyName {
 
 func (v *KeyName) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *LedgerEntry) Copy() *LedgerEntry {
+	u := new(LedgerEntry)
+
+	if v.Account != nil {
+		u.Account = v.Account
+	}
+	u.Index = v.Index
+	if v.TxID != nil {
+		u.TxID = v.TxID
+	}
+	u.Time = v.Time
+	u.Type = v.Type
+	if v.Counterparty != nil {
+		u.Counterparty = v.Counterparty
+	}
+	if v.Token != nil {
+		u.Token = v.Token
+	}
+	u.Amount = *encoding.BigintCopy(&v.Amount)
+	u.Incoming = v.Incoming
+	u.FeeCredits = v.FeeCredits
+	u.FeeAcme = *encoding.BigintCopy(&v.FeeAcme)
+	u.Memo = v.Memo
+	u.Precision = v.Precision
+
+	return u
+}
+
+func (v *LedgerEntry) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *LiteLabel) Copy() *LiteLabel {
 	u := new(LiteLabel)
 
@@ -169,6 +422,70 @@ func (v *Page) Copy() *Page {
 
 func (v *Page) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *PartiallySignedTransaction) Copy() *PartiallySignedTransaction {
+	u := new(PartiallySignedTransaction)
+
+	u.Version = v.Version
+	if v.Transaction != nil {
+		u.Transaction = (v.Transaction).Copy()
+	}
+	u.Signers = make([]*PstSigner, len(v.Signers))
+	for i, v := range v.Signers {
+		if v != nil {
+			u.Signers[i] = (v).Copy()
+		}
+	}
+	u.Signatures = make([]protocol.Signature, len(v.Signatures))
+	for i, v := range v.Signatures {
+		if v != nil {
+			u.Signatures[i] = (v).CopyAsInterface().(protocol.Signature)
+		}
+	}
+
+	return u
+}
+
+func (v *PartiallySignedTransaction) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *PstSigner) Copy() *PstSigner {
+	u := new(PstSigner)
+
+	if v.Url != nil {
+		u.Url = v.Url
+	}
+	if v.Authority != nil {
+		u.Authority = v.Authority
+	}
+	u.Version = v.Version
+	u.Threshold = v.Threshold
+	u.Delegators = make([]*url.URL, len(v.Delegators))
+	for i, v := range v.Delegators {
+		if v != nil {
+			u.Delegators[i] = v
+		}
+	}
+
+	return u
+}
+
+func (v *PstSigner) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *RecipientLimit) Copy() *RecipientLimit {
+	u := new(RecipientLimit)
+
+	if v.Recipient != nil {
+		u.Recipient = v.Recipient
+	}
+	if v.Token != nil {
+		u.Token = v.Token
+	}
+	u.Amount = *encoding.BigintCopy(&v.Amount)
+
+	return u
+}
+
+func (v *RecipientLimit) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *SeedInfo) Copy() *SeedInfo {
 	u := new(SeedInfo)
 
@@ -184,6 +501,52 @@ func (v *SeedInfo) Copy() *SeedInfo {
 
 func (v *SeedInfo) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *SigningPolicy) Copy() *SigningPolicy {
+	u := new(SigningPolicy)
+
+	u.Name = v.Name
+	u.Signers = make([]*url.URL, len(v.Signers))
+	for i, v := range v.Signers {
+		if v != nil {
+			u.Signers[i] = v
+		}
+	}
+	u.TransactionTypes = make([]protocol.TransactionType, len(v.TransactionTypes))
+	for i, v := range v.TransactionTypes {
+		u.TransactionTypes[i] = v
+	}
+	u.Principals = make([]*url.URL, len(v.Principals))
+	for i, v := range v.Principals {
+		if v != nil {
+			u.Principals[i] = v
+		}
+	}
+	u.Recipients = make([]*RecipientLimit, len(v.Recipients))
+	for i, v := range v.Recipients {
+		if v != nil {
+			u.Recipients[i] = (v).Copy()
+		}
+	}
+	u.MemoPattern = v.MemoPattern
+
+	return u
+}
+
+func (v *SigningPolicy) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *TokenAmount) Copy() *TokenAmount {
+	u := new(TokenAmount)
+
+	if v.Token != nil {
+		u.Token = v.Token
+	}
+	u.Amount = *encoding.BigintCopy(&v.Amount)
+
+	return u
+}
+
+func (v *TokenAmount) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *Version) Copy() *Version {
 	u := new(Version)
 
@@ -224,6 +587,47 @@ func (v *Wallet) Copy() *Wallet {
 
 func (v *Wallet) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *WatchedAccount) Copy() *WatchedAccount {
+	u := new(WatchedAccount)
+
+	if v.Url != nil {
+		u.Url = v.Url
+	}
+	u.Label = v.Label
+
+	return u
+}
+
+func (v *WatchedAccount) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *WatchedKey) Copy() *WatchedKey {
+	u := new(WatchedKey)
+
+	u.PublicKey = encoding.BytesCopy(v.PublicKey)
+	u.Type = v.Type
+	u.Label = v.Label
+
+	return u
+}
+
+func (v *WatchedKey) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *AddressBookEntry) Equal(u *AddressBookEntry) bool {
+	if !(v.Label == u.Label) {
+		return false
+	}
+	switch {
+	case v.Url == u.Url:
+		// equal
+	case v.Url == nil || u.Url == nil:
+		return false
+	case !((v.Url).Equal(u.Url)):
+		return false
+	}
+
+	return true
+}
+
 func (v *Adi) Equal(u *Adi) bool {
 	if !((&v.Url).Equal(&u.Url)) {
 		return false
@@ -240,6 +644,68 @@ func (v *Adi) Equal(u *Adi) bool {
 	return true
 }
 
+func (v *ApiToken) Equal(u *ApiToken) bool {
+	if !(v.Name == u.Name) {
+		return false
+	}
+	if !(bytes.Equal(v.Hash, u.Hash)) {
+		return false
+	}
+	if !(v.Permission == u.Permission) {
+		return false
+	}
+	if len(v.Signers) != len(u.Signers) {
+		return false
+	}
+	for i := range v.Signers {
+		if !((v.Signers[i]).Equal(u.Signers[i])) {
+			return false
+		}
+	}
+	if len(v.Principals) != len(u.Principals) {
+		return false
+	}
+	for i := range v.Principals {
+		if !((v.Principals[i]).Equal(u.Principals[i])) {
+			return false
+		}
+	}
+	if len(v.DailyLimits) != len(u.DailyLimits) {
+		return false
+	}
+	for i := range v.DailyLimits {
+		if !((v.DailyLimits[i]).Equal(u.DailyLimits[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *ApiTokenUsage) Equal(u *ApiTokenUsage) bool {
+	if !(v.Day == u.Day) {
+		return false
+	}
+	if len(v.Spent) != len(u.Spent) {
+		return false
+	}
+	for i := range v.Spent {
+		if !((v.Spent[i]).Equal(u.Spent[i])) {
+			return false
+		}
+	}
+	if len(v.Transactions) != len(u.Transactions) {
+		return false
+	}
+	for i := range v.Transactions {
+		if !(v.Transactions[i] == u.Transactions[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (v *DerivationCount) Equal(u *DerivationCount) bool {
 	if !(v.Type == u.Type) {
 		return false
@@ -247,6 +713,25 @@ func (v *DerivationCount) Equal(u *DerivationCount) bool {
 	if !(v.Count == u.Count) {
 		return false
 	}
+	if !(v.Account == u.Account) {
+		return false
+	}
+
+	return true
+}
+
+func (v *HistorySyncState) Equal(u *HistorySyncState) bool {
+	switch {
+	case v.Account == u.Account:
+		// equal
+	case v.Account == nil || u.Account == nil:
+		return false
+	case !((v.Account).Equal(u.Account)):
+		return false
+	}
+	if !(v.Height == u.Height) {
+		return false
+	}
 
 	return true
 }
@@ -287,6 +772,70 @@ func (v *KeyName) Equal(u *KeyName) bool {
 	return true
 }
 
+func (v *LedgerEntry) Equal(u *LedgerEntry) bool {
+	switch {
+	case v.Account == u.Account:
+		// equal
+	case v.Account == nil || u.Account == nil:
+		return false
+	case !((v.Account).Equal(u.Account)):
+		return false
+	}
+	if !(v.Index == u.Index) {
+		return false
+	}
+	switch {
+	case v.TxID == u.TxID:
+		// equal
+	case v.TxID == nil || u.TxID == nil:
+		return false
+	case !((v.TxID).Equal(u.TxID)):
+		return false
+	}
+	if !((v.Time).Equal(u.Time)) {
+		return false
+	}
+	if !(v.Type == u.Type) {
+		return false
+	}
+	switch {
+	case v.Counterparty == u.Counterparty:
+		// equal
+	case v.Counterparty == nil || u.Counterparty == nil:
+		return false
+	case !((v.Counterparty).Equal(u.Counterparty)):
+		return false
+	}
+	switch {
+	case v.Token == u.Token:
+		// equal
+	case v.Token == nil || u.Token == nil:
+		return false
+	case !((v.Token).Equal(u.Token)):
+		return false
+	}
+	if !((&v.Amount).Cmp(&u.Amount) == 0) {
+		return false
+	}
+	if !(v.Incoming == u.Incoming) {
+		return false
+	}
+	if !(v.FeeCredits == u.FeeCredits) {
+		return false
+	}
+	if !((&v.FeeAcme).Cmp(&u.FeeAcme) == 0) {
+		return false
+	}
+	if !(v.Memo == u.Memo) {
+		return false
+	}
+	if !(v.Precision == u.Precision) {
+		return false
+	}
+
+	return true
+}
+
 func (v *LiteLabel) Equal(u *LiteLabel) bool {
 	if !(v.LiteName == u.LiteName) {
 		return false
@@ -314,11 +863,102 @@ func (v *Page) Equal(u *Page) bool {
 	return true
 }
 
-func (v *SeedInfo) Equal(u *SeedInfo) bool {
-	if !(v.Mnemonic == u.Mnemonic) {
+func (v *PartiallySignedTransaction) Equal(u *PartiallySignedTransaction) bool {
+	if !(v.Version == u.Version) {
 		return false
 	}
-	if !(bytes.Equal(v.Seed, u.Seed)) {
+	switch {
+	case v.Transaction == u.Transaction:
+		// equal
+	case v.Transaction == nil || u.Transaction == nil:
+		return false
+	case !((v.Transaction).Equal(u.Transaction)):
+		return false
+	}
+	if len(v.Signers) != len(u.Signers) {
+		return false
+	}
+	for i := range v.Signers {
+		if !((v.Signers[i]).Equal(u.Signers[i])) {
+			return false
+		}
+	}
+	if len(v.Signatures) != len(u.Signatures) {
+		return false
+	}
+	for i := range v.Signatures {
+		if !(protocol.EqualSignature(v.Signatures[i], u.Signatures[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *PstSigner) Equal(u *PstSigner) bool {
+	switch {
+	case v.Url == u.Url:
+		// equal
+	case v.Url == nil || u.Url == nil:
+		return false
+	case !((v.Url).Equal(u.Url)):
+		return false
+	}
+	switch {
+	case v.Authority == u.Authority:
+		// equal
+	case v.Authority == nil || u.Authority == nil:
+		return false
+	case !((v.Authority).Equal(u.Authority)):
+		return false
+	}
+	if !(v.Version == u.Version) {
+		return false
+	}
+	if !(v.Threshold == u.Threshold) {
+		return false
+	}
+	if len(v.Delegators) != len(u.Delegators) {
+		return false
+	}
+	for i := range v.Delegators {
+		if !((v.Delegators[i]).Equal(u.Delegators[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *RecipientLimit) Equal(u *RecipientLimit) bool {
+	switch {
+	case v.Recipient == u.Recipient:
+		// equal
+	case v.Recipient == nil || u.Recipient == nil:
+		return false
+	case !((v.Recipient).Equal(u.Recipient)):
+		return false
+	}
+	switch {
+	case v.Token == u.Token:
+		// equal
+	case v.Token == nil || u.Token == nil:
+		return false
+	case !((v.Token).Equal(u.Token)):
+		return false
+	}
+	if !((&v.Amount).Cmp(&u.Amount) == 0) {
+		return false
+	}
+
+	return true
+}
+
+func (v *SeedInfo) Equal(u *SeedInfo) bool {
+	if !(v.Mnemonic == u.Mnemonic) {
+		return false
+	}
+	if !(bytes.Equal(v.Seed, u.Seed)) {
 		return false
 	}
 	if len(v.Derivations) != len(u.Derivations) {
@@ -333,6 +973,65 @@ func (v *SeedInfo) Equal(u *SeedInfo) bool {
 	return true
 }
 
+func (v *SigningPolicy) Equal(u *SigningPolicy) bool {
+	if !(v.Name == u.Name) {
+		return false
+	}
+	if len(v.Signers) != len(u.Signers) {
+		return false
+	}
+	for i := range v.Signers {
+		if !((v.Signers[i]).Equal(u.Signers[i])) {
+			return false
+		}
+	}
+	if len(v.TransactionTypes) != len(u.TransactionTypes) {
+		return false
+	}
+	for i := range v.TransactionTypes {
+		if !(v.TransactionTypes[i] == u.TransactionTypes[i]) {
+			return false
+		}
+	}
+	if len(v.Principals) != len(u.Principals) {
+		return false
+	}
+	for i := range v.Principals {
+		if !((v.Principals[i]).Equal(u.Principals[i])) {
+			return false
+		}
+	}
+	if len(v.Recipients) != len(u.Recipients) {
+		return false
+	}
+	for i := range v.Recipients {
+		if !((v.Recipients[i]).Equal(u.Recipients[i])) {
+			return false
+		}
+	}
+	if !(v.MemoPattern == u.MemoPattern) {
+		return false
+	}
+
+	return true
+}
+
+func (v *TokenAmount) Equal(u *TokenAmount) bool {
+	switch {
+	case v.Token == u.Token:
+		// equal
+	case v.Token == nil || u.Token == nil:
+		return false
+	case !((v.Token).Equal(u.Token)):
+		return false
+	}
+	if !((&v.Amount).Cmp(&u.Amount) == 0) {
+		return false
+	}
+
+	return true
+}
+
 func (v *Version) Equal(u *Version) bool {
 	if !(v.Major == u.Major) {
 		return false
@@ -393,6 +1092,84 @@ func (v *Wallet) Equal(u *Wallet) bool {
 	return true
 }
 
+func (v *WatchedAccount) Equal(u *WatchedAccount) bool {
+	switch {
+	case v.Url == u.Url:
+		// equal
+	case v.Url == nil || u.Url == nil:
+		return false
+	case !((v.Url).Equal(u.Url)):
+		return false
+	}
+	if !(v.Label == u.Label) {
+		return false
+	}
+
+	return true
+}
+
+func (v *WatchedKey) Equal(u *WatchedKey) bool {
+	if !(bytes.Equal(v.PublicKey, u.PublicKey)) {
+		return false
+	}
+	if !(v.Type == u.Type) {
+		return false
+	}
+	if !(v.Label == u.Label) {
+		return false
+	}
+
+	return true
+}
+
+var fieldNames_AddressBookEntry = []string{
+	1: "Label",
+	2: "Url",
+}
+
+func (v *AddressBookEntry) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(len(v.Label) == 0) {
+		writer.WriteString(1, v.Label)
+	}
+	if !(v.Url == nil) {
+		writer.WriteUrl(2, v.Url)
+	}
+
+	_, _, err := writer.Reset(fieldNames_AddressBookEntry)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *AddressBookEntry) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Label is missing")
+	} else if len(v.Label) == 0 {
+		errs = append(errs, "field Label is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Url is missing")
+	} else if v.Url == nil {
+		errs = append(errs, "field Url is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_Adi = []string{
 	1: "Url",
 	2: "Pages",
@@ -438,27 +1215,45 @@ func (v *Adi) IsValid() error {
 	}
 }
 
-var fieldNames_Key = []string{
-	1: "PrivateKey",
-	2: "PublicKey",
-	3: "KeyInfo",
+var fieldNames_ApiToken = []string{
+	1: "Name",
+	2: "Hash",
+	3: "Permission",
+	4: "Signers",
+	5: "Principals",
+	6: "DailyLimits",
 }
 
-func (v *Key) MarshalBinary() ([]byte, error) {
+func (v *ApiToken) MarshalBinary() ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	writer := encoding.NewWriter(buffer)
 
-	if !(len(v.PrivateKey) == 0) {
-		writer.WriteBytes(1, v.PrivateKey)
+	if !(len(v.Name) == 0) {
+		writer.WriteString(1, v.Name)
 	}
-	if !(len(v.PublicKey) == 0) {
-		writer.WriteBytes(2, v.PublicKey)
+	if !(len(v.Hash) == 0) {
+		writer.WriteBytes(2, v.Hash)
 	}
-	if !((v.KeyInfo).Equal(new(KeyInfo))) {
-		writer.WriteValue(3, v.KeyInfo.MarshalBinary)
+	if !(v.Permission == 0) {
+		writer.WriteEnum(3, v.Permission)
+	}
+	if !(len(v.Signers) == 0) {
+		for _, v := range v.Signers {
+			writer.WriteUrl(4, v)
+		}
+	}
+	if !(len(v.Principals) == 0) {
+		for _, v := range v.Principals {
+			writer.WriteUrl(5, v)
+		}
+	}
+	if !(len(v.DailyLimits) == 0) {
+		for _, v := range v.DailyLimits {
+			writer.WriteValue(6, v.MarshalBinary)
+		}
 	}
 
-	_, _, err := writer.Reset(fieldNames_Key)
+	_, _, err := writer.Reset(fieldNames_ApiToken)
 	if err != nil {
 		return nil, encoding.Error{E: err}
 	}
@@ -466,23 +1261,23 @@ func (v *Key) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
-func (v *Key) IsValid() error {
+func (v *ApiToken) IsValid() error {
 	var errs []string
 
 	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
-		errs = append(errs, "field PrivateKey is missing")
-	} else if len(v.PrivateKey) == 0 {
-		errs = append(errs, "field PrivateKey is not set")
+		errs = append(errs, "field Name is missing")
+	} else if len(v.Name) == 0 {
+		errs = append(errs, "field Name is not set")
 	}
 	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
-		errs = append(errs, "field PublicKey is missing")
-	} else if len(v.PublicKey) == 0 {
-		errs = append(errs, "field PublicKey is not set")
+		errs = append(errs, "field Hash is missing")
+	} else if len(v.Hash) == 0 {
+		errs = append(errs, "field Hash is not set")
 	}
 	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
-		errs = append(errs, "field KeyInfo is missing")
-	} else if (v.KeyInfo).Equal(new(KeyInfo)) {
-		errs = append(errs, "field KeyInfo is not set")
+		errs = append(errs, "field Permission is missing")
+	} else if v.Permission == 0 {
+		errs = append(errs, "field Permission is not set")
 	}
 
 	switch len(errs) {
@@ -495,23 +1290,31 @@ func (v *Key) IsValid() error {
 	}
 }
 
-var fieldNames_KeyInfo = []string{
-	1: "Type",
-	2: "Derivation",
+var fieldNames_ApiTokenUsage = []string{
+	1: "Day",
+	2: "Spent",
+	3: "Transactions",
 }
 
-func (v *KeyInfo) MarshalBinary() ([]byte, error) {
+func (v *ApiTokenUsage) MarshalBinary() ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	writer := encoding.NewWriter(buffer)
 
-	if !(v.Type == 0) {
-		writer.WriteEnum(1, v.Type)
+	if !(v.Day == 0) {
+		writer.WriteUint(1, v.Day)
 	}
-	if !(len(v.Derivation) == 0) {
-		writer.WriteString(2, v.Derivation)
+	if !(len(v.Spent) == 0) {
+		for _, v := range v.Spent {
+			writer.WriteValue(2, v.MarshalBinary)
+		}
+	}
+	if !(len(v.Transactions) == 0) {
+		for _, v := range v.Transactions {
+			writer.WriteHash(3, &v)
+		}
 	}
 
-	_, _, err := writer.Reset(fieldNames_KeyInfo)
+	_, _, err := writer.Reset(fieldNames_ApiTokenUsage)
 	if err != nil {
 		return nil, encoding.Error{E: err}
 	}
@@ -519,13 +1322,23 @@ func (v *KeyInfo) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
-func (v *KeyInfo) IsValid() error {
+func (v *ApiTokenUsage) IsValid() error {
 	var errs []string
 
 	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
-		errs = append(errs, "field Type is missing")
-	} else if v.Type == 0 {
-		errs = append(errs, "field Type is not set")
+		errs = append(errs, "field Day is missing")
+	} else if v.Day == 0 {
+		errs = append(errs, "field Day is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Spent is missing")
+	} else if len(v.Spent) == 0 {
+		errs = append(errs, "field Spent is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field Transactions is missing")
+	} else if len(v.Transactions) == 0 {
+		errs = append(errs, "field Transactions is not set")
 	}
 
 	switch len(errs) {
@@ -538,23 +1351,23 @@ func (v *KeyInfo) IsValid() error {
 	}
 }
 
-var fieldNames_KeyName = []string{
-	1: "Name",
-	2: "PublicKey",
+var fieldNames_HistorySyncState = []string{
+	1: "Account",
+	2: "Height",
 }
 
-func (v *KeyName) MarshalBinary() ([]byte, error) {
+func (v *HistorySyncState) MarshalBinary() ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	writer := encoding.NewWriter(buffer)
 
-	if !(len(v.Name) == 0) {
-		writer.WriteString(1, v.Name)
+	if !(v.Account == nil) {
+		writer.WriteUrl(1, v.Account)
 	}
-	if !(len(v.PublicKey) == 0) {
-		writer.WriteBytes(2, v.PublicKey)
+	if !(v.Height == 0) {
+		writer.WriteUint(2, v.Height)
 	}
 
-	_, _, err := writer.Reset(fieldNames_KeyName)
+	_, _, err := writer.Reset(fieldNames_HistorySyncState)
 	if err != nil {
 		return nil, encoding.Error{E: err}
 	}
@@ -562,18 +1375,18 @@ func (v *KeyName) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
-func (v *KeyName) IsValid() error {
+func (v *HistorySyncState) IsValid() error {
 	var errs []string
 
 	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
-		errs = append(errs, "field Name is missing")
-	} else if len(v.Name) == 0 {
-		errs = append(errs, "field Name is not set")
+		errs = append(errs, "field Account is missing")
+	} else if v.Account == nil {
+		errs = append(errs, "field Account is not set")
 	}
 	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
-		errs = append(errs, "field PublicKey is missing")
-	} else if len(v.PublicKey) == 0 {
-		errs = append(errs, "field PublicKey is not set")
+		errs = append(errs, "field Height is missing")
+	} else if v.Height == 0 {
+		errs = append(errs, "field Height is not set")
 	}
 
 	switch len(errs) {
@@ -586,25 +1399,27 @@ func (v *KeyName) IsValid() error {
 	}
 }
 
-var fieldNames_Page = []string{
-	1: "Url",
-	2: "KeyNames",
+var fieldNames_Key = []string{
+	1: "PrivateKey",
+	2: "PublicKey",
+	3: "KeyInfo",
 }
 
-func (v *Page) MarshalBinary() ([]byte, error) {
+func (v *Key) MarshalBinary() ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	writer := encoding.NewWriter(buffer)
 
-	if !(v.Url == (url.URL{})) {
-		writer.WriteUrl(1, &v.Url)
+	if !(len(v.PrivateKey) == 0) {
+		writer.WriteBytes(1, v.PrivateKey)
 	}
-	if !(len(v.KeyNames) == 0) {
-		for _, v := range v.KeyNames {
-			writer.WriteString(2, v)
-		}
+	if !(len(v.PublicKey) == 0) {
+		writer.WriteBytes(2, v.PublicKey)
+	}
+	if !((v.KeyInfo).Equal(new(KeyInfo))) {
+		writer.WriteValue(3, v.KeyInfo.MarshalBinary)
 	}
 
-	_, _, err := writer.Reset(fieldNames_Page)
+	_, _, err := writer.Reset(fieldNames_Key)
 	if err != nil {
 		return nil, encoding.Error{E: err}
 	}
@@ -612,21 +1427,354 @@ func (v *Page) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
-func (v *Page) IsValid() error {
+func (v *Key) IsValid() error {
 	var errs []string
 
 	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
-		errs = append(errs, "field Url is missing")
-	} else if v.Url == (url.URL{}) {
-		errs = append(errs, "field Url is not set")
+		errs = append(errs, "field PrivateKey is missing")
+	} else if len(v.PrivateKey) == 0 {
+		errs = append(errs, "field PrivateKey is not set")
 	}
 	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
-		errs = append(errs, "field KeyNames is missing")
-	} else if len(v.KeyNames) == 0 {
-		errs = append(errs, "field KeyNames is not set")
+		errs = append(errs, "field PublicKey is missing")
+	} else if len(v.PublicKey) == 0 {
+		errs = append(errs, "field PublicKey is not set")
 	}
-
-	switch len(errs) {
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field KeyInfo is missing")
+	} else if (v.KeyInfo).Equal(new(KeyInfo)) {
+		errs = append(errs, "field KeyInfo is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_KeyInfo = []string{
+	1: "Type",
+	2: "Derivation",
+}
+
+func (v *KeyInfo) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Type == 0) {
+		writer.WriteEnum(1, v.Type)
+	}
+	if !(len(v.Derivation) == 0) {
+		writer.WriteString(2, v.Derivation)
+	}
+
+	_, _, err := writer.Reset(fieldNames_KeyInfo)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *KeyInfo) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Type is missing")
+	} else if v.Type == 0 {
+		errs = append(errs, "field Type is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_KeyName = []string{
+	1: "Name",
+	2: "PublicKey",
+}
+
+func (v *KeyName) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(len(v.Name) == 0) {
+		writer.WriteString(1, v.Name)
+	}
+	if !(len(v.PublicKey) == 0) {
+		writer.WriteBytes(2, v.PublicKey)
+	}
+
+	_, _, err := writer.Reset(fieldNames_KeyName)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *KeyName) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Name is missing")
+	} else if len(v.Name) == 0 {
+		errs = append(errs, "field Name is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field PublicKey is missing")
+	} else if len(v.PublicKey) == 0 {
+		errs = append(errs, "field PublicKey is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_LedgerEntry = []string{
+	1:  "Account",
+	2:  "Index",
+	3:  "TxID",
+	4:  "Time",
+	5:  "Type",
+	6:  "Counterparty",
+	7:  "Token",
+	8:  "Amount",
+	9:  "Incoming",
+	10: "FeeCredits",
+	11: "FeeAcme",
+	12: "Memo",
+	13: "Precision",
+}
+
+func (v *LedgerEntry) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Account == nil) {
+		writer.WriteUrl(1, v.Account)
+	}
+	if !(v.Index == 0) {
+		writer.WriteUint(2, v.Index)
+	}
+	if !(v.TxID == nil) {
+		writer.WriteTxid(3, v.TxID)
+	}
+	if !(v.Time == (time.Time{})) {
+		writer.WriteTime(4, v.Time)
+	}
+	if !(v.Type == 0) {
+		writer.WriteEnum(5, v.Type)
+	}
+	if !(v.Counterparty == nil) {
+		writer.WriteUrl(6, v.Counterparty)
+	}
+	if !(v.Token == nil) {
+		writer.WriteUrl(7, v.Token)
+	}
+	if !((v.Amount).Cmp(new(big.Int)) == 0) {
+		writer.WriteBigInt(8, &v.Amount)
+	}
+	if !(!v.Incoming) {
+		writer.WriteBool(9, v.Incoming)
+	}
+	if !(v.FeeCredits == 0) {
+		writer.WriteUint(10, v.FeeCredits)
+	}
+	if !((v.FeeAcme).Cmp(new(big.Int)) == 0) {
+		writer.WriteBigInt(11, &v.FeeAcme)
+	}
+	if !(len(v.Memo) == 0) {
+		writer.WriteString(12, v.Memo)
+	}
+	if !(v.Precision == 0) {
+		writer.WriteUint(13, v.Precision)
+	}
+
+	_, _, err := writer.Reset(fieldNames_LedgerEntry)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *LedgerEntry) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Account is missing")
+	} else if v.Account == nil {
+		errs = append(errs, "field Account is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Index is missing")
+	} else if v.Index == 0 {
+		errs = append(errs, "field Index is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field TxID is missing")
+	} else if v.TxID == nil {
+		errs = append(errs, "field TxID is not set")
+	}
+	if len(v.fieldsSet) > 5 && !v.fieldsSet[5] {
+		errs = append(errs, "field Type is missing")
+	} else if v.Type == 0 {
+		errs = append(errs, "field Type is not set")
+	}
+	if len(v.fieldsSet) > 8 && !v.fieldsSet[8] {
+		errs = append(errs, "field Amount is missing")
+	} else if (v.Amount).Cmp(new(big.Int)) == 0 {
+		errs = append(errs, "field Amount is not set")
+	}
+	if len(v.fieldsSet) > 9 && !v.fieldsSet[9] {
+		errs = append(errs, "field Incoming is missing")
+	} else if !v.Incoming {
+		errs = append(errs, "field Incoming is not set")
+	}
+	if len(v.fieldsSet) > 10 && !v.fieldsSet[10] {
+		errs = append(errs, "field FeeCredits is missing")
+	} else if v.FeeCredits == 0 {
+		errs = append(errs, "field FeeCredits is not set")
+	}
+	if len(v.fieldsSet) > 11 && !v.fieldsSet[11] {
+		errs = append(errs, "field FeeAcme is missing")
+	} else if (v.FeeAcme).Cmp(new(big.Int)) == 0 {
+		errs = append(errs, "field FeeAcme is not set")
+	}
+	if len(v.fieldsSet) > 13 && !v.fieldsSet[13] {
+		errs = append(errs, "field Precision is missing")
+	} else if v.Precision == 0 {
+		errs = append(errs, "field Precision is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_Page = []string{
+	1: "Url",
+	2: "KeyNames",
+}
+
+func (v *Page) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Url == (url.URL{})) {
+		writer.WriteUrl(1, &v.Url)
+	}
+	if !(len(v.KeyNames) == 0) {
+		for _, v := range v.KeyNames {
+			writer.WriteString(2, v)
+		}
+	}
+
+	_, _, err := writer.Reset(fieldNames_Page)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *Page) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Url is missing")
+	} else if v.Url == (url.URL{}) {
+		errs = append(errs, "field Url is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field KeyNames is missing")
+	} else if len(v.KeyNames) == 0 {
+		errs = append(errs, "field KeyNames is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_PartiallySignedTransaction = []string{
+	1: "Version",
+	2: "Transaction",
+	3: "Signers",
+	4: "Signatures",
+}
+
+func (v *PartiallySignedTransaction) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Version == 0) {
+		writer.WriteUint(1, v.Version)
+	}
+	if !(v.Transaction == nil) {
+		writer.WriteValue(2, v.Transaction.MarshalBinary)
+	}
+	if !(len(v.Signers) == 0) {
+		for _, v := range v.Signers {
+			writer.WriteValue(3, v.MarshalBinary)
+		}
+	}
+	if !(len(v.Signatures) == 0) {
+		for _, v := range v.Signatures {
+			writer.WriteValue(4, v.MarshalBinary)
+		}
+	}
+
+	_, _, err := writer.Reset(fieldNames_PartiallySignedTransaction)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *PartiallySignedTransaction) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Version is missing")
+	} else if v.Version == 0 {
+		errs = append(errs, "field Version is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Transaction is missing")
+	} else if v.Transaction == nil {
+		errs = append(errs, "field Transaction is not set")
+	}
+
+	switch len(errs) {
 	case 0:
 		return nil
 	case 1:
@@ -636,25 +1784,752 @@ func (v *Page) IsValid() error {
 	}
 }
 
-func (v *Adi) UnmarshalBinary(data []byte) error {
+var fieldNames_PstSigner = []string{
+	1: "Url",
+	2: "Authority",
+	3: "Version",
+	4: "Threshold",
+	5: "Delegators",
+}
+
+func (v *PstSigner) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Url == nil) {
+		writer.WriteUrl(1, v.Url)
+	}
+	if !(v.Authority == nil) {
+		writer.WriteUrl(2, v.Authority)
+	}
+	if !(v.Version == 0) {
+		writer.WriteUint(3, v.Version)
+	}
+	if !(v.Threshold == 0) {
+		writer.WriteUint(4, v.Threshold)
+	}
+	if !(len(v.Delegators) == 0) {
+		for _, v := range v.Delegators {
+			writer.WriteUrl(5, v)
+		}
+	}
+
+	_, _, err := writer.Reset(fieldNames_PstSigner)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *PstSigner) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Url is missing")
+	} else if v.Url == nil {
+		errs = append(errs, "field Url is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field Version is missing")
+	} else if v.Version == 0 {
+		errs = append(errs, "field Version is not set")
+	}
+	if len(v.fieldsSet) > 4 && !v.fieldsSet[4] {
+		errs = append(errs, "field Threshold is missing")
+	} else if v.Threshold == 0 {
+		errs = append(errs, "field Threshold is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_RecipientLimit = []string{
+	1: "Recipient",
+	2: "Token",
+	3: "Amount",
+}
+
+func (v *RecipientLimit) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Recipient == nil) {
+		writer.WriteUrl(1, v.Recipient)
+	}
+	if !(v.Token == nil) {
+		writer.WriteUrl(2, v.Token)
+	}
+	if !((v.Amount).Cmp(new(big.Int)) == 0) {
+		writer.WriteBigInt(3, &v.Amount)
+	}
+
+	_, _, err := writer.Reset(fieldNames_RecipientLimit)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *RecipientLimit) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Token is missing")
+	} else if v.Token == nil {
+		errs = append(errs, "field Token is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field Amount is missing")
+	} else if (v.Amount).Cmp(new(big.Int)) == 0 {
+		errs = append(errs, "field Amount is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_SigningPolicy = []string{
+	1: "Name",
+	2: "Signers",
+	3: "TransactionTypes",
+	4: "Principals",
+	5: "Recipients",
+	6: "MemoPattern",
+}
+
+func (v *SigningPolicy) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(len(v.Name) == 0) {
+		writer.WriteString(1, v.Name)
+	}
+	if !(len(v.Signers) == 0) {
+		for _, v := range v.Signers {
+			writer.WriteUrl(2, v)
+		}
+	}
+	if !(len(v.TransactionTypes) == 0) {
+		for _, v := range v.TransactionTypes {
+			writer.WriteEnum(3, v)
+		}
+	}
+	if !(len(v.Principals) == 0) {
+		for _, v := range v.Principals {
+			writer.WriteUrl(4, v)
+		}
+	}
+	if !(len(v.Recipients) == 0) {
+		for _, v := range v.Recipients {
+			writer.WriteValue(5, v.MarshalBinary)
+		}
+	}
+	if !(len(v.MemoPattern) == 0) {
+		writer.WriteString(6, v.MemoPattern)
+	}
+
+	_, _, err := writer.Reset(fieldNames_SigningPolicy)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *SigningPolicy) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Name is missing")
+	} else if len(v.Name) == 0 {
+		errs = append(errs, "field Name is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field TransactionTypes is missing")
+	} else if len(v.TransactionTypes) == 0 {
+		errs = append(errs, "field TransactionTypes is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_TokenAmount = []string{
+	1: "Token",
+	2: "Amount",
+}
+
+func (v *TokenAmount) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Token == nil) {
+		writer.WriteUrl(1, v.Token)
+	}
+	if !((v.Amount).Cmp(new(big.Int)) == 0) {
+		writer.WriteBigInt(2, &v.Amount)
+	}
+
+	_, _, err := writer.Reset(fieldNames_TokenAmount)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *TokenAmount) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Token is missing")
+	} else if v.Token == nil {
+		errs = append(errs, "field Token is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Amount is missing")
+	} else if (v.Amount).Cmp(new(big.Int)) == 0 {
+		errs = append(errs, "field Amount is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_WatchedAccount = []string{
+	1: "Url",
+	2: "Label",
+}
+
+func (v *WatchedAccount) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Url == nil) {
+		writer.WriteUrl(1, v.Url)
+	}
+	if !(len(v.Label) == 0) {
+		writer.WriteString(2, v.Label)
+	}
+
+	_, _, err := writer.Reset(fieldNames_WatchedAccount)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *WatchedAccount) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Url is missing")
+	} else if v.Url == nil {
+		errs = append(errs, "field Url is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_WatchedKey = []string{
+	1: "PublicKey",
+	2: "Type",
+	3: "Label",
+}
+
+func (v *WatchedKey) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(len(v.PublicKey) == 0) {
+		writer.WriteBytes(1, v.PublicKey)
+	}
+	if !(v.Type == 0) {
+		writer.WriteEnum(2, v.Type)
+	}
+	if !(len(v.Label) == 0) {
+		writer.WriteString(3, v.Label)
+	}
+
+	_, _, err := writer.Reset(fieldNames_WatchedKey)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *WatchedKey) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field PublicKey is missing")
+	} else if len(v.PublicKey) == 0 {
+		errs = append(errs, "field PublicKey is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Type is missing")
+	} else if v.Type == 0 {
+		errs = append(errs, "field Type is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+func (v *AddressBookEntry) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *AddressBookEntry) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadString(1); ok {
+		v.Label = x
+	}
+	if x, ok := reader.ReadUrl(2); ok {
+		v.Url = x
+	}
+
+	seen, err := reader.Reset(fieldNames_AddressBookEntry)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *Adi) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *Adi) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUrl(1); ok {
+		v.Url = *x
+	}
+	for {
+		if x := new(Page); reader.ReadValue(2, x.UnmarshalBinary) {
+			v.Pages = append(v.Pages, *x)
+		} else {
+			break
+		}
+	}
+
+	seen, err := reader.Reset(fieldNames_Adi)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *ApiToken) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *ApiToken) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadString(1); ok {
+		v.Name = x
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.Hash = x
+	}
+	if x := new(ApiPermission); reader.ReadEnum(3, x) {
+		v.Permission = *x
+	}
+	for {
+		if x, ok := reader.ReadUrl(4); ok {
+			v.Signers = append(v.Signers, x)
+		} else {
+			break
+		}
+	}
+	for {
+		if x, ok := reader.ReadUrl(5); ok {
+			v.Principals = append(v.Principals, x)
+		} else {
+			break
+		}
+	}
+	for {
+		if x := new(TokenAmount); reader.ReadValue(6, x.UnmarshalBinary) {
+			v.DailyLimits = append(v.DailyLimits, x)
+		} else {
+			break
+		}
+	}
+
+	seen, err := reader.Reset(fieldNames_ApiToken)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *ApiTokenUsage) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *ApiTokenUsage) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUint(1); ok {
+		v.Day = x
+	}
+	for {
+		if x := new(TokenAmount); reader.ReadValue(2, x.UnmarshalBinary) {
+			v.Spent = append(v.Spent, x)
+		} else {
+			break
+		}
+	}
+	for {
+		if x, ok := reader.ReadHash(3); ok {
+			v.Transactions = append(v.Transactions, *x)
+		} else {
+			break
+		}
+	}
+
+	seen, err := reader.Reset(fieldNames_ApiTokenUsage)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *HistorySyncState) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *HistorySyncState) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUrl(1); ok {
+		v.Account = x
+	}
+	if x, ok := reader.ReadUint(2); ok {
+		v.Height = x
+	}
+
+	seen, err := reader.Reset(fieldNames_HistorySyncState)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *Key) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *Key) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadBytes(1); ok {
+		v.PrivateKey = x
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.PublicKey = x
+	}
+	if x := new(KeyInfo); reader.ReadValue(3, x.UnmarshalBinary) {
+		v.KeyInfo = *x
+	}
+
+	seen, err := reader.Reset(fieldNames_Key)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *KeyInfo) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *KeyInfo) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x := new(protocol.SignatureType); reader.ReadEnum(1, x) {
+		v.Type = *x
+	}
+	if x, ok := reader.ReadString(2); ok {
+		v.Derivation = x
+	}
+
+	seen, err := reader.Reset(fieldNames_KeyInfo)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *KeyName) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *KeyName) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadString(1); ok {
+		v.Name = x
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.PublicKey = x
+	}
+
+	seen, err := reader.Reset(fieldNames_KeyName)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *LedgerEntry) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *LedgerEntry) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUrl(1); ok {
+		v.Account = x
+	}
+	if x, ok := reader.ReadUint(2); ok {
+		v.Index = x
+	}
+	if x, ok := reader.ReadTxid(3); ok {
+		v.TxID = x
+	}
+	if x, ok := reader.ReadTime(4); ok {
+		v.Time = x
+	}
+	if x := new(protocol.TransactionType); reader.ReadEnum(5, x) {
+		v.Type = *x
+	}
+	if x, ok := reader.ReadUrl(6); ok {
+		v.Counterparty = x
+	}
+	if x, ok := reader.ReadUrl(7); ok {
+		v.Token = x
+	}
+	if x, ok := reader.ReadBigInt(8); ok {
+		v.Amount = *x
+	}
+	if x, ok := reader.ReadBool(9); ok {
+		v.Incoming = x
+	}
+	if x, ok := reader.ReadUint(10); ok {
+		v.FeeCredits = x
+	}
+	if x, ok := reader.ReadBigInt(11); ok {
+		v.FeeAcme = *x
+	}
+	if x, ok := reader.ReadString(12); ok {
+		v.Memo = x
+	}
+	if x, ok := reader.ReadUint(13); ok {
+		v.Precision = x
+	}
+
+	seen, err := reader.Reset(fieldNames_LedgerEntry)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *Page) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *Page) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUrl(1); ok {
+		v.Url = *x
+	}
+	for {
+		if x, ok := reader.ReadString(2); ok {
+			v.KeyNames = append(v.KeyNames, x)
+		} else {
+			break
+		}
+	}
+
+	seen, err := reader.Reset(fieldNames_Page)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *PartiallySignedTransaction) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *PartiallySignedTransaction) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUint(1); ok {
+		v.Version = x
+	}
+	if x := new(protocol.Transaction); reader.ReadValue(2, x.UnmarshalBinary) {
+		v.Transaction = x
+	}
+	for {
+		if x := new(PstSigner); reader.ReadValue(3, x.UnmarshalBinary) {
+			v.Signers = append(v.Signers, x)
+		} else {
+			break
+		}
+	}
+	for {
+		ok := reader.ReadValue(4, func(b []byte) error {
+			x, err := protocol.UnmarshalSignature(b)
+			if err == nil {
+				v.Signatures = append(v.Signatures, x)
+			}
+			return err
+		})
+		if !ok {
+			break
+		}
+	}
+
+	seen, err := reader.Reset(fieldNames_PartiallySignedTransaction)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *PstSigner) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
 
-func (v *Adi) UnmarshalBinaryFrom(rd io.Reader) error {
+func (v *PstSigner) UnmarshalBinaryFrom(rd io.Reader) error {
 	reader := encoding.NewReader(rd)
 
 	if x, ok := reader.ReadUrl(1); ok {
-		v.Url = *x
+		v.Url = x
+	}
+	if x, ok := reader.ReadUrl(2); ok {
+		v.Authority = x
+	}
+	if x, ok := reader.ReadUint(3); ok {
+		v.Version = x
+	}
+	if x, ok := reader.ReadUint(4); ok {
+		v.Threshold = x
 	}
 	for {
-		if x := new(Page); reader.ReadValue(2, x.UnmarshalBinary) {
-			v.Pages = append(v.Pages, *x)
+		if x, ok := reader.ReadUrl(5); ok {
+			v.Delegators = append(v.Delegators, x)
 		} else {
 			break
 		}
 	}
 
-	seen, err := reader.Reset(fieldNames_Adi)
+	seen, err := reader.Reset(fieldNames_PstSigner)
 	if err != nil {
 		return encoding.Error{E: err}
 	}
@@ -666,24 +2541,24 @@ func (v *Adi) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
-func (v *Key) UnmarshalBinary(data []byte) error {
+func (v *RecipientLimit) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
 
-func (v *Key) UnmarshalBinaryFrom(rd io.Reader) error {
+func (v *RecipientLimit) UnmarshalBinaryFrom(rd io.Reader) error {
 	reader := encoding.NewReader(rd)
 
-	if x, ok := reader.ReadBytes(1); ok {
-		v.PrivateKey = x
+	if x, ok := reader.ReadUrl(1); ok {
+		v.Recipient = x
 	}
-	if x, ok := reader.ReadBytes(2); ok {
-		v.PublicKey = x
+	if x, ok := reader.ReadUrl(2); ok {
+		v.Token = x
 	}
-	if x := new(KeyInfo); reader.ReadValue(3, x.UnmarshalBinary) {
-		v.KeyInfo = *x
+	if x, ok := reader.ReadBigInt(3); ok {
+		v.Amount = *x
 	}
 
-	seen, err := reader.Reset(fieldNames_Key)
+	seen, err := reader.Reset(fieldNames_RecipientLimit)
 	if err != nil {
 		return encoding.Error{E: err}
 	}
@@ -695,21 +2570,49 @@ func (v *Key) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
-func (v *KeyInfo) UnmarshalBinary(data []byte) error {
+func (v *SigningPolicy) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
 
-func (v *KeyInfo) UnmarshalBinaryFrom(rd io.Reader) error {
+func (v *SigningPolicy) UnmarshalBinaryFrom(rd io.Reader) error {
 	reader := encoding.NewReader(rd)
 
-	if x := new(protocol.SignatureType); reader.ReadEnum(1, x) {
-		v.Type = *x
+	if x, ok := reader.ReadString(1); ok {
+		v.Name = x
 	}
-	if x, ok := reader.ReadString(2); ok {
-		v.Derivation = x
+	for {
+		if x, ok := reader.ReadUrl(2); ok {
+			v.Signers = append(v.Signers, x)
+		} else {
+			break
+		}
+	}
+	for {
+		if x := new(protocol.TransactionType); reader.ReadEnum(3, x) {
+			v.TransactionTypes = append(v.TransactionTypes, *x)
+		} else {
+			break
+		}
+	}
+	for {
+		if x, ok := reader.ReadUrl(4); ok {
+			v.Principals = append(v.Principals, x)
+		} else {
+			break
+		}
+	}
+	for {
+		if x := new(RecipientLimit); reader.ReadValue(5, x.UnmarshalBinary) {
+			v.Recipients = append(v.Recipients, x)
+		} else {
+			break
+		}
+	}
+	if x, ok := reader.ReadString(6); ok {
+		v.MemoPattern = x
 	}
 
-	seen, err := reader.Reset(fieldNames_KeyInfo)
+	seen, err := reader.Reset(fieldNames_SigningPolicy)
 	if err != nil {
 		return encoding.Error{E: err}
 	}
@@ -721,21 +2624,21 @@ func (v *KeyInfo) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
-func (v *KeyName) UnmarshalBinary(data []byte) error {
+func (v *TokenAmount) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
 
-func (v *KeyName) UnmarshalBinaryFrom(rd io.Reader) error {
+func (v *TokenAmount) UnmarshalBinaryFrom(rd io.Reader) error {
 	reader := encoding.NewReader(rd)
 
-	if x, ok := reader.ReadString(1); ok {
-		v.Name = x
+	if x, ok := reader.ReadUrl(1); ok {
+		v.Token = x
 	}
-	if x, ok := reader.ReadBytes(2); ok {
-		v.PublicKey = x
+	if x, ok := reader.ReadBigInt(2); ok {
+		v.Amount = *x
 	}
 
-	seen, err := reader.Reset(fieldNames_KeyName)
+	seen, err := reader.Reset(fieldNames_TokenAmount)
 	if err != nil {
 		return encoding.Error{E: err}
 	}
@@ -747,25 +2650,50 @@ func (v *KeyName) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
-func (v *Page) UnmarshalBinary(data []byte) error {
+func (v *WatchedAccount) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
 
-func (v *Page) UnmarshalBinaryFrom(rd io.Reader) error {
+func (v *WatchedAccount) UnmarshalBinaryFrom(rd io.Reader) error {
 	reader := encoding.NewReader(rd)
 
 	if x, ok := reader.ReadUrl(1); ok {
-		v.Url = *x
+		v.Url = x
 	}
-	for {
-		if x, ok := reader.ReadString(2); ok {
-			v.KeyNames = append(v.KeyNames, x)
-		} else {
-			break
-		}
+	if x, ok := reader.ReadString(2); ok {
+		v.Label = x
 	}
 
-	seen, err := reader.Reset(fieldNames_Page)
+	seen, err := reader.Reset(fieldNames_WatchedAccount)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *WatchedKey) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *WatchedKey) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadBytes(1); ok {
+		v.PublicKey = x
+	}
+	if x := new(protocol.SignatureType); reader.ReadEnum(2, x) {
+		v.Type = *x
+	}
+	if x, ok := reader.ReadString(3); ok {
+		v.Label = x
+	}
+
+	seen, err := reader.Reset(fieldNames_WatchedKey)
 	if err != nil {
 		return encoding.Error{E: err}
 	}
@@ -787,6 +2715,39 @@ func (v *Adi) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *ApiToken) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Name        string                          `json:"name,omitempty"`
+		Hash        *string                         `json:"hash,omitempty"`
+		Permission  ApiPermission                   `json:"permission,omitempty"`
+		Signers     encoding.JsonList[*url.URL]     `json:"signers,omitempty"`
+		Principals  encoding.JsonList[*url.URL]     `json:"principals,omitempty"`
+		DailyLimits encoding.JsonList[*TokenAmount] `json:"dailyLimits,omitempty"`
+	}{}
+	u.Name = v.Name
+	u.Hash = encoding.BytesToJSON(v.Hash)
+	u.Permission = v.Permission
+	u.Signers = v.Signers
+	u.Principals = v.Principals
+	u.DailyLimits = v.DailyLimits
+	return json.Marshal(&u)
+}
+
+func (v *ApiTokenUsage) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Day          uint64                          `json:"day,omitempty"`
+		Spent        encoding.JsonList[*TokenAmount] `json:"spent,omitempty"`
+		Transactions encoding.JsonList[string]       `json:"transactions,omitempty"`
+	}{}
+	u.Day = v.Day
+	u.Spent = v.Spent
+	u.Transactions = make(encoding.JsonList[string], len(v.Transactions))
+	for i, x := range v.Transactions {
+		u.Transactions[i] = encoding.ChainToJSON(x)
+	}
+	return json.Marshal(&u)
+}
+
 func (v *Key) MarshalJSON() ([]byte, error) {
 	u := struct {
 		PrivateKey *string `json:"privateKey,omitempty"`
@@ -809,6 +2770,38 @@ func (v *KeyName) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *LedgerEntry) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Account      *url.URL                 `json:"account,omitempty"`
+		Index        uint64                   `json:"index,omitempty"`
+		TxID         *url.TxID                `json:"txID,omitempty"`
+		Time         time.Time                `json:"time,omitempty"`
+		Type         protocol.TransactionType `json:"type,omitempty"`
+		Counterparty *url.URL                 `json:"counterparty,omitempty"`
+		Token        *url.URL                 `json:"token,omitempty"`
+		Amount       *string                  `json:"amount,omitempty"`
+		Incoming     bool                     `json:"incoming,omitempty"`
+		FeeCredits   uint64                   `json:"feeCredits,omitempty"`
+		FeeAcme      *string                  `json:"feeAcme,omitempty"`
+		Memo         string                   `json:"memo,omitempty"`
+		Precision    uint64                   `json:"precision,omitempty"`
+	}{}
+	u.Account = v.Account
+	u.Index = v.Index
+	u.TxID = v.TxID
+	u.Time = v.Time
+	u.Type = v.Type
+	u.Counterparty = v.Counterparty
+	u.Token = v.Token
+	u.Amount = encoding.BigintToJSON(&v.Amount)
+	u.Incoming = v.Incoming
+	u.FeeCredits = v.FeeCredits
+	u.FeeAcme = encoding.BigintToJSON(&v.FeeAcme)
+	u.Memo = v.Memo
+	u.Precision = v.Precision
+	return json.Marshal(&u)
+}
+
 func (v *Page) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Url      url.URL                   `json:"url,omitempty"`
@@ -819,6 +2812,48 @@ func (v *Page) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *PartiallySignedTransaction) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Version     uint64                                             `json:"version,omitempty"`
+		Transaction *protocol.Transaction                              `json:"transaction,omitempty"`
+		Signers     encoding.JsonList[*PstSigner]                      `json:"signers,omitempty"`
+		Signatures  encoding.JsonUnmarshalListWith[protocol.Signature] `json:"signatures,omitempty"`
+	}{}
+	u.Version = v.Version
+	u.Transaction = v.Transaction
+	u.Signers = v.Signers
+	u.Signatures = encoding.JsonUnmarshalListWith[protocol.Signature]{Value: v.Signatures, Func: protocol.UnmarshalSignatureJSON}
+	return json.Marshal(&u)
+}
+
+func (v *PstSigner) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Url        *url.URL                    `json:"url,omitempty"`
+		Authority  *url.URL                    `json:"authority,omitempty"`
+		Version    uint64                      `json:"version,omitempty"`
+		Threshold  uint64                      `json:"threshold,omitempty"`
+		Delegators encoding.JsonList[*url.URL] `json:"delegators,omitempty"`
+	}{}
+	u.Url = v.Url
+	u.Authority = v.Authority
+	u.Version = v.Version
+	u.Threshold = v.Threshold
+	u.Delegators = v.Delegators
+	return json.Marshal(&u)
+}
+
+func (v *RecipientLimit) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Recipient *url.URL `json:"recipient,omitempty"`
+		Token     *url.URL `json:"token,omitempty"`
+		Amount    *string  `json:"amount,omitempty"`
+	}{}
+	u.Recipient = v.Recipient
+	u.Token = v.Token
+	u.Amount = encoding.BigintToJSON(&v.Amount)
+	return json.Marshal(&u)
+}
+
 func (v *SeedInfo) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Mnemonic    string                             `json:"mnemonic,omitempty"`
@@ -831,6 +2866,34 @@ func (v *SeedInfo) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *SigningPolicy) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Name             string                                      `json:"name,omitempty"`
+		Signers          encoding.JsonList[*url.URL]                 `json:"signers,omitempty"`
+		TransactionTypes encoding.JsonList[protocol.TransactionType] `json:"transactionTypes,omitempty"`
+		Principals       encoding.JsonList[*url.URL]                 `json:"principals,omitempty"`
+		Recipients       encoding.JsonList[*RecipientLimit]          `json:"recipients,omitempty"`
+		MemoPattern      string                                      `json:"memoPattern,omitempty"`
+	}{}
+	u.Name = v.Name
+	u.Signers = v.Signers
+	u.TransactionTypes = v.TransactionTypes
+	u.Principals = v.Principals
+	u.Recipients = v.Recipients
+	u.MemoPattern = v.MemoPattern
+	return json.Marshal(&u)
+}
+
+func (v *TokenAmount) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Token  *url.URL `json:"token,omitempty"`
+		Amount *string  `json:"amount,omitempty"`
+	}{}
+	u.Token = v.Token
+	u.Amount = encoding.BigintToJSON(&v.Amount)
+	return json.Marshal(&u)
+}
+
 func (v *Wallet) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Version    Version                      `json:"version,omitempty"`
@@ -849,6 +2912,18 @@ func (v *Wallet) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *WatchedKey) MarshalJSON() ([]byte, error) {
+	u := struct {
+		PublicKey *string                `json:"publicKey,omitempty"`
+		Type      protocol.SignatureType `json:"type,omitempty"`
+		Label     string                 `json:"label,omitempty"`
+	}{}
+	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.Type = v.Type
+	u.Label = v.Label
+	return json.Marshal(&u)
+}
+
 func (v *Adi) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Url   url.URL                 `json:"url,omitempty"`
@@ -864,6 +2939,65 @@ func (v *Adi) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *ApiToken) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Name        string                          `json:"name,omitempty"`
+		Hash        *string                         `json:"hash,omitempty"`
+		Permission  ApiPermission                   `json:"permission,omitempty"`
+		Signers     encoding.JsonList[*url.URL]     `json:"signers,omitempty"`
+		Principals  encoding.JsonList[*url.URL]     `json:"principals,omitempty"`
+		DailyLimits encoding.JsonList[*TokenAmount] `json:"dailyLimits,omitempty"`
+	}{}
+	u.Name = v.Name
+	u.Hash = encoding.BytesToJSON(v.Hash)
+	u.Permission = v.Permission
+	u.Signers = v.Signers
+	u.Principals = v.Principals
+	u.DailyLimits = v.DailyLimits
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Name = u.Name
+	if x, err := encoding.BytesFromJSON(u.Hash); err != nil {
+		return fmt.Errorf("error decoding Hash: %w", err)
+	} else {
+		v.Hash = x
+	}
+	v.Permission = u.Permission
+	v.Signers = u.Signers
+	v.Principals = u.Principals
+	v.DailyLimits = u.DailyLimits
+	return nil
+}
+
+func (v *ApiTokenUsage) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Day          uint64                          `json:"day,omitempty"`
+		Spent        encoding.JsonList[*TokenAmount] `json:"spent,omitempty"`
+		Transactions encoding.JsonList[string]       `json:"transactions,omitempty"`
+	}{}
+	u.Day = v.Day
+	u.Spent = v.Spent
+	u.Transactions = make(encoding.JsonList[string], len(v.Transactions))
+	for i, x := range v.Transactions {
+		u.Transactions[i] = encoding.ChainToJSON(x)
+	}
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Day = u.Day
+	v.Spent = u.Spent
+	v.Transactions = make([][32]byte, len(u.Transactions))
+	for i, x := range u.Transactions {
+		if x, err := encoding.ChainFromJSON(x); err != nil {
+			return fmt.Errorf("error decoding Transactions: %w", err)
+		} else {
+			v.Transactions[i] = x
+		}
+	}
+	return nil
+}
+
 func (v *Key) UnmarshalJSON(data []byte) error {
 	u := struct {
 		PrivateKey *string `json:"privateKey,omitempty"`
@@ -909,6 +3043,62 @@ func (v *KeyName) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *LedgerEntry) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Account      *url.URL                 `json:"account,omitempty"`
+		Index        uint64                   `json:"index,omitempty"`
+		TxID         *url.TxID                `json:"txID,omitempty"`
+		Time         time.Time                `json:"time,omitempty"`
+		Type         protocol.TransactionType `json:"type,omitempty"`
+		Counterparty *url.URL                 `json:"counterparty,omitempty"`
+		Token        *url.URL                 `json:"token,omitempty"`
+		Amount       *string                  `json:"amount,omitempty"`
+		Incoming     bool                     `json:"incoming,omitempty"`
+		FeeCredits   uint64                   `json:"feeCredits,omitempty"`
+		FeeAcme      *string                  `json:"feeAcme,omitempty"`
+		Memo         string                   `json:"memo,omitempty"`
+		Precision    uint64                   `json:"precision,omitempty"`
+	}{}
+	u.Account = v.Account
+	u.Index = v.Index
+	u.TxID = v.TxID
+	u.Time = v.Time
+	u.Type = v.Type
+	u.Counterparty = v.Counterparty
+	u.Token = v.Token
+	u.Amount = encoding.BigintToJSON(&v.Amount)
+	u.Incoming = v.Incoming
+	u.FeeCredits = v.FeeCredits
+	u.FeeAcme = encoding.BigintToJSON(&v.FeeAcme)
+	u.Memo = v.Memo
+	u.Precision = v.Precision
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Account = u.Account
+	v.Index = u.Index
+	v.TxID = u.TxID
+	v.Time = u.Time
+	v.Type = u.Type
+	v.Counterparty = u.Counterparty
+	v.Token = u.Token
+	if x, err := encoding.BigintFromJSON(u.Amount); err != nil {
+		return fmt.Errorf("error decoding Amount: %w", err)
+	} else {
+		v.Amount = *x
+	}
+	v.Incoming = u.Incoming
+	v.FeeCredits = u.FeeCredits
+	if x, err := encoding.BigintFromJSON(u.FeeAcme); err != nil {
+		return fmt.Errorf("error decoding FeeAcme: %w", err)
+	} else {
+		v.FeeAcme = *x
+	}
+	v.Memo = u.Memo
+	v.Precision = u.Precision
+	return nil
+}
+
 func (v *Page) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Url      url.URL                   `json:"url,omitempty"`
@@ -924,6 +3114,76 @@ func (v *Page) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *PartiallySignedTransaction) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Version     uint64                                             `json:"version,omitempty"`
+		Transaction *protocol.Transaction                              `json:"transaction,omitempty"`
+		Signers     encoding.JsonList[*PstSigner]                      `json:"signers,omitempty"`
+		Signatures  encoding.JsonUnmarshalListWith[protocol.Signature] `json:"signatures,omitempty"`
+	}{}
+	u.Version = v.Version
+	u.Transaction = v.Transaction
+	u.Signers = v.Signers
+	u.Signatures = encoding.JsonUnmarshalListWith[protocol.Signature]{Value: v.Signatures, Func: protocol.UnmarshalSignatureJSON}
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Version = u.Version
+	v.Transaction = u.Transaction
+	v.Signers = u.Signers
+	v.Signatures = make([]protocol.Signature, len(u.Signatures.Value))
+	for i, x := range u.Signatures.Value {
+		v.Signatures[i] = x
+	}
+	return nil
+}
+
+func (v *PstSigner) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Url        *url.URL                    `json:"url,omitempty"`
+		Authority  *url.URL                    `json:"authority,omitempty"`
+		Version    uint64                      `json:"version,omitempty"`
+		Threshold  uint64                      `json:"threshold,omitempty"`
+		Delegators encoding.JsonList[*url.URL] `json:"delegators,omitempty"`
+	}{}
+	u.Url = v.Url
+	u.Authority = v.Authority
+	u.Version = v.Version
+	u.Threshold = v.Threshold
+	u.Delegators = v.Delegators
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Url = u.Url
+	v.Authority = u.Authority
+	v.Version = u.Version
+	v.Threshold = u.Threshold
+	v.Delegators = u.Delegators
+	return nil
+}
+
+func (v *RecipientLimit) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Recipient *url.URL `json:"recipient,omitempty"`
+		Token     *url.URL `json:"token,omitempty"`
+		Amount    *string  `json:"amount,omitempty"`
+	}{}
+	u.Recipient = v.Recipient
+	u.Token = v.Token
+	u.Amount = encoding.BigintToJSON(&v.Amount)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Recipient = u.Recipient
+	v.Token = u.Token
+	if x, err := encoding.BigintFromJSON(u.Amount); err != nil {
+		return fmt.Errorf("error decoding Amount: %w", err)
+	} else {
+		v.Amount = *x
+	}
+	return nil
+}
+
 func (v *SeedInfo) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Mnemonic    string                             `json:"mnemonic,omitempty"`
@@ -946,6 +3206,52 @@ func (v *SeedInfo) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *SigningPolicy) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Name             string                                      `json:"name,omitempty"`
+		Signers          encoding.JsonList[*url.URL]                 `json:"signers,omitempty"`
+		TransactionTypes encoding.JsonList[protocol.TransactionType] `json:"transactionTypes,omitempty"`
+		Principals       encoding.JsonList[*url.URL]                 `json:"principals,omitempty"`
+		Recipients       encoding.JsonList[*RecipientLimit]          `json:"recipients,omitempty"`
+		MemoPattern      string                                      `json:"memoPattern,omitempty"`
+	}{}
+	u.Name = v.Name
+	u.Signers = v.Signers
+	u.TransactionTypes = v.TransactionTypes
+	u.Principals = v.Principals
+	u.Recipients = v.Recipients
+	u.MemoPattern = v.MemoPattern
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Name = u.Name
+	v.Signers = u.Signers
+	v.TransactionTypes = u.TransactionTypes
+	v.Principals = u.Principals
+	v.Recipients = u.Recipients
+	v.MemoPattern = u.MemoPattern
+	return nil
+}
+
+func (v *TokenAmount) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Token  *url.URL `json:"token,omitempty"`
+		Amount *string  `json:"amount,omitempty"`
+	}{}
+	u.Token = v.Token
+	u.Amount = encoding.BigintToJSON(&v.Amount)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Token = u.Token
+	if x, err := encoding.BigintFromJSON(u.Amount); err != nil {
+		return fmt.Errorf("error decoding Amount: %w", err)
+	} else {
+		v.Amount = *x
+	}
+	return nil
+}
+
 func (v *Wallet) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Version    Version                      `json:"version,omitempty"`
@@ -972,3 +3278,25 @@ func (v *Wallet) UnmarshalJSON(data []byte) error {
 	v.Adis = u.Adis
 	return nil
 }
+
+func (v *WatchedKey) UnmarshalJSON(data []byte) error {
+	u := struct {
+		PublicKey *string                `json:"publicKey,omitempty"`
+		Type      protocol.SignatureType `json:"type,omitempty"`
+		Label     string                 `json:"label,omitempty"`
+	}{}
+	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.Type = v.Type
+	u.Label = v.Label
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.BytesFromJSON(u.PublicKey); err != nil {
+		return fmt.Errorf("error decoding PublicKey: %w", err)
+	} else {
+		v.PublicKey = x
+	}
+	v.Type = u.Type
+	v.Label = u.Label
+	return nil
+}
@@ -10,7 +10,7 @@ import (
 
 func (m *JrpcMethods) populateMethodTable() jsonrpc2.MethodMap {
 	if m.methods == nil {
-		m.methods = make(jsonrpc2.MethodMap, 12)
+		m.methods = make(jsonrpc2.MethodMap, 24)
 	}
 
 	m.methods["add-output"] = m.AddSendTokensOutput
@@ -18,17 +18,61 @@ func (m *JrpcMethods) populateMethodTable() jsonrpc2.MethodMap {
 	m.methods["create-envelope"] = m.CreateEnvelope
 	m.methods["create-transaction"] = m.CreateTransaction
 	m.methods["decode"] = m.Decode
-	m.methods["new-transaction"] = m.DeleteSendTokensTransaction
+	m.methods["delete-transaction"] = m.DeleteSendTokensTransaction
 	m.methods["encode"] = m.Encode
+	m.methods["history-export"] = m.HistoryExport
+	m.methods["history-sync"] = m.HistorySync
 	m.methods["key-list"] = m.KeyList
 	m.methods["new-transaction"] = m.NewSendTokensTransaction
+	m.methods["pst-export"] = m.PstExport
+	m.methods["pst-import"] = m.PstImport
+	m.methods["pst-inspect"] = m.PstInspect
+	m.methods["pst-list"] = m.PstList
+	m.methods["pst-merge"] = m.PstMerge
+	m.methods["pst-sign"] = m.PstSign
+	m.methods["pst-submit"] = m.PstSubmit
 	m.methods["resolve-key"] = m.ResolveKey
 	m.methods["sign"] = m.Sign
 	m.methods["version"] = m.Version
+	m.methods["wallet-close"] = m.WalletClose
+	m.methods["wallet-list"] = m.WalletList
+	m.methods["wallet-open"] = m.WalletOpen
 
 	return m.methods
 }
 
+// methodPermissions returns the permission required to call each method.
+func methodPermissions() map[string]string {
+	permissions := make(map[string]string, 24)
+
+	permissions["add-output"] = "sign"
+	permissions["adi-list"] = "read"
+	permissions["create-envelope"] = "sign"
+	permissions["create-transaction"] = "sign"
+	permissions["decode"] = "read"
+	permissions["delete-transaction"] = "sign"
+	permissions["encode"] = "read"
+	permissions["history-export"] = "read"
+	permissions["history-sync"] = "sign"
+	permissions["key-list"] = "read"
+	permissions["new-transaction"] = "sign"
+	permissions["pst-export"] = "read"
+	permissions["pst-import"] = "sign"
+	permissions["pst-inspect"] = "read"
+	permissions["pst-list"] = "read"
+	permissions["pst-merge"] = "sign"
+	permissions["pst-sign"] = "sign"
+	permissions["pst-submit"] = "sign"
+	permissions["resolve-key"] = "read"
+	permissions["sign"] = "sign"
+	permissions["version"] = "read"
+	permissions["wallet-close"] = "admin"
+	permissions["wallet-list"] = "read"
+	permissions["wallet-open"] = "admin"
+
+	return permissions
+}
+
 func (m *JrpcMethods) parse(params json.RawMessage, target interface{}, validateFields ...string) error {
 	err := json.Unmarshal(params, target)
 	if err != nil {
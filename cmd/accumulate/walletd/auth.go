@@ -0,0 +1,303 @@
+package walletd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// CreateApiToken generates a secret for the token and stores the token in the
+// wallet. Only the hash of the secret is stored, so the returned secret cannot
+// be recovered later.
+func CreateApiToken(token *api.ApiToken) (string, error) {
+	if token.Name == "" {
+		return "", fmt.Errorf("missing token name")
+	}
+	if token.Permission == 0 {
+		return "", fmt.Errorf("missing token permission")
+	}
+	_, err := GetWallet().Get(BucketApiToken, []byte(token.Name))
+	if err == nil {
+		return "", fmt.Errorf("token %q already exists", token.Name)
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	encoded := hex.EncodeToString(secret)
+	hash := sha256.Sum256([]byte(encoded))
+	token.Hash = hash[:]
+
+	data, err := token.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	err = GetWallet().Put(BucketApiToken, []byte(token.Name), data)
+	if err != nil {
+		return "", err
+	}
+	return encoded, nil
+}
+
+// RevokeApiToken removes a token and its usage from the wallet.
+func RevokeApiToken(name string) error {
+	_, err := GetWallet().Get(BucketApiToken, []byte(name))
+	if err != nil {
+		return fmt.Errorf("token %q not found", name)
+	}
+	err = GetWallet().Delete(BucketApiToken, []byte(name))
+	if err != nil {
+		return err
+	}
+	err = GetWallet().Delete(BucketApiTokenUsage, []byte(name))
+	if err != nil && err != db.ErrNotFound && err != db.ErrNoBucket {
+		return err
+	}
+	return nil
+}
+
+// ListApiTokens returns the tokens stored in the wallet.
+func ListApiTokens() ([]*api.ApiToken, error) {
+	b, err := GetWallet().GetBucket(BucketApiToken)
+	if err != nil {
+		if err == db.ErrNoBucket {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var tokens []*api.ApiToken
+	for _, v := range b.KeyValueList {
+		token := new(api.ApiToken)
+		err = token.UnmarshalBinary(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+type authTokenKey struct{}
+type authorizationKey struct{}
+
+// withAuthToken adds the bearer token of the request to the context.
+func withAuthToken(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer"))
+		if secret != "" {
+			r = r.WithContext(context.WithValue(r.Context(), authTokenKey{}, secret))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// authorization is the API token a request was authenticated with and the
+// wallet the token is stored in.
+type authorization struct {
+	token  *api.ApiToken
+	wallet db.DB
+}
+
+// authenticate verifies that the request's token permits the method. If the
+// wallet has no tokens, every request is rejected unless unauthenticated access
+// is enabled, in which case every request is permitted.
+func authenticate(ctx context.Context, method string, required api.ApiPermission, unauthenticated bool) (*authorization, error) {
+	tokens, err := ListApiTokens()
+	if err != nil {
+		return nil, jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "authorization error", err)
+	}
+	if len(tokens) == 0 {
+		if unauthenticated {
+			return nil, nil
+		}
+		return nil, jsonrpc2.NewError(api.ErrorCodeUnauthorized.Code(), "authorization error", "the wallet has no API tokens, create one with `accumulate wallet api-token create`")
+	}
+
+	secret, _ := ctx.Value(authTokenKey{}).(string)
+	hash := sha256.Sum256([]byte(secret))
+	for _, token := range tokens {
+		if secret == "" || subtle.ConstantTimeCompare(token.Hash, hash[:]) != 1 {
+			continue
+		}
+		if !token.Permission.Permits(required) {
+			return nil, jsonrpc2.NewError(api.ErrorCodePermissionDenied.Code(), "authorization error", fmt.Sprintf("token %q does not permit %s", token.Name, method))
+		}
+		return &authorization{token: token, wallet: GetWallet()}, nil
+	}
+
+	return nil, jsonrpc2.NewError(api.ErrorCodeUnauthorized.Code(), "authorization error", "a valid API token is required")
+}
+
+// getAuthorization returns the authorization of the request, or nil if
+// unauthenticated access is enabled and the wallet has no tokens.
+func getAuthorization(ctx context.Context) *authorization {
+	auth, _ := ctx.Value(authorizationKey{}).(*authorization)
+	return auth
+}
+
+// authorizeTransaction verifies that the token permits signing or submitting
+// the transaction and records the amount it spends against the token's daily
+// limits. The spending is recorded once per transaction, even if the
+// transaction is signed by multiple keys.
+func (a *authorization) authorizeTransaction(ctx context.Context, c *client.Client, txn *protocol.Transaction, signer *url.URL) error {
+	if a == nil {
+		return nil
+	}
+
+	token := a.token
+	if signer != nil && len(token.Signers) > 0 && !containsUrl(token.Signers, signer) {
+		return fmt.Errorf("token %q does not permit signing with %v", token.Name, signer)
+	}
+	if len(token.Principals) > 0 && !containsUrl(token.Principals, txn.Header.Principal) {
+		return fmt.Errorf("token %q does not permit transactions for %v", token.Name, txn.Header.Principal)
+	}
+	if len(token.DailyLimits) == 0 {
+		return nil
+	}
+
+	spending, err := transactionSpending(ctx, c, txn)
+	if err != nil {
+		return err
+	}
+	if len(spending) == 0 {
+		return nil
+	}
+
+	usage, err := a.usage()
+	if err != nil {
+		return err
+	}
+	hash := *(*[32]byte)(txn.GetHash())
+	for _, h := range usage.Transactions {
+		if h == hash {
+			return nil
+		}
+	}
+
+	for _, spend := range spending {
+		limit := findTokenAmount(token.DailyLimits, spend.Token)
+		if limit == nil {
+			return fmt.Errorf("token %q does not permit spending %v", token.Name, spend.Token)
+		}
+
+		spent := findTokenAmount(usage.Spent, spend.Token)
+		if spent == nil {
+			spent = &api.TokenAmount{Token: spend.Token}
+			usage.Spent = append(usage.Spent, spent)
+		}
+		total := new(big.Int).Add(&spent.Amount, &spend.Amount)
+		if total.Cmp(&limit.Amount) > 0 {
+			return fmt.Errorf("transaction exceeds the daily limit of token %q for %v", token.Name, spend.Token)
+		}
+		spent.Amount = *total
+	}
+
+	usage.Transactions = append(usage.Transactions, hash)
+	data, err := usage.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return a.wallet.Put(BucketApiTokenUsage, []byte(token.Name), data)
+}
+
+// usage returns the token's usage for the current day.
+func (a *authorization) usage() (*api.ApiTokenUsage, error) {
+	today := uint64(time.Now().UTC().Unix() / (24 * 60 * 60))
+	usage := new(api.ApiTokenUsage)
+	data, err := a.wallet.Get(BucketApiTokenUsage, []byte(a.token.Name))
+	switch {
+	case err == nil:
+		err = usage.UnmarshalBinary(data)
+		if err != nil {
+			return nil, err
+		}
+	case err != db.ErrNotFound && err != db.ErrNoBucket:
+		return nil, err
+	}
+
+	if usage.Day != today {
+		usage = &api.ApiTokenUsage{Day: today}
+	}
+	return usage, nil
+}
+
+// transactionSpending returns the amount of each token the transaction
+// spends.
+func transactionSpending(ctx context.Context, c *client.Client, txn *protocol.Transaction) ([]*api.TokenAmount, error) {
+	var amount *big.Int
+	switch body := txn.Body.(type) {
+	case *protocol.SendTokens:
+		amount = new(big.Int)
+		for _, to := range body.To {
+			amount.Add(amount, &to.Amount)
+		}
+	case *protocol.BurnTokens:
+		amount = &body.Amount
+	case *protocol.AddCredits:
+		return []*api.TokenAmount{{Token: protocol.AcmeUrl(), Amount: body.Amount}}, nil
+	default:
+		return nil, nil
+	}
+
+	token, err := principalToken(ctx, c, txn.Header.Principal)
+	if err != nil {
+		return nil, err
+	}
+	return []*api.TokenAmount{{Token: token, Amount: *amount}}, nil
+}
+
+// principalToken returns the token of a token account.
+func principalToken(ctx context.Context, c *client.Client, u *url.URL) (*url.URL, error) {
+	_, token, err := protocol.ParseLiteTokenAddress(u)
+	if err == nil && token != nil {
+		return token, nil
+	}
+
+	if c == nil {
+		return nil, fmt.Errorf("cannot determine the token of %v, the wallet is not connected to a node", u)
+	}
+	account, err := queryAccount(ctx, c, u)
+	if err != nil {
+		return nil, err
+	}
+	tokens, ok := account.(protocol.AccountWithTokens)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a token account", u)
+	}
+	return tokens.GetTokenUrl(), nil
+}
+
+func containsUrl(list []*url.URL, u *url.URL) bool {
+	for _, v := range list {
+		if v.Equal(u) {
+			return true
+		}
+	}
+	return false
+}
+
+func findTokenAmount(list []*api.TokenAmount, token *url.URL) *api.TokenAmount {
+	for _, v := range list {
+		if v.Token.Equal(token) {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package walletd
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestApiTokenPermissions(t *testing.T) {
+	InitTestDB(t)
+	m, err := NewJrpc(Options{})
+	require.NoError(t, err)
+	open, err := NewJrpc(Options{Unauthenticated: true})
+	require.NoError(t, err)
+
+	call := func(method, secret string) interface{} {
+		ctx := context.Background()
+		if secret != "" {
+			ctx = context.WithValue(ctx, authTokenKey{}, secret)
+		}
+		return m.methods[method](ctx, []byte(`{}`))
+	}
+	requireCode := func(code api.ErrorCode, res interface{}) {
+		t.Helper()
+		err, ok := res.(jsonrpc2.Error)
+		require.True(t, ok, "expected an error, got %v", res)
+		require.Equal(t, code.Code(), err.Code)
+	}
+
+	// Without tokens, requests are rejected unless unauthenticated access is
+	// enabled
+	requireCode(api.ErrorCodeUnauthorized, call("pst-list", ""))
+	_, ok := open.methods["pst-list"](context.Background(), []byte(`{}`)).(api.PstListResponse)
+	require.True(t, ok)
+
+	read, err := CreateApiToken(&api.ApiToken{Name: "reader", Permission: api.ApiPermissionRead})
+	require.NoError(t, err)
+	_, err = CreateApiToken(&api.ApiToken{Name: "reader", Permission: api.ApiPermissionRead})
+	require.Error(t, err)
+
+	requireCode(api.ErrorCodeUnauthorized, call("pst-list", ""))
+	requireCode(api.ErrorCodeUnauthorized, call("pst-list", "bogus"))
+	_, ok = call("pst-list", read).(api.PstListResponse)
+	require.True(t, ok)
+	requireCode(api.ErrorCodePermissionDenied, call("pst-sign", read))
+	requireCode(api.ErrorCodePermissionDenied, call("wallet-open", read))
+	requireCode(api.ErrorCodePermissionDenied, call("history-sync", read))
+
+	require.NoError(t, RevokeApiToken("reader"))
+	requireCode(api.ErrorCodeUnauthorized, call("pst-list", read))
+}
+
+func TestApiTokenSignerScope(t *testing.T) {
+	InitTestDB(t)
+	m, err := NewJrpc(Options{})
+	require.NoError(t, err)
+
+	key := newTestKey("bot")
+	require.NoError(t, key.Save("bot", ""))
+	page := url.MustParse("alice/book/1")
+	secret, err := CreateApiToken(&api.ApiToken{Name: "bot", Permission: api.ApiPermissionSign, Signers: []*url.URL{page}})
+	require.NoError(t, err)
+
+	sign := func(signer *url.URL) interface{} {
+		txn := new(protocol.Transaction)
+		txn.Header.Principal = url.MustParse("alice/data")
+		txn.Body = new(protocol.WriteData)
+		params, err := json.Marshal(&api.SignRequest{KeyName: "bot", Transaction: txn, Signer: signer, SignerVersion: 1})
+		require.NoError(t, err)
+		ctx := context.WithValue(context.Background(), authTokenKey{}, secret)
+		return m.methods["sign"](ctx, params)
+	}
+
+	// The sign method only signs with the token's signers
+	_, ok := sign(page).(api.SignResponse)
+	require.True(t, ok)
+	jerr, ok := sign(url.MustParse("bob/book/1")).(jsonrpc2.Error)
+	require.True(t, ok)
+	require.Equal(t, api.ErrorCodePermissionDenied.Code(), jerr.Code)
+}
+
+func TestApiTokenSpendingLimit(t *testing.T) {
+	InitTestDB(t)
+
+	lta, err := protocol.LiteTokenAddress(newTestKey("alice").PublicKey, protocol.ACME, protocol.SignatureTypeED25519)
+	require.NoError(t, err)
+	limit := &api.TokenAmount{Token: protocol.AcmeUrl(), Amount: *big.NewInt(10 * protocol.AcmePrecision)}
+	token := &api.ApiToken{Name: "spender", Permission: api.ApiPermissionSign, Principals: []*url.URL{lta}, DailyLimits: []*api.TokenAmount{limit}}
+	_, err = CreateApiToken(token)
+	require.NoError(t, err)
+	auth := &authorization{token: token, wallet: GetWallet()}
+
+	send := func(amount int64) *protocol.Transaction {
+		txn := new(protocol.Transaction)
+		txn.Header.Principal = lta
+		txn.Body = &protocol.SendTokens{To: []*protocol.TokenRecipient{{Url: url.MustParse("bob/tokens"), Amount: *big.NewInt(amount * protocol.AcmePrecision)}}}
+		return txn
+	}
+
+	ctx := context.Background()
+	txn := send(6)
+	require.NoError(t, auth.authorizeTransaction(ctx, nil, txn, nil))
+	require.NoError(t, auth.authorizeTransaction(ctx, nil, txn, nil), "a transaction is only counted once")
+	require.Error(t, auth.authorizeTransaction(ctx, nil, send(5), nil), "the daily limit is exceeded")
+	require.NoError(t, auth.authorizeTransaction(ctx, nil, send(4), nil))
+
+	other := send(1)
+	other.Header.Principal = url.MustParse("alice/tokens")
+	require.Error(t, auth.authorizeTransaction(ctx, nil, other, nil), "the principal is not permitted")
+}
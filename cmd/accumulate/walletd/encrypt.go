@@ -71,13 +71,13 @@ func newPassword() (string, error) {
 
 func EncryptDatabase() (string, error) {
 	//we will test to see if we already have an unencrypted database
-	dbePath := filepath.Join(DatabaseDir, "wallet_encrypted.db")
+	dbePath := filepath.Join(WalletDir(WalletName), "wallet_encrypted.db")
 	if _, err := os.Stat(dbePath); !errors.Is(err, os.ErrNotExist) {
 		return "", fmt.Errorf("encrypted database wallet already exists %s", dbePath)
 	}
 
 	// check to see if unencrypted wallet is present.
-	unencryptedWalletPath := filepath.Join(DatabaseDir, "wallet.db")
+	unencryptedWalletPath := filepath.Join(WalletDir(WalletName), "wallet.db")
 	haveUnencryptedWallet := false
 	if _, err := os.Stat(unencryptedWalletPath); !errors.Is(err, os.ErrNotExist) {
 		haveUnencryptedWallet = true
@@ -115,43 +115,17 @@ func EncryptDatabase() (string, error) {
 		return fmt.Sprintf("success - encrypted wallet created at %s.\n", dbe.Name()), nil
 	}
 
-	err = copyBucket(dbe, dbu, BucketLabel)
-	if err != nil && err != db.ErrNoBucket {
-		return "", err
-	}
-
-	err = copyBucket(dbe, dbu, BucketAdi)
-	if err != nil && err != db.ErrNoBucket {
-		return "", err
-	}
-
-	err = copyBucket(dbe, dbu, BucketKeys)
-	if err != nil && err != db.ErrNoBucket {
-		return "", err
-	}
-
-	err = copyBucket(dbe, dbu, BucketLite)
-	if err != nil && err != db.ErrNoBucket {
-		return "", err
-	}
-
-	err = copyBucket(dbe, dbu, BucketMnemonic)
-	if err != nil && err != db.ErrNoBucket {
-		return "", err
-	}
-
-	err = copyBucket(dbe, dbu, BucketKeyInfo)
-	if err != nil && err != db.ErrNoBucket {
-		return "", err
+	for _, bucket := range encryptedBuckets {
+		err = copyBucket(dbe, dbu, bucket)
+		if err != nil && err != db.ErrNoBucket {
+			return "", err
+		}
 	}
 
-	if !equalBucket(dbe, dbu, BucketMnemonic) ||
-		!equalBucket(dbe, dbu, BucketAdi) ||
-		!equalBucket(dbe, dbu, BucketKeys) ||
-		!equalBucket(dbe, dbu, BucketLabel) ||
-		!equalBucket(dbe, dbu, BucketLite) ||
-		!equalBucket(dbe, dbu, BucketKeyInfo) {
-		return "", db.ErrMalformedEncryptedDatabase
+	for _, bucket := range encryptedBuckets {
+		if !equalBucket(dbe, dbu, bucket) {
+			return "", db.ErrMalformedEncryptedDatabase
+		}
 	}
 
 	msg := fmt.Sprintf("\nSuccess:\tEncrypted wallet created at %s.\n", dbe.Name())
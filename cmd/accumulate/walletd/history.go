@@ -0,0 +1,690 @@
+package walletd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	apiv2 "gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2/query"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// historyPageSize is the number of transactions requested per
+// query-tx-history call when syncing.
+const historyPageSize = 50
+
+// historyValue is a network value and the time it took effect.
+type historyValue[T any] struct {
+	time  time.Time
+	value T
+}
+
+// historyValueAt returns the value that was in effect at the given time. If
+// the time precedes every recorded value, the earliest value is returned.
+func historyValueAt[T any](values []historyValue[T], t time.Time, def T) T {
+	i := sort.Search(len(values), func(i int) bool { return values[i].time.After(t) })
+	switch {
+	case i > 0:
+		return values[i-1].value
+	case len(values) > 0:
+		return values[0].value
+	default:
+		return def
+	}
+}
+
+// historyFees resolves the block time of synced transactions and the fee
+// schedule and oracle price that were in effect at that time. The schedule and
+// oracle history is read from the directory network's globals and oracle data
+// accounts.
+type historyFees struct {
+	client    *client.Client
+	schedule  *protocol.FeeSchedule
+	oracle    uint64
+	schedules []historyValue[*protocol.FeeSchedule]
+	oracles   []historyValue[uint64]
+	times     map[string]time.Time
+}
+
+// newHistoryFees loads the history of the fee schedule and oracle. The current
+// values are used if the history is not available.
+func newHistoryFees(ctx context.Context, c *client.Client) (*historyFees, error) {
+	desc, err := c.Describe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("describe network: %w", err)
+	}
+
+	fees := new(historyFees)
+	fees.client = c
+	fees.times = map[string]time.Time{}
+	fees.schedule = new(protocol.FeeSchedule)
+	if desc.Values.Globals != nil && desc.Values.Globals.FeeSchedule != nil {
+		fees.schedule = desc.Values.Globals.FeeSchedule
+	}
+	if desc.Values.Oracle != nil {
+		fees.oracle = desc.Values.Oracle.Price
+	}
+
+	err = fees.loadHistory(ctx, protocol.DnUrl().JoinPath(protocol.Oracle), func(t time.Time, data []byte) error {
+		oracle := new(protocol.AcmeOracle)
+		err := oracle.UnmarshalBinary(data)
+		if err != nil {
+			return err
+		}
+		fees.oracles = append(fees.oracles, historyValue[uint64]{t, oracle.Price})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load oracle history: %w", err)
+	}
+
+	err = fees.loadHistory(ctx, protocol.DnUrl().JoinPath(protocol.Globals), func(t time.Time, data []byte) error {
+		globals := new(protocol.NetworkGlobals)
+		err := globals.UnmarshalBinary(data)
+		if err != nil {
+			return err
+		}
+		if globals.FeeSchedule != nil {
+			fees.schedules = append(fees.schedules, historyValue[*protocol.FeeSchedule]{t, globals.FeeSchedule})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load globals history: %w", err)
+	}
+
+	return fees, nil
+}
+
+// loadHistory calls fn with the block time and data of each entry written to
+// the data account, in order.
+func (f *historyFees) loadHistory(ctx context.Context, account *url.URL, fn func(time.Time, []byte) error) error {
+	req := new(apiv2.TxHistoryQuery)
+	req.Url = account
+	req.Count = historyPageSize
+	for {
+		res, err := f.client.QueryTxHistory(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range res.Items {
+			txr := new(apiv2.TransactionQueryResponse)
+			err = remarshal(item, txr)
+			if err != nil {
+				return err
+			}
+			if txr.Transaction == nil || txr.Status == nil || txr.Status.Failed() {
+				continue
+			}
+			body, ok := txr.Transaction.Body.(*protocol.WriteData)
+			if !ok || body.Entry == nil || len(body.Entry.GetData()) != 1 {
+				continue
+			}
+			t, ok, err := f.blockTime(ctx, account, txr.Status.Received)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			err = fn(t, body.Entry.GetData()[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		req.Start += uint64(len(res.Items))
+		if len(res.Items) == 0 || req.Start >= res.Total {
+			return nil
+		}
+	}
+}
+
+// blockTime returns the time of the block of the account's partition.
+func (f *historyFees) blockTime(ctx context.Context, account *url.URL, block uint64) (time.Time, bool, error) {
+	if block == 0 {
+		return time.Time{}, false, nil
+	}
+
+	key := fmt.Sprintf("%v#%d", account, block)
+	if t, ok := f.times[key]; ok {
+		return t, true, nil
+	}
+
+	req := new(apiv2.MinorBlocksQuery)
+	req.Url = account
+	req.Start = block
+	req.Count = 1
+	req.TxFetchMode = query.TxFetchModeOmit
+	req.BlockFilterMode = query.BlockFilterModeExcludeEmpty
+	res, err := f.client.QueryMinorBlocks(ctx, req)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("query block %d of %v: %w", block, account, err)
+	}
+	if len(res.Items) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	entry := new(apiv2.MinorQueryResponse)
+	err = remarshal(res.Items[0], entry)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if entry.BlockIndex != block || entry.BlockTime == nil {
+		return time.Time{}, false, nil
+	}
+
+	t := entry.BlockTime.UTC()
+	f.times[key] = t
+	return t, true, nil
+}
+
+// at returns the fee schedule and oracle price in effect at the given time.
+func (f *historyFees) at(t time.Time) (*protocol.FeeSchedule, uint64) {
+	return historyValueAt(f.schedules, t, f.schedule), historyValueAt(f.oracles, t, f.oracle)
+}
+
+// transactionInfo is the time and fee of a synced transaction.
+type transactionInfo struct {
+	Time   time.Time
+	Fee    protocol.Fee
+	Oracle uint64
+}
+
+// transactionInfo resolves the block time of the transaction and the fee paid
+// by its signers. The initiator pays the transaction fee and every other
+// signer pays its signature fee.
+func (f *historyFees) transactionInfo(ctx context.Context, account *url.URL, txr *apiv2.TransactionQueryResponse) (*transactionInfo, error) {
+	info := new(transactionInfo)
+	var ok bool
+	if txr.Status != nil {
+		var err error
+		info.Time, ok, err = f.blockTime(ctx, account, txr.Status.Received)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		info.Time, _ = transactionTime(txr.Signatures)
+	}
+
+	var schedule *protocol.FeeSchedule
+	schedule, info.Oracle = f.at(info.Time)
+	if txr.Transaction.Body.Type().IsUser() {
+		var err error
+		info.Fee, err = transactionFee(schedule, txr.Transaction, txr.Signatures)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// transactionFee computes the fees paid for a transaction and its signatures.
+// The initiator pays the transaction fee in place of the base signature fee.
+// Signatures of system signers are not charged.
+func transactionFee(schedule *protocol.FeeSchedule, txn *protocol.Transaction, signatures []protocol.Signature) (protocol.Fee, error) {
+	fee, err := schedule.ComputeTransactionFee(txn)
+	if err != nil {
+		return 0, err
+	}
+
+	var signed bool
+	for _, sig := range signatures {
+		for {
+			delegated, ok := sig.(*protocol.DelegatedSignature)
+			if !ok {
+				break
+			}
+			sig = delegated.Signature
+		}
+
+		keySig, ok := sig.(protocol.KeySignature)
+		if !ok {
+			continue
+		}
+		if _, ok := protocol.ParsePartitionUrl(keySig.GetSigner()); ok || protocol.IsDnUrl(keySig.GetSigner()) {
+			continue
+		}
+
+		sigFee, err := schedule.ComputeSignatureFee(keySig)
+		if err != nil {
+			return 0, err
+		}
+		fee += sigFee
+		signed = true
+	}
+	if signed {
+		fee -= protocol.FeeSignature
+	}
+	return fee, nil
+}
+
+// HistoryAccounts returns the token accounts owned by the wallet: the ACME lite
+// token account of each lite key and the token accounts of each ADI.
+func HistoryAccounts(ctx context.Context, w db.DB, c *client.Client) ([]*url.URL, error) {
+	var accounts []*url.URL
+	b, err := w.GetBucket(BucketLite)
+	switch {
+	case err == nil:
+		for _, v := range b.KeyValueList {
+			k := new(Key)
+			err = k.LoadByLabel(w, string(v.Value))
+			if err != nil {
+				return nil, err
+			}
+			lta, err := protocol.LiteTokenAddressFromHash(k.PublicKeyHash(), protocol.ACME)
+			if err != nil {
+				return nil, err
+			}
+			accounts = append(accounts, lta)
+		}
+	case err != db.ErrNoBucket:
+		return nil, err
+	}
+
+	b, err = w.GetBucket(BucketAdi)
+	switch {
+	case err == nil:
+		for _, v := range b.KeyValueList {
+			adi, err := url.Parse(string(v.Key))
+			if err != nil {
+				return nil, err
+			}
+			tokens, err := directoryTokenAccounts(ctx, c, adi)
+			if err != nil {
+				return nil, err
+			}
+			accounts = append(accounts, tokens...)
+		}
+	case err != db.ErrNoBucket:
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// directoryTokenAccounts returns the token accounts in an identity's
+// directory.
+func directoryTokenAccounts(ctx context.Context, c *client.Client, identity *url.URL) ([]*url.URL, error) {
+	var accounts []*url.URL
+	req := new(apiv2.DirectoryQuery)
+	req.Url = identity
+	req.Count = 100
+	req.Expand = true
+	for {
+		res, err := c.QueryDirectory(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("query directory of %v: %w", identity, err)
+		}
+
+		for _, item := range res.OtherItems {
+			entry := new(apiv2.ChainQueryResponse)
+			err = remarshal(item, entry)
+			if err != nil {
+				return nil, err
+			}
+			account, err := accountFromResponse(entry)
+			if err != nil {
+				return nil, err
+			}
+			if account, ok := account.(protocol.AccountWithTokens); ok {
+				accounts = append(accounts, account.GetUrl())
+			}
+		}
+
+		req.Start += uint64(len(res.Items))
+		if len(res.Items) == 0 || req.Start >= res.Total {
+			return accounts, nil
+		}
+	}
+}
+
+// SyncHistory caches the transaction history of each account. Each account's
+// sync resumes from the main chain height recorded by the previous sync, so an
+// interrupted sync can be restarted without fetching everything again.
+func SyncHistory(ctx context.Context, w db.DB, c *client.Client, accounts []*url.URL) ([]*api.HistorySyncState, error) {
+	fees, err := newHistoryFees(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*api.HistorySyncState, 0, len(accounts))
+	for _, account := range accounts {
+		state, err := syncAccountHistory(ctx, w, c, account, fees)
+		if err != nil {
+			return nil, fmt.Errorf("sync %v: %w", account, err)
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func syncAccountHistory(ctx context.Context, w db.DB, c *client.Client, u *url.URL, fees *historyFees) (*api.HistorySyncState, error) {
+	state, err := GetHistorySyncState(w, u)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := queryAccount(ctx, c, u)
+	if err != nil {
+		return nil, err
+	}
+	tokens, ok := account.(protocol.AccountWithTokens)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a token account", u)
+	}
+	precision, err := queryTokenPrecision(ctx, c, tokens.GetTokenUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(apiv2.TxHistoryQuery)
+	req.Url = u
+	req.Count = historyPageSize
+	for {
+		req.Start = state.Height
+		res, err := c.QueryTxHistory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Items) == 0 {
+			return state, nil
+		}
+
+		for i, item := range res.Items {
+			txr := new(apiv2.TransactionQueryResponse)
+			err = remarshal(item, txr)
+			if err != nil {
+				return nil, err
+			}
+
+			index := state.Height + uint64(i)
+			if txr.Transaction == nil || txr.Transaction.Body == nil {
+				continue
+			}
+			info, err := fees.transactionInfo(ctx, u, txr)
+			if err != nil {
+				return nil, err
+			}
+			for j, entry := range ledgerEntries(u, index, txr, tokens.GetTokenUrl(), precision, info) {
+				data, err := entry.MarshalBinary()
+				if err != nil {
+					return nil, err
+				}
+				err = w.Put(BucketHistory, ledgerKey(u, index, j), data)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// Record the height after each page so that an interrupted sync
+		// resumes from here
+		state.Height += uint64(len(res.Items))
+		data, err := state.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		err = w.Put(BucketHistorySync, []byte(u.String()), data)
+		if err != nil {
+			return nil, err
+		}
+
+		if state.Height >= res.Total {
+			return state, nil
+		}
+	}
+}
+
+// ledgerEntries converts a transaction on the account's main chain into
+// ledger entries. A transaction that sends tokens to multiple recipients
+// produces an entry for each recipient, and the fee is recorded on the first.
+func ledgerEntries(account *url.URL, index uint64, txr *apiv2.TransactionQueryResponse, token *url.URL, precision uint64, info *transactionInfo) []*api.LedgerEntry {
+	txn := txr.Transaction
+	if txn == nil || txn.Body == nil || txr.Status != nil && txr.Status.Failed() {
+		return nil
+	}
+
+	newEntry := func(counterparty *url.URL, amount *big.Int, incoming bool) *api.LedgerEntry {
+		entry := new(api.LedgerEntry)
+		entry.Account = account
+		entry.Index = index
+		entry.TxID = txn.ID()
+		entry.Type = txn.Body.Type()
+		entry.Counterparty = counterparty
+		entry.Token = token
+		entry.Precision = precision
+		entry.Incoming = incoming
+		entry.Memo = txn.Header.Memo
+		if amount != nil {
+			entry.Amount.Set(amount)
+		}
+		return entry
+	}
+
+	var entries []*api.LedgerEntry
+	switch body := txn.Body.(type) {
+	case *protocol.SendTokens:
+		for _, to := range body.To {
+			entries = append(entries, newEntry(to.Url, &to.Amount, false))
+		}
+	case *protocol.AddCredits:
+		entries = append(entries, newEntry(body.Recipient, &body.Amount, false))
+	case *protocol.BurnTokens:
+		entries = append(entries, newEntry(nil, &body.Amount, false))
+	case *protocol.SyntheticDepositTokens:
+		entry := newEntry(body.Source(), &body.Amount, true)
+		entry.Token = body.Token
+		entries = append(entries, entry)
+	default:
+		if !body.Type().IsUser() {
+			return nil
+		}
+		entries = append(entries, newEntry(nil, nil, false))
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if txn.Header.Principal.Equal(account) && txn.Body.Type().IsUser() {
+		entries[0].FeeCredits = info.Fee.AsUInt64()
+		entries[0].FeeAcme = *info.Fee.AsAcme(info.Oracle)
+	}
+
+	if !info.Time.IsZero() {
+		for _, entry := range entries {
+			entry.Time = info.Time
+		}
+	}
+	return entries
+}
+
+// transactionTime returns the earliest signature timestamp, if the signatures
+// use millisecond timestamps.
+func transactionTime(signatures []protocol.Signature) (time.Time, bool) {
+	// Timestamps are milliseconds since the epoch unless the signer used a
+	// nonce, so ignore values that are not plausible times
+	const min, max = 1e12, 1e13
+
+	var ts uint64
+	for _, sig := range signatures {
+		sig, ok := sig.(protocol.KeySignature)
+		if !ok {
+			continue
+		}
+		v := sig.GetTimestamp()
+		if v < min || v >= max {
+			continue
+		}
+		if ts == 0 || v < ts {
+			ts = v
+		}
+	}
+	if ts == 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(ts)).UTC(), true
+}
+
+// ledgerKey orders the entries of an account by main chain index.
+func ledgerKey(account *url.URL, index uint64, i int) []byte {
+	return []byte(fmt.Sprintf("%v#%020d#%04d", account, index, i))
+}
+
+// GetHistorySyncState returns the sync state of the account, or an empty
+// state if it has not been synced.
+func GetHistorySyncState(w db.DB, account *url.URL) (*api.HistorySyncState, error) {
+	data, err := w.Get(BucketHistorySync, []byte(account.String()))
+	switch {
+	case err == nil:
+	case err == db.ErrNotFound || err == db.ErrNoBucket:
+		return &api.HistorySyncState{Account: account}, nil
+	default:
+		return nil, err
+	}
+
+	state := new(api.HistorySyncState)
+	err = state.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ListLedger returns the cached ledger entries of the account, or of every
+// account if account is nil, ordered by account and main chain index.
+func ListLedger(w db.DB, account *url.URL) ([]*api.LedgerEntry, error) {
+	b, err := w.GetBucket(BucketHistory)
+	if err != nil {
+		if err == db.ErrNoBucket {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	type keyed struct {
+		key   string
+		entry *api.LedgerEntry
+	}
+	var list []keyed
+	for _, v := range b.KeyValueList {
+		if account != nil && !strings.HasPrefix(string(v.Key), account.String()+"#") {
+			continue
+		}
+		entry := new(api.LedgerEntry)
+		err = entry.UnmarshalBinary(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, keyed{string(v.Key), entry})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].key < list[j].key })
+	entries := make([]*api.LedgerEntry, len(list))
+	for i, v := range list {
+		entries[i] = v.entry
+	}
+	return entries, nil
+}
+
+// WriteLedgerCSV writes the ledger entries as CSV. Outgoing amounts are
+// negative.
+func WriteLedgerCSV(w io.Writer, entries []*api.LedgerEntry) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"date", "account", "txid", "type", "counterparty", "amount", "token", "fee_credits", "fee_acme", "memo"})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		var date, counterparty, token string
+		if !entry.Time.IsZero() {
+			date = entry.Time.Format(time.RFC3339)
+		}
+		if entry.Counterparty != nil {
+			counterparty = entry.Counterparty.String()
+		}
+		if entry.Token != nil {
+			token = entry.Token.String()
+		}
+		amount := protocol.FormatBigAmount(&entry.Amount, int(entry.Precision))
+		if !entry.Incoming && entry.Amount.Sign() != 0 {
+			amount = "-" + amount
+		}
+
+		err = cw.Write([]string{
+			date,
+			entry.Account.String(),
+			entry.TxID.String(),
+			entry.Type.String(),
+			counterparty,
+			amount,
+			token,
+			protocol.FormatAmount(entry.FeeCredits, protocol.CreditPrecisionPower),
+			protocol.FormatBigAmount(&entry.FeeAcme, protocol.AcmePrecisionPower),
+			entry.Memo,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func queryAccount(ctx context.Context, c *client.Client, u *url.URL) (protocol.Account, error) {
+	req := new(apiv2.GeneralQuery)
+	req.Url = u
+	res := new(apiv2.ChainQueryResponse)
+	err := c.RequestAPIv2(ctx, "query", req, res)
+	if err != nil {
+		return nil, err
+	}
+	return accountFromResponse(res)
+}
+
+func queryTokenPrecision(ctx context.Context, c *client.Client, u *url.URL) (uint64, error) {
+	if protocol.AcmeUrl().Equal(u) {
+		return protocol.AcmePrecisionPower, nil
+	}
+
+	account, err := queryAccount(ctx, c, u)
+	if err != nil {
+		return 0, err
+	}
+	issuer, ok := account.(*protocol.TokenIssuer)
+	if !ok {
+		return 0, fmt.Errorf("%v is not a token issuer", u)
+	}
+	return issuer.Precision, nil
+}
+
+func accountFromResponse(res *apiv2.ChainQueryResponse) (protocol.Account, error) {
+	data, err := json.Marshal(res.Data)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.UnmarshalAccountJSON(data)
+}
+
+func remarshal(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
@@ -0,0 +1,98 @@
+package walletd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiv2 "gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestLedger(t *testing.T) {
+	InitTestDB(t)
+
+	alice := url.MustParse("alice/tokens")
+	schedule := new(protocol.FeeSchedule)
+	oracle := uint64(5 * protocol.AcmeOraclePrecision)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	// An outgoing transfer to two recipients
+	send := new(protocol.Transaction)
+	send.Header.Principal = alice
+	send.Header.Memo = "invoice 42"
+	send.Body = &protocol.SendTokens{To: []*protocol.TokenRecipient{
+		{Url: url.MustParse("bob/tokens"), Amount: *big.NewInt(150000000)},
+		{Url: url.MustParse("charlie/tokens"), Amount: *big.NewInt(1)},
+	}}
+	sig := &protocol.ED25519Signature{Signer: url.MustParse("alice/book/1"), Timestamp: uint64(now.UnixMilli())}
+	cosig := &protocol.ED25519Signature{Signer: url.MustParse("bob/book/1"), Timestamp: uint64(now.UnixMilli())}
+	fee, err := transactionFee(schedule, send, []protocol.Signature{sig, cosig})
+	require.NoError(t, err)
+	require.Equal(t, protocol.FeeTransferTokens+protocol.FeeTransferTokensExtra+protocol.FeeSignature, fee, "the fee includes the signature fee of the cosigner")
+	info := &transactionInfo{Time: now, Fee: fee, Oracle: oracle}
+	out := ledgerEntries(alice, 0, &apiv2.TransactionQueryResponse{Transaction: send, Signatures: []protocol.Signature{sig, cosig}}, protocol.AcmeUrl(), protocol.AcmePrecisionPower, info)
+	require.Len(t, out, 2)
+	require.Equal(t, now, out[0].Time)
+	require.Equal(t, fee.AsUInt64(), out[0].FeeCredits)
+	require.Equal(t, fee.AsAcme(oracle).String(), out[0].FeeAcme.String())
+	require.Zero(t, out[1].FeeCredits, "the fee is recorded once")
+
+	// An incoming deposit
+	deposit := new(protocol.Transaction)
+	deposit.Header.Principal = alice
+	body := &protocol.SyntheticDepositTokens{Token: protocol.AcmeUrl(), Amount: *big.NewInt(200000000)}
+	body.Cause = url.MustParse("bob/tokens").WithTxID([32]byte{1})
+	deposit.Body = body
+	in := ledgerEntries(alice, 1, &apiv2.TransactionQueryResponse{Transaction: deposit}, protocol.AcmeUrl(), protocol.AcmePrecisionPower, &transactionInfo{Time: now.Add(time.Second)})
+	require.Len(t, in, 1)
+	require.Equal(t, now.Add(time.Second), in[0].Time, "deposits are dated by their block")
+	require.True(t, in[0].Incoming)
+	require.Equal(t, "acc://bob/tokens", in[0].Counterparty.String())
+	require.Zero(t, in[0].FeeCredits)
+
+	// Store the entries out of order and list them
+	for i, entry := range append(in, out...) {
+		data, err := entry.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, GetWallet().Put(BucketHistory, ledgerKey(entry.Account, entry.Index, i), data))
+	}
+	entries, err := ListLedger(GetWallet(), alice)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, uint64(0), entries[0].Index)
+	require.Equal(t, uint64(1), entries[2].Index)
+
+	entries, err = ListLedger(GetWallet(), url.MustParse("alice/other"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// Export
+	buf := new(bytes.Buffer)
+	require.NoError(t, WriteLedgerCSV(buf, append(out, in...)))
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, now.Format(time.RFC3339), records[1][0])
+	require.Equal(t, "acc://bob/tokens", records[1][4])
+	require.Equal(t, "-1.50000000", records[1][5])
+	require.Equal(t, "invoice 42", records[1][9])
+	require.Equal(t, "2.00000000", records[3][5])
+}
+
+func TestHistoryValueAt(t *testing.T) {
+	now := time.Now()
+	values := []historyValue[uint64]{
+		{now, 1},
+		{now.Add(time.Hour), 2},
+	}
+	require.Equal(t, uint64(1), historyValueAt(values, now.Add(-time.Hour), 0), "before the first value")
+	require.Equal(t, uint64(1), historyValueAt(values, now, 0))
+	require.Equal(t, uint64(1), historyValueAt(values, now.Add(time.Minute), 0))
+	require.Equal(t, uint64(2), historyValueAt(values, now.Add(2*time.Hour), 0))
+	require.Equal(t, uint64(3), historyValueAt[uint64](nil, now, 3), "no history")
+}
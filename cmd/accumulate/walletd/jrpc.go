@@ -35,14 +35,17 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/AccumulateNetwork/jsonrpc2/v15"
 	"github.com/go-playground/validator/v10"
 	"github.com/tendermint/tendermint/libs/log"
 	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
 	apiv2 "gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
 	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
 	"gitlab.com/accumulatenetwork/accumulate/smt/storage"
 )
@@ -52,6 +55,11 @@ type Options struct {
 	TxMaxWaitTime time.Duration
 	listenAddress string
 	database      db.DB
+	Client        *client.Client
+
+	// Unauthenticated permits every request while the wallet has no API
+	// tokens. Otherwise requests are rejected until a token is created.
+	Unauthenticated bool
 }
 
 type JrpcMethods struct {
@@ -60,6 +68,8 @@ type JrpcMethods struct {
 	validate *validator.Validate
 	logger   log.Logger
 	api      *http.Server
+	walletMu sync.Mutex
+	wallets  map[string]db.DB
 }
 
 func NewJrpc(opts Options) (*JrpcMethods, error) {
@@ -77,14 +87,77 @@ func NewJrpc(opts Options) (*JrpcMethods, error) {
 	}
 
 	m.populateMethodTable()
+	permissions := methodPermissions()
+	for name, method := range m.methods {
+		// Methods without a permission can only be called with an admin
+		// token
+		required := api.ApiPermissionAdmin
+		if s, ok := permissions[name]; ok {
+			required, ok = api.ApiPermissionByName(s)
+			if !ok {
+				return nil, fmt.Errorf("method %s has an invalid permission %q", name, s)
+			}
+		}
+		m.methods[name] = m.withWallet(name, required, method)
+	}
 
 	return m, nil
 }
 
+// withWallet authenticates the request and runs the method against the wallet
+// selected by the request's wallet parameter, or the default wallet if the
+// parameter is not specified. The selected wallet is passed to the method
+// through the context, see walletFrom. API tokens are stored in the default
+// wallet. Requests are serialized so that spending limits are checked and
+// recorded atomically and a wallet cannot be closed while it is in use.
+func (m *JrpcMethods) withWallet(name string, required api.ApiPermission, method jsonrpc2.MethodFunc) jsonrpc2.MethodFunc {
+	return func(ctx context.Context, params json.RawMessage) interface{} {
+		// Methods that do not take an object are always run against the
+		// default wallet
+		var req struct {
+			Wallet string `json:"wallet"`
+		}
+		_ = json.Unmarshal(params, &req)
+
+		m.walletMu.Lock()
+		defer m.walletMu.Unlock()
+
+		auth, err := authenticate(ctx, name, required, m.Unauthenticated)
+		if err != nil {
+			return err
+		}
+		ctx = context.WithValue(ctx, authorizationKey{}, auth)
+
+		w := GetWallet()
+		if req.Wallet != "" && req.Wallet != DefaultWalletName {
+			var ok bool
+			w, ok = m.wallets[req.Wallet]
+			if !ok {
+				return jsonrpc2.NewError(api.ErrorCodeNotFound.Code(), "wallet error", fmt.Sprintf("wallet %q is not open", req.Wallet))
+			}
+		}
+
+		ctx = context.WithValue(ctx, walletKey{}, w)
+		return method(ctx, params)
+	}
+}
+
+type walletKey struct{}
+
+// walletFrom returns the wallet selected by the request. If the context does
+// not have a wallet, walletFrom returns the default wallet.
+func walletFrom(ctx context.Context) db.DB {
+	w, ok := ctx.Value(walletKey{}).(db.DB)
+	if !ok {
+		return GetWallet()
+	}
+	return w
+}
+
 func (m *JrpcMethods) NewMux() *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.Handle("/version", m.jrpc2http(m.Version))
-	mux.Handle("/wallet", jsonrpc2.HTTPRequestHandler(m.methods, stdlog.New(os.Stdout, "", 0)))
+	mux.Handle("/wallet", withAuthToken(jsonrpc2.HTTPRequestHandler(m.methods, stdlog.New(os.Stdout, "", 0))))
 	return mux
 }
 
@@ -154,7 +227,9 @@ func listenHttpUrl(s string) (net.Listener, bool, error) {
 func (m *JrpcMethods) Start() error {
 	// Create the JSON-RPC handler
 	jrpc, err := NewJrpc(Options{
-		TxMaxWaitTime: time.Minute,
+		TxMaxWaitTime:   time.Minute,
+		Client:          m.Client,
+		Unauthenticated: m.Unauthenticated,
 	})
 
 	if err != nil {
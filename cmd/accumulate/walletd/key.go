@@ -5,42 +5,43 @@ import (
 	"crypto/sha256"
 	"fmt"
 
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
 	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
 )
 
-func LookupByLiteTokenUrl(lite string) (*Key, error) {
+func LookupByLiteTokenUrl(w db.DB, lite string) (*Key, error) {
 	liteKey, isLite := LabelForLiteTokenAccount(lite)
 	if !isLite {
 		return nil, fmt.Errorf("invalid lite account %s", liteKey)
 	}
 
-	label, err := GetWallet().Get(BucketLite, []byte(liteKey))
+	label, err := w.Get(BucketLite, []byte(liteKey))
 	if err != nil {
 		return nil, fmt.Errorf("lite account not found %s", lite)
 	}
 
-	return LookupByLabel(string(label))
+	return LookupByLabel(w, string(label))
 }
 
-func LookupByLiteIdentityUrl(lite string) (*Key, error) {
+func LookupByLiteIdentityUrl(w db.DB, lite string) (*Key, error) {
 	liteKey, isLite := LabelForLiteIdentity(lite)
 	if !isLite {
 		return nil, fmt.Errorf("invalid lite identity %s", liteKey)
 	}
 
-	label, err := GetWallet().Get(BucketLite, []byte(liteKey))
+	label, err := w.Get(BucketLite, []byte(liteKey))
 	if err != nil {
 		return nil, fmt.Errorf("lite identity account not found %s", lite)
 	}
 
-	return LookupByLabel(string(label))
+	return LookupByLabel(w, string(label))
 }
 
-func LookupByLabel(label string) (*Key, error) {
+func LookupByLabel(w db.DB, label string) (*Key, error) {
 	k := new(Key)
-	return k, k.LoadByLabel(label)
+	return k, k.LoadByLabel(w, label)
 }
 
 // LabelForLiteTokenAccount returns the identity of the token account if label
@@ -77,20 +78,20 @@ func LabelForLiteIdentity(label string) (string, bool) {
 	return u.Hostname(), true
 }
 
-func LookupByPubKey(pubKey []byte) (*Key, error) {
+func LookupByPubKey(w db.DB, pubKey []byte) (*Key, error) {
 	k := new(Key)
-	return k, k.LoadByPublicKey(pubKey)
+	return k, k.LoadByPublicKey(w, pubKey)
 }
 
-func GetKeyList() (kla []api.KeyData, err error) {
-	b, err := GetWallet().GetBucket(BucketLabel)
+func GetKeyList(w db.DB) (kla []api.KeyData, err error) {
+	b, err := w.GetBucket(BucketLabel)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, v := range b.KeyValueList {
 		k := Key{}
-		err := k.LoadByLabel(string(v.Value))
+		err := k.LoadByLabel(w, string(v.Value))
 		if err != nil {
 			return nil, err
 		}
@@ -6,9 +6,12 @@ import (
 	"encoding/binary"
 	"fmt"
 	"runtime/debug"
+	"strconv"
+	"strings"
 
 	btc "github.com/btcsuite/btcd/btcec"
 	"github.com/tyler-smith/go-bip32"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
 	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
 )
@@ -73,27 +76,27 @@ func (k *Key) Save(label, liteLabel string) error {
 	return nil
 }
 
-func (k *Key) LoadByLabel(label string) error {
+func (k *Key) LoadByLabel(w db.DB, label string) error {
 	label, _ = LabelForLiteTokenAccount(label)
 
-	pubKey, err := GetWallet().Get(BucketLabel, []byte(label))
+	pubKey, err := w.Get(BucketLabel, []byte(label))
 	if err != nil {
 		return fmt.Errorf("valid key not found for %s", label)
 	}
 
-	return k.LoadByPublicKey(pubKey)
+	return k.LoadByPublicKey(w, pubKey)
 }
 
-func (k *Key) LoadByPublicKey(publicKey []byte) error {
+func (k *Key) LoadByPublicKey(w db.DB, publicKey []byte) error {
 	k.PublicKey = publicKey
 
 	var err error
-	k.PrivateKey, err = GetWallet().Get(BucketKeys, k.PublicKey)
+	k.PrivateKey, err = w.Get(BucketKeys, k.PublicKey)
 	if err != nil {
 		return fmt.Errorf("private key not found for %x", publicKey)
 	}
 
-	b, err := GetWallet().Get(BucketKeyInfo, k.PublicKey)
+	b, err := w.Get(BucketKeyInfo, k.PublicKey)
 	if err != nil {
 		return fmt.Errorf("key type info not found for key %x", k.PublicKey)
 	}
@@ -154,17 +157,28 @@ func (k *Key) NativeAddress() (address string, err error) {
 }
 
 func GenerateKey(sigtype protocol.SignatureType) (k *Key, err error) {
+	return GenerateKeyForAccount(sigtype, 0)
+}
+
+// GenerateKeyForAccount derives the next key of the given type within the
+// BIP-44 account, so that keys used for different purposes are derived from
+// separate branches of the seed.
+func GenerateKeyForAccount(sigtype protocol.SignatureType, account uint32) (k *Key, err error) {
+	if account >= bip32.FirstHardenedChild {
+		return nil, fmt.Errorf("invalid account %d", account)
+	}
+
 	hd, err := NewDerivationPath(sigtype)
 	if err != nil {
 		return nil, err
 	}
 
-	address, err := getKeyCountAndIncrement(sigtype)
+	address, err := getKeyCountAndIncrement(sigtype, account)
 	if err != nil {
 		return nil, err
 	}
 
-	hd = Derivation{hd.Purpose(), hd.CoinType(), hd.Account(), hd.Chain(), address}
+	hd = Derivation{hd.Purpose(), hd.CoinType(), bip32.FirstHardenedChild + account, hd.Chain(), address}
 
 	derivationPath, err := hd.ToPath()
 	if err != nil {
@@ -207,15 +221,42 @@ func GenerateKeyFromHDPath(derivationPath string, sigtype protocol.SignatureType
 	return key, nil
 }
 
-func getKeyCountAndIncrement(sigtype protocol.SignatureType) (count uint32, err error) {
-	ct, _ := GetWallet().Get(BucketMnemonic, []byte(sigtype.String()))
+// DerivationCounterKey returns the key of the counter of keys derived for the
+// signature type and BIP-44 account. The counter of the first account is keyed
+// by the signature type alone, for compatibility with older wallets.
+func DerivationCounterKey(sigtype protocol.SignatureType, account uint32) []byte {
+	if account == 0 {
+		return []byte(sigtype.String())
+	}
+	return []byte(fmt.Sprintf("%v/%d", sigtype, account))
+}
+
+// ParseDerivationCounterKey parses a key returned by DerivationCounterKey.
+func ParseDerivationCounterKey(key []byte) (protocol.SignatureType, uint32, bool) {
+	s, account := string(key), uint64(0)
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		var err error
+		account, err = strconv.ParseUint(s[i+1:], 10, 31)
+		if err != nil {
+			return 0, 0, false
+		}
+		s = s[:i]
+	}
+
+	sigtype, ok := protocol.SignatureTypeByName(s)
+	return sigtype, uint32(account), ok
+}
+
+func getKeyCountAndIncrement(sigtype protocol.SignatureType, account uint32) (count uint32, err error) {
+	key := DerivationCounterKey(sigtype, account)
+	ct, _ := GetWallet().Get(BucketMnemonic, key)
 	if ct != nil {
 		count = binary.LittleEndian.Uint32(ct)
 	}
 
 	ct = make([]byte, 8)
 	binary.LittleEndian.PutUint32(ct, count+1)
-	err = GetWallet().Put(BucketMnemonic, []byte(sigtype.String()), ct)
+	err = GetWallet().Put(BucketMnemonic, key, ct)
 	if err != nil {
 		return 0, err
 	}
@@ -137,14 +137,43 @@ func (m *JrpcMethods) Encode(_ context.Context, params json.RawMessage) interfac
 	return accumulateError(fmt.Errorf("malformed encoding request"))
 }
 
-func (m *JrpcMethods) Sign(_ context.Context, params json.RawMessage) interface{} {
+func (m *JrpcMethods) Sign(ctx context.Context, params json.RawMessage) interface{} {
 	req := api.SignRequest{}
 	err := json.Unmarshal(params, &req)
 	if err != nil {
 		return validatorError(err)
 	}
+	if req.Transaction == nil || req.Signer == nil {
+		return validatorError(fmt.Errorf("a transaction and signer are required"))
+	}
+
+	key, err := LookupByLabel(walletFrom(ctx), req.KeyName)
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodeNotFound.Code(), "sign error", err)
+	}
+
+	err = CheckSigningPolicies(ctx, walletFrom(ctx), m.Client, req.Transaction, req.Signer)
+	if err != nil {
+		return policyError("sign error", err)
+	}
+
+	sig, err := signTransaction(req.Transaction, key, req.Signer, req.SignerVersion, nil)
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "sign error", err)
+	}
+
+	err = getAuthorization(ctx).authorizeTransaction(ctx, m.Client, req.Transaction, req.Signer)
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodePermissionDenied.Code(), "sign error", err)
+	}
 
 	resp := api.SignResponse{}
+	resp.Signature, err = sig.MarshalBinary()
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "sign error", err)
+	}
+	resp.PublicKey = key.PublicKey
+	resp.Transaction = req.Transaction
 	return resp
 }
 
@@ -158,17 +187,17 @@ func (m *JrpcMethods) CreateTransaction(_ context.Context, params json.RawMessag
 	return resp
 }
 
-func (m *JrpcMethods) KeyList(_ context.Context, params json.RawMessage) interface{} {
+func (m *JrpcMethods) KeyList(ctx context.Context, params json.RawMessage) interface{} {
 	resp := api.KeyListResponse{}
 	var err error
-	resp.KeyList, err = GetKeyList()
+	resp.KeyList, err = GetKeyList(walletFrom(ctx))
 	if err != nil {
 		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "key list error", err)
 	}
 	return resp
 }
 
-func (m *JrpcMethods) ResolveKey(_ context.Context, params json.RawMessage) interface{} {
+func (m *JrpcMethods) ResolveKey(ctx context.Context, params json.RawMessage) interface{} {
 	resp := api.ResolveKeyResponse{}
 
 	req := api.ResolveKeyRequest{}
@@ -181,13 +210,13 @@ func (m *JrpcMethods) ResolveKey(_ context.Context, params json.RawMessage) inte
 	label, isLite := LabelForLiteIdentity(req.KeyNameOrLiteAddress)
 	var k *Key
 	if isLite {
-		k, err = LookupByLiteIdentityUrl(label)
+		k, err = LookupByLiteIdentityUrl(walletFrom(ctx), label)
 	} else {
 		label, isLite = LabelForLiteTokenAccount(req.KeyNameOrLiteAddress)
 		if isLite {
-			k, err = LookupByLiteTokenUrl(label)
+			k, err = LookupByLiteTokenUrl(walletFrom(ctx), label)
 		} else {
-			k, err = LookupByLabel(label)
+			k, err = LookupByLabel(walletFrom(ctx), label)
 		}
 	}
 
@@ -200,10 +229,10 @@ func (m *JrpcMethods) ResolveKey(_ context.Context, params json.RawMessage) inte
 	return resp
 }
 
-func (m *JrpcMethods) AdiList(_ context.Context, params json.RawMessage) interface{} {
+func (m *JrpcMethods) AdiList(ctx context.Context, params json.RawMessage) interface{} {
 	resp := api.AdiListResponse{}
 	var err error
-	adis, err := getAdiList()
+	adis, err := getAdiList(walletFrom(ctx))
 	if err != nil {
 		return jsonrpc2.NewError(api.ErrorCodeNotFound.Code(), "adi list error", err)
 	}
@@ -214,7 +243,7 @@ func (m *JrpcMethods) AdiList(_ context.Context, params json.RawMessage) interfa
 	return resp
 }
 
-func (m *JrpcMethods) NewSendTokensTransaction(_ context.Context, params json.RawMessage) interface{} {
+func (m *JrpcMethods) NewSendTokensTransaction(ctx context.Context, params json.RawMessage) interface{} {
 	req := api.NewTransactionRequest{}
 	err := json.Unmarshal(params, &req)
 	if err != nil {
@@ -226,25 +255,25 @@ func (m *JrpcMethods) NewSendTokensTransaction(_ context.Context, params json.Ra
 	if err != nil {
 		return validatorError(err)
 	}
-	value, _ := GetWallet().Get(BucketTransactionCache, []byte(req.TxName))
+	value, _ := walletFrom(ctx).Get(BucketTransactionCache, []byte(req.TxName))
 	if value != nil {
 		return validatorError(fmt.Errorf("txn already available with the tx name"))
 	}
-	err = GetWallet().Put(BucketTransactionCache, []byte(req.TxName), resp)
+	err = walletFrom(ctx).Put(BucketTransactionCache, []byte(req.TxName), resp)
 	if err != nil {
 		return validatorError(err)
 	}
 	return resp
 }
 
-func (m *JrpcMethods) AddSendTokensOutput(_ context.Context, params json.RawMessage) interface{} {
+func (m *JrpcMethods) AddSendTokensOutput(ctx context.Context, params json.RawMessage) interface{} {
 	req := api.AddSendTokensOutputRequest{}
 	err := json.Unmarshal(params, &req)
 	if err != nil {
 		return validatorError(err)
 	}
 
-	value, err := GetWallet().Get(BucketTransactionCache, []byte(req.TxName))
+	value, err := walletFrom(ctx).Get(BucketTransactionCache, []byte(req.TxName))
 	if err != nil {
 		return validatorError(err)
 	}
@@ -266,21 +295,21 @@ func (m *JrpcMethods) AddSendTokensOutput(_ context.Context, params json.RawMess
 	if err != nil {
 		return validatorError(err)
 	}
-	err = GetWallet().Put(BucketTransactionCache, []byte(req.TxName), resp)
+	err = walletFrom(ctx).Put(BucketTransactionCache, []byte(req.TxName), resp)
 	if err != nil {
 		return validatorError(err)
 	}
 	return resp
 }
 
-func (m *JrpcMethods) DeleteSendTokensTransaction(_ context.Context, params json.RawMessage) interface{} {
+func (m *JrpcMethods) DeleteSendTokensTransaction(ctx context.Context, params json.RawMessage) interface{} {
 	req := api.DeleteTransactionRequest{}
 	err := json.Unmarshal(params, &req)
 	if err != nil {
 		return validatorError(err)
 	}
 
-	value, err := GetWallet().Get(BucketTransactionCache, []byte(req.Name))
+	value, err := walletFrom(ctx).Get(BucketTransactionCache, []byte(req.Name))
 	if err != nil {
 		return validatorError(err)
 	}
@@ -289,7 +318,7 @@ func (m *JrpcMethods) DeleteSendTokensTransaction(_ context.Context, params json
 	if err != nil {
 		return validatorError(err)
 	}
-	err = GetWallet().Delete(BucketTransactionCache, []byte(req.Name))
+	err = walletFrom(ctx).Delete(BucketTransactionCache, []byte(req.Name))
 	if err != nil {
 		return validatorError(err)
 	}
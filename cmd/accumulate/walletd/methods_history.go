@@ -0,0 +1,69 @@
+package walletd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+)
+
+func (m *JrpcMethods) HistorySync(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.HistorySyncRequest{}
+	if len(params) > 0 {
+		err := json.Unmarshal(params, &req)
+		if err != nil {
+			return validatorError(err)
+		}
+	}
+	if m.Client == nil {
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "history sync error", "the wallet is not connected to a node")
+	}
+
+	accounts := req.Accounts
+	if len(accounts) == 0 {
+		var err error
+		accounts, err = HistoryAccounts(ctx, walletFrom(ctx), m.Client)
+		if err != nil {
+			return accumulateError(err)
+		}
+	}
+
+	states, err := SyncHistory(ctx, walletFrom(ctx), m.Client, accounts)
+	if err != nil {
+		return accumulateError(err)
+	}
+	return &api.HistorySyncResponse{Accounts: states}
+}
+
+func (m *JrpcMethods) HistoryExport(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.HistoryExportRequest{}
+	if len(params) > 0 {
+		err := json.Unmarshal(params, &req)
+		if err != nil {
+			return validatorError(err)
+		}
+	}
+
+	entries, err := ListLedger(walletFrom(ctx), req.Account)
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "history export error", err)
+	}
+
+	res := &api.HistoryExportResponse{Entries: entries}
+	switch req.Format {
+	case "", "json":
+	case "csv":
+		buf := new(bytes.Buffer)
+		err = WriteLedgerCSV(buf, entries)
+		if err != nil {
+			return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "history export error", err)
+		}
+		res.Csv = buf.String()
+	default:
+		return validatorError(fmt.Errorf("unknown format %q, want json or csv", req.Format))
+	}
+	return res
+}
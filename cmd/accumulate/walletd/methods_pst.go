@@ -0,0 +1,194 @@
+package walletd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+)
+
+func (m *JrpcMethods) PstImport(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.PstRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+	if req.Pst == nil {
+		return validatorError(fmt.Errorf("missing partially signed transaction"))
+	}
+
+	err = ValidatePST(req.Pst)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	err = ImportPST(walletFrom(ctx), req.Pst)
+	if err != nil {
+		return pstError(err)
+	}
+	return InspectPST(req.Pst)
+}
+
+func (m *JrpcMethods) PstMerge(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.PstRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+	if req.Pst == nil {
+		return validatorError(fmt.Errorf("missing partially signed transaction"))
+	}
+
+	err = ValidatePST(req.Pst)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	pst, err := MergeStoredPST(walletFrom(ctx), req.Pst)
+	if err != nil {
+		return pstError(err)
+	}
+	return InspectPST(pst)
+}
+
+func (m *JrpcMethods) PstSign(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.PstSignRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	pst, err := LoadPST(walletFrom(ctx), req.TransactionHash)
+	if err != nil {
+		return pstError(err)
+	}
+
+	key, err := LookupByLabel(walletFrom(ctx), req.KeyName)
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodeNotFound.Code(), "pst sign error", err)
+	}
+
+	signer := req.Signer
+	if signer == nil && len(pst.Signers) == 1 {
+		signer = pst.Signers[0].Url
+	}
+	err = CheckSigningPolicies(ctx, walletFrom(ctx), m.Client, pst.Transaction, signer)
+	if err != nil {
+		return policyError("pst sign error", err)
+	}
+
+	sig, err := SignPST(pst, key, req.Signer)
+	if err != nil {
+		return pstError(err)
+	}
+
+	// Authorize the signature once the transaction has been initiated, so
+	// spending is recorded against its final hash. If it is not authorized,
+	// the signature is discarded.
+	err = getAuthorization(ctx).authorizeTransaction(ctx, m.Client, pst.Transaction, sig.GetSigner())
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodePermissionDenied.Code(), "pst sign error", err)
+	}
+
+	// Signing the first signature initiates the transaction, which changes
+	// its hash
+	err = DeletePST(walletFrom(ctx), req.TransactionHash)
+	if err != nil {
+		return pstError(err)
+	}
+	err = SavePST(walletFrom(ctx), pst)
+	if err != nil {
+		return pstError(err)
+	}
+	return InspectPST(pst)
+}
+
+func (m *JrpcMethods) PstInspect(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.PstHashRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	pst, err := LoadPST(walletFrom(ctx), req.TransactionHash)
+	if err != nil {
+		return pstError(err)
+	}
+	return InspectPST(pst)
+}
+
+func (m *JrpcMethods) PstExport(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.PstHashRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	pst, err := LoadPST(walletFrom(ctx), req.TransactionHash)
+	if err != nil {
+		return pstError(err)
+	}
+	return api.PstResponse{Pst: pst}
+}
+
+func (m *JrpcMethods) PstList(ctx context.Context, params json.RawMessage) interface{} {
+	psts, err := ListPSTs(walletFrom(ctx))
+	if err != nil {
+		return pstError(err)
+	}
+
+	resp := api.PstListResponse{}
+	for _, pst := range psts {
+		resp.Transactions = append(resp.Transactions, InspectPST(pst))
+	}
+	return resp
+}
+
+func (m *JrpcMethods) PstSubmit(ctx context.Context, params json.RawMessage) interface{} {
+	req := api.PstHashRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+	if m.Client == nil {
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "pst submit error", "the wallet is not connected to a node")
+	}
+
+	pst, err := LoadPST(walletFrom(ctx), req.TransactionHash)
+	if err != nil {
+		return pstError(err)
+	}
+
+	err = getAuthorization(ctx).authorizeTransaction(ctx, m.Client, pst.Transaction, nil)
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodePermissionDenied.Code(), "pst submit error", err)
+	}
+
+	resp, err := SubmitPST(ctx, m.Client, pst)
+	if err != nil {
+		return accumulateError(err)
+	}
+	if resp.Code != 0 {
+		return resp
+	}
+
+	err = DeletePST(walletFrom(ctx), req.TransactionHash)
+	if err != nil {
+		return pstError(err)
+	}
+	return resp
+}
+
+func pstError(err error) jsonrpc2.Error {
+	switch err {
+	case db.ErrNotFound, db.ErrNoBucket:
+		return jsonrpc2.NewError(api.ErrorCodeNotFound.Code(), "pst error", "partially signed transaction not found")
+	case ErrPstExists:
+		return jsonrpc2.NewError(api.ErrorCodeAlreadyExists.Code(), "pst error", err)
+	default:
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "pst error", err)
+	}
+}
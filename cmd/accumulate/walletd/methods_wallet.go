@@ -0,0 +1,75 @@
+package walletd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+)
+
+func (m *JrpcMethods) WalletOpen(_ context.Context, params json.RawMessage) interface{} {
+	req := api.WalletOpenRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+	if req.Name == "" || req.Name == DefaultWalletName {
+		return validatorError(fmt.Errorf("the default wallet is always open"))
+	}
+
+	if _, ok := m.wallets[req.Name]; !ok {
+		w, err := OpenWallet(req.Name, req.Password)
+		if err != nil {
+			return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "wallet open error", err)
+		}
+		if m.wallets == nil {
+			m.wallets = map[string]db.DB{}
+		}
+		m.wallets[req.Name] = w
+	}
+
+	return m.listWallets()
+}
+
+func (m *JrpcMethods) WalletClose(_ context.Context, params json.RawMessage) interface{} {
+	req := api.WalletCloseRequest{}
+	err := json.Unmarshal(params, &req)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	w, ok := m.wallets[req.Name]
+	if !ok {
+		return jsonrpc2.NewError(api.ErrorCodeNotFound.Code(), "wallet close error", fmt.Sprintf("wallet %q is not open", req.Name))
+	}
+	delete(m.wallets, req.Name)
+
+	err = w.Close()
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "wallet close error", err)
+	}
+	return m.listWallets()
+}
+
+func (m *JrpcMethods) WalletList(_ context.Context, params json.RawMessage) interface{} {
+	return m.listWallets()
+}
+
+func (m *JrpcMethods) listWallets() interface{} {
+	resp := api.WalletListResponse{}
+	var err error
+	resp.Wallets, err = ListWallets()
+	if err != nil {
+		return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), "wallet list error", err)
+	}
+
+	for name := range m.wallets {
+		resp.Open = append(resp.Open, name)
+	}
+	sort.Strings(resp.Open)
+	return resp
+}
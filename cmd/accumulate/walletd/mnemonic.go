@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/tyler-smith/go-bip39"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/slip39"
 )
 
 func ImportMnemonic(mnemonic []string) (string, error) {
@@ -34,3 +35,36 @@ func ImportMnemonic(mnemonic []string) (string, error) {
 
 	return "mnemonic import successful", nil
 }
+
+// ExportSeedShares splits the entropy of the wallet's mnemonic into SLIP-39
+// shares, any threshold of which can be used to restore the wallet with
+// ImportSeedShares.
+func ExportSeedShares(threshold, count int, passphrase string) ([]string, error) {
+	phrase, err := GetWallet().Get(BucketMnemonic, []byte("phrase"))
+	if err != nil {
+		return nil, fmt.Errorf("mnemonic phrase not found")
+	}
+
+	entropy, err := bip39.EntropyFromMnemonic(string(phrase))
+	if err != nil {
+		return nil, err
+	}
+
+	return slip39.Split(entropy, []byte(passphrase), threshold, count)
+}
+
+// ImportSeedShares combines SLIP-39 shares created by ExportSeedShares and
+// imports the mnemonic they encode.
+func ImportSeedShares(shares []string, passphrase string) (string, error) {
+	entropy, err := slip39.Combine(shares, []byte(passphrase))
+	if err != nil {
+		return "", err
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", err
+	}
+
+	return ImportMnemonic(strings.Fields(mnemonic))
+}
@@ -0,0 +1,239 @@
+package walletd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// SaveSigningPolicy validates the policy and stores it in the wallet,
+// replacing any policy with the same name.
+func SaveSigningPolicy(policy *api.SigningPolicy) error {
+	if policy.Name == "" {
+		return fmt.Errorf("missing policy name")
+	}
+	if len(policy.TransactionTypes) == 0 {
+		return fmt.Errorf("a policy must list the transaction types it permits")
+	}
+	if policy.MemoPattern != "" {
+		_, err := compileMemoPattern(policy.MemoPattern)
+		if err != nil {
+			return fmt.Errorf("invalid memo pattern: %w", err)
+		}
+	}
+	for _, limit := range policy.Recipients {
+		if limit.Token == nil {
+			return fmt.Errorf("recipient limit is missing the token")
+		}
+	}
+
+	data, err := policy.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return GetWallet().Put(BucketSigningPolicy, []byte(policy.Name), data)
+}
+
+// DeleteSigningPolicy removes a policy from the wallet.
+func DeleteSigningPolicy(name string) error {
+	_, err := GetWallet().Get(BucketSigningPolicy, []byte(name))
+	if err != nil {
+		return fmt.Errorf("policy %q not found", name)
+	}
+	return GetWallet().Delete(BucketSigningPolicy, []byte(name))
+}
+
+// ListSigningPolicies returns the policies stored in the wallet, sorted by
+// name.
+func ListSigningPolicies(w db.DB) ([]*api.SigningPolicy, error) {
+	b, err := w.GetBucket(BucketSigningPolicy)
+	if err != nil {
+		if err == db.ErrNoBucket {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var policies []*api.SigningPolicy
+	for _, v := range b.KeyValueList {
+		policy := new(api.SigningPolicy)
+		err = policy.UnmarshalBinary(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies, nil
+}
+
+// CheckSigningPolicies verifies that the transaction satisfies every policy
+// that applies to the signer. If the transaction does not, the returned error
+// is an *api.PolicyViolation. If the wallet has no policies, every
+// transaction is permitted.
+func CheckSigningPolicies(ctx context.Context, w db.DB, c *client.Client, txn *protocol.Transaction, signer *url.URL) error {
+	policies, err := ListSigningPolicies(w)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		if len(policy.Signers) > 0 && (signer == nil || !containsUrl(policy.Signers, signer)) {
+			continue
+		}
+		err = checkSigningPolicy(ctx, c, policy, txn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSigningPolicy(ctx context.Context, c *client.Client, policy *api.SigningPolicy, txn *protocol.Transaction) error {
+	violation := func(rule api.PolicyRule, format string, args ...interface{}) error {
+		return &api.PolicyViolation{Policy: policy.Name, Rule: rule, Message: fmt.Sprintf(format, args...)}
+	}
+
+	// Transaction types the policy does not list are never permitted
+	typ := txn.Body.Type()
+	if !containsTransactionType(policy.TransactionTypes, typ) {
+		return violation(api.PolicyRuleTransactionType, "%v transactions are not permitted", typ)
+	}
+
+	if len(policy.Principals) > 0 && !containsUrl(policy.Principals, txn.Header.Principal) {
+		return violation(api.PolicyRulePrincipal, "transactions for %v are not permitted", txn.Header.Principal)
+	}
+
+	if policy.MemoPattern != "" {
+		re, err := compileMemoPattern(policy.MemoPattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(txn.Header.Memo) {
+			return violation(api.PolicyRuleMemo, "memo %q does not match %q", txn.Header.Memo, policy.MemoPattern)
+		}
+	}
+
+	if len(policy.Recipients) == 0 {
+		return nil
+	}
+
+	token, recipients, err := transactionRecipients(ctx, c, txn)
+	if err != nil || token == nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		limit := findRecipientLimit(policy.Recipients, recipient.Url, token)
+		if limit == nil {
+			return violation(api.PolicyRuleRecipient, "sending %v to %v is not permitted", token, recipient.Url)
+		}
+		if recipient.Amount.Cmp(&limit.Amount) > 0 {
+			return violation(api.PolicyRuleAmount, "sending %s of %v to %v exceeds the limit of %s", recipient.Amount.String(), token, recipient.Url, limit.Amount.String())
+		}
+	}
+	return nil
+}
+
+// compileMemoPattern compiles the pattern so that it must match the entire
+// memo.
+func compileMemoPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+// transactionRecipients returns the token moved by the transaction and the
+// total amount sent to each recipient. Credits are purchased from the
+// recipient with ACME and burned tokens are returned to the token's issuer.
+func transactionRecipients(ctx context.Context, c *client.Client, txn *protocol.Transaction) (*url.URL, []*protocol.TokenRecipient, error) {
+	var to []*protocol.TokenRecipient
+	var token *url.URL
+	switch body := txn.Body.(type) {
+	case *protocol.SendTokens:
+		var err error
+		token, err = principalToken(ctx, c, txn.Header.Principal)
+		if err != nil {
+			return nil, nil, err
+		}
+		to = body.To
+	case *protocol.IssueTokens:
+		token = txn.Header.Principal
+		to = body.To
+		if body.Recipient != nil {
+			to = append(to, &protocol.TokenRecipient{Url: body.Recipient, Amount: body.Amount})
+		}
+	case *protocol.BurnTokens:
+		var err error
+		token, err = principalToken(ctx, c, txn.Header.Principal)
+		if err != nil {
+			return nil, nil, err
+		}
+		to = []*protocol.TokenRecipient{{Url: token, Amount: body.Amount}}
+	case *protocol.AddCredits:
+		token = protocol.AcmeUrl()
+		to = []*protocol.TokenRecipient{{Url: body.Recipient, Amount: body.Amount}}
+	default:
+		return nil, nil, nil
+	}
+
+	var recipients []*protocol.TokenRecipient
+	for _, r := range to {
+		var total *protocol.TokenRecipient
+		for _, existing := range recipients {
+			if existing.Url.Equal(r.Url) {
+				total = existing
+				break
+			}
+		}
+		if total == nil {
+			total = &protocol.TokenRecipient{Url: r.Url}
+			recipients = append(recipients, total)
+		}
+		total.Amount = *new(big.Int).Add(&total.Amount, &r.Amount)
+	}
+	return token, recipients, nil
+}
+
+// findRecipientLimit returns the limit for the recipient, preferring a limit
+// for the specific recipient over a limit for every recipient.
+func findRecipientLimit(limits []*api.RecipientLimit, recipient, token *url.URL) *api.RecipientLimit {
+	var any *api.RecipientLimit
+	for _, limit := range limits {
+		if !limit.Token.Equal(token) {
+			continue
+		}
+		if limit.Recipient == nil {
+			any = limit
+			continue
+		}
+		if limit.Recipient.Equal(recipient) {
+			return limit
+		}
+	}
+	return any
+}
+
+func containsTransactionType(list []protocol.TransactionType, typ protocol.TransactionType) bool {
+	for _, v := range list {
+		if v == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func policyError(msg string, err error) jsonrpc2.Error {
+	var violation *api.PolicyViolation
+	if errors.As(err, &violation) {
+		return jsonrpc2.NewError(api.ErrorCodePolicyViolation.Code(), msg, violation)
+	}
+	return jsonrpc2.NewError(api.ErrorCodeGeneralError.Code(), msg, err)
+}
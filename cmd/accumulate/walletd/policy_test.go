@@ -0,0 +1,142 @@
+package walletd
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestSigningPolicy(t *testing.T) {
+	InitTestDB(t)
+
+	lta, err := protocol.LiteTokenAddress(newTestKey("alice").PublicKey, protocol.ACME, protocol.SignatureTypeED25519)
+	require.NoError(t, err)
+	page := url.MustParse("bot/book/1")
+	bob := url.MustParse("bob/tokens")
+	carol := url.MustParse("carol/tokens")
+
+	require.NoError(t, SaveSigningPolicy(&api.SigningPolicy{
+		Name:             "payouts",
+		Signers:          []*url.URL{page},
+		TransactionTypes: []protocol.TransactionType{protocol.TransactionTypeSendTokens},
+		Principals:       []*url.URL{lta},
+		Recipients: []*api.RecipientLimit{
+			{Token: protocol.AcmeUrl(), Amount: *big.NewInt(10 * protocol.AcmePrecision)},
+			{Recipient: bob, Token: protocol.AcmeUrl(), Amount: *big.NewInt(100 * protocol.AcmePrecision)},
+		},
+		MemoPattern: `^payout-\d+$`,
+	}))
+	require.Error(t, SaveSigningPolicy(&api.SigningPolicy{Name: "bad", TransactionTypes: []protocol.TransactionType{protocol.TransactionTypeSendTokens}, MemoPattern: "("}))
+	require.Error(t, SaveSigningPolicy(&api.SigningPolicy{Name: "bad"}), "a policy must list the permitted types")
+
+	send := func(memo string, to ...*protocol.TokenRecipient) *protocol.Transaction {
+		txn := new(protocol.Transaction)
+		txn.Header.Principal = lta
+		txn.Header.Memo = memo
+		txn.Body = &protocol.SendTokens{To: to}
+		return txn
+	}
+	acme := func(u *url.URL, amount int64) *protocol.TokenRecipient {
+		return &protocol.TokenRecipient{Url: u, Amount: *big.NewInt(amount * protocol.AcmePrecision)}
+	}
+	requireRule := func(rule api.PolicyRule, err error) {
+		t.Helper()
+		var violation *api.PolicyViolation
+		require.ErrorAs(t, err, &violation)
+		require.Equal(t, "payouts", violation.Policy)
+		require.Equal(t, rule, violation.Rule)
+	}
+
+	ctx := context.Background()
+	require.NoError(t, CheckSigningPolicies(ctx, GetWallet(), nil, send("payout-1", acme(bob, 50), acme(carol, 5)), page))
+	requireRule(api.PolicyRuleAmount, CheckSigningPolicies(ctx, GetWallet(), nil, send("payout-1", acme(bob, 60), acme(bob, 60)), page))
+	requireRule(api.PolicyRuleAmount, CheckSigningPolicies(ctx, GetWallet(), nil, send("payout-1", acme(carol, 11)), page))
+	requireRule(api.PolicyRuleMemo, CheckSigningPolicies(ctx, GetWallet(), nil, send("other", acme(bob, 1)), page))
+	requireRule(api.PolicyRuleMemo, CheckSigningPolicies(ctx, GetWallet(), nil, send("payout-1 and more", acme(bob, 1)), page))
+
+	other := send("payout-1", acme(bob, 1))
+	other.Header.Principal = url.MustParse("alice/tokens")
+	requireRule(api.PolicyRulePrincipal, CheckSigningPolicies(ctx, GetWallet(), nil, other, page))
+
+	burn := send("payout-1")
+	burn.Body = &protocol.BurnTokens{Amount: *big.NewInt(1)}
+	requireRule(api.PolicyRuleTransactionType, CheckSigningPolicies(ctx, GetWallet(), nil, burn, page))
+	require.NoError(t, CheckSigningPolicies(ctx, GetWallet(), nil, burn, url.MustParse("alice/book/1")), "the policy does not apply to other signers")
+
+	// The sign method rejects transactions that violate the policy
+	key := newTestKey("bot")
+	require.NoError(t, key.Save("bot", ""))
+	m, err := NewJrpc(Options{Unauthenticated: true})
+	require.NoError(t, err)
+	sign := func(txn *protocol.Transaction) interface{} {
+		params, err := json.Marshal(&api.SignRequest{KeyName: "bot", Transaction: txn, Signer: page, SignerVersion: 1})
+		require.NoError(t, err)
+		return m.methods["sign"](ctx, params)
+	}
+
+	resp, ok := sign(send("payout-2", acme(bob, 1))).(api.SignResponse)
+	require.True(t, ok)
+	require.Equal(t, key.PublicKey, resp.PublicKey)
+	sig, err := protocol.UnmarshalSignature(resp.Signature)
+	require.NoError(t, err)
+	keySig, ok := sig.(protocol.KeySignature)
+	require.True(t, ok)
+	require.True(t, keySig.Verify(nil, resp.Transaction.GetHash()))
+
+	jerr, ok := sign(burn).(jsonrpc2.Error)
+	require.True(t, ok)
+	require.Equal(t, api.ErrorCodePolicyViolation.Code(), jerr.Code)
+}
+
+func TestSigningPolicyValueLimits(t *testing.T) {
+	InitTestDB(t)
+
+	lta, err := protocol.LiteTokenAddress(newTestKey("alice").PublicKey, protocol.ACME, protocol.SignatureTypeED25519)
+	require.NoError(t, err)
+	page := url.MustParse("treasury/book/1")
+	credits := url.MustParse("treasury/book/2")
+
+	require.NoError(t, SaveSigningPolicy(&api.SigningPolicy{
+		Name:             "treasury",
+		TransactionTypes: []protocol.TransactionType{protocol.TransactionTypeBurnTokens, protocol.TransactionTypeAddCredits},
+		Recipients: []*api.RecipientLimit{
+			{Recipient: protocol.AcmeUrl(), Token: protocol.AcmeUrl(), Amount: *big.NewInt(5 * protocol.AcmePrecision)},
+			{Recipient: credits, Token: protocol.AcmeUrl(), Amount: *big.NewInt(2 * protocol.AcmePrecision)},
+		},
+		MemoPattern: `treasury`,
+	}))
+
+	txn := func(body protocol.TransactionBody) *protocol.Transaction {
+		txn := new(protocol.Transaction)
+		txn.Header.Principal = lta
+		txn.Header.Memo = "treasury"
+		txn.Body = body
+		return txn
+	}
+	requireRule := func(rule api.PolicyRule, err error) {
+		t.Helper()
+		var violation *api.PolicyViolation
+		require.ErrorAs(t, err, &violation)
+		require.Equal(t, rule, violation.Rule)
+	}
+
+	// Burned tokens are received by the issuer
+	ctx := context.Background()
+	require.NoError(t, CheckSigningPolicies(ctx, GetWallet(), nil, txn(&protocol.BurnTokens{Amount: *big.NewInt(5 * protocol.AcmePrecision)}), page))
+	requireRule(api.PolicyRuleAmount, CheckSigningPolicies(ctx, GetWallet(), nil, txn(&protocol.BurnTokens{Amount: *big.NewInt(6 * protocol.AcmePrecision)}), page))
+
+	// ACME spent on credits is received by the credit recipient
+	require.NoError(t, CheckSigningPolicies(ctx, GetWallet(), nil, txn(&protocol.AddCredits{Recipient: credits, Amount: *big.NewInt(2 * protocol.AcmePrecision)}), page))
+	requireRule(api.PolicyRuleAmount, CheckSigningPolicies(ctx, GetWallet(), nil, txn(&protocol.AddCredits{Recipient: credits, Amount: *big.NewInt(3 * protocol.AcmePrecision)}), page))
+	requireRule(api.PolicyRuleRecipient, CheckSigningPolicies(ctx, GetWallet(), nil, txn(&protocol.AddCredits{Recipient: page, Amount: *big.NewInt(1)}), page))
+
+	// Types the policy does not list are denied
+	requireRule(api.PolicyRuleTransactionType, CheckSigningPolicies(ctx, GetWallet(), nil, txn(&protocol.SendTokens{}), page))
+}
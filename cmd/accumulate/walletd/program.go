@@ -5,12 +5,14 @@ import (
 
 	"github.com/kardianos/service"
 	"github.com/spf13/cobra"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
 )
 
 type ServiceOptions struct {
 	WorkDir         string
 	LogFilename     string
 	JsonLogFilename string
+	Unauthenticated bool
 }
 
 type Program struct {
@@ -19,11 +21,11 @@ type Program struct {
 	primary        *JrpcMethods
 }
 
-func NewProgram(cmd *cobra.Command, options *ServiceOptions, listenAddress string) (p *Program, err error) {
+func NewProgram(cmd *cobra.Command, options *ServiceOptions, listenAddress string, client *client.Client) (p *Program, err error) {
 	p = new(Program)
 	p.cmd = cmd
 	p.serviceOptions = *options
-	p.primary, err = NewJrpc(Options{nil, time.Second, listenAddress, GetWallet()})
+	p.primary, err = NewJrpc(Options{nil, time.Second, listenAddress, GetWallet(), client, options.Unauthenticated})
 	return p, err
 }
 
@@ -0,0 +1,337 @@
+package walletd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	apiv2 "gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/client/signing"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// PstVersion is the current version of the partially signed transaction
+// format.
+const PstVersion = 1
+
+var ErrPstExists = fmt.Errorf("partially signed transaction already exists")
+
+// NewPST returns a partially signed transaction for the transaction.
+func NewPST(txn *protocol.Transaction, signers ...*api.PstSigner) *api.PartiallySignedTransaction {
+	pst := new(api.PartiallySignedTransaction)
+	pst.Version = PstVersion
+	pst.Transaction = txn
+	pst.Signers = signers
+	return pst
+}
+
+// ReadPST reads a partially signed transaction from a JSON file and validates
+// it.
+func ReadPST(filename string) (*api.PartiallySignedTransaction, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	pst := new(api.PartiallySignedTransaction)
+	err = json.Unmarshal(data, pst)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partially signed transaction: %v", err)
+	}
+
+	err = ValidatePST(pst)
+	if err != nil {
+		return nil, err
+	}
+	return pst, nil
+}
+
+// WritePST writes a partially signed transaction to a JSON file.
+func WritePST(filename string, pst *api.PartiallySignedTransaction) error {
+	data, err := json.MarshalIndent(pst, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0600)
+}
+
+// ValidatePST verifies that every signature of the partially signed
+// transaction is valid, signs the transaction, and belongs to one of the
+// listed signers.
+func ValidatePST(pst *api.PartiallySignedTransaction) error {
+	if pst.Version != PstVersion {
+		return fmt.Errorf("unsupported partially signed transaction version %d", pst.Version)
+	}
+	if pst.Transaction == nil || pst.Transaction.Body == nil {
+		return fmt.Errorf("invalid partially signed transaction: missing transaction")
+	}
+
+	for i, sig := range pst.Signatures {
+		err := verifyPstSignature(pst, sig)
+		if err != nil {
+			return fmt.Errorf("invalid partially signed transaction: signature %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func verifyPstSignature(pst *api.PartiallySignedTransaction, sig protocol.Signature) error {
+	hash := pst.Transaction.GetHash()
+	var ok bool
+	switch sig := sig.(type) {
+	case *protocol.DelegatedSignature:
+		ok = sig.Verify(sig.Metadata().Hash(), hash)
+	case protocol.KeySignature:
+		ok = sig.Verify(nil, hash)
+	default:
+		return fmt.Errorf("unsupported signature type %v", sig.Type())
+	}
+	if !ok {
+		return fmt.Errorf("invalid signature")
+	}
+
+	if len(pst.Signers) > 0 && findPstSigner(pst, sig.GetSigner()) == nil {
+		return fmt.Errorf("%v is not a signer of the transaction", sig.GetSigner())
+	}
+	return nil
+}
+
+func findPstSigner(pst *api.PartiallySignedTransaction, u *url.URL) *api.PstSigner {
+	for _, signer := range pst.Signers {
+		if signer.Url.Equal(u) {
+			return signer
+		}
+	}
+	return nil
+}
+
+// SignPST signs the partially signed transaction with the key as the given
+// signer. If signer is nil, the transaction must have exactly one signer. The
+// first signature initiates the transaction, which changes its hash, so a
+// partially signed transaction must be initiated before it is distributed to
+// co-signers.
+func SignPST(pst *api.PartiallySignedTransaction, key *Key, signerUrl *url.URL) (protocol.Signature, error) {
+	var signer *api.PstSigner
+	switch {
+	case signerUrl != nil:
+		signer = findPstSigner(pst, signerUrl)
+		if signer == nil {
+			return nil, fmt.Errorf("%v is not a signer of the transaction", signerUrl)
+		}
+	case len(pst.Signers) == 1:
+		signer = pst.Signers[0]
+	default:
+		return nil, fmt.Errorf("the transaction has %d signers, a signer must be specified", len(pst.Signers))
+	}
+
+	for _, sig := range pst.Signatures {
+		keySig, ok := sig.(protocol.KeySignature)
+		if ok && keySig.GetSigner().Equal(signer.Url) && bytes.Equal(keySig.GetPublicKey(), key.PublicKey) {
+			return nil, fmt.Errorf("the transaction has already been signed by this key")
+		}
+	}
+
+	if pst.Transaction.Header.Initiator == ([32]byte{}) && len(pst.Signatures) > 0 {
+		return nil, fmt.Errorf("the transaction has signatures but has not been initiated")
+	}
+
+	sig, err := signTransaction(pst.Transaction, key, signer.Url, signer.Version, signer.Delegators)
+	if err != nil {
+		return nil, err
+	}
+
+	pst.Signatures = append(pst.Signatures, sig)
+	return sig, nil
+}
+
+// signTransaction signs the transaction with the key. If the transaction has
+// not been initiated, the signature initiates it.
+func signTransaction(txn *protocol.Transaction, key *Key, signer *url.URL, version uint64, delegators []*url.URL) (protocol.Signature, error) {
+	builder := new(signing.Builder)
+	builder.Type = key.KeyInfo.Type
+	builder.Url = signer
+	builder.Version = version
+	builder.Delegators = delegators
+	builder.SetPrivateKey(key.PrivateKey)
+	builder.SetTimestampToNow()
+
+	if txn.Header.Initiator == ([32]byte{}) {
+		return builder.Initiate(txn)
+	}
+	return builder.Sign(txn.GetHash())
+}
+
+// MergePST adds the signers and signatures of src to dst. Both must be for the
+// same transaction.
+func MergePST(dst, src *api.PartiallySignedTransaction) error {
+	if !bytes.Equal(dst.Transaction.GetHash(), src.Transaction.GetHash()) {
+		return fmt.Errorf("cannot merge partially signed transactions for different transactions (%X != %X)", dst.Transaction.GetHash(), src.Transaction.GetHash())
+	}
+
+	for _, signer := range src.Signers {
+		existing := findPstSigner(dst, signer.Url)
+		switch {
+		case existing == nil:
+			dst.Signers = append(dst.Signers, signer)
+		case existing.Version != signer.Version:
+			return fmt.Errorf("conflicting versions for signer %v (%d != %d)", signer.Url, existing.Version, signer.Version)
+		}
+	}
+
+	have := map[[32]byte]bool{}
+	for _, sig := range dst.Signatures {
+		have[*(*[32]byte)(sig.Hash())] = true
+	}
+	for _, sig := range src.Signatures {
+		if have[*(*[32]byte)(sig.Hash())] {
+			continue
+		}
+		err := verifyPstSignature(dst, sig)
+		if err != nil {
+			return err
+		}
+		have[*(*[32]byte)(sig.Hash())] = true
+		dst.Signatures = append(dst.Signatures, sig)
+	}
+	return nil
+}
+
+// InspectPST reports how many signatures have been collected for each signer,
+// and whether every authority of the transaction has collected enough
+// signatures.
+func InspectPST(pst *api.PartiallySignedTransaction) *api.PstStatus {
+	status := new(api.PstStatus)
+	status.TransactionHash = pst.Transaction.GetHash()
+	status.Principal = pst.Transaction.Header.Principal
+	status.TransactionType = pst.Transaction.Body.Type()
+	status.Initiated = pst.Transaction.Header.Initiator != [32]byte{}
+
+	// An authority is satisfied if any of its signers has reached its
+	// threshold
+	satisfied := map[[32]byte]bool{}
+	for _, signer := range pst.Signers {
+		s := new(api.PstSignerStatus)
+		s.Url = signer.Url
+		s.Threshold = signer.Threshold
+		for _, sig := range pst.Signatures {
+			if sig.GetSigner().Equal(signer.Url) {
+				s.Signatures++
+			}
+		}
+		status.Signers = append(status.Signers, s)
+
+		authority := signer.Authority
+		if authority == nil {
+			authority = signer.Url
+		}
+		id := authority.AccountID32()
+		satisfied[id] = satisfied[id] || s.Signatures >= signer.Threshold
+	}
+
+	status.Complete = status.Initiated && len(pst.Signatures) > 0
+	for _, ok := range satisfied {
+		status.Complete = status.Complete && ok
+	}
+	return status
+}
+
+// SubmitPST submits the transaction and signatures of the partially signed
+// transaction.
+func SubmitPST(ctx context.Context, c *client.Client, pst *api.PartiallySignedTransaction) (*apiv2.TxResponse, error) {
+	if pst.Transaction.Header.Initiator == ([32]byte{}) || len(pst.Signatures) == 0 {
+		return nil, fmt.Errorf("the transaction has not been signed")
+	}
+
+	req := new(apiv2.ExecuteRequest)
+	req.Envelope = new(protocol.Envelope)
+	req.Envelope.Transaction = []*protocol.Transaction{pst.Transaction}
+	req.Envelope.Signatures = pst.Signatures
+	return c.ExecuteDirect(ctx, req)
+}
+
+// ImportPST stores the partially signed transaction in the wallet. It fails if
+// the wallet already has a partially signed transaction for the same
+// transaction.
+func ImportPST(w db.DB, pst *api.PartiallySignedTransaction) error {
+	_, err := LoadPST(w, pst.Transaction.GetHash())
+	switch {
+	case err == nil:
+		return ErrPstExists
+	case err != db.ErrNotFound && err != db.ErrNoBucket:
+		return err
+	}
+	return SavePST(w, pst)
+}
+
+// MergeStoredPST merges the partially signed transaction into the one stored
+// in the wallet.
+func MergeStoredPST(w db.DB, pst *api.PartiallySignedTransaction) (*api.PartiallySignedTransaction, error) {
+	stored, err := LoadPST(w, pst.Transaction.GetHash())
+	if err != nil {
+		return nil, err
+	}
+
+	err = MergePST(stored, pst)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, SavePST(w, stored)
+}
+
+// SavePST stores the partially signed transaction in the wallet.
+func SavePST(w db.DB, pst *api.PartiallySignedTransaction) error {
+	data, err := pst.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return w.Put(BucketPst, pst.Transaction.GetHash(), data)
+}
+
+// LoadPST loads a partially signed transaction from the wallet.
+func LoadPST(w db.DB, hash []byte) (*api.PartiallySignedTransaction, error) {
+	data, err := w.Get(BucketPst, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pst := new(api.PartiallySignedTransaction)
+	err = pst.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	return pst, nil
+}
+
+// ListPSTs returns the partially signed transactions stored in the wallet.
+func ListPSTs(w db.DB) ([]*api.PartiallySignedTransaction, error) {
+	b, err := w.GetBucket(BucketPst)
+	if err != nil {
+		if err == db.ErrNoBucket {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var psts []*api.PartiallySignedTransaction
+	for _, v := range b.KeyValueList {
+		pst := new(api.PartiallySignedTransaction)
+		err = pst.UnmarshalBinary(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		psts = append(psts, pst)
+	}
+	return psts, nil
+}
+
+// DeletePST removes a partially signed transaction from the wallet.
+func DeletePST(w db.DB, hash []byte) error {
+	return w.Delete(BucketPst, hash)
+}
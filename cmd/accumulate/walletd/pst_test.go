@@ -0,0 +1,78 @@
+package walletd
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func newTestKey(seed string) *Key {
+	h := sha256.Sum256([]byte(seed))
+	sk := ed25519.NewKeyFromSeed(h[:])
+	k := new(Key)
+	k.PrivateKey = sk
+	k.PublicKey = sk[32:]
+	k.KeyInfo.Type = protocol.SignatureTypeED25519
+	return k
+}
+
+func TestPartiallySignedTransaction(t *testing.T) {
+	InitTestDB(t)
+
+	page := url.MustParse("alice/book/1")
+	txn := new(protocol.Transaction)
+	txn.Header.Principal = url.MustParse("alice/tokens")
+	txn.Body = &protocol.SendTokens{To: []*protocol.TokenRecipient{{Url: url.MustParse("bob/tokens")}}}
+	pst := NewPST(txn, &api.PstSigner{Url: page, Authority: page.Identity(), Version: 1, Threshold: 2})
+
+	// Initiate
+	_, err := SignPST(pst, newTestKey("alice1"), nil)
+	require.NoError(t, err)
+	status := InspectPST(pst)
+	require.True(t, status.Initiated)
+	require.False(t, status.Complete)
+
+	// Round trip through a file
+	filename := filepath.Join(t.TempDir(), "pst.json")
+	require.NoError(t, WritePST(filename, pst))
+	copy1, err := ReadPST(filename)
+	require.NoError(t, err)
+	copy2, err := ReadPST(filename)
+	require.NoError(t, err)
+
+	// Two co-signers sign separately
+	_, err = SignPST(copy1, newTestKey("alice2"), page)
+	require.NoError(t, err)
+	_, err = SignPST(copy2, newTestKey("alice3"), page)
+	require.NoError(t, err)
+	_, err = SignPST(copy2, newTestKey("alice3"), page)
+	require.Error(t, err, "signing twice with the same key must fail")
+
+	// Merge
+	require.NoError(t, ImportPST(GetWallet(), pst))
+	require.ErrorIs(t, ImportPST(GetWallet(), pst), ErrPstExists)
+	_, err = MergeStoredPST(GetWallet(), copy1)
+	require.NoError(t, err)
+	merged, err := MergeStoredPST(GetWallet(), copy2)
+	require.NoError(t, err)
+	require.Len(t, merged.Signatures, 3)
+
+	status = InspectPST(merged)
+	require.True(t, status.Complete)
+	require.Equal(t, uint64(3), status.Signers[0].Signatures)
+
+	// A tampered signature is rejected
+	bad := merged.Copy()
+	bad.Signatures[1].(*protocol.ED25519Signature).Signature[0]++
+	require.Error(t, ValidatePST(bad))
+
+	// A PST for a different transaction cannot be merged
+	other := NewPST(&protocol.Transaction{Header: txn.Header, Body: &protocol.BurnTokens{}})
+	require.Error(t, MergePST(merged, other))
+}
@@ -0,0 +1,54 @@
+package slip39
+
+// point is a share of a secret: the value of the secret polynomial at x.
+type point struct {
+	x uint8
+	y []byte
+}
+
+// exp and log are the exponent and logarithm tables of GF(256) with the
+// Rijndael polynomial x^8 + x^4 + x^3 + x + 1 and generator 3.
+var exp, log = func() (exp, log [256]byte) {
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+		poly = poly<<1 ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11B
+		}
+	}
+	return exp, log
+}()
+
+// interpolate evaluates the Lagrange polynomial through the points at x.
+func interpolate(points []point, x uint8) []byte {
+	for _, p := range points {
+		if p.x == x {
+			return p.y
+		}
+	}
+
+	var logProd int
+	for _, p := range points {
+		logProd += int(log[p.x^x])
+	}
+
+	result := make([]byte, len(points[0].y))
+	for _, p := range points {
+		logBasis := logProd - int(log[p.x^x])
+		for _, q := range points {
+			if q.x != p.x {
+				logBasis -= int(log[p.x^q.x])
+			}
+		}
+		logBasis = (logBasis%255 + 255) % 255
+
+		for i, y := range p.y {
+			if y != 0 {
+				result[i] ^= exp[(int(log[y])+logBasis)%255]
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,410 @@
+// Package slip39 implements SLIP-39 Shamir's secret-sharing for mnemonic
+// codes.
+//
+// https://github.com/satoshilabs/slips/blob/master/slip-0039.md
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	radixBits          = 10
+	extendableBits     = 1
+	iterationExpBits   = 4
+	checksumWords      = 3
+	metadataWords      = 7 // Identifier and parameters (4 words) plus checksum
+	digestLength       = 4
+	digestIndex        = 254
+	secretIndex        = 255
+	maxShareCount      = 16
+	minSecretLength    = 16
+	minMnemonicWords   = 20
+	baseIterationCount = 10000
+	roundCount         = 4
+)
+
+var (
+	ErrInvalidChecksum = errors.New("invalid share checksum")
+	ErrInvalidDigest   = errors.New("invalid digest of the shared secret")
+)
+
+// Share is a decoded SLIP-39 mnemonic share.
+type Share struct {
+	Identifier        uint16
+	Extendable        bool
+	IterationExponent uint8
+	GroupIndex        uint8
+	GroupThreshold    uint8
+	GroupCount        uint8
+	MemberIndex       uint8
+	MemberThreshold   uint8
+	Value             []byte
+}
+
+// Split encrypts the master secret with the passphrase and splits it into
+// count mnemonic shares, any threshold of which can be combined to recover the
+// master secret. The shares belong to a single group.
+func Split(secret, passphrase []byte, threshold, count int) ([]string, error) {
+	if len(secret) < minSecretLength || len(secret)%2 != 0 {
+		return nil, fmt.Errorf("the master secret must be at least %d bytes and an even number of bytes", minSecretLength)
+	}
+	if threshold < 1 || threshold > count || count > maxShareCount {
+		return nil, fmt.Errorf("invalid %d-of-%d sharing, at most %d shares are allowed", threshold, count, maxShareCount)
+	}
+	if threshold == 1 && count > 1 {
+		return nil, fmt.Errorf("creating multiple shares with a threshold of 1 is not allowed, use 1-of-1 sharing instead")
+	}
+	for _, c := range passphrase {
+		if c < 32 || c > 126 {
+			return nil, fmt.Errorf("the passphrase must only contain printable ASCII characters")
+		}
+	}
+
+	var id [2]byte
+	_, err := rand.Read(id[:])
+	if err != nil {
+		return nil, err
+	}
+
+	share := new(Share)
+	share.Identifier = binary.BigEndian.Uint16(id[:]) >> 1
+	share.IterationExponent = 1
+	share.GroupThreshold = 1
+	share.GroupCount = 1
+	share.MemberThreshold = uint8(threshold)
+
+	// With a single group, the group share is the encrypted master secret
+	encrypted := encrypt(secret, passphrase, share)
+	values, err := splitSecret(threshold, count, encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([]string, len(values))
+	for i, value := range values {
+		share.MemberIndex = uint8(i)
+		share.Value = value
+		mnemonics[i] = share.Mnemonic()
+	}
+	return mnemonics, nil
+}
+
+// Combine recovers the master secret from mnemonic shares and decrypts it with
+// the passphrase.
+func Combine(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+
+	var first *Share
+	groups := map[uint8]map[uint8]*Share{}
+	for _, mnemonic := range mnemonics {
+		share, err := ParseShare(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+
+		if first == nil {
+			first = share
+		} else if share.Identifier != first.Identifier || share.Extendable != first.Extendable || share.IterationExponent != first.IterationExponent {
+			return nil, errors.New("the shares do not belong to the same secret")
+		} else if share.GroupThreshold != first.GroupThreshold || share.GroupCount != first.GroupCount || len(share.Value) != len(first.Value) {
+			return nil, errors.New("the shares have inconsistent parameters")
+		}
+
+		group, ok := groups[share.GroupIndex]
+		if !ok {
+			group = map[uint8]*Share{}
+			groups[share.GroupIndex] = group
+		}
+		for _, other := range group {
+			if other.MemberThreshold != share.MemberThreshold {
+				return nil, fmt.Errorf("the shares of group %d have inconsistent thresholds", share.GroupIndex)
+			}
+			break
+		}
+		if other, ok := group[share.MemberIndex]; ok && !hmac.Equal(other.Value, share.Value) {
+			return nil, fmt.Errorf("group %d has conflicting shares with index %d", share.GroupIndex, share.MemberIndex)
+		}
+		group[share.MemberIndex] = share
+	}
+
+	var groupShares []point
+	for index, group := range groups {
+		var threshold int
+		var members []point
+		for _, share := range group {
+			threshold = int(share.MemberThreshold)
+			members = append(members, point{share.MemberIndex, share.Value})
+		}
+		if len(members) < threshold {
+			continue
+		}
+		value, err := recoverSecret(threshold, members)
+		if err != nil {
+			return nil, err
+		}
+		groupShares = append(groupShares, point{index, value})
+	}
+	if len(groupShares) < int(first.GroupThreshold) {
+		return nil, fmt.Errorf("insufficient shares, %d of %d groups are complete", len(groupShares), first.GroupThreshold)
+	}
+
+	encrypted, err := recoverSecret(int(first.GroupThreshold), groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, first), nil
+}
+
+// ParseShare decodes a mnemonic share and verifies its checksum and padding.
+func ParseShare(mnemonic string) (*Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < minMnemonicWords {
+		return nil, fmt.Errorf("invalid share length, a share must have at least %d words", minMnemonicWords)
+	}
+
+	data := make([]uint16, len(words))
+	for i, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, fmt.Errorf("invalid share: %q is not in the wordlist", word)
+		}
+		data[i] = index
+	}
+
+	padding := (radixBits * (len(data) - metadataWords)) % 16
+	if padding > 8 {
+		return nil, errors.New("invalid share length")
+	}
+
+	share := new(Share)
+	idExp := intFromWords(data[:2]).Uint64()
+	share.Identifier = uint16(idExp >> (extendableBits + iterationExpBits))
+	share.Extendable = (idExp>>iterationExpBits)&1 == 1
+	share.IterationExponent = uint8(idExp & (1<<iterationExpBits - 1))
+
+	if polymod(customization(share.Extendable), data) != 1 {
+		return nil, ErrInvalidChecksum
+	}
+
+	params := intFromWords(data[2:4]).Uint64()
+	share.GroupIndex = uint8(params >> 16 & 0xF)
+	share.GroupThreshold = uint8(params>>12&0xF) + 1
+	share.GroupCount = uint8(params>>8&0xF) + 1
+	share.MemberIndex = uint8(params >> 4 & 0xF)
+	share.MemberThreshold = uint8(params&0xF) + 1
+	if share.GroupCount < share.GroupThreshold {
+		return nil, errors.New("invalid share: the group threshold exceeds the number of groups")
+	}
+
+	value := data[4 : len(data)-checksumWords]
+	if value[0] >= 1<<(radixBits-padding) {
+		return nil, errors.New("invalid share padding")
+	}
+	length := (radixBits*len(value) - padding) / 8
+	share.Value = intFromWords(value).FillBytes(make([]byte, length))
+	return share, nil
+}
+
+// Mnemonic encodes the share as a mnemonic.
+func (s *Share) Mnemonic() string {
+	idExp := uint64(s.Identifier)<<(extendableBits+iterationExpBits) | uint64(s.IterationExponent)
+	if s.Extendable {
+		idExp |= 1 << iterationExpBits
+	}
+	params := uint64(s.GroupIndex)<<16 |
+		uint64(s.GroupThreshold-1)<<12 |
+		uint64(s.GroupCount-1)<<8 |
+		uint64(s.MemberIndex)<<4 |
+		uint64(s.MemberThreshold-1)
+
+	valueWords := (len(s.Value)*8 + radixBits - 1) / radixBits
+	var data []uint16
+	data = append(data, wordsFromInt(new(big.Int).SetUint64(idExp), 2)...)
+	data = append(data, wordsFromInt(new(big.Int).SetUint64(params), 2)...)
+	data = append(data, wordsFromInt(new(big.Int).SetBytes(s.Value), valueWords)...)
+	data = append(data, checksum(customization(s.Extendable), data)...)
+
+	words := make([]string, len(data))
+	for i, index := range data {
+		words[i] = wordlist[index]
+	}
+	return strings.Join(words, " ")
+}
+
+var wordIndex = func() map[string]uint16 {
+	m := make(map[string]uint16, len(wordlist))
+	for i, word := range wordlist {
+		m[word] = uint16(i)
+	}
+	return m
+}()
+
+func intFromWords(data []uint16) *big.Int {
+	v := new(big.Int)
+	for _, d := range data {
+		v.Lsh(v, radixBits)
+		v.Or(v, big.NewInt(int64(d)))
+	}
+	return v
+}
+
+func wordsFromInt(v *big.Int, count int) []uint16 {
+	data := make([]uint16, count)
+	v = new(big.Int).Set(v)
+	mask := big.NewInt(1<<radixBits - 1)
+	for i := count - 1; i >= 0; i-- {
+		data[i] = uint16(new(big.Int).And(v, mask).Uint64())
+		v.Rsh(v, radixBits)
+	}
+	return data
+}
+
+func customization(extendable bool) string {
+	if extendable {
+		return "shamir_extendable"
+	}
+	return "shamir"
+}
+
+// polymod computes the RS1024 checksum polynomial.
+func polymod(customization string, data []uint16) uint32 {
+	gen := [10]uint32{
+		0xE0E040, 0x1C1C080, 0x3838100, 0x7070200, 0xE0E0009,
+		0x1C0C2412, 0x38086C24, 0x3090FC48, 0x21B1F890, 0x3F3F120,
+	}
+
+	chk := uint32(1)
+	step := func(v uint32) {
+		b := chk >> 20
+		chk = (chk&0xFFFFF)<<10 ^ v
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	for _, c := range []byte(customization) {
+		step(uint32(c))
+	}
+	for _, v := range data {
+		step(uint32(v))
+	}
+	return chk
+}
+
+func checksum(customization string, data []uint16) []uint16 {
+	values := append(append([]uint16{}, data...), 0, 0, 0)
+	mod := polymod(customization, values) ^ 1
+	return []uint16{
+		uint16(mod >> 20 & 1023),
+		uint16(mod >> 10 & 1023),
+		uint16(mod & 1023),
+	}
+}
+
+// encrypt encrypts the master secret with a four round Feistel network.
+func encrypt(secret, passphrase []byte, s *Share) []byte {
+	l, r := secret[:len(secret)/2], secret[len(secret)/2:]
+	for i := 0; i < roundCount; i++ {
+		l, r = r, xor(l, roundFunction(byte(i), passphrase, s, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+// decrypt reverses encrypt.
+func decrypt(encrypted, passphrase []byte, s *Share) []byte {
+	l, r := encrypted[:len(encrypted)/2], encrypted[len(encrypted)/2:]
+	for i := roundCount - 1; i >= 0; i-- {
+		l, r = r, xor(l, roundFunction(byte(i), passphrase, s, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+func roundFunction(i byte, passphrase []byte, s *Share, r []byte) []byte {
+	var salt []byte
+	if !s.Extendable {
+		salt = []byte("shamir")
+		salt = append(salt, byte(s.Identifier>>8), byte(s.Identifier))
+	}
+	password := append([]byte{i}, passphrase...)
+	iterations := (baseIterationCount << s.IterationExponent) / roundCount
+	return pbkdf2.Key(password, append(salt, r...), iterations, len(r), sha256.New)
+}
+
+func xor(a, b []byte) []byte {
+	c := make([]byte, len(a))
+	for i := range a {
+		c[i] = a[i] ^ b[i]
+	}
+	return c
+}
+
+func digest(random, secret []byte) []byte {
+	mac := hmac.New(sha256.New, random)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// splitSecret splits the secret into count shares. Unless the threshold is 1,
+// the shares include a digest of the secret that is verified when the secret
+// is recovered.
+func splitSecret(threshold, count int, secret []byte) ([][]byte, error) {
+	shares := make([][]byte, count)
+	if threshold == 1 {
+		for i := range shares {
+			shares[i] = secret
+		}
+		return shares, nil
+	}
+
+	var base []point
+	for i := 0; i < threshold-2; i++ {
+		shares[i] = make([]byte, len(secret))
+		_, err := rand.Read(shares[i])
+		if err != nil {
+			return nil, err
+		}
+		base = append(base, point{uint8(i), shares[i]})
+	}
+
+	random := make([]byte, len(secret)-digestLength)
+	_, err := rand.Read(random)
+	if err != nil {
+		return nil, err
+	}
+	base = append(base,
+		point{digestIndex, append(digest(random, secret), random...)},
+		point{secretIndex, secret})
+
+	for i := threshold - 2; i < count; i++ {
+		shares[i] = interpolate(base, uint8(i))
+	}
+	return shares, nil
+}
+
+// recoverSecret recovers the secret from threshold shares and verifies its
+// digest.
+func recoverSecret(threshold int, shares []point) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].y, nil
+	}
+
+	secret := interpolate(shares, secretIndex)
+	d := interpolate(shares, digestIndex)
+	if !hmac.Equal(d[:digestLength], digest(d[digestLength:], secret)) {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
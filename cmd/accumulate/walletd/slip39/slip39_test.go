@@ -0,0 +1,76 @@
+package slip39
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from the SLIP-39 specification
+func TestVectors(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Mnemonics []string
+		Secret    string
+	}{
+		{"Without sharing", []string{
+			"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard",
+		}, "bb54aac4b89dc868ba37d9cc21b2cece"},
+		{"2-of-3", []string{
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+			"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+		}, "b43ceb7e57a0ea8766221624d01b0864"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			secret, err := Combine(c.Mnemonics, []byte("TREZOR"))
+			require.NoError(t, err)
+			require.Equal(t, c.Secret, hex.EncodeToString(secret))
+		})
+	}
+
+	_, err := ParseShare("duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney")
+	require.ErrorIs(t, err, ErrInvalidChecksum)
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := make([]byte, 32)
+	for i := range secret {
+		secret[i] = byte(i)
+	}
+
+	shares, err := Split(secret, nil, 3, 5)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		var mnemonics []string
+		for _, i := range subset {
+			mnemonics = append(mnemonics, shares[i])
+		}
+		recovered, err := Combine(mnemonics, nil)
+		require.NoError(t, err)
+		require.Equal(t, secret, recovered)
+	}
+
+	_, err = Combine(shares[:2], nil)
+	require.Error(t, err, "two shares are not enough")
+
+	recovered, err := Combine(shares[:3], []byte("wrong"))
+	require.NoError(t, err)
+	require.NotEqual(t, secret, recovered, "a different passphrase yields a different secret")
+
+	// A share with a valid checksum but a corrupted value fails the digest
+	// check
+	share, err := ParseShare(shares[0])
+	require.NoError(t, err)
+	share.Value[0]++
+	_, err = Combine([]string{share.Mnemonic(), shares[1], shares[2]}, nil)
+	require.ErrorIs(t, err, ErrInvalidDigest)
+
+	_, err = Split(secret, nil, 1, 2)
+	require.Error(t, err)
+	_, err = Split(secret[:15], nil, 2, 3)
+	require.Error(t, err)
+}
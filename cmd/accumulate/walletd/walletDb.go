@@ -12,7 +12,7 @@ import (
 
 func GetWallet() db.DB {
 	if wallet == nil {
-		wallet = initDB(DatabaseDir, false)
+		wallet = initDB(WalletDir(WalletName), false)
 		//upon first use, make sure database format is up-to-date.
 		if !NoWalletVersionCheck {
 			out, err := RestoreAccounts()
@@ -38,12 +38,43 @@ var (
 	BucketKeyInfo           = []byte("keyinfo")
 	BucketSigTypeDeprecated = []byte("sigtype")
 	BucketTransactionCache  = []byte("TransactionCache")
+	BucketPst               = []byte("pst")
+	BucketWatchAccount      = []byte("watchaccount")
+	BucketWatchKey          = []byte("watchkey")
+	BucketAddressBook       = []byte("addressbook")
+	BucketHistory           = []byte("history")
+	BucketHistorySync       = []byte("historysync")
+	BucketApiToken          = []byte("apitoken")
+	BucketApiTokenUsage     = []byte("apitokenusage")
+	BucketSigningPolicy     = []byte("signingpolicy")
 )
+
+// encryptedBuckets are the buckets copied from an unencrypted wallet when the
+// wallet is encrypted.
+var encryptedBuckets = [][]byte{
+	BucketLabel,
+	BucketAdi,
+	BucketKeys,
+	BucketLite,
+	BucketMnemonic,
+	BucketKeyInfo,
+	BucketPst,
+	BucketWatchAccount,
+	BucketWatchKey,
+	BucketAddressBook,
+	BucketHistory,
+	BucketHistorySync,
+	BucketApiToken,
+	BucketApiTokenUsage,
+	BucketSigningPolicy,
+}
+
 var (
 	UseUnencryptedWallet bool
 	wallet               db.DB
 	Password             string
 	DatabaseDir          string
+	WalletName           string
 	NoWalletVersionCheck bool
 	Entropy              uint
 )
@@ -0,0 +1,105 @@
+package walletd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+)
+
+// DefaultWalletName is the name of the wallet that is stored directly in the
+// database directory.
+const DefaultWalletName = "default"
+
+var walletNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidateWalletName returns an error if the name cannot be used as a wallet
+// name.
+func ValidateWalletName(name string) error {
+	if name == "" || walletNameRegexp.MatchString(name) {
+		return nil
+	}
+	return fmt.Errorf("invalid wallet name %q, a wallet name may only contain letters, numbers, dashes, and underscores", name)
+}
+
+// WalletDir returns the directory of the named wallet. The default wallet is
+// stored in the database directory and every other wallet is stored in its own
+// directory under the wallets directory, with its own seed and password.
+func WalletDir(name string) string {
+	if name == "" || name == DefaultWalletName {
+		return DatabaseDir
+	}
+	return filepath.Join(DatabaseDir, "wallets", name)
+}
+
+// walletFile returns the database file of the wallet in the given directory,
+// preferring the encrypted database, and whether it is encrypted.
+func walletFile(dir string) (string, bool, error) {
+	file := filepath.Join(dir, "wallet_encrypted.db")
+	if _, err := os.Stat(file); err == nil {
+		return file, true, nil
+	}
+
+	file = filepath.Join(dir, "wallet.db")
+	if _, err := os.Stat(file); err == nil {
+		return file, false, nil
+	}
+
+	return "", false, os.ErrNotExist
+}
+
+// ListWallets returns the names of the wallets in the database directory.
+func ListWallets() ([]string, error) {
+	var names []string
+	if _, _, err := walletFile(DatabaseDir); err == nil {
+		names = append(names, DefaultWalletName)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(DatabaseDir, "wallets"))
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return names, nil
+	default:
+		return nil, err
+	}
+
+	var named []string
+	for _, entry := range entries {
+		if !entry.IsDir() || ValidateWalletName(entry.Name()) != nil {
+			continue
+		}
+		if _, _, err := walletFile(WalletDir(entry.Name())); err == nil {
+			named = append(named, entry.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(names, named...), nil
+}
+
+// OpenWallet opens an existing named wallet without prompting for a password.
+func OpenWallet(name, password string) (db.DB, error) {
+	err := ValidateWalletName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, encrypted, err := walletFile(WalletDir(name))
+	if err != nil {
+		return nil, fmt.Errorf("wallet %q does not exist", name)
+	}
+	if !encrypted {
+		password = ""
+	}
+
+	w := new(db.BoltDB)
+	err = w.InitDB(file, password)
+	if err != nil && err != db.ErrDatabaseNotEncrypted {
+		return nil, err
+	}
+	return w, nil
+}
@@ -0,0 +1,85 @@
+package walletd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestNamedWallets(t *testing.T) {
+	dir, unencrypted := DatabaseDir, UseUnencryptedWallet
+	t.Cleanup(func() { DatabaseDir, UseUnencryptedWallet = dir, unencrypted })
+	DatabaseDir, UseUnencryptedWallet = t.TempDir(), true
+
+	require.Error(t, ValidateWalletName("../treasury"))
+	require.Equal(t, DatabaseDir, WalletDir(DefaultWalletName))
+	require.Equal(t, filepath.Join(DatabaseDir, "wallets", "ops"), WalletDir("ops"))
+
+	for _, name := range []string{"ops", "treasury"} {
+		w := initDB(WalletDir(name), false)
+		require.NoError(t, w.Close())
+	}
+	names, err := ListWallets()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ops", "treasury"}, names)
+
+	w, err := OpenWallet("ops", "")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	_, err = OpenWallet("test", "")
+	require.Error(t, err)
+}
+
+func TestAccountSeparation(t *testing.T) {
+	InitTestDB(t)
+	_, err := ImportMnemonic(strings.Fields("yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow"))
+	require.NoError(t, err)
+
+	k0, err := GenerateKeyForAccount(protocol.SignatureTypeED25519, 0)
+	require.NoError(t, err)
+	k1, err := GenerateKeyForAccount(protocol.SignatureTypeED25519, 1)
+	require.NoError(t, err)
+	k2, err := GenerateKeyForAccount(protocol.SignatureTypeED25519, 1)
+	require.NoError(t, err)
+
+	require.Equal(t, "m/44'/281'/0'/0/0", k0.KeyInfo.Derivation)
+	require.Equal(t, "m/44'/281'/1'/0/0", k1.KeyInfo.Derivation)
+	require.Equal(t, "m/44'/281'/1'/0/1", k2.KeyInfo.Derivation)
+	require.NotEqual(t, k0.PublicKey, k1.PublicKey)
+
+	typ, account, ok := ParseDerivationCounterKey(DerivationCounterKey(protocol.SignatureTypeED25519, 1))
+	require.True(t, ok)
+	require.Equal(t, protocol.SignatureTypeED25519, typ)
+	require.Equal(t, uint32(1), account)
+
+	_, err = GetWallet().Get(BucketMnemonic, []byte("ed25519"))
+	require.NotEqual(t, db.ErrNotFound, err, "the first account uses the legacy counter")
+}
+
+func TestWalletParameter(t *testing.T) {
+	InitTestDB(t)
+	m, err := NewJrpc(Options{Unauthenticated: true})
+	require.NoError(t, err)
+
+	ops := initDB("", true)
+	require.NoError(t, ops.Put(BucketAdi, []byte("acc://ops.acme"), nil))
+	m.wallets = map[string]db.DB{"ops": ops}
+
+	// The request runs against the named wallet
+	params, err := json.Marshal(map[string]string{"wallet": "ops"})
+	require.NoError(t, err)
+	resp, ok := m.methods["adi-list"](context.Background(), params).(api.AdiListResponse)
+	require.True(t, ok)
+	require.Equal(t, []string{"acc://ops.acme"}, resp.Urls)
+
+	// The default wallet is not affected
+	_, ok = m.methods["adi-list"](context.Background(), nil).(api.AdiListResponse)
+	require.False(t, ok, "the default wallet has no ADIs")
+}
@@ -0,0 +1,196 @@
+package walletd
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/db"
+	"gitlab.com/accumulatenetwork/accumulate/cmd/accumulate/walletd/api"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// WatchAccount adds an account the user does not control to the wallet.
+func WatchAccount(u *url.URL, label string) error {
+	data, err := (&api.WatchedAccount{Url: u, Label: label}).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return GetWallet().Put(BucketWatchAccount, []byte(u.String()), data)
+}
+
+// UnwatchAccount removes a watched account from the wallet.
+func UnwatchAccount(u *url.URL) error {
+	_, err := GetWallet().Get(BucketWatchAccount, []byte(u.String()))
+	if err != nil {
+		return fmt.Errorf("%v is not watched", u)
+	}
+	return GetWallet().Delete(BucketWatchAccount, []byte(u.String()))
+}
+
+// ListWatchedAccounts returns the watched accounts.
+func ListWatchedAccounts() ([]*api.WatchedAccount, error) {
+	b, err := GetWallet().GetBucket(BucketWatchAccount)
+	if err != nil {
+		if err == db.ErrNoBucket {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var accounts []*api.WatchedAccount
+	for _, v := range b.KeyValueList {
+		account := new(api.WatchedAccount)
+		err = account.UnmarshalBinary(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// WatchKey adds a public key the user does not control to the wallet.
+func WatchKey(publicKey []byte, typ protocol.SignatureType, label string) error {
+	switch typ {
+	case protocol.SignatureTypeED25519, protocol.SignatureTypeRCD1,
+		protocol.SignatureTypeBTC, protocol.SignatureTypeBTCLegacy,
+		protocol.SignatureTypeETH:
+	default:
+		return fmt.Errorf("unsupported key type %v", typ)
+	}
+
+	data, err := (&api.WatchedKey{PublicKey: publicKey, Type: typ, Label: label}).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return GetWallet().Put(BucketWatchKey, publicKey, data)
+}
+
+// UnwatchKey removes a watched public key from the wallet.
+func UnwatchKey(publicKey []byte) error {
+	_, err := GetWallet().Get(BucketWatchKey, publicKey)
+	if err != nil {
+		return fmt.Errorf("key %x is not watched", publicKey)
+	}
+	return GetWallet().Delete(BucketWatchKey, publicKey)
+}
+
+// ListWatchedKeys returns the watched public keys.
+func ListWatchedKeys() ([]*api.WatchedKey, error) {
+	b, err := GetWallet().GetBucket(BucketWatchKey)
+	if err != nil {
+		if err == db.ErrNoBucket {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var keys []*api.WatchedKey
+	for _, v := range b.KeyValueList {
+		key := new(api.WatchedKey)
+		err = key.UnmarshalBinary(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// WatchedKeyLiteTokenAccount returns the ACME lite token account of a watched
+// public key.
+func WatchedKeyLiteTokenAccount(key *api.WatchedKey) (*url.URL, error) {
+	k := new(Key)
+	k.PublicKey = key.PublicKey
+	k.KeyInfo.Type = key.Type
+	return protocol.LiteTokenAddressFromHash(k.PublicKeyHash(), protocol.ACME)
+}
+
+// AddAddress adds a labeled address to the address book, replacing any
+// existing address with the same label.
+func AddAddress(label string, u *url.URL) error {
+	if !isAddressLabel(label) {
+		return fmt.Errorf("invalid address book label %q", label)
+	}
+
+	data, err := (&api.AddressBookEntry{Label: label, Url: u}).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return GetWallet().Put(BucketAddressBook, []byte(label), data)
+}
+
+// RemoveAddress removes a labeled address from the address book.
+func RemoveAddress(label string) error {
+	_, err := LookupAddress(label)
+	if err != nil {
+		return err
+	}
+	return GetWallet().Delete(BucketAddressBook, []byte(label))
+}
+
+// LookupAddress returns the address with the given label.
+func LookupAddress(label string) (*url.URL, error) {
+	data, err := GetWallet().Get(BucketAddressBook, []byte(label))
+	if err != nil {
+		return nil, fmt.Errorf("address book label %q not found", label)
+	}
+
+	entry := new(api.AddressBookEntry)
+	err = entry.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Url, nil
+}
+
+// ListAddresses returns the entries of the address book.
+func ListAddresses() ([]*api.AddressBookEntry, error) {
+	b, err := GetWallet().GetBucket(BucketAddressBook)
+	if err != nil {
+		if err == db.ErrNoBucket {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []*api.AddressBookEntry
+	for _, v := range b.KeyValueList {
+		entry := new(api.AddressBookEntry)
+		err = entry.UnmarshalBinary(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ResolveAddress returns the address book entry if s is a label in the address
+// book, otherwise it parses s as a URL.
+func ResolveAddress(s string) (*url.URL, error) {
+	if isAddressLabel(s) {
+		u, err := LookupAddress(s)
+		if err == nil {
+			return u, nil
+		}
+	}
+	return url.Parse(s)
+}
+
+// isAddressLabel returns false if s could be mistaken for an account URL. A
+// label cannot contain URL punctuation or a '.', which would make it look like
+// an ADI such as foo.acme, and cannot be a long hex string, which would make it
+// look like a lite address.
+func isAddressLabel(s string) bool {
+	if s == "" || strings.ContainsAny(s, "/:@.") {
+		return false
+	}
+
+	// A lite identity is at least 40 hex characters (a 20 byte key hash)
+	if len(s) < 40 {
+		return true
+	}
+	return strings.Trim(s, "0123456789abcdefABCDEF") != ""
+}
@@ -0,0 +1,69 @@
+package walletd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestWatchOnly(t *testing.T) {
+	InitTestDB(t)
+
+	bob := url.MustParse("bob/tokens")
+	require.NoError(t, WatchAccount(bob, "bob"))
+	accounts, err := ListWatchedAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.True(t, accounts[0].Url.Equal(bob))
+
+	key := newTestKey("charlie")
+	require.NoError(t, WatchKey(key.PublicKey, protocol.SignatureTypeED25519, "charlie"))
+	require.Error(t, WatchKey(key.PublicKey, protocol.SignatureTypeUnknown, ""))
+	keys, err := ListWatchedKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	lta, err := WatchedKeyLiteTokenAccount(keys[0])
+	require.NoError(t, err)
+	expected, err := protocol.LiteTokenAddress(key.PublicKey, protocol.ACME, protocol.SignatureTypeED25519)
+	require.NoError(t, err)
+	require.True(t, lta.Equal(expected))
+
+	require.NoError(t, UnwatchAccount(bob))
+	require.Error(t, UnwatchAccount(bob))
+	require.NoError(t, UnwatchKey(key.PublicKey))
+	keys, err = ListWatchedKeys()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestAddressBook(t *testing.T) {
+	InitTestDB(t)
+
+	bob := url.MustParse("bob/tokens")
+	require.NoError(t, AddAddress("bob", bob))
+	require.Error(t, AddAddress("bob/tokens", bob), "labels that look like URLs are rejected")
+	require.Error(t, AddAddress("bob.acme", bob), "labels that look like ADIs are rejected")
+	require.Error(t, AddAddress("8b5fa5f6b7e4ec7a3d7c7e1ff6d4c9e8d1e63d8e9e5b3c7a", bob), "labels that look like lite addresses are rejected")
+	require.NoError(t, AddAddress("deadbeef", bob), "short hex labels are allowed")
+	require.NoError(t, RemoveAddress("deadbeef"))
+
+	u, err := ResolveAddress("bob")
+	require.NoError(t, err)
+	require.True(t, u.Equal(bob))
+
+	// Anything that is not a label is parsed as a URL
+	u, err = ResolveAddress("alice/tokens")
+	require.NoError(t, err)
+	require.Equal(t, "acc://alice/tokens", u.String())
+	u, err = ResolveAddress("alice")
+	require.NoError(t, err)
+	require.Equal(t, "acc://alice", u.String())
+
+	require.NoError(t, RemoveAddress("bob"))
+	require.Error(t, RemoveAddress("bob"))
+	entries, err := ListAddresses()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
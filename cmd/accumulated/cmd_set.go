@@ -27,6 +27,7 @@ func init() {
 		cmdSetOracle,
 		cmdSetSchedule,
 		cmdSetAnchorEmptyBlocks,
+		cmdSetExternalAnchors,
 		cmdSetRouting,
 	)
 
@@ -87,6 +88,20 @@ var cmdSetAnchorEmptyBlocks = &cobra.Command{
 	},
 }
 
+var cmdSetExternalAnchors = &cobra.Command{
+	Use:   "external-anchors [true|false]",
+	Short: "Set whether major blocks are anchored externally",
+	Args:  cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		value, err := strconv.ParseBool(args[0])
+		checkf(err, "value is invalid")
+
+		setNetworkValue(protocol.Globals, func(v *core.GlobalValues) {
+			v.Globals.EnableExternalAnchors = value
+		})
+	},
+}
+
 var cmdSetRouting = &cobra.Command{
 	Use:   "routing [table]",
 	Short: "Set the routing table",
@@ -141,6 +141,8 @@ func Default(netName string, net NetworkType, node NodeType, partitionId string)
 	c.Accumulate.AnalysisLog.Enabled = false
 	c.Accumulate.API.ReadHeaderTimeout = 10 * time.Second
 	c.Accumulate.BatchReplayLimit = 500
+	c.Accumulate.Mempool.MaxPendingPerPrincipal = 100
+	c.Accumulate.Mempool.MaxPendingPerSigner = 100
 	// c.Accumulate.Snapshots.Frequency = 2
 	switch node {
 	default:
@@ -171,6 +173,12 @@ type Accumulate struct {
 	Storage     Storage     `toml:"storage" mapstructure:"storage"`
 	API         API         `toml:"api" mapstructure:"api"`
 	AnalysisLog AnalysisLog `toml:"analysis" mapstructure:"analysis"`
+	Mempool     Mempool     `toml:"mempool" mapstructure:"mempool"`
+
+	// AnchorSink is the file or HTTP URL of the sink the directory network's
+	// major blocks are anchored in. If empty, major blocks are not anchored
+	// externally.
+	AnchorSink string `toml:"anchor-sink" mapstructure:"anchor-sink"`
 }
 
 type Snapshots struct {
@@ -185,6 +193,17 @@ type Snapshots struct {
 	// Frequency int `toml:"frequency" mapstructure:"frequency"`
 }
 
+type Mempool struct {
+	// MaxPendingPerPrincipal is the maximum number of pending transactions a
+	// single principal may have in the mempool. Zero disables the limit. The
+	// limits rely on Tendermint rechecking the mempool after each block.
+	MaxPendingPerPrincipal int `toml:"max-pending-per-principal" mapstructure:"max-pending-per-principal"`
+
+	// MaxPendingPerSigner is the maximum number of pending transactions a
+	// single signer may have in the mempool. Zero disables the limit.
+	MaxPendingPerSigner int `toml:"max-pending-per-signer" mapstructure:"max-pending-per-signer"`
+}
+
 type AnalysisLog struct {
 	Directory  string `toml:"directory" mapstructure:"directory"`
 	Enabled    bool   `toml:"enabled" mapstructure:"enabled"`
@@ -45,6 +45,7 @@ type Accumulator struct {
 	lastSnapshot   uint64
 	checkTxBatch   *database.Batch
 	checkTxMutex   *sync.Mutex
+	pending        *pendingLimiter
 	pendingUpdates abci.ValidatorUpdates
 	startTime      time.Time
 
@@ -66,6 +67,7 @@ func NewAccumulator(opts AccumulatorOptions) *Accumulator {
 		AccumulatorOptions: opts,
 		logger:             opts.Logger.With("module", "accumulate", "partition", opts.Accumulate.PartitionId),
 		checkTxMutex:       &sync.Mutex{},
+		pending:            newPendingLimiter(opts.Accumulate.Mempool),
 	}
 
 	events.SubscribeSync(opts.EventBus, app.willChangeGlobals)
@@ -76,6 +78,14 @@ func NewAccumulator(opts AccumulatorOptions) *Accumulator {
 		atomic.StoreUint64(&app.lastSnapshot, e.MinorIndex)
 	})
 
+	// The pending transaction counts are rebuilt by rechecking the mempool
+	// after each commit, so without recheck the limits are not enforced
+	// across blocks
+	if opts.Config.Mempool != nil && !opts.Config.Mempool.Recheck &&
+		(opts.Accumulate.Mempool.MaxPendingPerPrincipal > 0 || opts.Accumulate.Mempool.MaxPendingPerSigner > 0) {
+		app.logger.Error("Mempool recheck is disabled, pending transaction limits will only apply within a single block")
+	}
+
 	app.logger.Info("Starting ABCI application", "accumulate", accumulate.Version, "abci", Version)
 	return app
 }
@@ -361,7 +371,10 @@ func (app *Accumulator) CheckTx(req abci.RequestCheckTx) (rct abci.ResponseCheck
 		defer batch.Discard()
 	}
 
-	envelopes, results, respData, err := executeTransactions(app.logger.With("operation", "CheckTx"), checkTx(app.Executor, batch), req.Tx)
+	// Enforce the pending transaction limits for new transactions. Rechecked
+	// transactions are already in the mempool so they are only counted.
+	execute := app.pending.wrap(checkTx(app.Executor, batch), req.Type == abci.CheckTxType_New)
+	envelopes, results, respData, err := executeTransactions(app.logger.With("operation", "CheckTx"), execute, req.Tx)
 	if err != nil {
 		b, _ := errors.Wrap(errors.StatusUnknownError, err).(*errors.Error).MarshalJSON()
 		var res abci.ResponseCheckTx
@@ -514,6 +527,11 @@ func (app *Accumulator) Commit() abci.ResponseCommit {
 	}
 	app.checkTxBatch = app.DB.Begin(false)
 
+	// Tendermint will recheck the transactions remaining in the mempool, which
+	// rebuilds the pending counts. This depends on recheck being enabled and
+	// on Tendermint calling Commit before it rechecks.
+	app.pending.reset()
+
 	// Notify the executor that we committed
 	var resp abci.ResponseCommit
 	batch := app.DB.Begin(false)
@@ -0,0 +1,141 @@
+package abci
+
+import (
+	"sync"
+
+	"gitlab.com/accumulatenetwork/accumulate/config"
+	"gitlab.com/accumulatenetwork/accumulate/internal/chain"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// pendingLimiter bounds the number of transactions a single principal or
+// signer can have in the mempool.
+//
+// Tendermint does not notify the application when a transaction leaves the
+// mempool. Instead the counts are reset when a block is committed and rebuilt
+// as Tendermint rechecks the transactions that remain in the mempool. The
+// counts are only correct because Tendermint locks the mempool and calls Commit
+// before it rechecks, so reset always happens before the recheck. If recheck
+// is disabled (mempool.recheck = false), the counts only cover the
+// transactions received since the last block.
+type pendingLimiter struct {
+	config.Mempool
+	mu         sync.Mutex
+	principals map[[32]byte]int
+	signers    map[[32]byte]int
+}
+
+func newPendingLimiter(cfg config.Mempool) *pendingLimiter {
+	l := new(pendingLimiter)
+	l.Mempool = cfg
+	l.reset()
+	return l
+}
+
+// reset clears the pending counts.
+func (l *pendingLimiter) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.principals = map[[32]byte]int{}
+	l.signers = map[[32]byte]int{}
+}
+
+// wrap returns an executeFunc that counts the user transactions accepted by
+// execute. If enforce is true, transactions that would push a principal or
+// signer over its limit are marked as failed with StatusTooManyPending.
+//
+// If any user transaction fails, Tendermint will reject the entire envelope,
+// so nothing is counted.
+func (l *pendingLimiter) wrap(execute executeFunc, enforce bool) executeFunc {
+	return func(deliveries []*chain.Delivery) []*protocol.TransactionStatus {
+		results := execute(deliveries)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		principals := map[[32]byte]int{}
+		signers := map[[32]byte]int{}
+		rejected := false
+		for i, delivery := range deliveries {
+			if !delivery.Transaction.Body.Type().IsUser() {
+				continue
+			}
+			if !results[i].Code.Success() {
+				rejected = true
+				continue
+			}
+
+			principal, signerUrls := pendingKeys(delivery)
+			if enforce {
+				err := l.check(principal, signerUrls, principals, signers)
+				if err != nil {
+					results[i].Set(err)
+					rejected = true
+					continue
+				}
+			}
+
+			if principal != nil {
+				principals[principal.AccountID32()]++
+			}
+			for _, signer := range signerUrls {
+				signers[signer.AccountID32()]++
+			}
+		}
+
+		if rejected {
+			return results
+		}
+
+		for id, n := range principals {
+			l.principals[id] += n
+		}
+		for id, n := range signers {
+			l.signers[id] += n
+		}
+		return results
+	}
+}
+
+// check returns an error if adding a transaction for the given principal and
+// signers would exceed a limit. The counts from the current envelope are
+// included.
+func (l *pendingLimiter) check(principal *url.URL, signers []*url.URL, envPrincipals, envSigners map[[32]byte]int) error {
+	if principal != nil && l.MaxPendingPerPrincipal > 0 {
+		id := principal.AccountID32()
+		if l.principals[id]+envPrincipals[id] >= l.MaxPendingPerPrincipal {
+			return errors.Format(errors.StatusTooManyPending, "principal %v has too many pending transactions (limit %d)", principal, l.MaxPendingPerPrincipal)
+		}
+	}
+
+	if l.MaxPendingPerSigner <= 0 {
+		return nil
+	}
+	for _, signer := range signers {
+		id := signer.AccountID32()
+		if l.signers[id]+envSigners[id] >= l.MaxPendingPerSigner {
+			return errors.Format(errors.StatusTooManyPending, "signer %v has too many pending transactions (limit %d)", signer, l.MaxPendingPerSigner)
+		}
+	}
+	return nil
+}
+
+// pendingKeys returns the principal and the distinct signers of a delivery.
+func pendingKeys(delivery *chain.Delivery) (*url.URL, []*url.URL) {
+	var signers []*url.URL
+	seen := map[[32]byte]bool{}
+	for _, sig := range delivery.Signatures {
+		if sig.Type().IsSystem() {
+			continue
+		}
+		signer := sig.GetSigner()
+		if signer == nil || seen[signer.AccountID32()] {
+			continue
+		}
+		seen[signer.AccountID32()] = true
+		signers = append(signers, signer)
+	}
+	return delivery.Transaction.Header.Principal, signers
+}
@@ -0,0 +1,54 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/config"
+	"gitlab.com/accumulatenetwork/accumulate/internal/chain"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestPendingLimiter(t *testing.T) {
+	okExecute := func(deliveries []*chain.Delivery) []*protocol.TransactionStatus {
+		results := make([]*protocol.TransactionStatus, len(deliveries))
+		for i := range results {
+			results[i] = &protocol.TransactionStatus{Code: errors.StatusOK}
+		}
+		return results
+	}
+
+	delivery := func(principal, signer string) []*chain.Delivery {
+		txn := new(protocol.Transaction)
+		txn.Header.Principal = url.MustParse(principal)
+		txn.Body = new(protocol.SendTokens)
+		sig := new(protocol.ED25519Signature)
+		sig.Signer = url.MustParse(signer)
+		return []*chain.Delivery{{Transaction: txn, Signatures: []protocol.Signature{sig}}}
+	}
+
+	l := newPendingLimiter(config.Mempool{MaxPendingPerPrincipal: 2, MaxPendingPerSigner: 3})
+	check := l.wrap(okExecute, true)
+	recheck := l.wrap(okExecute, false)
+
+	// The principal limit is reached after two transactions
+	require.Equal(t, errors.StatusOK, check(delivery("foo/tokens", "foo/book/1"))[0].Code)
+	require.Equal(t, errors.StatusOK, check(delivery("FOO/tokens", "foo/book/1"))[0].Code)
+	require.Equal(t, errors.StatusTooManyPending, check(delivery("foo/tokens", "foo/book/1"))[0].Code)
+
+	// The signer limit is reached after three transactions
+	require.Equal(t, errors.StatusOK, check(delivery("foo/data", "foo/book/1"))[0].Code)
+	require.Equal(t, errors.StatusTooManyPending, check(delivery("foo/other", "foo/book/1"))[0].Code)
+
+	// Other signers are not affected
+	require.Equal(t, errors.StatusOK, check(delivery("bar/tokens", "bar/book/1"))[0].Code)
+
+	// Resetting and rechecking rebuilds the counts without enforcing the limit
+	l.reset()
+	for i := 0; i < 3; i++ {
+		require.Equal(t, errors.StatusOK, recheck(delivery("foo/tokens", "foo/book/1"))[0].Code)
+	}
+	require.Equal(t, errors.StatusTooManyPending, check(delivery("foo/tokens", "foo/book/1"))[0].Code)
+}
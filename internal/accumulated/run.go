@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	tmnode "github.com/tendermint/tendermint/node"
 	"io"
 	"net"
 	"net/http"
@@ -159,13 +160,20 @@ func (d *Daemon) Start() (err error) {
 		Describe:         d.Config.Accumulate.Describe,
 		Router:           router,
 		EventBus:         d.eventBus,
-		IsFollower:       d.Config.Mode != "validator",
+		IsFollower:       false,
 		BatchReplayLimit: d.Config.Accumulate.BatchReplayLimit,
 	}
 
 	// On DNs initialize the major block scheduler
 	if execOpts.Describe.NetworkType == config.Directory {
 		execOpts.MajorBlockScheduler = blockscheduler.Init(execOpts.EventBus)
+
+		if d.Config.Accumulate.AnchorSink != "" {
+			execOpts.AnchorSink, err = block.NewAnchorSink(d.Config.Accumulate.AnchorSink)
+			if err != nil {
+				return fmt.Errorf("failed to initialize anchor sink: %v", err)
+			}
+		}
 	}
 
 	exec, err := block.NewNodeExecutor(execOpts, d.db)
@@ -199,7 +207,6 @@ func (d *Daemon) Start() (err error) {
 	defer func() {
 		if err != nil {
 			_ = d.node.Stop()
-			d.node.Wait()
 		}
 	}()
 
@@ -215,14 +222,11 @@ func (d *Daemon) Start() (err error) {
 	})
 
 	// Create a local client
-	lnode, ok := d.node.Service.(local.NodeService)
+	lnode, ok := d.node.Service.(*tmnode.Node)
 	if !ok {
 		return fmt.Errorf("node is not a local node service")
 	}
-	d.localTm, err = local.New(lnode)
-	if err != nil {
-		return fmt.Errorf("failed to create local node client: %v", err)
-	}
+	d.localTm = local.New(lnode)
 
 	if d.Config.Accumulate.API.DebugJSONRPC {
 		jsonrpc2.DebugMethodFunc = true
@@ -238,6 +242,7 @@ func (d *Daemon) Start() (err error) {
 		Database:          d.db,
 		ConnectionManager: d.connectionManager,
 		Key:               d.Key().Bytes(),
+		Simulator:         exec,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to start API: %v", err)
@@ -293,8 +298,6 @@ func (d *Daemon) Start() (err error) {
 	go func() {
 		defer close(d.done)
 
-		d.node.Wait()
-
 		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(5*time.Second))
 		defer cancel()
 
@@ -10,6 +10,7 @@ import (
 	"gitlab.com/accumulatenetwork/accumulate/internal/database"
 	"gitlab.com/accumulatenetwork/accumulate/internal/routing"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
 )
 
 //go:generate go run ../../../tools/cmd/gen-types --package api types.yml
@@ -25,6 +26,13 @@ type Options struct {
 	Database          database.Beginner
 	ConnectionManager connections.ConnectionManager
 	Key               []byte
+	Simulator         Simulator
+}
+
+// Simulator executes an envelope without committing the changes.
+// block.Executor implements Simulator.
+type Simulator interface {
+	Simulate(*protocol.Envelope) (*protocol.SimulateResponse, error)
 }
 
 func (o *Options) loadGlobals() (*core.GlobalValues, error) {
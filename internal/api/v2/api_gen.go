@@ -12,10 +12,11 @@ import (
 
 func (m *JrpcMethods) populateMethodTable() jsonrpc2.MethodMap {
 	if m.methods == nil {
-		m.methods = make(jsonrpc2.MethodMap, 35)
+		m.methods = make(jsonrpc2.MethodMap, 37)
 	}
 
 	m.methods["describe"] = m.Describe
+	m.methods["estimate-fee"] = m.EstimateFee
 	m.methods["execute"] = m.Execute
 	m.methods["add-credits"] = m.ExecuteAddCredits
 	m.methods["burn-tokens"] = m.ExecuteBurnTokens
@@ -48,6 +49,7 @@ func (m *JrpcMethods) populateMethodTable() jsonrpc2.MethodMap {
 	m.methods["query-tx"] = m.QueryTx
 	m.methods["query-tx-history"] = m.QueryTxHistory
 	m.methods["query-tx-local"] = m.QueryTxLocal
+	m.methods["simulate"] = m.Simulate
 	m.methods["status"] = m.Status
 	m.methods["version"] = m.Version
 
@@ -341,7 +343,6 @@ func (m *JrpcMethods) QueryMinorBlocks(ctx context.Context, params json.RawMessa
 	return result
 }
 
-//
 // WARNING: EXPERIMENTAL!
 func (m *JrpcMethods) QuerySynth(ctx context.Context, params json.RawMessage) interface{} {
 	req := new(SyntheticTransactionRequest)
@@ -5,9 +5,9 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/client/signing"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
 )
@@ -208,6 +208,35 @@ func (m *JrpcMethods) ExecuteLocal(ctx context.Context, params json.RawMessage)
 	return m.submit(ctx, m.Options.Describe.PartitionId, req.Envelope, req.CheckOnly)
 }
 
+func (m *JrpcMethods) Simulate(ctx context.Context, params json.RawMessage) interface{} {
+	req := new(ExecuteRequest)
+	err := json.Unmarshal(params, req)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	// Route the request
+	partition, err := m.Router.Route(req.Envelope)
+	if err != nil {
+		return validatorError(err)
+	}
+
+	if partition != m.Options.Describe.PartitionId {
+		var result interface{}
+		err = m.Router.RequestAPIv2(ctx, partition, "simulate", params, &result)
+		if err != nil {
+			return accumulateError(err)
+		}
+		return result
+	}
+
+	if m.Simulator == nil {
+		return accumulateError(errors.New(errors.StatusNotAllowed, "simulation is not supported by this node"))
+	}
+
+	return jrpcFormatResponse(m.Simulator.Simulate(req.Envelope))
+}
+
 func (m *JrpcMethods) submit(ctx context.Context, partition string, env *protocol.Envelope, checkOnly bool) interface{} {
 	// Marshal the envelope
 	txData, err := env.MarshalBinary()
@@ -241,6 +270,14 @@ func (m *JrpcMethods) submit(ctx context.Context, partition string, env *protoco
 		m.logError("Failed to decode transaction results", "error", err)
 	}
 
+	// If the principal or signer has too many pending transactions, return an
+	// error so the client can back off and retry
+	for _, r := range results.Results {
+		if r.Code == errors.StatusTooManyPending && r.Error != nil {
+			return accumulateError(r.Error)
+		}
+	}
+
 	if len(results.Results) == 1 {
 		res.Result = results.Results[0]
 	} else if len(results.Results) > 0 {
@@ -307,7 +344,7 @@ func processExecuteRequest(req *TxRequest, payload []byte) (*protocol.Envelope,
 	} else if req.KeyPage.Version != 0 {
 		sigBuilder.SetVersion(req.KeyPage.Version)
 	} else {
-		return nil, validatorError(errors.New("missing signer version"))
+		return nil, validatorError(errors.New(errors.StatusBadRequest, "missing signer version"))
 	}
 
 	var sig protocol.Signature
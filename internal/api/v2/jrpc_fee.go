@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// EstimateFee computes the fee the executor would charge for a transaction
+// and the given signatures, using the active fee schedule and oracle.
+func (m *JrpcMethods) EstimateFee(_ context.Context, params json.RawMessage) interface{} {
+	req := new(EstimateFeeRequest)
+	err := json.Unmarshal(params, req)
+	if err != nil {
+		return validatorError(err)
+	}
+	if req.Transaction == nil || req.Transaction.Header.Principal == nil || req.Transaction.Body == nil {
+		return validatorError(errors.New(errors.StatusBadRequest, "missing transaction principal or body"))
+	}
+
+	globals, err := m.loadGlobals()
+	if err != nil {
+		return accumulateError(errors.Format(errors.StatusUnknownError, "load globals: %w", err))
+	}
+
+	return jrpcFormatResponse(estimateFee(globals.Globals.FeeSchedule, globals.Oracle.Price, req))
+}
+
+func estimateFee(schedule *protocol.FeeSchedule, oracle uint64, req *EstimateFeeRequest) (*EstimateFeeResponse, error) {
+	// The transaction is unsigned so the initiator is not known. Use a
+	// placeholder so the initiator is included in the size.
+	txn := req.Transaction.Copy()
+	if txn.Header.Initiator == ([32]byte{}) {
+		fillPlaceholder(txn.Header.Initiator[:])
+	}
+
+	var err error
+	res := new(EstimateFeeResponse)
+	res.Oracle = oracle
+	res.TransactionFee, err = schedule.ComputeTransactionFee(txn)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	var sponsorPaid bool
+	for i, intended := range req.Signatures {
+		signer := intended.Signer
+		if signer == nil {
+			signer = txn.Header.Principal
+		}
+
+		sig, err := placeholderSignature(intended.Type, signer, intended.Delegators)
+		if err != nil {
+			return nil, errors.Format(errors.StatusBadRequest, "signature %d: %w", i, err)
+		}
+
+		fee, err := schedule.ComputeSignerFee(txn, sig, i == 0, sponsorPaid)
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "signature %d: %w", i, err)
+		}
+
+		switch {
+		case txn.IsSponsor(signer):
+			sponsorPaid = true
+
+		case i == 0 && txn.Header.Sponsor != nil:
+			// The initiator of a sponsored transaction pays its signature
+			// fee, and the base signature fee is refunded when the
+			// transaction executes
+			res.Refund = protocol.FeeSignature
+		}
+
+		res.SignatureFees = append(res.SignatureFees, fee)
+		res.Total += fee
+	}
+
+	// An unsigned transaction still needs an initiator
+	if len(req.Signatures) == 0 {
+		res.Total = res.TransactionFee
+	}
+
+	res.Acme = *res.Total.AsAcme(oracle)
+	return res, nil
+}
+
+// placeholderSignature constructs a signature of the given type with
+// placeholder keys and signatures of the expected size, wrapped in a
+// delegated signature for each delegator.
+func placeholderSignature(typ protocol.SignatureType, signer *url.URL, delegators []*url.URL) (protocol.Signature, error) {
+	var sig protocol.Signature
+	switch typ {
+	case protocol.SignatureTypeLegacyED25519:
+		sig = &protocol.LegacyED25519Signature{PublicKey: make([]byte, 32), Signature: make([]byte, 64)}
+	case protocol.SignatureTypeED25519:
+		sig = &protocol.ED25519Signature{PublicKey: make([]byte, 32), Signature: make([]byte, 64)}
+	case protocol.SignatureTypeRCD1:
+		sig = &protocol.RCD1Signature{PublicKey: make([]byte, 32), Signature: make([]byte, 64)}
+	case protocol.SignatureTypeBTC:
+		sig = &protocol.BTCSignature{PublicKey: make([]byte, 33), Signature: make([]byte, 72)}
+	case protocol.SignatureTypeBTCLegacy:
+		sig = &protocol.BTCLegacySignature{PublicKey: make([]byte, 65), Signature: make([]byte, 72)}
+	case protocol.SignatureTypeETH:
+		sig = &protocol.ETHSignature{PublicKey: make([]byte, 65), Signature: make([]byte, 72)}
+	default:
+		return nil, errors.Format(errors.StatusBadRequest, "cannot estimate the fee of a %v signature", typ)
+	}
+
+	// Use the maximum values so the estimate is not low
+	ks := sig.(protocol.KeySignature)
+	fillPlaceholder(ks.GetPublicKey())
+	fillPlaceholder(ks.GetSignature())
+
+	switch sig := sig.(type) {
+	case *protocol.LegacyED25519Signature:
+		sig.Signer, sig.SignerVersion, sig.Timestamp = signer, ^uint64(0), ^uint64(0)
+		fillPlaceholder(sig.TransactionHash[:])
+	case *protocol.ED25519Signature:
+		sig.Signer, sig.SignerVersion, sig.Timestamp = signer, ^uint64(0), ^uint64(0)
+		fillPlaceholder(sig.TransactionHash[:])
+	case *protocol.RCD1Signature:
+		sig.Signer, sig.SignerVersion, sig.Timestamp = signer, ^uint64(0), ^uint64(0)
+		fillPlaceholder(sig.TransactionHash[:])
+	case *protocol.BTCSignature:
+		sig.Signer, sig.SignerVersion, sig.Timestamp = signer, ^uint64(0), ^uint64(0)
+		fillPlaceholder(sig.TransactionHash[:])
+	case *protocol.BTCLegacySignature:
+		sig.Signer, sig.SignerVersion, sig.Timestamp = signer, ^uint64(0), ^uint64(0)
+		fillPlaceholder(sig.TransactionHash[:])
+	case *protocol.ETHSignature:
+		sig.Signer, sig.SignerVersion, sig.Timestamp = signer, ^uint64(0), ^uint64(0)
+		fillPlaceholder(sig.TransactionHash[:])
+	}
+
+	for _, delegator := range delegators {
+		sig = &protocol.DelegatedSignature{Signature: sig, Delegator: delegator}
+	}
+	return sig, nil
+}
+
+func fillPlaceholder(b []byte) {
+	for i := range b {
+		b[i] = 0xFF
+	}
+}
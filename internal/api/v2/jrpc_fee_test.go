@@ -0,0 +1,60 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestEstimateFee_Sponsored(t *testing.T) {
+	alice := protocol.AccountUrl("alice")
+	bob := protocol.AccountUrl("bob")
+	schedule := new(protocol.FeeSchedule)
+
+	txn := new(protocol.Transaction)
+	txn.Header.Principal = alice
+	txn.Body = &protocol.CreateTokenAccount{Url: alice.JoinPath("tokens"), TokenUrl: protocol.AcmeUrl()}
+
+	plain, err := estimateFee(schedule, 500, &EstimateFeeRequest{
+		Transaction: txn,
+		Signatures:  []IntendedSignature{{Type: protocol.SignatureTypeED25519, Signer: alice.JoinPath("book", "1")}},
+	})
+	require.NoError(t, err)
+	initFee := plain.SignatureFees[0] - plain.TransactionFee + protocol.FeeSignature
+
+	// The sponsor pays the transaction fee once, no matter how many of its
+	// keys sign, and the initiator is refunded its base signature fee
+	txn = txn.Copy()
+	txn.Header.Sponsor = bob.JoinPath("book", "1")
+	res, err := estimateFee(schedule, 500, &EstimateFeeRequest{
+		Transaction: txn,
+		Signatures: []IntendedSignature{
+			{Type: protocol.SignatureTypeED25519, Signer: alice.JoinPath("book", "1")},
+			{Type: protocol.SignatureTypeED25519, Signer: bob.JoinPath("book", "1")},
+			{Type: protocol.SignatureTypeED25519, Signer: bob.JoinPath("book", "1")},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.SignatureFees, 3)
+	require.Equal(t, initFee, res.SignatureFees[0])
+	require.Equal(t, initFee+res.TransactionFee, res.SignatureFees[1])
+	require.Equal(t, initFee, res.SignatureFees[2])
+	require.Equal(t, protocol.FeeSignature, res.Refund)
+
+	// A lite token account sponsors as its lite identity
+	liteKey := make([]byte, 32)
+	lite, err := protocol.LiteTokenAddress(liteKey, protocol.ACME, protocol.SignatureTypeED25519)
+	require.NoError(t, err)
+	txn = txn.Copy()
+	txn.Header.Sponsor = lite
+	res, err = estimateFee(schedule, 500, &EstimateFeeRequest{
+		Transaction: txn,
+		Signatures: []IntendedSignature{
+			{Type: protocol.SignatureTypeED25519, Signer: alice.JoinPath("book", "1")},
+			{Type: protocol.SignatureTypeED25519, Signer: lite.RootIdentity()},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, initFee+res.TransactionFee, res.SignatureFees[1])
+}
@@ -198,6 +198,14 @@ type ResponseDataEntrySet struct {
 	extraData   []byte
 }
 
+type ResponseExternalAnchor struct {
+	fieldsSet []bool
+	Anchor    *protocol.ExternalAnchor `json:"anchor,omitempty" form:"anchor" query:"anchor" validate:"required"`
+	// Transaction is the hash of the transaction that recorded the anchor.
+	Transaction [32]byte `json:"transaction,omitempty" form:"transaction" query:"transaction" validate:"required"`
+	extraData   []byte
+}
+
 type ResponseKeyPageIndex struct {
 	fieldsSet []bool
 	Authority *url.URL `json:"authority,omitempty" form:"authority" query:"authority" validate:"required"`
@@ -206,6 +214,15 @@ type ResponseKeyPageIndex struct {
 	extraData []byte
 }
 
+type ResponseKeyValue struct {
+	fieldsSet []bool
+	Url       *url.URL        `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	Key       []byte          `json:"key,omitempty" form:"key" query:"key" validate:"required"`
+	Value     []byte          `json:"value,omitempty" form:"value" query:"value" validate:"required"`
+	Receipt   *GeneralReceipt `json:"receipt,omitempty" form:"receipt" query:"receipt" validate:"required"`
+	extraData []byte
+}
+
 type ResponseMajorBlocks struct {
 	fieldsSet   []bool
 	TotalBlocks uint64                `json:"totalBlocks" form:"totalBlocks" query:"totalBlocks" validate:"required"`
@@ -626,6 +643,19 @@ func (v *ResponseDataEntrySet) Copy() *ResponseDataEntrySet {
 
 func (v *ResponseDataEntrySet) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *ResponseExternalAnchor) Copy() *ResponseExternalAnchor {
+	u := new(ResponseExternalAnchor)
+
+	if v.Anchor != nil {
+		u.Anchor = (v.Anchor).Copy()
+	}
+	u.Transaction = v.Transaction
+
+	return u
+}
+
+func (v *ResponseExternalAnchor) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *ResponseKeyPageIndex) Copy() *ResponseKeyPageIndex {
 	u := new(ResponseKeyPageIndex)
 
@@ -642,6 +672,23 @@ func (v *ResponseKeyPageIndex) Copy() *ResponseKeyPageIndex {
 
 func (v *ResponseKeyPageIndex) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *ResponseKeyValue) Copy() *ResponseKeyValue {
+	u := new(ResponseKeyValue)
+
+	if v.Url != nil {
+		u.Url = v.Url
+	}
+	u.Key = encoding.BytesCopy(v.Key)
+	u.Value = encoding.BytesCopy(v.Value)
+	if v.Receipt != nil {
+		u.Receipt = (v.Receipt).Copy()
+	}
+
+	return u
+}
+
+func (v *ResponseKeyValue) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *ResponseMajorBlocks) Copy() *ResponseMajorBlocks {
 	u := new(ResponseMajorBlocks)
 
@@ -1227,6 +1274,22 @@ func (v *ResponseDataEntrySet) Equal(u *ResponseDataEntrySet) bool {
 	return true
 }
 
+func (v *ResponseExternalAnchor) Equal(u *ResponseExternalAnchor) bool {
+	switch {
+	case v.Anchor == u.Anchor:
+		// equal
+	case v.Anchor == nil || u.Anchor == nil:
+		return false
+	case !((v.Anchor).Equal(u.Anchor)):
+		return false
+	}
+	if !(v.Transaction == u.Transaction) {
+		return false
+	}
+
+	return true
+}
+
 func (v *ResponseKeyPageIndex) Equal(u *ResponseKeyPageIndex) bool {
 	switch {
 	case v.Authority == u.Authority:
@@ -1251,6 +1314,33 @@ func (v *ResponseKeyPageIndex) Equal(u *ResponseKeyPageIndex) bool {
 	return true
 }
 
+func (v *ResponseKeyValue) Equal(u *ResponseKeyValue) bool {
+	switch {
+	case v.Url == u.Url:
+		// equal
+	case v.Url == nil || u.Url == nil:
+		return false
+	case !((v.Url).Equal(u.Url)):
+		return false
+	}
+	if !(bytes.Equal(v.Key, u.Key)) {
+		return false
+	}
+	if !(bytes.Equal(v.Value, u.Value)) {
+		return false
+	}
+	switch {
+	case v.Receipt == u.Receipt:
+		// equal
+	case v.Receipt == nil || u.Receipt == nil:
+		return false
+	case !((v.Receipt).Equal(u.Receipt)):
+		return false
+	}
+
+	return true
+}
+
 func (v *ResponseMajorBlocks) Equal(u *ResponseMajorBlocks) bool {
 	if !(v.TotalBlocks == u.TotalBlocks) {
 		return false
@@ -2711,6 +2801,54 @@ func (v *ResponseDataEntrySet) IsValid() error {
 	}
 }
 
+var fieldNames_ResponseExternalAnchor = []string{
+	1: "Anchor",
+	2: "Transaction",
+}
+
+func (v *ResponseExternalAnchor) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Anchor == nil) {
+		writer.WriteValue(1, v.Anchor.MarshalBinary)
+	}
+	if !(v.Transaction == ([32]byte{})) {
+		writer.WriteHash(2, &v.Transaction)
+	}
+
+	_, _, err := writer.Reset(fieldNames_ResponseExternalAnchor)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *ResponseExternalAnchor) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Anchor is missing")
+	} else if v.Anchor == nil {
+		errs = append(errs, "field Anchor is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Transaction is missing")
+	} else if v.Transaction == ([32]byte{}) {
+		errs = append(errs, "field Transaction is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_ResponseKeyPageIndex = []string{
 	1: "Authority",
 	2: "Signer",
@@ -2764,6 +2902,72 @@ func (v *ResponseKeyPageIndex) IsValid() error {
 	}
 }
 
+var fieldNames_ResponseKeyValue = []string{
+	1: "Url",
+	2: "Key",
+	3: "Value",
+	4: "Receipt",
+}
+
+func (v *ResponseKeyValue) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Url == nil) {
+		writer.WriteUrl(1, v.Url)
+	}
+	if !(len(v.Key) == 0) {
+		writer.WriteBytes(2, v.Key)
+	}
+	if !(len(v.Value) == 0) {
+		writer.WriteBytes(3, v.Value)
+	}
+	if !(v.Receipt == nil) {
+		writer.WriteValue(4, v.Receipt.MarshalBinary)
+	}
+
+	_, _, err := writer.Reset(fieldNames_ResponseKeyValue)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *ResponseKeyValue) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Url is missing")
+	} else if v.Url == nil {
+		errs = append(errs, "field Url is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Key is missing")
+	} else if len(v.Key) == 0 {
+		errs = append(errs, "field Key is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field Value is missing")
+	} else if len(v.Value) == 0 {
+		errs = append(errs, "field Value is not set")
+	}
+	if len(v.fieldsSet) > 4 && !v.fieldsSet[4] {
+		errs = append(errs, "field Receipt is missing")
+	} else if v.Receipt == nil {
+		errs = append(errs, "field Receipt is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_ResponseMajorBlocks = []string{
 	1: "TotalBlocks",
 	2: "Entries",
@@ -4009,6 +4213,32 @@ func (v *ResponseDataEntrySet) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *ResponseExternalAnchor) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *ResponseExternalAnchor) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x := new(protocol.ExternalAnchor); reader.ReadValue(1, x.UnmarshalBinary) {
+		v.Anchor = x
+	}
+	if x, ok := reader.ReadHash(2); ok {
+		v.Transaction = *x
+	}
+
+	seen, err := reader.Reset(fieldNames_ResponseExternalAnchor)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *ResponseKeyPageIndex) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -4038,6 +4268,38 @@ func (v *ResponseKeyPageIndex) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *ResponseKeyValue) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *ResponseKeyValue) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUrl(1); ok {
+		v.Url = x
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.Key = x
+	}
+	if x, ok := reader.ReadBytes(3); ok {
+		v.Value = x
+	}
+	if x := new(GeneralReceipt); reader.ReadValue(4, x.UnmarshalBinary) {
+		v.Receipt = x
+	}
+
+	seen, err := reader.Reset(fieldNames_ResponseKeyValue)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *ResponseMajorBlocks) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -4642,6 +4904,16 @@ func (v *ResponseDataEntrySet) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *ResponseExternalAnchor) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Anchor      *protocol.ExternalAnchor `json:"anchor,omitempty"`
+		Transaction string                   `json:"transaction,omitempty"`
+	}{}
+	u.Anchor = v.Anchor
+	u.Transaction = encoding.ChainToJSON(v.Transaction)
+	return json.Marshal(&u)
+}
+
 func (v *ResponseKeyPageIndex) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Authority *url.URL `json:"authority,omitempty"`
@@ -4658,6 +4930,20 @@ func (v *ResponseKeyPageIndex) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *ResponseKeyValue) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Url     *url.URL        `json:"url,omitempty"`
+		Key     *string         `json:"key,omitempty"`
+		Value   *string         `json:"value,omitempty"`
+		Receipt *GeneralReceipt `json:"receipt,omitempty"`
+	}{}
+	u.Url = v.Url
+	u.Key = encoding.BytesToJSON(v.Key)
+	u.Value = encoding.BytesToJSON(v.Value)
+	u.Receipt = v.Receipt
+	return json.Marshal(&u)
+}
+
 func (v *ResponseMajorBlocks) MarshalJSON() ([]byte, error) {
 	u := struct {
 		TotalBlocks uint64                                 `json:"totalBlocks"`
@@ -5318,6 +5604,25 @@ func (v *ResponseDataEntrySet) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *ResponseExternalAnchor) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Anchor      *protocol.ExternalAnchor `json:"anchor,omitempty"`
+		Transaction string                   `json:"transaction,omitempty"`
+	}{}
+	u.Anchor = v.Anchor
+	u.Transaction = encoding.ChainToJSON(v.Transaction)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Anchor = u.Anchor
+	if x, err := encoding.ChainFromJSON(u.Transaction); err != nil {
+		return fmt.Errorf("error decoding Transaction: %w", err)
+	} else {
+		v.Transaction = x
+	}
+	return nil
+}
+
 func (v *ResponseKeyPageIndex) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Authority *url.URL `json:"authority,omitempty"`
@@ -5348,6 +5653,35 @@ func (v *ResponseKeyPageIndex) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *ResponseKeyValue) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Url     *url.URL        `json:"url,omitempty"`
+		Key     *string         `json:"key,omitempty"`
+		Value   *string         `json:"value,omitempty"`
+		Receipt *GeneralReceipt `json:"receipt,omitempty"`
+	}{}
+	u.Url = v.Url
+	u.Key = encoding.BytesToJSON(v.Key)
+	u.Value = encoding.BytesToJSON(v.Value)
+	u.Receipt = v.Receipt
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Url = u.Url
+	if x, err := encoding.BytesFromJSON(u.Key); err != nil {
+		return fmt.Errorf("error decoding Key: %w", err)
+	} else {
+		v.Key = x
+	}
+	if x, err := encoding.BytesFromJSON(u.Value); err != nil {
+		return fmt.Errorf("error decoding Value: %w", err)
+	} else {
+		v.Value = x
+	}
+	v.Receipt = u.Receipt
+	return nil
+}
+
 func (v *ResponseMajorBlocks) UnmarshalJSON(data []byte) error {
 	u := struct {
 		TotalBlocks uint64                                 `json:"totalBlocks"`
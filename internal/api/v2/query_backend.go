@@ -67,6 +67,87 @@ func (m *queryBackend) queryAccount(batch *database.Batch, account *database.Acc
 	return resp, nil
 }
 
+// queryExternalAnchor searches the external anchors account for the record of
+// the given directory root chain anchor.
+func (m *queryBackend) queryExternalAnchor(batch *database.Batch, u *url.URL, root [32]byte) (*query.ResponseExternalAnchor, error) {
+	u = u.WithFragment("")
+	if !protocol.DnUrl().JoinPath(protocol.ExternalAnchors).Equal(u) {
+		return nil, errors.Format(errors.StatusBadRequest, "%v does not record external anchors", u)
+	}
+
+	data := indexing.Data(batch, u)
+	count, err := data.Count()
+	if err != nil {
+		return nil, errors.Format(errors.StatusUnknownError, "load entry count of %v: %w", u, err)
+	}
+
+	// Search backwards since recent anchors are the most likely to be queried
+	for i := int64(count) - 1; i >= 0; i-- {
+		entryHash, err := data.Entry(uint64(i))
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "load entry %d of %v: %w", i, u, err)
+		}
+
+		txnHash, err := data.Transaction(entryHash)
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "load transaction of entry %d of %v: %w", i, u, err)
+		}
+
+		entry, err := indexing.GetDataEntry(batch, txnHash)
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "load entry %d of %v: %w", i, u, err)
+		}
+
+		anchor := new(protocol.ExternalAnchor)
+		if len(entry.GetData()) != 1 || anchor.UnmarshalBinary(entry.GetData()[0]) != nil || anchor.RootChainAnchor != root {
+			continue
+		}
+
+		resp := new(query.ResponseExternalAnchor)
+		resp.Anchor = anchor
+		resp.Transaction = *(*[32]byte)(txnHash)
+		return resp, nil
+	}
+
+	return nil, errors.NotFound("external anchor of %X not found", root[:4])
+}
+
+// queryKeyValue loads the value of a key of a key/value account along with a
+// receipt from the value to the BPT root.
+func (m *queryBackend) queryKeyValue(batch *database.Batch, u *url.URL, key []byte, prove bool) (*query.ResponseKeyValue, error) {
+	account := batch.Account(u)
+	value, err := account.GetKeyValue(key)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	resp := new(query.ResponseKeyValue)
+	resp.Url = u
+	resp.Key = key
+	resp.Value = value
+	if !prove {
+		return resp, nil
+	}
+
+	resp.Receipt = new(query.GeneralReceipt)
+	r, err := account.KeyValueReceipt(key)
+	if err != nil {
+		resp.Receipt.Error = err.Error()
+		return resp, nil
+	}
+
+	// Load the latest root index entry (just for the block index)
+	ledger := batch.Account(m.Describe.Ledger())
+	rootEntry, err := indexing.LoadIndexEntryFromEnd(ledger.RootChain().Index(), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Receipt.LocalBlock = rootEntry.BlockIndex
+	resp.Receipt.Proof = *r
+	return resp, nil
+}
+
 func (m *queryBackend) queryByUrl(batch *database.Batch, u *url.URL, prove bool, scratch bool) ([]byte, encoding.BinaryMarshaler, error) {
 	qv := u.QueryValues()
 
@@ -150,6 +231,38 @@ func (m *queryBackend) queryByUrl(batch *database.Batch, u *url.URL, prove bool,
 
 		return []byte("chain-entry"), res, nil
 
+	case "external-anchor":
+		if len(fragment) < 2 {
+			return nil, nil, fmt.Errorf("invalid fragment")
+		}
+
+		root, err := hex.DecodeString(fragment[1])
+		if err != nil || len(root) != 32 {
+			return nil, nil, fmt.Errorf("invalid anchor: %q is not a hash", fragment[1])
+		}
+
+		res, err := m.queryExternalAnchor(batch, u, *(*[32]byte)(root))
+		if err != nil {
+			return nil, nil, err
+		}
+		return []byte("external-anchor"), res, nil
+
+	case "key":
+		if len(fragment) < 2 {
+			return nil, nil, fmt.Errorf("invalid fragment")
+		}
+
+		key, err := hex.DecodeString(fragment[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid key: %q is not hex", fragment[1])
+		}
+
+		res, err := m.queryKeyValue(batch, u, key, prove)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []byte("key-value"), res, nil
+
 	case "chain":
 		if len(fragment) < 2 {
 			return nil, nil, fmt.Errorf("invalid fragment")
@@ -51,6 +51,30 @@ func (q *queryFrontend) QueryUrl(u *url.URL, opts QueryOptions) (interface{}, er
 
 		return packStateResponse(resp.Account, resp.ChainState, resp.Receipt)
 
+	case "external-anchor":
+		res := new(query.ResponseExternalAnchor)
+		err = res.UnmarshalBinary(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response: %v", err)
+		}
+
+		qr := new(ChainQueryResponse)
+		qr.Type = "externalAnchor"
+		qr.Data = res
+		return qr, nil
+
+	case "key-value":
+		res := new(query.ResponseKeyValue)
+		err = res.UnmarshalBinary(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response: %v", err)
+		}
+
+		qr := new(ChainQueryResponse)
+		qr.Type = "keyValue"
+		qr.Data = res
+		return qr, nil
+
 	case "tx":
 		res := new(query.ResponseByTxId)
 		err := res.UnmarshalBinary(v)
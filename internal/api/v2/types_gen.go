@@ -78,6 +78,28 @@ type DirectoryQuery struct {
 	QueryOptions
 }
 
+type EstimateFeeRequest struct {
+	Transaction *protocol.Transaction `json:"transaction,omitempty" form:"transaction" query:"transaction" validate:"required"`
+	// Signatures describes the signatures that will be submitted with the transaction. The first is the initiator.
+	Signatures []IntendedSignature `json:"signatures,omitempty" form:"signatures" query:"signatures" validate:"required"`
+}
+
+type EstimateFeeResponse struct {
+
+	// TransactionFee is the fee paid by the initiator, or the sponsor if there is one, for the transaction.
+	TransactionFee protocol.Fee `json:"transactionFee,omitempty" form:"transactionFee" query:"transactionFee" validate:"required"`
+	// SignatureFees is the fee for each signature.
+	SignatureFees []protocol.Fee `json:"signatureFees,omitempty" form:"signatureFees" query:"signatureFees" validate:"required"`
+	// Total is the total fee in credits.
+	Total protocol.Fee `json:"total,omitempty" form:"total" query:"total" validate:"required"`
+	// Refund is the amount refunded to the initiator of a sponsored transaction when the transaction is executed.
+	Refund protocol.Fee `json:"refund,omitempty" form:"refund" query:"refund" validate:"required"`
+	// Acme is the amount of ACME that must be converted to credits to pay the total fee.
+	Acme big.Int `json:"acme,omitempty" form:"acme" query:"acme" validate:"required"`
+	// Oracle is the ACME oracle price used to compute the ACME amount.
+	Oracle uint64 `json:"oracle,omitempty" form:"oracle" query:"oracle" validate:"required"`
+}
+
 type ExecuteRequest struct {
 	Envelope  *protocol.Envelope `json:"envelope,omitempty" form:"envelope" query:"envelope" validate:"required"`
 	CheckOnly bool               `json:"checkOnly,omitempty" form:"checkOnly" query:"checkOnly"`
@@ -88,6 +110,14 @@ type GeneralQuery struct {
 	QueryOptions
 }
 
+type IntendedSignature struct {
+	Type protocol.SignatureType `json:"type,omitempty" form:"type" query:"type" validate:"required"`
+	// Signer defaults to the principal of the transaction.
+	Signer *url.URL `json:"signer,omitempty" form:"signer" query:"signer"`
+	// Delegators lists the authorities the signature is delegated through, innermost first.
+	Delegators []*url.URL `json:"delegators,omitempty" form:"delegators" query:"delegators"`
+}
+
 type KeyPage struct {
 	Version uint64 `json:"version,omitempty" form:"version" query:"version"`
 }
@@ -619,6 +649,34 @@ func (v *DirectoryQuery) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *EstimateFeeRequest) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Transaction *protocol.Transaction                `json:"transaction,omitempty"`
+		Signatures  encoding.JsonList[IntendedSignature] `json:"signatures,omitempty"`
+	}{}
+	u.Transaction = v.Transaction
+	u.Signatures = v.Signatures
+	return json.Marshal(&u)
+}
+
+func (v *EstimateFeeResponse) MarshalJSON() ([]byte, error) {
+	u := struct {
+		TransactionFee protocol.Fee                    `json:"transactionFee,omitempty"`
+		SignatureFees  encoding.JsonList[protocol.Fee] `json:"signatureFees,omitempty"`
+		Total          protocol.Fee                    `json:"total,omitempty"`
+		Refund         protocol.Fee                    `json:"refund,omitempty"`
+		Acme           *string                         `json:"acme,omitempty"`
+		Oracle         uint64                          `json:"oracle,omitempty"`
+	}{}
+	u.TransactionFee = v.TransactionFee
+	u.SignatureFees = v.SignatureFees
+	u.Total = v.Total
+	u.Refund = v.Refund
+	u.Acme = encoding.BigintToJSON(&v.Acme)
+	u.Oracle = v.Oracle
+	return json.Marshal(&u)
+}
+
 func (v *GeneralQuery) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Url          *url.URL `json:"url,omitempty"`
@@ -637,6 +695,18 @@ func (v *GeneralQuery) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *IntendedSignature) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Type       protocol.SignatureType      `json:"type,omitempty"`
+		Signer     *url.URL                    `json:"signer,omitempty"`
+		Delegators encoding.JsonList[*url.URL] `json:"delegators,omitempty"`
+	}{}
+	u.Type = v.Type
+	u.Signer = v.Signer
+	u.Delegators = v.Delegators
+	return json.Marshal(&u)
+}
+
 func (v *KeyPage) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Version uint64 `json:"version,omitempty"`
@@ -1244,6 +1314,52 @@ func (v *DirectoryQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *EstimateFeeRequest) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Transaction *protocol.Transaction                `json:"transaction,omitempty"`
+		Signatures  encoding.JsonList[IntendedSignature] `json:"signatures,omitempty"`
+	}{}
+	u.Transaction = v.Transaction
+	u.Signatures = v.Signatures
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Transaction = u.Transaction
+	v.Signatures = u.Signatures
+	return nil
+}
+
+func (v *EstimateFeeResponse) UnmarshalJSON(data []byte) error {
+	u := struct {
+		TransactionFee protocol.Fee                    `json:"transactionFee,omitempty"`
+		SignatureFees  encoding.JsonList[protocol.Fee] `json:"signatureFees,omitempty"`
+		Total          protocol.Fee                    `json:"total,omitempty"`
+		Refund         protocol.Fee                    `json:"refund,omitempty"`
+		Acme           *string                         `json:"acme,omitempty"`
+		Oracle         uint64                          `json:"oracle,omitempty"`
+	}{}
+	u.TransactionFee = v.TransactionFee
+	u.SignatureFees = v.SignatureFees
+	u.Total = v.Total
+	u.Refund = v.Refund
+	u.Acme = encoding.BigintToJSON(&v.Acme)
+	u.Oracle = v.Oracle
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.TransactionFee = u.TransactionFee
+	v.SignatureFees = u.SignatureFees
+	v.Total = u.Total
+	v.Refund = u.Refund
+	if x, err := encoding.BigintFromJSON(u.Acme); err != nil {
+		return fmt.Errorf("error decoding Acme: %w", err)
+	} else {
+		v.Acme = *x
+	}
+	v.Oracle = u.Oracle
+	return nil
+}
+
 func (v *GeneralQuery) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Url          *url.URL `json:"url,omitempty"`
@@ -1274,6 +1390,24 @@ func (v *GeneralQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *IntendedSignature) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Type       protocol.SignatureType      `json:"type,omitempty"`
+		Signer     *url.URL                    `json:"signer,omitempty"`
+		Delegators encoding.JsonList[*url.URL] `json:"delegators,omitempty"`
+	}{}
+	u.Type = v.Type
+	u.Signer = v.Signer
+	u.Delegators = v.Delegators
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Type = u.Type
+	v.Signer = u.Signer
+	v.Delegators = u.Delegators
+	return nil
+}
+
 func (v *KeyPage) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Version uint64 `json:"version,omitempty"`
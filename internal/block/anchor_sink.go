@@ -0,0 +1,119 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// AnchorSink anchors the directory network's root chain in an external
+// system.
+type AnchorSink interface {
+	// Anchor records the anchor externally and returns a reference to the
+	// external record.
+	Anchor(ctx context.Context, anchor *protocol.ExternalAnchor) (string, error)
+}
+
+// NewAnchorSink creates an anchor sink from a URL. A file URL creates a
+// FileAnchorSink and an HTTP(S) URL creates an HTTPAnchorSink.
+func NewAnchorSink(sinkUrl string) (AnchorSink, error) {
+	switch {
+	case strings.HasPrefix(sinkUrl, "file://"):
+		return &FileAnchorSink{Dir: strings.TrimPrefix(sinkUrl, "file://")}, nil
+	case strings.HasPrefix(sinkUrl, "http://"), strings.HasPrefix(sinkUrl, "https://"):
+		return &HTTPAnchorSink{Url: sinkUrl}, nil
+	default:
+		return nil, errors.Format(errors.StatusBadRequest, "invalid anchor sink %q: expected a file or HTTP URL", sinkUrl)
+	}
+}
+
+// FileAnchorSink writes each anchor to a JSON file in a directory. It stands
+// in for an external system in tests and development networks.
+type FileAnchorSink struct {
+	Dir string
+}
+
+// Anchor writes the anchor to {dir}/{major block index}.json and returns the
+// file URL of the file.
+func (s *FileAnchorSink) Anchor(_ context.Context, anchor *protocol.ExternalAnchor) (string, error) {
+	data, err := json.Marshal(anchor)
+	if err != nil {
+		return "", errors.Format(errors.StatusInternalError, "marshal anchor: %w", err)
+	}
+
+	err = os.MkdirAll(s.Dir, 0755)
+	if err != nil {
+		return "", errors.Format(errors.StatusUnknownError, "create anchor directory: %w", err)
+	}
+
+	file, err := filepath.Abs(filepath.Join(s.Dir, fmt.Sprintf("%d.json", anchor.MajorBlockIndex)))
+	if err != nil {
+		return "", errors.Format(errors.StatusUnknownError, "resolve anchor file: %w", err)
+	}
+
+	err = os.WriteFile(file, data, 0644)
+	if err != nil {
+		return "", errors.Format(errors.StatusUnknownError, "write anchor: %w", err)
+	}
+
+	return "file://" + file, nil
+}
+
+// HTTPAnchorSink posts each anchor as JSON to an HTTP endpoint, which must
+// respond with {"reference": "..."}. It stands in for an external system in
+// tests and development networks.
+type HTTPAnchorSink struct {
+	Url    string
+	Client *http.Client
+}
+
+// Anchor posts the anchor to the endpoint and returns the reference the
+// endpoint responds with.
+func (s *HTTPAnchorSink) Anchor(ctx context.Context, anchor *protocol.ExternalAnchor) (string, error) {
+	data, err := json.Marshal(anchor)
+	if err != nil {
+		return "", errors.Format(errors.StatusInternalError, "marshal anchor: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Url, bytes.NewReader(data))
+	if err != nil {
+		return "", errors.Format(errors.StatusBadRequest, "create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Format(errors.StatusUnknownError, "post anchor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return "", errors.Format(errors.StatusUnknownError, "post anchor: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	var result struct {
+		Reference string `json:"reference"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return "", errors.Format(errors.StatusUnknownError, "decode response: %w", err)
+	}
+	if result.Reference == "" {
+		return "", errors.Format(errors.StatusUnknownError, "response is missing the reference")
+	}
+	return result.Reference, nil
+}
@@ -0,0 +1,78 @@
+package block_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/block"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func testExternalAnchor() *protocol.ExternalAnchor {
+	anchor := new(protocol.ExternalAnchor)
+	anchor.MajorBlockIndex = 3
+	anchor.MinorBlockIndex = 1000
+	anchor.RootChainIndex = 2000
+	anchor.RootChainAnchor[0] = 1
+	anchor.StateTreeAnchor[0] = 2
+	return anchor
+}
+
+func TestFileAnchorSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := block.NewAnchorSink("file://" + dir)
+	require.NoError(t, err)
+
+	anchor := testExternalAnchor()
+	ref, err := sink.Anchor(context.Background(), anchor)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(ref, "file://"), "Reference is a file URL")
+
+	// The file contains the anchor
+	data, err := os.ReadFile(strings.TrimPrefix(ref, "file://"))
+	require.NoError(t, err)
+	got := new(protocol.ExternalAnchor)
+	require.NoError(t, json.Unmarshal(data, got))
+	require.True(t, anchor.Equal(got))
+}
+
+func TestHTTPAnchorSink(t *testing.T) {
+	var anchors []*protocol.ExternalAnchor
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		anchor := new(protocol.ExternalAnchor)
+		if json.NewDecoder(r.Body).Decode(anchor) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		anchors = append(anchors, anchor)
+		_ = json.NewEncoder(w).Encode(map[string]string{"reference": fmt.Sprintf("test:%d", len(anchors))})
+	}))
+	defer server.Close()
+
+	sink, err := block.NewAnchorSink(server.URL)
+	require.NoError(t, err)
+
+	anchor := testExternalAnchor()
+	ref, err := sink.Anchor(context.Background(), anchor)
+	require.NoError(t, err)
+	require.Equal(t, "test:1", ref)
+	require.Len(t, anchors, 1)
+	require.True(t, anchor.Equal(anchors[0]))
+
+	// Errors are reported
+	sink = &block.HTTPAnchorSink{Url: server.URL + "/missing", Client: server.Client()}
+	server.Config.Handler = http.NotFoundHandler()
+	_, err = sink.Anchor(context.Background(), anchor)
+	require.Error(t, err)
+
+	// Unknown sinks are rejected
+	_, err = block.NewAnchorSink("ftp://example.com")
+	require.Error(t, err)
+}
@@ -242,6 +242,10 @@ func (x *Executor) finalizeBlock(block *Block) error {
 			x.logger.Info("Start major block", "major-index", anchor.MajorBlockIndex, "minor-index", ledger.Index)
 			block.State.OpenedMajorBlock = true
 			x.ExecutorOptions.MajorBlockScheduler.UpdateNextMajorBlockTime(anchor.MakeMajorBlockTime)
+			err = x.anchorMajorBlock(block, anchor)
+			if err != nil {
+				return errors.Format(errors.StatusUnknownError, "anchor major block %d: %w", anchor.MakeMajorBlock, err)
+			}
 		}
 
 		// DN -> BVN
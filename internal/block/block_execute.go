@@ -12,6 +12,10 @@ type Block struct {
 	BlockMeta
 	State BlockState
 	Batch *database.Batch
+
+	// DryRun is set when the block is simulated and will never be committed.
+	// Transaction results are not logged.
+	DryRun bool
 }
 
 func (x *Executor) ExecuteEnvelopeSet(block *Block, deliveries []*chain.Delivery, captureError func(error, *chain.Delivery, *protocol.TransactionStatus)) []*protocol.TransactionStatus {
@@ -48,13 +52,11 @@ func (x *Executor) ExecuteEnvelope(block *Block, delivery *chain.Delivery) (*pro
 		return nil, errors.Wrap(errors.StatusUnknownError, err)
 	}
 
-	// Record when the transaction is received
-	if status.Received == 0 {
-		status.Received = block.Index
-		err = block.Batch.Transaction(delivery.Transaction.GetHash()).PutStatus(status)
-		if err != nil {
-			return nil, errors.Wrap(errors.StatusUnknownError, err)
-		}
+	// Record when the transaction is received, if executeEnvelope returned
+	// before doing so
+	err = recordReceived(block, delivery, status)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
 	}
 
 	// Process additional transactions. This is intentionally non-recursive.
@@ -78,8 +80,13 @@ func (x *Executor) ExecuteEnvelope(block *Block, delivery *chain.Delivery) (*pro
 }
 
 func (x *Executor) executeEnvelope(block *Block, delivery *chain.Delivery, additional bool) (*protocol.TransactionStatus, []*chain.Delivery, error) {
+	logger := x.logger
+	if block.DryRun {
+		logger.L = nil
+	}
+
 	{
-		fn := x.logger.Debug
+		fn := logger.Debug
 		kv := []interface{}{
 			"block", block.Index,
 			"type", delivery.Transaction.Body.Type(),
@@ -89,7 +96,7 @@ func (x *Executor) executeEnvelope(block *Block, delivery *chain.Delivery, addit
 		switch delivery.Transaction.Body.Type() {
 		case protocol.TransactionTypeDirectoryAnchor,
 			protocol.TransactionTypeBlockValidatorAnchor:
-			fn = x.logger.Info
+			fn = logger.Info
 			kv = append(kv, "module", "anchoring")
 		}
 		if additional {
@@ -115,6 +122,12 @@ func (x *Executor) executeEnvelope(block *Block, delivery *chain.Delivery, addit
 		// Transaction has already been delivered
 		status := status.Copy()
 		status.Code = errors.StatusDelivered
+
+		// Refund any forwarded signatures that arrived too late
+		err = x.refundLateSignatures(block, delivery)
+		if err != nil {
+			return nil, nil, errors.Wrap(errors.StatusUnknownError, err)
+		}
 		return status, nil, nil
 	}
 
@@ -181,22 +194,22 @@ func (x *Executor) executeEnvelope(block *Block, delivery *chain.Delivery, addit
 		if status.Error != nil {
 			kv = append(kv, "error", status.Error)
 			if additional {
-				x.logger.Info("Additional transaction failed", kv...)
+				logger.Info("Additional transaction failed", kv...)
 			} else {
-				x.logger.Info("Transaction failed", kv...)
+				logger.Info("Transaction failed", kv...)
 			}
 		} else if status.Pending() {
 			if additional {
-				x.logger.Debug("Additional transaction pending", kv...)
+				logger.Debug("Additional transaction pending", kv...)
 			} else {
-				x.logger.Debug("Transaction pending", kv...)
+				logger.Debug("Transaction pending", kv...)
 			}
 		} else {
-			fn := x.logger.Debug
+			fn := logger.Debug
 			switch delivery.Transaction.Body.Type() {
 			case protocol.TransactionTypeDirectoryAnchor,
 				protocol.TransactionTypeBlockValidatorAnchor:
-				fn = x.logger.Info
+				fn = logger.Info
 				kv = append(kv, "module", "anchoring")
 			}
 			if additional {
@@ -217,6 +230,16 @@ func (x *Executor) executeEnvelope(block *Block, delivery *chain.Delivery, addit
 
 	block.State.MergeTransaction(&delivery.State)
 
+	// Record when the transaction is received. This must be done before
+	// producing synthetic transactions, because that may update the stored
+	// status, e.g. to link refunds.
+	if !additional {
+		err = recordReceived(block, delivery, status)
+		if err != nil {
+			return nil, nil, errors.Wrap(errors.StatusUnknownError, err)
+		}
+	}
+
 	// Process synthetic transactions generated by the validator
 	{
 		batch := block.Batch.Begin(true)
@@ -238,3 +261,15 @@ func (x *Executor) executeEnvelope(block *Block, delivery *chain.Delivery, addit
 	// overflow.
 	return status, delivery.State.AdditionalTransactions, nil
 }
+
+// recordReceived records the block the transaction was received in, if it has
+// not already been recorded.
+func recordReceived(block *Block, delivery *chain.Delivery, status *protocol.TransactionStatus) error {
+	if status.Received != 0 {
+		return nil
+	}
+
+	status.Received = block.Index
+	err := block.Batch.Transaction(delivery.Transaction.GetHash()).PutStatus(status)
+	return errors.Wrap(errors.StatusUnknownError, err)
+}
@@ -28,6 +28,10 @@ type Executor struct {
 	dispatcher *dispatcher
 	logger     logging.OptionalLogger
 	db         database.Beginner
+
+	// pendingExternalAnchor is the major block anchor to anchor externally
+	// once the block that recorded it is committed
+	pendingExternalAnchor *pendingExternalAnchor
 	// oldBlockMeta blockMetadata
 }
 
@@ -41,6 +45,7 @@ type ExecutorOptions struct {
 	Background          func(func())                       // Background task launcher
 	IsFollower          bool                               //
 	BatchReplayLimit    int
+	AnchorSink          AnchorSink // Sink for external anchors of major blocks (DN only)
 
 	isGenesis bool
 
@@ -57,12 +62,15 @@ func NewNodeExecutor(opts ExecutorOptions, db database.Beginner) (*Executor, err
 		chain.CreateIdentity{},
 		chain.CreateKeyBook{},
 		chain.CreateKeyPage{},
+		chain.CreateKeyValueAccount{},
 		chain.CreateLiteTokenAccount{},
 		chain.CreateToken{},
 		chain.CreateTokenAccount{},
+		chain.DeleteKeyValue{},
 		chain.IssueTokens{},
 		chain.LockAccount{},
 		chain.SendTokens{},
+		chain.SetKeyValue{},
 		chain.UpdateAccountAuth{},
 		chain.UpdateKey{},
 		chain.UpdateKeyPage{},
@@ -99,7 +107,16 @@ func NewNodeExecutor(opts ExecutorOptions, db database.Beginner) (*Executor, err
 	// This is a no-op in dev
 	executors = addTestnetExecutors(executors)
 
-	return newExecutor(opts, db, executors...)
+	exec, err := newExecutor(opts, db, executors...)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	// Anchor major blocks externally once they are committed
+	if opts.Describe.NetworkType == config.Directory && opts.EventBus != nil {
+		events.SubscribeSync(opts.EventBus, exec.didCommitBlock)
+	}
+	return exec, nil
 }
 
 // NewGenesisExecutor creates a transaction executor that can be used to set up
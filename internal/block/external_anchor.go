@@ -0,0 +1,357 @@
+package block
+
+import (
+	"context"
+	"crypto/sha256"
+	"time"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/internal/events"
+	"gitlab.com/accumulatenetwork/accumulate/internal/indexing"
+	"gitlab.com/accumulatenetwork/accumulate/internal/logging"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/client/signing"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage"
+)
+
+// externalAnchorTimeout limits how long anchoring a major block externally
+// may take.
+const externalAnchorTimeout = 5 * time.Minute
+
+// pendingExternalAnchor is a major block anchor that will be anchored
+// externally once the block that recorded it is committed.
+type pendingExternalAnchor struct {
+	block  uint64
+	anchor *protocol.ExternalAnchor
+}
+
+// anchorMajorBlock records the directory anchor of a major block so that it
+// can be anchored externally. Every node records the anchor, so every
+// validator can check the reference that is recorded for it. Only the leader
+// anchors externally, once the block is committed, so each major block is
+// anchored once.
+//
+// Recording the anchor changes the state, so nothing is recorded until the
+// network enables external anchors. Otherwise nodes that do not support them
+// would fork.
+func (x *Executor) anchorMajorBlock(block *Block, anchor *protocol.DirectoryAnchor) error {
+	if !x.globals.Active.Globals.EnableExternalAnchors {
+		return nil
+	}
+
+	external := new(protocol.ExternalAnchor)
+	external.MajorBlockIndex = anchor.MakeMajorBlock
+	external.MinorBlockIndex = anchor.MinorBlockIndex
+	external.RootChainIndex = anchor.RootChainIndex
+	external.RootChainAnchor = anchor.RootChainAnchor
+	external.StateTreeAnchor = anchor.StateTreeAnchor
+
+	// Networks created before external anchors existed do not have the account
+	err := x.createExternalAnchorsAccount(block.Batch)
+	if err != nil {
+		return errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	err = block.Batch.SystemData(x.Describe.PartitionId).ExternalAnchor(external.MajorBlockIndex).Put(external)
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "store external anchor: %w", err)
+	}
+
+	if x.AnchorSink != nil && block.IsLeader && !x.IsFollower {
+		x.pendingExternalAnchor = &pendingExternalAnchor{block.Index, external.Copy()}
+	}
+	return nil
+}
+
+// createExternalAnchorsAccount creates the external anchors account if it
+// does not exist.
+func (x *Executor) createExternalAnchorsAccount(batch *database.Batch) error {
+	account := batch.Account(x.Describe.NodeUrl(protocol.ExternalAnchors))
+	_, err := account.GetState()
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, storage.ErrNotFound):
+		return errors.Format(errors.StatusUnknownError, "load external anchors account: %w", err)
+	}
+
+	da := new(protocol.DataAccount)
+	da.Url = x.Describe.NodeUrl(protocol.ExternalAnchors)
+	da.AddAuthority(x.Describe.Operators())
+	err = account.PutState(da)
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "store external anchors account: %w", err)
+	}
+
+	err = indexing.Directory(batch, x.Describe.NodeUrl()).Add(da.Url)
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "add external anchors account to the directory: %w", err)
+	}
+
+	x.logger.Info("Created the external anchors account", "module", "anchoring")
+	return nil
+}
+
+// didCommitBlock anchors the pending major block anchor externally once the
+// block that recorded it has been committed, and signs records of external
+// anchors that are waiting for the operators.
+func (x *Executor) didCommitBlock(e events.DidCommitBlock) error {
+	if x.IsFollower {
+		return nil
+	}
+
+	if p := x.pendingExternalAnchor; p != nil && p.block <= e.Index {
+		x.pendingExternalAnchor = nil
+		if p.block == e.Index {
+			x.Background(func() { x.anchorExternally(p.anchor) })
+		}
+	}
+
+	x.Background(x.signExternalAnchors)
+	return nil
+}
+
+// anchorExternally hands the anchor to the anchor sink and initiates a
+// transaction that records the returned reference in the external anchors
+// account. The other operators sign the transaction once they see it.
+func (x *Executor) anchorExternally(anchor *protocol.ExternalAnchor) {
+	ctx, cancel := context.WithTimeout(context.Background(), externalAnchorTimeout)
+	defer cancel()
+
+	reference, err := x.AnchorSink.Anchor(ctx, anchor)
+	if err != nil {
+		x.logger.Error("Failed to anchor major block externally", "module", "anchoring", "major-index", anchor.MajorBlockIndex, "error", err)
+		return
+	}
+	anchor.Reference = reference
+	x.logger.Info("Anchored major block externally", "module", "anchoring", "major-index", anchor.MajorBlockIndex, "root", logging.AsHex(anchor.RootChainAnchor).Slice(0, 4), "reference", reference)
+
+	env, err := x.buildExternalAnchorRecord(anchor)
+	if err != nil {
+		x.logger.Error("Failed to record external anchor", "module", "anchoring", "major-index", anchor.MajorBlockIndex, "error", err)
+		return
+	}
+
+	x.submitExternalAnchorRecord(ctx, env, anchor)
+}
+
+// buildExternalAnchorRecord builds a transaction that writes the anchor to the
+// external anchors account, initiated by the node's key as an operator.
+func (x *Executor) buildExternalAnchorRecord(anchor *protocol.ExternalAnchor) (*protocol.Envelope, error) {
+	data, err := anchor.MarshalBinary()
+	if err != nil {
+		return nil, errors.Format(errors.StatusInternalError, "marshal anchor: %w", err)
+	}
+
+	txn := new(protocol.Transaction)
+	txn.Header.Principal = x.Describe.NodeUrl(protocol.ExternalAnchors)
+	txn.Body = &protocol.WriteData{Entry: &protocol.AccumulateDataEntry{Data: [][]byte{data}}}
+
+	batch := x.db.Begin(false)
+	defer batch.Discard()
+	signer, err := x.operatorSigner(batch)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	sig, err := signer.Initiate(txn)
+	if err != nil {
+		return nil, errors.Format(errors.StatusInternalError, "sign: %w", err)
+	}
+
+	return &protocol.Envelope{Transaction: []*protocol.Transaction{txn}, Signatures: []protocol.Signature{sig}}, nil
+}
+
+// signExternalAnchors signs pending records of external anchors that the node
+// has not signed, if they match the anchor the node recorded for the major
+// block. This is how the operators other than the one that initiated a record
+// approve it.
+func (x *Executor) signExternalAnchors() {
+	batch := x.db.Begin(false)
+	defer batch.Discard()
+
+	pending, err := batch.Account(x.Describe.NodeUrl(protocol.ExternalAnchors)).Pending().Get()
+	if err != nil {
+		if !errors.Is(err, errors.StatusNotFound) {
+			x.logger.Error("Failed to load pending external anchors", "module", "anchoring", "error", err)
+		}
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	var page *protocol.KeyPage
+	err = batch.Account(x.Describe.OperatorsPage()).GetStateAs(&page)
+	if err != nil {
+		x.logger.Error("Failed to load operator page", "module", "anchoring", "error", err)
+		return
+	}
+	keyHash := sha256.Sum256(x.Key[32:])
+	keyIndex, _, ok := page.EntryByKeyHash(keyHash[:])
+	if !ok {
+		return // Not an operator
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalAnchorTimeout)
+	defer cancel()
+	for _, txid := range pending {
+		hash := txid.Hash()
+		env, anchor, err := x.signExternalAnchorRecord(batch, page, uint64(keyIndex), hash[:])
+		if err != nil {
+			x.logger.Error("Failed to sign external anchor", "module", "anchoring", "hash", logging.AsHex(hash).Slice(0, 4), "error", err)
+			continue
+		}
+		if env != nil {
+			x.submitExternalAnchorRecord(ctx, env, anchor)
+		}
+	}
+}
+
+// signExternalAnchorRecord signs a pending record of an external anchor.
+// signExternalAnchorRecord returns nil if the node has already signed the
+// record.
+func (x *Executor) signExternalAnchorRecord(batch *database.Batch, page *protocol.KeyPage, keyIndex uint64, hash []byte) (*protocol.Envelope, *protocol.ExternalAnchor, error) {
+	sigs, err := batch.Transaction(hash).ReadSignaturesForSigner(page)
+	if err != nil {
+		return nil, nil, errors.Format(errors.StatusUnknownError, "load signatures: %w", err)
+	}
+	for _, entry := range sigs.Entries() {
+		if entry.KeyEntryIndex == keyIndex {
+			return nil, nil, nil
+		}
+	}
+
+	state, err := batch.Transaction(hash).Main().Get()
+	if err != nil {
+		return nil, nil, errors.Format(errors.StatusUnknownError, "load transaction: %w", err)
+	}
+	if state.Transaction == nil {
+		return nil, nil, errors.Format(errors.StatusInternalError, "not a transaction")
+	}
+	body, ok := state.Transaction.Body.(*protocol.WriteData)
+	if !ok {
+		return nil, nil, errors.Format(errors.StatusBadRequest, "invalid external anchor: want %v, got %v", protocol.TransactionTypeWriteData, state.Transaction.Body.Type())
+	}
+	anchor, err := parseExternalAnchor(body.Entry)
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	// Only sign a record that matches the anchor this node recorded
+	err = x.checkExternalAnchor(batch, anchor)
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	signer, err := x.operatorSigner(batch)
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	sig, err := signer.Sign(hash)
+	if err != nil {
+		return nil, nil, errors.Format(errors.StatusInternalError, "sign: %w", err)
+	}
+
+	env := &protocol.Envelope{Transaction: []*protocol.Transaction{state.Transaction}, Signatures: []protocol.Signature{sig}}
+	return env, anchor, nil
+}
+
+// operatorSigner returns a signature builder for the node's key as an entry
+// of the operator page.
+func (x *Executor) operatorSigner(batch *database.Batch) (*signing.Builder, error) {
+	var page *protocol.KeyPage
+	err := batch.Account(x.Describe.OperatorsPage()).GetStateAs(&page)
+	if err != nil {
+		return nil, errors.Format(errors.StatusUnknownError, "load operator page: %w", err)
+	}
+
+	signer := new(signing.Builder).
+		SetType(protocol.SignatureTypeED25519).
+		SetPrivateKey(x.Key).
+		SetUrl(page.Url).
+		SetVersion(page.Version).
+		SetTimestampToNow()
+	return signer, nil
+}
+
+// submitExternalAnchorRecord submits a signed record of an external anchor.
+func (x *Executor) submitExternalAnchorRecord(ctx context.Context, env *protocol.Envelope, anchor *protocol.ExternalAnchor) {
+	dispatcher := newDispatcher(x.ExecutorOptions)
+	err := dispatcher.BroadcastTxLocal(ctx, env)
+	if err != nil {
+		x.logger.Error("Failed to record external anchor", "module", "anchoring", "major-index", anchor.MajorBlockIndex, "error", err)
+		return
+	}
+	for err := range dispatcher.Send(ctx) {
+		x.logger.Error("Failed to record external anchor", "module", "anchoring", "major-index", anchor.MajorBlockIndex, "error", err)
+	}
+}
+
+// checkExternalAnchor verifies that the anchor matches the anchor recorded for
+// its major block, and that no reference has been recorded for the major
+// block.
+func (x *Executor) checkExternalAnchor(batch *database.Batch, anchor *protocol.ExternalAnchor) error {
+	recorded, err := batch.SystemData(x.Describe.PartitionId).ExternalAnchor(anchor.MajorBlockIndex).Get()
+	switch {
+	case err == nil:
+		// Ok
+	case errors.Is(err, storage.ErrNotFound):
+		return errors.Format(errors.StatusBadRequest, "invalid external anchor: major block %d has not been recorded", anchor.MajorBlockIndex)
+	default:
+		return errors.Format(errors.StatusUnknownError, "load anchor of major block %d: %w", anchor.MajorBlockIndex, err)
+	}
+
+	if recorded.Reference != "" {
+		return errors.Format(errors.StatusConflict, "invalid external anchor: major block %d has already been anchored externally", anchor.MajorBlockIndex)
+	}
+
+	unreferenced := anchor.Copy()
+	unreferenced.Reference = ""
+	if !unreferenced.Equal(recorded) {
+		return errors.Format(errors.StatusBadRequest, "invalid external anchor: does not match the anchor of major block %d", anchor.MajorBlockIndex)
+	}
+	return nil
+}
+
+// recordExternalAnchor checks an anchor written to the external anchors
+// account and records its reference.
+func (x *Executor) recordExternalAnchor(batch *database.Batch, entry protocol.DataEntry) error {
+	if !x.globals.Active.Globals.EnableExternalAnchors {
+		return errors.Format(errors.StatusBadRequest, "external anchors are not enabled")
+	}
+
+	anchor, err := parseExternalAnchor(entry)
+	if err != nil {
+		return errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	err = x.checkExternalAnchor(batch, anchor)
+	if err != nil {
+		return errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	err = batch.SystemData(x.Describe.PartitionId).ExternalAnchor(anchor.MajorBlockIndex).Put(anchor)
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "store external anchor: %w", err)
+	}
+	return nil
+}
+
+// parseExternalAnchor parses an entry of the external anchors account.
+func parseExternalAnchor(entry protocol.DataEntry) (*protocol.ExternalAnchor, error) {
+	if entry == nil || len(entry.GetData()) != 1 {
+		return nil, errors.Format(errors.StatusBadRequest, "invalid external anchor: want 1 record")
+	}
+
+	anchor := new(protocol.ExternalAnchor)
+	err := anchor.UnmarshalBinary(entry.GetData()[0])
+	if err != nil {
+		return nil, errors.Format(errors.StatusBadRequest, "invalid external anchor: %w", err)
+	}
+	if anchor.Reference == "" {
+		return nil, errors.Format(errors.StatusBadRequest, "invalid external anchor: missing reference")
+	}
+	return anchor, nil
+}
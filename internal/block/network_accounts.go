@@ -73,6 +73,11 @@ func (x *Executor) processNetworkAccountUpdates(batch *database.Batch, delivery
 			// Prevent direct writes
 			return errors.Format(errors.StatusBadRequest, "%v cannot be updated directly", principal)
 
+		case protocol.ExternalAnchors:
+			// Validate the entry and record the reference, but do not push it
+			err := x.recordExternalAnchor(batch, body.Entry)
+			return errors.Wrap(errors.StatusUnknownError, err)
+
 		default:
 			return nil
 		}
@@ -0,0 +1,179 @@
+package block
+
+import (
+	"gitlab.com/accumulatenetwork/accumulate/internal/chain"
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// refundLateSignatures issues credit refunds for forwarded signatures that
+// arrive after the transaction has been delivered. The signer paid the fee on
+// its own partition, but the signature did not contribute to the transaction.
+// The base signature fee is kept to cover the cost of processing the
+// signature, and the rest of the fee that was charged, as reported by the
+// signer's partition, is refunded.
+//
+// A signature that was included in a set received before the transaction was
+// delivered, or that has already been refunded, is not refunded.
+func (x *Executor) refundLateSignatures(block *Block, delivery *chain.Delivery) error {
+	if !delivery.IsForwarded() || !delivery.Transaction.Body.Type().IsUser() {
+		return nil
+	}
+
+	batch := block.Batch.Begin(true)
+	defer batch.Discard()
+
+	txn := batch.Transaction(delivery.Transaction.GetHash())
+	var refunds []*url.URL
+	amounts := map[[32]byte]protocol.Fee{}
+	for _, signature := range delivery.Signatures {
+		// Delegated signature sets are not stored by the principal's
+		// partition, so there is no way to tell which of their signatures
+		// were received before the transaction was delivered
+		remote, ok := signature.(*protocol.RemoteSignature)
+		if !ok {
+			continue
+		}
+		set, ok := remote.Signature.(*protocol.SignatureSet)
+		if !ok {
+			continue
+		}
+
+		received, err := receivedSignatures(txn, set.Signer)
+		if err != nil {
+			return errors.Wrap(errors.StatusUnknownError, err)
+		}
+
+		for i, signature := range set.Signatures {
+			keySig, ok := signature.(protocol.KeySignature)
+			if !ok || i >= len(set.Fees) || received[*(*[32]byte)(keySig.Hash())] {
+				continue
+			}
+
+			fee := protocol.Fee(set.Fees[i])
+			if fee <= protocol.FeeSignature {
+				continue
+			}
+
+			// Record the refund so the signature is not refunded twice
+			hash := *(*[32]byte)(keySig.Hash())
+			_, err = txn.RefundedSignatures().Index(hash)
+			switch {
+			case err == nil:
+				continue
+			case !errors.Is(err, errors.StatusNotFound):
+				return errors.Format(errors.StatusUnknownError, "load refunded signatures: %w", err)
+			}
+			err = txn.RefundedSignatures().Add(hash)
+			if err != nil {
+				return errors.Format(errors.StatusUnknownError, "store refunded signature: %w", err)
+			}
+
+			signer := keySig.GetSigner()
+			if key, _, _ := protocol.ParseLiteTokenAddress(signer); key != nil {
+				signer = signer.RootIdentity()
+			}
+			id := signer.AccountID32()
+			if _, ok := amounts[id]; !ok {
+				refunds = append(refunds, signer)
+			}
+			amounts[id] += fee - protocol.FeeSignature
+		}
+	}
+
+	if len(refunds) == 0 {
+		return nil
+	}
+
+	for _, signer := range refunds {
+		refund := new(protocol.SyntheticDepositCredits)
+		refund.Amount = amounts[signer.AccountID32()].AsUInt64()
+		refund.IsRefund = true
+		delivery.State.DidProduceTxn(signer, refund)
+	}
+
+	err := x.ProduceSynthetic(batch, delivery.Transaction, delivery.State.ProducedTxns)
+	if err != nil {
+		return errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	err = batch.Commit()
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "commit batch: %w", err)
+	}
+
+	block.State.MergeTransaction(&delivery.State)
+	return nil
+}
+
+// receivedSignatures returns the hashes of the signatures within the
+// signature sets that have been received from the signer.
+func receivedSignatures(txn *database.Transaction, signer *url.URL) (map[[32]byte]bool, error) {
+	sets, err := database.GetSignaturesForSigner(txn, &protocol.UnknownSigner{Url: signer})
+	if err != nil {
+		return nil, errors.Format(errors.StatusUnknownError, "load signatures: %w", err)
+	}
+
+	received := map[[32]byte]bool{}
+	for _, set := range sets {
+		set, ok := set.(*protocol.SignatureSet)
+		if !ok {
+			continue
+		}
+		for _, signature := range set.Signatures {
+			received[*(*[32]byte)(signature.Hash())] = true
+		}
+	}
+	return received, nil
+}
+
+// feePayer returns the signer that paid the transaction fee: the sponsor if the
+// transaction is sponsored, otherwise the initiator.
+func feePayer(transaction *protocol.Transaction, status *protocol.TransactionStatus) *url.URL {
+	sponsor := transaction.Header.Sponsor
+	if sponsor == nil {
+		return status.Initiator
+	}
+	if key, _, _ := protocol.ParseLiteTokenAddress(sponsor); key != nil {
+		return sponsor.RootIdentity()
+	}
+	return sponsor
+}
+
+// sponsorHasSigned returns true if the principal's partition has received a
+// signature from the sponsor of the transaction.
+func sponsorHasSigned(batch *database.Batch, transaction *protocol.Transaction, status *protocol.TransactionStatus) (bool, error) {
+	sigs, err := batch.Transaction(transaction.GetHash()).ReadSignatures(feePayer(transaction, status))
+	if err != nil {
+		return false, errors.Format(errors.StatusUnknownError, "load sponsor signatures: %w", err)
+	}
+	return sigs.Count() > 0, nil
+}
+
+// refundSponsoredInitiator refunds the base signature fee paid by the
+// initiator of a sponsored transaction. The sponsor pays for the transaction,
+// but the initiator is charged when it signs so that a transaction the
+// sponsor never signs is not free.
+func refundSponsoredInitiator(state *chain.ProcessTransactionState, transaction *protocol.Transaction, status *protocol.TransactionStatus) {
+	if transaction.Header.Sponsor == nil || status.Initiator == nil || transaction.IsSponsor(status.Initiator) {
+		return
+	}
+
+	// Don't refund internal administrative signers, they are not charged
+	_, isBvn := protocol.ParsePartitionUrl(status.Initiator)
+	if isBvn || protocol.IsDnUrl(status.Initiator) {
+		return
+	}
+
+	initiator := status.Initiator
+	if key, _, _ := protocol.ParseLiteTokenAddress(initiator); key != nil {
+		initiator = initiator.RootIdentity()
+	}
+
+	refund := new(protocol.SyntheticDepositCredits)
+	refund.Amount = protocol.FeeSignature.AsUInt64()
+	refund.IsRefund = true
+	state.DidProduceTxn(initiator, refund)
+}
@@ -126,6 +126,19 @@ func (x *Executor) shouldForwardSignature(batch *database.Batch, transaction *pr
 	set.TransactionHash = *(*[32]byte)(transaction.GetHash())
 	set.Signatures = sigset
 
+	// Include the fees that were charged, so the principal's partition can
+	// refund signatures that arrive too late
+	set.Fees = make([]uint64, len(sigset))
+	for i, signature := range sigset {
+		fee, err := record.SignatureFee(*(*[32]byte)(signature.Hash())).Get()
+		switch {
+		case err == nil:
+			set.Fees[i] = fee
+		case !errors.Is(err, errors.StatusNotFound):
+			return nil, nil, errors.Format(errors.StatusUnknownError, "load signature fee: %w", err)
+		}
+	}
+
 	fwd := new(protocol.RemoteSignature)
 	fwd.Destination = destination
 	fwd.Signature = set
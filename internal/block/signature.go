@@ -439,10 +439,15 @@ func validateKeySignature(transaction *protocol.Transaction, signer protocol.Sig
 
 // SignerIsAuthorized verifies that the signer is allowed to sign the transaction
 func (x *Executor) SignerIsAuthorized(batch *database.Batch, transaction *protocol.Transaction, signer protocol.Signer, checkAuthz bool) error {
+	// The sponsor pays the fee but is not required to be one of the
+	// principal's authorities. Its signature only counts towards the
+	// principal's authorities if it belongs to one of them.
+	sponsor := transaction.IsSponsor(signer.GetUrl())
+
 	switch signer := signer.(type) {
 	case *protocol.LiteIdentity:
 		// Otherwise a lite token account is only allowed to sign for itself
-		if !signer.Url.Equal(transaction.Header.Principal.RootIdentity()) {
+		if !sponsor && !signer.Url.Equal(transaction.Header.Principal.RootIdentity()) {
 			return errors.Format(errors.StatusUnauthorized, "%v is not authorized to sign transactions for %v", signer.Url, transaction.Header.Principal)
 		}
 
@@ -455,12 +460,12 @@ func (x *Executor) SignerIsAuthorized(batch *database.Batch, transaction *protoc
 			return errors.Format(errors.StatusUnauthorized, "page %s is not authorized to sign %v", signer.Url, transaction.Body.Type())
 		}
 
-		if !checkAuthz {
+		if !checkAuthz || sponsor {
 			return nil
 		}
 
 	case *protocol.UnknownSigner:
-		if !checkAuthz {
+		if !checkAuthz || sponsor {
 			return nil
 		}
 
@@ -515,40 +520,29 @@ func (x *Executor) verifyPageIsAuthorized(batch *database.Batch, transaction *pr
 	return errors.Format(errors.StatusUnauthorized, "%v is not authorized to sign transactions for %v", signer.GetUrl(), principal.GetUrl())
 }
 
-// computeSignerFee computes the fee that will be charged to the signer.
-//
-// If the signature is the initial signature, the fee is the base transaction
-// fee + signature data surcharge + transaction data surcharge.
-//
-// Otherwise, the fee is the base signature fee + signature data surcharge.
-func (x *Executor) computeSignerFee(transaction *protocol.Transaction, signature protocol.KeySignature, md sigExecMetadata) (protocol.Fee, error) {
-	// Don't charge fees for internal administrative functions
-	signer := signature.GetSigner()
-	_, isBvn := protocol.ParsePartitionUrl(signer)
-	if isBvn || protocol.IsDnUrl(signer) {
-		return 0, nil
-	}
-
-	// Compute the signature fee
-	fee, err := x.globals.Active.Globals.FeeSchedule.ComputeSignatureFee(signature)
-	if err != nil {
-		return 0, errors.Wrap(errors.StatusUnknownError, err)
-	}
+// computeSignerFee computes the fee that will be charged to the signer, using
+// the active fee schedule. See protocol.FeeSchedule.ComputeSignerFee.
+func (x *Executor) computeSignerFee(transaction *protocol.Transaction, signature protocol.KeySignature, md sigExecMetadata, sponsorPaid bool) (protocol.Fee, error) {
+	fee, err := x.globals.Active.Globals.FeeSchedule.ComputeSignerFee(transaction, signature, md.IsInitiator, sponsorPaid)
+	return fee, errors.Wrap(errors.StatusUnknownError, err)
+}
 
-	// Only charge the transaction fee for the initial signature
-	if !md.IsInitiator {
-		return fee, nil
+// sponsorHasPaid returns true if the sponsor of the transaction has paid the
+// transaction fee. sponsorHasPaid must be called on the sponsor's partition.
+func sponsorHasPaid(batch *database.Batch, transaction *protocol.Transaction) (bool, error) {
+	if transaction.Header.Sponsor == nil {
+		return false, nil
 	}
 
-	// Add the transaction fee for the initial signature
-	txnFee, err := x.globals.Active.Globals.FeeSchedule.ComputeTransactionFee(transaction)
-	if err != nil {
-		return 0, errors.Wrap(errors.StatusUnknownError, err)
+	_, err := batch.Transaction(transaction.GetHash()).SponsorFee().Get()
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, errors.StatusNotFound):
+		return false, nil
+	default:
+		return false, errors.Format(errors.StatusUnknownError, "load sponsor fee: %w", err)
 	}
-
-	// Subtract the base signature fee, but not the oversize surcharge if there is one
-	fee += txnFee - protocol.FeeSignature
-	return fee, nil
 }
 
 // validateKeySignature validates a private key signature.
@@ -571,7 +565,11 @@ func (x *Executor) validateKeySignature(batch *database.Batch, delivery *chain.D
 	}
 
 	// Ensure the signer has sufficient credits for the fee
-	fee, err := x.computeSignerFee(delivery.Transaction, signature, md)
+	sponsorPaid, err := sponsorHasPaid(batch, delivery.Transaction)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	fee, err := x.computeSignerFee(delivery.Transaction, signature, md, sponsorPaid)
 	if err != nil {
 		return nil, errors.Wrap(errors.StatusUnknownError, err)
 	}
@@ -621,8 +619,8 @@ func (x *Executor) processKeySignature(batch *database.Batch, delivery *chain.De
 		return nil, errors.Wrap(errors.StatusUnknownError, err)
 	}
 
-	// TODO If the forwarded signature paid the full fee unnecessarily, refund
-	// it
+	// If the signature is forwarded and arrives after the transaction is
+	// delivered, the fee recorded below is refunded by refundLateSignatures
 
 	// Validate the signature against the signer. This should also not fail.
 	entry, err := validateKeySignature(delivery.Transaction, signer, signature)
@@ -636,7 +634,11 @@ func (x *Executor) processKeySignature(batch *database.Batch, delivery *chain.De
 	}
 
 	// Charge the fee
-	fee, err := x.computeSignerFee(delivery.Transaction, signature, md)
+	sponsorPaid, err := sponsorHasPaid(batch, delivery.Transaction)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	fee, err := x.computeSignerFee(delivery.Transaction, signature, md, sponsorPaid)
 	if err != nil {
 		return nil, errors.Format(errors.StatusBadRequest, "calculating fee: %w", err)
 	}
@@ -646,6 +648,24 @@ func (x *Executor) processKeySignature(batch *database.Batch, delivery *chain.De
 			protocol.FormatAmount(fee.AsUInt64(), protocol.CreditPrecisionPower))
 	}
 
+	// Record the fee of a signature that will be forwarded, so the fee that
+	// was actually charged is refunded if the signature arrives too late
+	if !delivery.Transaction.Header.Principal.LocalTo(md.Location) {
+		err = batch.Transaction(delivery.Transaction.GetHash()).SignatureFee(*(*[32]byte)(signature.Hash())).Put(fee.AsUInt64())
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "store signature fee: %w", err)
+		}
+	}
+
+	// Record that the sponsor has paid so its other keys are not charged the
+	// transaction fee
+	if !sponsorPaid && delivery.Transaction.IsSponsor(signature.GetSigner()) {
+		err = batch.Transaction(delivery.Transaction.GetHash()).SponsorFee().Put(fee.AsUInt64())
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "store sponsor fee: %w", err)
+		}
+	}
+
 	// Update the timestamp - the value is validated by validateSignature
 	if signature.GetTimestamp() != 0 {
 		entry.SetLastUsedOn(signature.GetTimestamp())
@@ -0,0 +1,212 @@
+package block
+
+import (
+	"time"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/chain"
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// Simulate executes the envelope as if it were delivered in the next block,
+// using a batch that is discarded afterwards, and reports the fee that would
+// be charged, the synthetic transactions that were produced, and the changes
+// made to each account. Synthetic transactions are produced but never
+// dispatched since the block is never ended.
+//
+// Validation, execution, and the comparison all use the same batch, so the
+// results reflect a single committed height even if a block is committed
+// while the simulation is running.
+//
+// The envelope is validated first, as it would be by CheckTx. If validation
+// fails, only the validation results are returned.
+func (x *Executor) Simulate(envelope *protocol.Envelope) (*protocol.SimulateResponse, error) {
+	deliveries, err := chain.NormalizeEnvelope(envelope)
+	if err != nil {
+		return nil, errors.Format(errors.StatusBadRequest, "normalize envelope: %w", err)
+	}
+
+	// The simulation is never committed. Changes are made in nested batches
+	// so the root batch retains the original state.
+	before := x.db.Begin(true)
+	defer before.Discard()
+
+	// Validate the envelope as CheckTx would
+	batch := before.Begin(false)
+	resp := new(protocol.SimulateResponse)
+	resp.Results = x.ValidateEnvelopeSet(batch, deliveries, nil)
+	batch.Discard()
+	for _, result := range resp.Results {
+		if result.Failed() {
+			return resp, nil
+		}
+	}
+
+	resp.Fee, err = x.computeEnvelopeFee(before, deliveries)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	block := new(Block)
+	block.DryRun = true
+	block.Batch = before.Begin(true)
+	defer block.Batch.Discard()
+
+	var ledger *protocol.SystemLedger
+	err = block.Batch.Account(x.Describe.NodeUrl(protocol.Ledger)).GetStateAs(&ledger)
+	if err != nil {
+		return nil, errors.Format(errors.StatusUnknownError, "load system ledger: %w", err)
+	}
+
+	block.Index = ledger.Index + 1
+	block.Time = time.Now().UTC()
+
+	resp.Results = x.ExecuteEnvelopeSet(block, deliveries, nil)
+	resp.Produced = block.State.ProducedTxns
+
+	// Compare every account that was modified against its original state
+	for _, account := range block.Batch.DirtyAccounts() {
+		change, err := diffAccount(before.Account(account.Url()), account)
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "diff %v: %w", account.Url(), err)
+		}
+		resp.Changes = append(resp.Changes, change)
+	}
+
+	return resp, nil
+}
+
+// computeEnvelopeFee computes the fees the signatures of the deliveries will be
+// charged, using the active fee schedule. The fees are charged when the
+// signatures are processed, regardless of whether the transaction succeeds.
+func (x *Executor) computeEnvelopeFee(batch *database.Batch, deliveries []*chain.Delivery) (protocol.Fee, error) {
+	var total protocol.Fee
+	for _, delivery := range deliveries {
+		if !delivery.Transaction.Body.Type().IsUser() {
+			continue
+		}
+
+		// The sponsor only pays the transaction fee once
+		sponsorPaid, err := sponsorHasPaid(batch, delivery.Transaction)
+		if err != nil {
+			return 0, errors.Wrap(errors.StatusUnknownError, err)
+		}
+
+		for _, signature := range delivery.Signatures {
+			var md sigExecMetadata
+			md.IsInitiator = protocol.SignatureDidInitiate(signature, delivery.Transaction.Header.Initiator[:], nil)
+
+			// The fee is charged to the key signature of a delegated signature
+			for {
+				delegated, ok := signature.(*protocol.DelegatedSignature)
+				if !ok {
+					break
+				}
+				signature = delegated.Signature
+			}
+
+			keySig, ok := signature.(protocol.KeySignature)
+			if !ok {
+				continue
+			}
+
+			fee, err := x.computeSignerFee(delivery.Transaction, keySig, md, sponsorPaid)
+			if err != nil {
+				return 0, errors.Format(errors.StatusUnknownError, "compute fee: %w", err)
+			}
+			total += fee
+
+			if delivery.Transaction.IsSponsor(keySig.GetSigner()) {
+				sponsorPaid = true
+			}
+		}
+	}
+	return total, nil
+}
+
+// diffAccount compares the state, directory, and chains of an account.
+func diffAccount(before, after *database.Account) (*protocol.AccountChange, error) {
+	change := new(protocol.AccountChange)
+	change.Url = after.Url()
+
+	var err error
+	change.Before, err = loadOptional(before.Main().Get)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	change.After, err = loadOptional(after.Main().Get)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	// Directory entries
+	dirBefore, err := loadOptional(before.Directory().Get)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	dirAfter, err := loadOptional(after.Directory().Get)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	change.DirectoryAdded = urlsNotIn(dirAfter, dirBefore)
+	change.DirectoryRemoved = urlsNotIn(dirBefore, dirAfter)
+
+	// Chain heights
+	chains, err := loadOptional(after.Chains().Get)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	for _, meta := range chains {
+		c, err := after.GetChainByName(meta.Name)
+		if err != nil {
+			return nil, errors.Format(errors.StatusUnknownError, "load %s chain: %w", meta.Name, err)
+		}
+		var height int64
+		if c, err := before.GetChainByName(meta.Name); err == nil {
+			height = c.Height()
+		}
+		if height == c.Height() {
+			continue
+		}
+		change.Chains = append(change.Chains, protocol.ChainChange{
+			Name:   meta.Name,
+			Before: uint64(height),
+			After:  uint64(c.Height()),
+		})
+	}
+
+	return change, nil
+}
+
+// loadOptional calls get and returns the zero value if the record does not
+// exist.
+func loadOptional[T any](get func() (T, error)) (T, error) {
+	v, err := get()
+	switch {
+	case err == nil:
+		return v, nil
+	case errors.Is(err, errors.StatusNotFound):
+		var z T
+		return z, nil
+	default:
+		return v, err
+	}
+}
+
+// urlsNotIn returns the URLs in a that are not in b.
+func urlsNotIn(a, b []*url.URL) []*url.URL {
+	seen := make(map[[32]byte]bool, len(b))
+	for _, u := range b {
+		seen[u.AccountID32()] = true
+	}
+
+	var diff []*url.URL
+	for _, u := range a {
+		if !seen[u.AccountID32()] {
+			diff = append(diff, u)
+		}
+	}
+	return diff
+}
@@ -177,6 +177,7 @@ func (sim *Simulator) Setup(opts SimulatorOptions) {
 			TxMaxWaitTime: time.Hour,
 			Database:      x,
 			Key:           execOpts.Key,
+			Simulator:     x.Executor,
 		})
 		require.NoError(sim, err)
 		x.API = acctesting.DirectJrpcClient(jrpc)
@@ -221,6 +222,7 @@ func (sim *Simulator) Setup(opts SimulatorOptions) {
 			TxMaxWaitTime: time.Hour,
 			Database:      x,
 			Key:           execOpts.Key,
+			Simulator:     x.Executor,
 		})
 		require.NoError(sim, err)
 		x.API = acctesting.DirectJrpcClient(jrpc)
@@ -712,11 +714,18 @@ func (x *ExecEntry) executeBlock(errg *errgroup.Group, statusChan chan<- *protoc
 		require.NoError(x, x.Executor.EndBlock(block))
 
 		// Is the block empty?
-		if !block.State.Empty() {
-			// Commit the batch
-			require.NoError(x, block.Batch.Commit())
+		if block.State.Empty() {
+			return nil
 		}
 
-		return nil
+		// Commit the batch
+		require.NoError(x, block.Batch.Commit())
+
+		// Notify subscribers
+		return x.Executor.EventBus.Publish(events.DidCommitBlock{
+			Index: block.Index,
+			Time:  block.Time,
+			Major: block.State.MakeMajorBlock,
+		})
 	})
 }
@@ -44,6 +44,20 @@ func (x *Executor) ProduceSynthetic(batch *database.Batch, from *protocol.Transa
 		if err != nil {
 			return err
 		}
+
+		// Link credit refunds to the transaction they refund
+		if deposit, ok := sub.Body.(*protocol.SyntheticDepositCredits); ok && deposit.IsRefund {
+			record := batch.Transaction(from.GetHash())
+			status, err := record.GetStatus()
+			if err != nil {
+				return errors.Format(errors.StatusUnknownError, "load status: %w", err)
+			}
+			status.Refunds = append(status.Refunds, tx.ID())
+			err = record.PutStatus(status)
+			if err != nil {
+				return errors.Format(errors.StatusUnknownError, "store status: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -66,8 +80,13 @@ func (x *Executor) setSyntheticOrigin(batch *database.Batch, from *protocol.Tran
 			continue
 		}
 
-		swos = append(swos, swo)
 		swo.SetCause(*(*[32]byte)(from.GetHash()), from.Header.Principal)
+
+		// Refunds are never refunded
+		if deposit, ok := swo.(*protocol.SyntheticDepositCredits); ok && deposit.IsRefund {
+			continue
+		}
+		swos = append(swos, swo)
 	}
 	if len(swos) == 0 {
 		return nil
@@ -85,7 +104,7 @@ func (x *Executor) setSyntheticOrigin(batch *database.Batch, from *protocol.Tran
 	}
 
 	for _, swo := range swos {
-		swo.SetRefund(status.Initiator, refund)
+		swo.SetRefund(feePayer(from, status), refund)
 	}
 	return nil
 }
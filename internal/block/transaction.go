@@ -145,6 +145,17 @@ func (x *Executor) userTransactionIsReady(batch *database.Batch, delivery *chain
 		return true, nil
 	}
 
+	// A sponsored transaction is not ready until the sponsor has signed it
+	if delivery.Transaction.Header.Sponsor != nil {
+		signed, err := sponsorHasSigned(batch, delivery.Transaction, status)
+		if err != nil {
+			return false, errors.Wrap(errors.StatusUnknownError, err)
+		}
+		if !signed {
+			return false, nil
+		}
+	}
+
 	// UpdateKey transactions are always M=1 and always require a signature from
 	// the initiator
 	if delivery.Transaction.Body.Type() == protocol.TransactionTypeUpdateKey {
@@ -504,6 +515,11 @@ func (x *Executor) recordSuccessfulTransaction(batch *database.Batch, state *cha
 		return nil, nil, fmt.Errorf("store pending list: %w", err)
 	}
 
+	// The sponsor has signed, so refund the initiator's signature fee
+	if typ.IsUser() {
+		refundSponsoredInitiator(state, delivery.Transaction, status)
+	}
+
 	// Add the transaction to the principal's main or scratch chain
 	chain := selectTargetChain(record, delivery.Transaction.Body)
 	err = state.ChainUpdates.AddChainEntry(batch, chain, delivery.Transaction.GetHash(), 0, 0)
@@ -539,6 +555,7 @@ func (x *Executor) recordFailedTransaction(batch *database.Batch, delivery *chai
 		if refundAmount > 0 {
 			refund := new(protocol.SyntheticDepositCredits)
 			refund.Amount = refundAmount.AsUInt64()
+			refund.IsRefund = true
 			state.DidProduceTxn(init, refund)
 		}
 	}
@@ -557,11 +574,24 @@ func (x *Executor) recordFailedTransaction(batch *database.Batch, delivery *chai
 		return nil, nil, fmt.Errorf("update pending list: %w", err)
 	}
 
-	// Issue a refund to the initial signer
+	// Issue a refund to the fee payer
 	if status.Initiator == nil || !delivery.Transaction.Body.Type().IsUser() {
 		return status, state, nil
 	}
 
+	// The sponsor only pays once it signs, and once it has signed the
+	// initiator's signature fee is refunded
+	if delivery.Transaction.Header.Sponsor != nil {
+		signed, err := sponsorHasSigned(batch, delivery.Transaction, status)
+		if err != nil {
+			return nil, nil, errors.Wrap(errors.StatusUnknownError, err)
+		}
+		if !signed {
+			return status, state, nil
+		}
+		refundSponsoredInitiator(state, delivery.Transaction, status)
+	}
+
 	// But only if the paid paid is larger than the max failure paid
 	paid, err := x.globals.Active.Globals.FeeSchedule.ComputeTransactionFee(delivery.Transaction)
 	if err != nil {
@@ -573,6 +603,7 @@ func (x *Executor) recordFailedTransaction(batch *database.Batch, delivery *chai
 
 	refund := new(protocol.SyntheticDepositCredits)
 	refund.Amount = (paid - protocol.FeeFailedMaximum).AsUInt64()
-	state.DidProduceTxn(status.Initiator, refund)
+	refund.IsRefund = true
+	state.DidProduceTxn(feePayer(delivery.Transaction, status), refund)
 	return status, state, nil
 }
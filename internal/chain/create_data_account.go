@@ -54,6 +54,10 @@ func (CreateDataAccount) Validate(st *StateManager, tx *Delivery) (protocol.Tran
 		}
 	}
 
+	if body.HashAlgorithm.Func() == nil {
+		return nil, errors.Format(errors.StatusBadRequest, "unknown hash algorithm %v", body.HashAlgorithm)
+	}
+
 	err := checkCreateAdiAccount(st, body.Url)
 	if err != nil {
 		return nil, err
@@ -68,6 +72,18 @@ func (CreateDataAccount) Validate(st *StateManager, tx *Delivery) (protocol.Tran
 		return nil, err
 	}
 
+	// Set the hash algorithm of the data chains before the account is created
+	// and its main chain gets its first entry
+	if body.HashAlgorithm != protocol.HashAlgorithmSHA256 && !st.Pretend {
+		record := st.batch.Account(account.Url)
+		for _, chain := range []*database.Chain2{record.MainChain(), record.ScratchChain()} {
+			err = chain.SetHashAlgorithm(body.HashAlgorithm)
+			if err != nil {
+				return nil, errors.Wrap(errors.StatusUnknownError, err)
+			}
+		}
+	}
+
 	err = st.Create(account)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create %v: %w", account.Url, err)
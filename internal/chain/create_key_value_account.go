@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"fmt"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+type CreateKeyValueAccount struct{}
+
+var _ SignerValidator = (*CreateKeyValueAccount)(nil)
+
+func (CreateKeyValueAccount) Type() protocol.TransactionType {
+	return protocol.TransactionTypeCreateKeyValueAccount
+}
+
+func (CreateKeyValueAccount) SignerIsAuthorized(delegate AuthDelegate, batch *database.Batch, transaction *protocol.Transaction, signer protocol.Signer, md SignatureValidationMetadata) (fallback bool, err error) {
+	body, ok := transaction.Body.(*protocol.CreateKeyValueAccount)
+	if !ok {
+		return false, fmt.Errorf("invalid payload: want %T, got %T", new(protocol.CreateKeyValueAccount), transaction.Body)
+	}
+
+	return additionalAuthorities(body.Authorities).SignerIsAuthorized(delegate, batch, transaction, signer, md)
+}
+
+func (CreateKeyValueAccount) TransactionIsReady(delegate AuthDelegate, batch *database.Batch, transaction *protocol.Transaction, status *protocol.TransactionStatus) (ready, fallback bool, err error) {
+	body, ok := transaction.Body.(*protocol.CreateKeyValueAccount)
+	if !ok {
+		return false, false, fmt.Errorf("invalid payload: want %T, got %T", new(protocol.CreateKeyValueAccount), transaction.Body)
+	}
+
+	return additionalAuthorities(body.Authorities).TransactionIsReady(delegate, batch, transaction, status)
+}
+
+func (CreateKeyValueAccount) Execute(st *StateManager, tx *Delivery) (protocol.TransactionResult, error) {
+	return (CreateKeyValueAccount{}).Validate(st, tx)
+}
+
+func (CreateKeyValueAccount) Validate(st *StateManager, tx *Delivery) (protocol.TransactionResult, error) {
+	body, ok := tx.Transaction.Body.(*protocol.CreateKeyValueAccount)
+	if !ok {
+		return nil, fmt.Errorf("invalid payload: want %T, got %T", new(protocol.CreateKeyValueAccount), tx.Transaction.Body)
+	}
+
+	if body.Url == nil {
+		return nil, errors.Format(errors.StatusBadRequest, "account URL is missing")
+	}
+
+	for _, u := range body.Authorities {
+		if u == nil {
+			return nil, errors.Format(errors.StatusBadRequest, "authority URL is nil")
+		}
+	}
+
+	err := checkCreateAdiAccount(st, body.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the key/value account
+	account := new(protocol.KeyValueAccount)
+	account.Url = body.Url
+
+	err = st.SetAuth(account, body.Authorities)
+	if err != nil {
+		return nil, err
+	}
+
+	err = st.Create(account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %v: %w", account.Url, err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package chain
+
+import (
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+type SetKeyValue struct{}
+
+func (SetKeyValue) Type() protocol.TransactionType { return protocol.TransactionTypeSetKeyValue }
+
+func (SetKeyValue) Execute(st *StateManager, tx *Delivery) (protocol.TransactionResult, error) {
+	return (SetKeyValue{}).Validate(st, tx)
+}
+
+func (SetKeyValue) Validate(st *StateManager, tx *Delivery) (protocol.TransactionResult, error) {
+	body, ok := tx.Transaction.Body.(*protocol.SetKeyValue)
+	if !ok {
+		return nil, errors.Format(errors.StatusInternalError, "invalid payload: want %T, got %T", new(protocol.SetKeyValue), tx.Transaction.Body)
+	}
+
+	err := checkKeyValue(st, body.Key)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	if len(body.Value) == 0 {
+		return nil, errors.Format(errors.StatusBadRequest, "value is missing")
+	}
+
+	st.UpdateKeyValue(st.OriginUrl, body.Key, body.Value)
+	return nil, nil
+}
+
+type DeleteKeyValue struct{}
+
+func (DeleteKeyValue) Type() protocol.TransactionType { return protocol.TransactionTypeDeleteKeyValue }
+
+func (DeleteKeyValue) Execute(st *StateManager, tx *Delivery) (protocol.TransactionResult, error) {
+	return (DeleteKeyValue{}).Validate(st, tx)
+}
+
+func (DeleteKeyValue) Validate(st *StateManager, tx *Delivery) (protocol.TransactionResult, error) {
+	body, ok := tx.Transaction.Body.(*protocol.DeleteKeyValue)
+	if !ok {
+		return nil, errors.Format(errors.StatusInternalError, "invalid payload: want %T, got %T", new(protocol.DeleteKeyValue), tx.Transaction.Body)
+	}
+
+	err := checkKeyValue(st, body.Key)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	_, err = st.batch.Account(st.OriginUrl).GetKeyValue(body.Key)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	st.UpdateKeyValue(st.OriginUrl, body.Key, nil)
+	return nil, nil
+}
+
+// checkKeyValue verifies the principal is a key/value account and the key is
+// valid.
+func checkKeyValue(st *StateManager, key []byte) error {
+	if st.Origin == nil {
+		return errors.NotFound("%v not found", st.OriginUrl)
+	}
+
+	if st.Origin.Type() != protocol.AccountTypeKeyValueAccount {
+		return errors.Format(errors.StatusBadRequest, "invalid principal: want %v, got %v",
+			protocol.AccountTypeKeyValueAccount, st.Origin.Type())
+	}
+
+	switch {
+	case len(key) == 0:
+		return errors.Format(errors.StatusBadRequest, "key is missing")
+	case len(key) > protocol.KeyValueKeyMaxLength:
+		return errors.Format(errors.StatusBadRequest, "key exceeds %d bytes", protocol.KeyValueKeyMaxLength)
+	}
+	return nil
+}
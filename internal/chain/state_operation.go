@@ -58,3 +58,32 @@ func (op *addDataEntry) Execute(st *stateCache) ([]protocol.Account, error) {
 	// Add TX to main chain
 	return nil, st.State.ChainUpdates.AddChainEntry(st.batch, record.MainChain(), st.txHash[:], 0, 0)
 }
+
+type updateKeyValue struct {
+	url   *url.URL
+	key   []byte
+	value []byte
+}
+
+// UpdateKeyValue will cache a change to a value of a key/value account. A nil
+// value deletes the value.
+func (m *stateCache) UpdateKeyValue(account *url.URL, key, value []byte) {
+	m.operations = append(m.operations, &updateKeyValue{account, key, value})
+}
+
+func (op *updateKeyValue) Execute(st *stateCache) ([]protocol.Account, error) {
+	record := st.batch.Account(op.url)
+
+	var err error
+	if op.value == nil {
+		err = record.DeleteKeyValue(op.key)
+	} else {
+		err = record.PutKeyValue(op.key, op.value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update key %X of %v: %w", op.key, op.url, err)
+	}
+
+	// Add TX to main chain
+	return nil, st.State.ChainUpdates.AddChainEntry(st.batch, record.MainChain(), st.txHash[:], 0, 0)
+}
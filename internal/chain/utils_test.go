@@ -80,6 +80,12 @@ func GetExecutor(t *testing.T, typ protocol.TransactionType) TransactionExecutor
 		return UpdateAccountAuth{}
 	case protocol.TransactionTypeUpdateKey:
 		return UpdateKey{}
+	case protocol.TransactionTypeCreateKeyValueAccount:
+		return CreateKeyValueAccount{}
+	case protocol.TransactionTypeSetKeyValue:
+		return SetKeyValue{}
+	case protocol.TransactionTypeDeleteKeyValue:
+		return DeleteKeyValue{}
 	case protocol.TransactionTypeSyntheticCreateIdentity:
 		return SyntheticCreateIdentity{}
 	case protocol.TransactionTypeSyntheticWriteData:
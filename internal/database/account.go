@@ -69,8 +69,15 @@ func (a *Account) Commit() error {
 		}
 	}
 
+	// Update the key/value tree before the BPT entry, since the entry
+	// includes the root of the tree
+	err := a.updateKeyValueTree()
+	if err != nil {
+		return errors.Wrap(errors.StatusUnknownError, err)
+	}
+
 	// If anything has changed, update the BPT entry
-	err := a.putBpt()
+	err = a.putBpt()
 	if err != nil {
 		return errors.Format(errors.StatusUnknownError, "update BPT entry for %v: %w", a.Url(), err)
 	}
@@ -49,6 +49,12 @@ func newChain2(parent record.Record, _ log.Logger, _ record.Store, key record.Ke
 	}
 
 	c := managed.NewChain(account.parent.logger.L, account.parent.store, key, markPower, typ, namefmt, labelfmt)
+
+	// Use the hash algorithm recorded in the chain's metadata, if there is any
+	meta, err := account.Chains().Find(&protocol.ChainMetadata{Name: c.Name()})
+	if err == nil {
+		c.SetHashAlgorithm(meta.HashAlgorithm)
+	}
 	return &Chain2{account, key, c, nil, labelfmt}
 }
 
@@ -61,6 +67,72 @@ func (c *Chain2) Name() string { return c.inner.Name() }
 // Type returns the type of the chain.
 func (c *Chain2) Type() managed.ChainType { return c.inner.Type() }
 
+// HashAlgorithm returns the algorithm used to combine the hashes of the chain.
+func (c *Chain2) HashAlgorithm() managed.HashAlgorithm { return c.inner.HashAlgorithm() }
+
+// SetHashAlgorithm sets the algorithm used to combine the hashes of the chain
+// and records it in the chain's metadata. Only the data chains of a data
+// account, its main and scratch chains, can use an algorithm other than the
+// default, and the algorithm cannot be changed once the chain has entries.
+func (c *Chain2) SetHashAlgorithm(alg managed.HashAlgorithm) error {
+	if alg.Func() == nil {
+		return errors.Format(errors.StatusBadRequest, "unknown hash algorithm %v", alg)
+	}
+	if alg != c.Type().DefaultHashAlgorithm() {
+		ok, err := c.isDataChain()
+		if err != nil {
+			return errors.Wrap(errors.StatusUnknownError, err)
+		}
+		if !ok {
+			return errors.Format(errors.StatusBadRequest, "chain %s of %v cannot use %v", c.Name(), c.Account(), alg)
+		}
+	}
+
+	head, err := c.inner.Head().Get()
+	if err != nil {
+		return errors.Wrap(errors.StatusUnknownError, err)
+	}
+	if head.Count > 0 && head.HashAlgorithm != alg {
+		return errors.Format(errors.StatusConflict, "cannot change the hash algorithm of chain %s from %v to %v", c.Name(), head.HashAlgorithm, alg)
+	}
+
+	c.inner.SetHashAlgorithm(alg)
+	err = c.account.Chains().Add(&protocol.ChainMetadata{Name: c.Name(), Type: c.Type(), HashAlgorithm: alg})
+	return errors.Wrap(errors.StatusUnknownError, err)
+}
+
+// isDataChain returns true if the chain is the main or scratch chain of a data
+// account. The chains of an account that does not exist yet are treated as
+// data chains, so a data account's algorithm can be chosen when it is created.
+func (c *Chain2) isDataChain() (bool, error) {
+	switch c.key[2] {
+	case "MainChain", "ScratchChain":
+	default:
+		return false, nil
+	}
+
+	state, err := c.account.Main().Get()
+	switch {
+	case err == nil:
+		switch state.Type() {
+		case protocol.AccountTypeDataAccount,
+			protocol.AccountTypeLiteDataAccount:
+			return true, nil
+		}
+		return false, nil
+	case errors.Is(err, errors.StatusNotFound):
+		return true, nil
+	default:
+		return false, errors.Format(errors.StatusUnknownError, "load %v: %w", c.Account(), err)
+	}
+}
+
+// Verify recomputes the chain's Merkle state from its entries and reports
+// stored records that do not match. See managed.Chain.Verify.
+func (c *Chain2) Verify(fix bool, visit func(*managed.MerkleState) error, report func(error)) error {
+	return c.inner.Verify(fix, visit, report)
+}
+
 // Url returns the URL of the chain: {account}#chain/{name}.
 func (c *Chain2) Url() *url.URL {
 	return c.Account().WithFragment("chain/" + c.Name())
@@ -103,7 +175,7 @@ func (c *Chain2) Get() (*Chain, error) {
 	case !errors.Is(err, errors.StatusNotFound):
 		return nil, errors.Wrap(errors.StatusUnknownError, err)
 	default:
-		err = c.account.Chains().Add(&protocol.ChainMetadata{Name: c.Name(), Type: c.Type()})
+		err = c.account.Chains().Add(&protocol.ChainMetadata{Name: c.Name(), Type: c.Type(), HashAlgorithm: c.HashAlgorithm()})
 		if err != nil {
 			return nil, errors.Wrap(errors.StatusUnknownError, err)
 		}
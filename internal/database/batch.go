@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"sort"
 
 	"gitlab.com/accumulatenetwork/accumulate/internal/database/record"
 	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
@@ -180,6 +181,21 @@ func (b *Batch) AccountByID(id []byte) (*Account, error) {
 	return b.Account(u), nil
 }
 
+// DirtyAccounts returns the accounts that have uncommitted changes, sorted by
+// URL.
+func (b *Batch) DirtyAccounts() []*Account {
+	var accounts []*Account
+	for _, a := range b.account {
+		if a.IsDirty() {
+			accounts = append(accounts, a)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Url().Compare(accounts[j].Url()) < 0
+	})
+	return accounts
+}
+
 // GetValue implements record.Store.
 func (b *Batch) GetValue(key record.Key, value record.ValueWriter) error {
 	if b.done {
@@ -223,5 +223,12 @@ func (a *Account) hashSecondaryState() (hash.Hasher, error) {
 	}
 	// Hash the hash to allow for future expansion
 	dirHash := hasher.MerkleHash()
-	return hash.Hasher{dirHash}, err
+	hasher = hash.Hasher{dirHash}
+
+	// Add the root of the key/value tree, if there is one
+	root := loadState(&err, false, a.KeyValueRoot().Get)
+	if root != ([32]byte{}) {
+		hasher.AddHash(&root)
+	}
+	return hasher, err
 }
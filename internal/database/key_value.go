@@ -0,0 +1,196 @@
+package database
+
+import (
+	"crypto/sha256"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+	"gitlab.com/accumulatenetwork/accumulate/smt/pmt"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage"
+)
+
+// The values of a key/value account are committed in a per-account BPT. The
+// key of a value in the tree is the hash of the key. The value in the tree is
+// the hash of the key hash and the hash of the value, so a receipt for a value
+// also proves the key. A deleted value is removed from the tree. The tree is
+// updated and its root is recorded when the account is committed, and the root
+// is part of the account's secondary state.
+
+// GetKeyValue returns the value of the key. GetKeyValue returns a not found
+// error if the key is not set or has been deleted.
+func (a *Account) GetKeyValue(key []byte) ([]byte, error) {
+	value, err := a.KeyValue(sha256.Sum256(key)).Get()
+	switch {
+	case err == nil && len(value) > 0:
+		return value, nil
+	case err == nil, errors.Is(err, errors.StatusNotFound):
+		return nil, errors.NotFound("key %X of %v not found", key, a.Url())
+	default:
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+}
+
+// PutKeyValue sets the value of the key.
+func (a *Account) PutKeyValue(key, value []byte) error {
+	if len(value) == 0 {
+		return errors.Format(errors.StatusBadRequest, "value of key %X is empty", key)
+	}
+	err := a.KeyValue(sha256.Sum256(key)).Put(value)
+	return errors.Wrap(errors.StatusUnknownError, err)
+}
+
+// DeleteKeyValue deletes the value of the key.
+func (a *Account) DeleteKeyValue(key []byte) error {
+	err := a.KeyValue(sha256.Sum256(key)).Put(nil)
+	return errors.Wrap(errors.StatusUnknownError, err)
+}
+
+// VisitKeyValues calls the function for every value of the key/value tree.
+func (a *Account) VisitKeyValues(visit func(keyHash [32]byte, value []byte) error) error {
+	tree := a.keyValueTree()
+
+	place := pmt.FirstPossibleBptKey
+	const window = 1000
+	for {
+		values, next := tree.Bpt.GetRange(place, window)
+		if len(values) == 0 {
+			return nil
+		}
+		place = next
+		for _, v := range values {
+			value, err := a.KeyValue(v.Key).Get()
+			if err != nil {
+				return errors.Format(errors.StatusUnknownError, "load value of %X: %w", v.Key, err)
+			}
+			err = visit(v.Key, value)
+			if err != nil {
+				return errors.Wrap(errors.StatusUnknownError, err)
+			}
+		}
+	}
+}
+
+// KeyValueReceipt builds a receipt from the hash of the value of the key to
+// the root of the BPT.
+func (a *Account) KeyValueReceipt(key []byte) (*managed.Receipt, error) {
+	if a.IsDirty() {
+		return nil, errors.New(errors.StatusInternalError, "cannot generate a key/value receipt when there are uncommitted changes")
+	}
+
+	value, err := a.GetKeyValue(key)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	// Value hash to tree entry
+	keyHash := sha256.Sum256(key)
+	valueHash := sha256.Sum256(value)
+	rValue := new(managed.Receipt)
+	rValue.Start = valueHash[:]
+	rValue.Entries = []*managed.ReceiptEntry{{Hash: keyHash[:]}}
+	rValue.Anchor = keyValueEntry(keyHash, value)
+
+	// Tree entry to tree root
+	rTree := a.keyValueTree().Bpt.GetReceipt(keyHash)
+	if rTree == nil {
+		return nil, errors.Format(errors.StatusInternalError, "key %X of %v is missing from the tree", key, a.Url())
+	}
+
+	// Tree root to secondary state
+	secondary, err := a.hashSecondaryState()
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	rSecondary := secondary.Receipt(len(secondary)-1, len(secondary)-1)
+
+	// Secondary state to account state to BPT root
+	hasher, err := a.hashState()
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	rState := hasher.Receipt(1, len(hasher)-1)
+	rBPT, err := a.BptReceipt()
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	receipt, err := managed.CombineReceipts(rValue, rTree, rSecondary, rState, rBPT)
+	if err != nil {
+		return nil, errors.Format(errors.StatusInternalError, "combine receipts: %w", err)
+	}
+	return receipt, nil
+}
+
+// updateKeyValueTree updates the key/value tree with the values that have
+// changed and records its root. The tree is only loaded if a value has
+// changed.
+func (a *Account) updateKeyValueTree() error {
+	var tree *pmt.Manager
+	for k, v := range a.keyValue {
+		if !v.IsDirty() {
+			continue
+		}
+		value, err := v.Get()
+		if err != nil {
+			return errors.Wrap(errors.StatusUnknownError, err)
+		}
+
+		if tree == nil {
+			tree = a.keyValueTree()
+		}
+		if len(value) == 0 {
+			tree.DeleteKV(k.KeyHash)
+		} else {
+			tree.InsertKV(k.KeyHash, *(*[32]byte)(keyValueEntry(k.KeyHash, value)))
+		}
+	}
+	if tree == nil {
+		return nil
+	}
+
+	err := tree.Bpt.Update()
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "update key/value tree: %w", err)
+	}
+
+	err = a.KeyValueRoot().Put(tree.GetRootHash())
+	return errors.Wrap(errors.StatusUnknownError, err)
+}
+
+// keyValueTree returns the account's key/value tree.
+func (a *Account) keyValueTree() *pmt.Manager {
+	return pmt.NewBPTManager(prefixedStore{a.key.Append("KeyValueTree").Hash(), a.parent.kvstore})
+}
+
+// keyValueEntry returns the tree entry of a value.
+func keyValueEntry(keyHash [32]byte, value []byte) []byte {
+	valueHash := sha256.Sum256(value)
+	entry := sha256.Sum256(append(keyHash[:], valueHash[:]...))
+	return entry[:]
+}
+
+// prefixedStore prefixes the keys of a key-value transaction.
+type prefixedStore struct {
+	prefix storage.Key
+	storage.KeyValueTxn
+}
+
+func (s prefixedStore) Get(key storage.Key) ([]byte, error) {
+	return s.KeyValueTxn.Get(s.prefix.Append(key[:]))
+}
+
+func (s prefixedStore) Put(key storage.Key, value []byte) error {
+	return s.KeyValueTxn.Put(s.prefix.Append(key[:]), value)
+}
+
+func (s prefixedStore) PutAll(values map[storage.Key][]byte) error {
+	prefixed := make(map[storage.Key][]byte, len(values))
+	for k, v := range values {
+		prefixed[s.prefix.Append(k[:])] = v
+	}
+	return s.KeyValueTxn.PutAll(prefixed)
+}
+
+func (s prefixedStore) Begin(writable bool) storage.KeyValueTxn {
+	return prefixedStore{s.prefix, s.KeyValueTxn.Begin(writable)}
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestKeyValue(t *testing.T) {
+	db := OpenInMemory(nil)
+	u := url.MustParse("acc://foo.acme/kv")
+	account := &protocol.KeyValueAccount{Url: u, AccountAuth: protocol.AccountAuth{Authorities: []protocol.AuthorityEntry{{Url: url.MustParse("acc://foo.acme/book")}}}}
+
+	// Setup
+	batch := db.Begin(true)
+	defer batch.Discard()
+	require.NoError(t, batch.Account(u).PutState(account))
+	require.NoError(t, batch.Commit())
+
+	batch = db.Begin(false)
+	h1, err := batch.Account(u).hashState()
+	require.NoError(t, err)
+	batch.Discard()
+
+	// Set values in a nested batch
+	batch = db.Begin(true)
+	defer batch.Discard()
+	sub := batch.Begin(true)
+	require.NoError(t, sub.Account(u).PutKeyValue([]byte("foo"), []byte("bar")))
+	require.NoError(t, sub.Account(u).PutKeyValue([]byte("baz"), []byte("bat")))
+	require.Error(t, sub.Account(u).PutKeyValue([]byte("empty"), nil))
+	require.NoError(t, sub.Commit())
+	require.NoError(t, batch.Commit())
+
+	// The values change the account hash
+	batch = db.Begin(false)
+	defer batch.Discard()
+	h2, err := batch.Account(u).hashState()
+	require.NoError(t, err)
+	require.NotEqual(t, h1.MerkleHash(), h2.MerkleHash())
+	entry, err := batch.Account(u).BptReceipt()
+	require.NoError(t, err)
+	require.NoError(t, batch.Account(u).VerifyHash(entry.Start))
+
+	value, err := batch.Account(u).GetKeyValue([]byte("foo"))
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(value))
+
+	// The receipt proves the value to the BPT root
+	receipt, err := batch.Account(u).KeyValueReceipt([]byte("foo"))
+	require.NoError(t, err)
+	require.True(t, receipt.Validate())
+	require.Equal(t, batch.BptRoot(), []byte(receipt.Anchor))
+
+	var count int
+	require.NoError(t, batch.Account(u).VisitKeyValues(func(_ [32]byte, _ []byte) error { count++; return nil }))
+	require.Equal(t, 2, count)
+	batch.Discard()
+
+	// Delete a value
+	batch = db.Begin(true)
+	defer batch.Discard()
+	require.NoError(t, batch.Account(u).DeleteKeyValue([]byte("foo")))
+	require.NoError(t, batch.Commit())
+
+	batch = db.Begin(false)
+	defer batch.Discard()
+	_, err = batch.Account(u).GetKeyValue([]byte("foo"))
+	require.True(t, errors.Is(err, errors.StatusNotFound))
+	_, err = batch.Account(u).KeyValueReceipt([]byte("foo"))
+	require.Error(t, err)
+
+	receipt, err = batch.Account(u).KeyValueReceipt([]byte("baz"))
+	require.NoError(t, err)
+	require.True(t, receipt.Validate())
+	require.Equal(t, batch.BptRoot(), []byte(receipt.Anchor))
+
+	// The deleted value is removed from the tree
+	count = 0
+	require.NoError(t, batch.Account(u).VisitKeyValues(func(_ [32]byte, _ []byte) error { count++; return nil }))
+	require.Equal(t, 1, count)
+	batch.Discard()
+
+	// Deleting every value restores the original hash
+	batch = db.Begin(true)
+	defer batch.Discard()
+	require.NoError(t, batch.Account(u).DeleteKeyValue([]byte("baz")))
+	require.NoError(t, batch.Commit())
+
+	batch = db.Begin(false)
+	defer batch.Discard()
+	h3, err := batch.Account(u).hashState()
+	require.NoError(t, err)
+	require.Equal(t, h1.MerkleHash(), h3.MerkleHash())
+}
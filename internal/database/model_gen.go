@@ -188,6 +188,8 @@ type Account struct {
 	pending                *record.Set[*url.TxID]
 	syntheticForAnchor     map[accountSyntheticForAnchorKey]*record.Set[*url.TxID]
 	directory              *record.Set[*url.URL]
+	keyValue               map[accountKeyValueKey]*record.Value[[]byte]
+	keyValueRoot           *record.Value[[32]byte]
 	mainChain              *Chain2
 	scratchChain           *Chain2
 	signatureChain         *Chain2
@@ -209,6 +211,14 @@ func keyForAccountSyntheticForAnchor(anchor [32]byte) accountSyntheticForAnchorK
 	return accountSyntheticForAnchorKey{anchor}
 }
 
+type accountKeyValueKey struct {
+	KeyHash [32]byte
+}
+
+func keyForAccountKeyValue(keyHash [32]byte) accountKeyValueKey {
+	return accountKeyValueKey{keyHash}
+}
+
 type accountSyntheticSequenceChainKey struct {
 	Partition string
 }
@@ -255,6 +265,18 @@ func (c *Account) Directory() *record.Set[*url.URL] {
 	})
 }
 
+func (c *Account) KeyValue(keyHash [32]byte) *record.Value[[]byte] {
+	return getOrCreateMap(&c.keyValue, keyForAccountKeyValue(keyHash), func() *record.Value[[]byte] {
+		return record.NewValue(c.logger.L, c.store, c.key.Append("KeyValue", keyHash), c.label+" "+"key value"+" "+hex.EncodeToString(keyHash[:]), false, record.Wrapped(record.BytesWrapper))
+	})
+}
+
+func (c *Account) KeyValueRoot() *record.Value[[32]byte] {
+	return getOrCreateField(&c.keyValueRoot, func() *record.Value[[32]byte] {
+		return record.NewValue(c.logger.L, c.store, c.key.Append("KeyValueRoot"), c.label+" "+"key value root", true, record.Wrapped(record.HashWrapper))
+	})
+}
+
 func (c *Account) MainChain() *Chain2 {
 	return getOrCreateField(&c.mainChain, func() *Chain2 {
 		return newChain2(c, c.logger.L, c.store, c.key.Append("MainChain"), "main", c.label+" "+"main chain")
@@ -357,6 +379,18 @@ func (c *Account) Resolve(key record.Key) (record.Record, record.Key, error) {
 		return v, key[2:], nil
 	case "Directory":
 		return c.Directory(), key[1:], nil
+	case "KeyValue":
+		if len(key) < 2 {
+			return nil, nil, errors.New(errors.StatusInternalError, "bad key for account")
+		}
+		keyHash, okKeyHash := key[1].([32]byte)
+		if !okKeyHash {
+			return nil, nil, errors.New(errors.StatusInternalError, "bad key for account")
+		}
+		v := c.KeyValue(keyHash)
+		return v, key[2:], nil
+	case "KeyValueRoot":
+		return c.KeyValueRoot(), key[1:], nil
 	case "MainChain":
 		return c.MainChain(), key[1:], nil
 	case "ScratchChain":
@@ -422,6 +456,14 @@ func (c *Account) IsDirty() bool {
 	if fieldIsDirty(c.directory) {
 		return true
 	}
+	for _, v := range c.keyValue {
+		if v.IsDirty() {
+			return true
+		}
+	}
+	if fieldIsDirty(c.keyValueRoot) {
+		return true
+	}
 	if fieldIsDirty(c.mainChain) {
 		return true
 	}
@@ -476,6 +518,10 @@ func (c *Account) baseCommit() error {
 		commitField(&err, v)
 	}
 	commitField(&err, c.directory)
+	for _, v := range c.keyValue {
+		commitField(&err, v)
+	}
+	commitField(&err, c.keyValueRoot)
 	commitField(&err, c.mainChain)
 	commitField(&err, c.scratchChain)
 	commitField(&err, c.signatureChain)
@@ -652,11 +698,22 @@ type Transaction struct {
 	label  string
 	parent *Batch
 
-	main       *record.Value[*SigOrTxn]
-	status     *record.Value[*protocol.TransactionStatus]
-	produced   *record.Set[*url.TxID]
-	signatures map[transactionSignaturesKey]*record.Value[*sigSetData]
-	chains     *record.Set[*TransactionChainEntry]
+	main               *record.Value[*SigOrTxn]
+	status             *record.Value[*protocol.TransactionStatus]
+	produced           *record.Set[*url.TxID]
+	sponsorFee         *record.Value[uint64]
+	signatureFee       map[transactionSignatureFeeKey]*record.Value[uint64]
+	refundedSignatures *record.Set[[32]byte]
+	signatures         map[transactionSignaturesKey]*record.Value[*sigSetData]
+	chains             *record.Set[*TransactionChainEntry]
+}
+
+type transactionSignatureFeeKey struct {
+	Signature [32]byte
+}
+
+func keyForTransactionSignatureFee(signature [32]byte) transactionSignatureFeeKey {
+	return transactionSignatureFeeKey{signature}
 }
 
 type transactionSignaturesKey struct {
@@ -685,6 +742,24 @@ func (c *Transaction) Produced() *record.Set[*url.TxID] {
 	})
 }
 
+func (c *Transaction) SponsorFee() *record.Value[uint64] {
+	return getOrCreateField(&c.sponsorFee, func() *record.Value[uint64] {
+		return record.NewValue(c.logger.L, c.store, c.key.Append("SponsorFee"), c.label+" "+"sponsor fee", false, record.Wrapped(record.UintWrapper))
+	})
+}
+
+func (c *Transaction) SignatureFee(signature [32]byte) *record.Value[uint64] {
+	return getOrCreateMap(&c.signatureFee, keyForTransactionSignatureFee(signature), func() *record.Value[uint64] {
+		return record.NewValue(c.logger.L, c.store, c.key.Append("SignatureFee", signature), c.label+" "+"signature fee"+" "+hex.EncodeToString(signature[:]), false, record.Wrapped(record.UintWrapper))
+	})
+}
+
+func (c *Transaction) RefundedSignatures() *record.Set[[32]byte] {
+	return getOrCreateField(&c.refundedSignatures, func() *record.Set[[32]byte] {
+		return record.NewSet(c.logger.L, c.store, c.key.Append("RefundedSignatures"), c.label+" "+"refunded signatures", record.Wrapped(record.HashWrapper), record.CompareHash)
+	})
+}
+
 func (c *Transaction) getSignatures(signer *url.URL) *record.Value[*sigSetData] {
 	return getOrCreateMap(&c.signatures, keyForTransactionSignatures(signer), func() *record.Value[*sigSetData] {
 		return record.NewValue(c.logger.L, c.store, c.key.Append("Signatures", signer), c.label+" "+"signatures"+" "+signer.RawString(), true, record.Struct[sigSetData]())
@@ -709,6 +784,20 @@ func (c *Transaction) Resolve(key record.Key) (record.Record, record.Key, error)
 		return c.Status(), key[1:], nil
 	case "Produced":
 		return c.Produced(), key[1:], nil
+	case "SponsorFee":
+		return c.SponsorFee(), key[1:], nil
+	case "SignatureFee":
+		if len(key) < 2 {
+			return nil, nil, errors.New(errors.StatusInternalError, "bad key for transaction")
+		}
+		signature, okSignature := key[1].([32]byte)
+		if !okSignature {
+			return nil, nil, errors.New(errors.StatusInternalError, "bad key for transaction")
+		}
+		v := c.SignatureFee(signature)
+		return v, key[2:], nil
+	case "RefundedSignatures":
+		return c.RefundedSignatures(), key[1:], nil
 	case "Signatures":
 		if len(key) < 2 {
 			return nil, nil, errors.New(errors.StatusInternalError, "bad key for transaction")
@@ -740,6 +829,17 @@ func (c *Transaction) IsDirty() bool {
 	if fieldIsDirty(c.produced) {
 		return true
 	}
+	if fieldIsDirty(c.sponsorFee) {
+		return true
+	}
+	for _, v := range c.signatureFee {
+		if v.IsDirty() {
+			return true
+		}
+	}
+	if fieldIsDirty(c.refundedSignatures) {
+		return true
+	}
 	for _, v := range c.signatures {
 		if v.IsDirty() {
 			return true
@@ -761,6 +861,11 @@ func (c *Transaction) Commit() error {
 	commitField(&err, c.main)
 	commitField(&err, c.status)
 	commitField(&err, c.produced)
+	commitField(&err, c.sponsorFee)
+	for _, v := range c.signatureFee {
+		commitField(&err, v)
+	}
+	commitField(&err, c.refundedSignatures)
 	for _, v := range c.signatures {
 		commitField(&err, v)
 	}
@@ -777,6 +882,7 @@ type SystemData struct {
 	parent *Batch
 
 	syntheticIndexIndex map[systemDataSyntheticIndexIndexKey]*record.Value[uint64]
+	externalAnchor      map[systemDataExternalAnchorKey]*record.Value[*protocol.ExternalAnchor]
 }
 
 type systemDataSyntheticIndexIndexKey struct {
@@ -787,12 +893,26 @@ func keyForSystemDataSyntheticIndexIndex(block uint64) systemDataSyntheticIndexI
 	return systemDataSyntheticIndexIndexKey{block}
 }
 
+type systemDataExternalAnchorKey struct {
+	MajorBlock uint64
+}
+
+func keyForSystemDataExternalAnchor(majorBlock uint64) systemDataExternalAnchorKey {
+	return systemDataExternalAnchorKey{majorBlock}
+}
+
 func (c *SystemData) SyntheticIndexIndex(block uint64) *record.Value[uint64] {
 	return getOrCreateMap(&c.syntheticIndexIndex, keyForSystemDataSyntheticIndexIndex(block), func() *record.Value[uint64] {
 		return record.NewValue(c.logger.L, c.store, c.key.Append("SyntheticIndexIndex", block), c.label+" "+"synthetic index index"+" "+strconv.FormatUint(block, 10), false, record.Wrapped(record.UintWrapper))
 	})
 }
 
+func (c *SystemData) ExternalAnchor(majorBlock uint64) *record.Value[*protocol.ExternalAnchor] {
+	return getOrCreateMap(&c.externalAnchor, keyForSystemDataExternalAnchor(majorBlock), func() *record.Value[*protocol.ExternalAnchor] {
+		return record.NewValue(c.logger.L, c.store, c.key.Append("ExternalAnchor", majorBlock), c.label+" "+"external anchor"+" "+strconv.FormatUint(majorBlock, 10), false, record.Struct[protocol.ExternalAnchor]())
+	})
+}
+
 func (c *SystemData) Resolve(key record.Key) (record.Record, record.Key, error) {
 	if len(key) == 0 {
 		return nil, nil, errors.New(errors.StatusInternalError, "bad key for system data")
@@ -809,6 +929,16 @@ func (c *SystemData) Resolve(key record.Key) (record.Record, record.Key, error)
 		}
 		v := c.SyntheticIndexIndex(block)
 		return v, key[2:], nil
+	case "ExternalAnchor":
+		if len(key) < 2 {
+			return nil, nil, errors.New(errors.StatusInternalError, "bad key for system data")
+		}
+		majorBlock, okMajorBlock := key[1].(uint64)
+		if !okMajorBlock {
+			return nil, nil, errors.New(errors.StatusInternalError, "bad key for system data")
+		}
+		v := c.ExternalAnchor(majorBlock)
+		return v, key[2:], nil
 	default:
 		return nil, nil, errors.New(errors.StatusInternalError, "bad key for system data")
 	}
@@ -824,6 +954,11 @@ func (c *SystemData) IsDirty() bool {
 			return true
 		}
 	}
+	for _, v := range c.externalAnchor {
+		if v.IsDirty() {
+			return true
+		}
+	}
 
 	return false
 }
@@ -837,6 +972,9 @@ func (c *SystemData) Commit() error {
 	for _, v := range c.syntheticIndexIndex {
 		commitField(&err, v)
 	}
+	for _, v := range c.externalAnchor {
+		commitField(&err, v)
+	}
 
 	return err
 }
@@ -93,6 +93,14 @@ func CollectAccount(record *database.Account, fullChainHistory bool) (*Account,
 		return nil, errors.Wrap(errors.StatusUnknownError, err)
 	}
 
+	err = record.VisitKeyValues(func(keyHash [32]byte, value []byte) error {
+		acct.KeyValues = append(acct.KeyValues, &KeyValue{KeyHash: keyHash, Value: value})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Format(errors.StatusUnknownError, "load key/values: %w", err)
+	}
+
 	for _, meta := range loadState(&err, false, record.Chains().Get) {
 		record, err := record.GetChainByName(meta.Name)
 		if err != nil {
@@ -102,6 +110,7 @@ func CollectAccount(record *database.Account, fullChainHistory bool) (*Account,
 		chain := new(Chain)
 		chain.Name = meta.Name
 		chain.Type = meta.Type
+		chain.HashAlgorithm = meta.HashAlgorithm
 		acct.Chains = append(acct.Chains, chain)
 
 		state := record.CurrentState()
@@ -132,16 +141,19 @@ func (a *Account) Restore(batch *database.Batch) error {
 	saveState(&err, record.Main().Put, a.Main)
 	saveState(&err, record.Directory().Put, a.Directory)
 	saveState(&err, record.Pending().Put, a.Pending)
+	for _, kv := range a.KeyValues {
+		saveState(&err, record.KeyValue(kv.KeyHash).Put, kv.Value)
+	}
 
 	return errors.Wrap(errors.StatusUnknownError, err)
 }
 
 func (c *Chain) Restore(account *database.Account) error {
-	mgr, err := account.GetChainByName(c.Name)
+	mgr, err := c.chain(account)
 	if err != nil {
 		return errors.Format(errors.StatusUnknownError, "store %s chain head: %w", c.Name, err)
 	}
-	err = mgr.RestoreHead(&managed.MerkleState{Count: int64(c.Count), Pending: c.Pending})
+	err = mgr.RestoreHead(&managed.MerkleState{Count: int64(c.Count), Pending: c.Pending, HashAlgorithm: c.HashAlgorithm})
 	if err != nil {
 		return errors.Format(errors.StatusUnknownError, "store %s chain head: %w", c.Name, err)
 	}
@@ -154,6 +166,19 @@ func (c *Chain) Restore(account *database.Account) error {
 	return nil
 }
 
+// chain loads the chain, setting its hash algorithm.
+func (c *Chain) chain(account *database.Account) (*database.Chain, error) {
+	record, err := account.ChainByName(c.Name)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	err = record.SetHashAlgorithm(c.HashAlgorithm)
+	if err != nil {
+		return nil, errors.Wrap(errors.StatusUnknownError, err)
+	}
+	return record.Get()
+}
+
 func zero[T any]() (z T) { return z }
 
 func loadState[T any](lastErr *error, allowMissing bool, get func() (T, error)) T {
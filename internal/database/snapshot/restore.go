@@ -48,6 +48,7 @@ func (v *RestoreVisitor) VisitAccount(acct *Account, i int) error {
 			ms := new(managed.MerkleState)
 			ms.Count = int64(c.Count)
 			ms.Pending = c.Pending
+			ms.HashAlgorithm = c.HashAlgorithm
 			for _, v := range c.Entries {
 				ms.AddToMerkleTree(v)
 			}
@@ -80,11 +81,11 @@ func (v *RestoreVisitor) VisitAccount(acct *Account, i int) error {
 	record := v.batch.Account(acct.Url)
 	chains := map[string][][]byte{}
 	for _, c := range acct.Chains {
-		mgr, err := record.GetChainByName(c.Name)
+		mgr, err := c.chain(record)
 		if err != nil {
 			return errors.Format(errors.StatusUnknownError, "store %s chain head: %w", c.Name, err)
 		}
-		err = mgr.RestoreHead(&managed.MerkleState{Count: int64(c.Count), Pending: c.Pending})
+		err = mgr.RestoreHead(&managed.MerkleState{Count: int64(c.Count), Pending: c.Pending, HashAlgorithm: c.HashAlgorithm})
 		if err != nil {
 			return errors.Format(errors.StatusUnknownError, "store %s chain head: %w", c.Name, err)
 		}
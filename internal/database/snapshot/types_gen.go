@@ -30,18 +30,21 @@ type Account struct {
 	// Directory lists the account's sub-accounts.
 	Directory []*url.URL `json:"directory,omitempty" form:"directory" query:"directory" validate:"required"`
 	// Url is the URL of the account.
-	Url       *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	Url *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	// KeyValues lists the values of a key/value account.
+	KeyValues []*KeyValue `json:"keyValues,omitempty" form:"keyValues" query:"keyValues" validate:"required"`
 	extraData []byte
 }
 
 type Chain struct {
-	fieldsSet []bool
-	Name      string             `json:"name,omitempty" form:"name" query:"name" validate:"required"`
-	Type      protocol.ChainType `json:"type,omitempty" form:"type" query:"type" validate:"required"`
-	Count     uint64             `json:"count,omitempty" form:"count" query:"count" validate:"required"`
-	Pending   [][]byte           `json:"pending,omitempty" form:"pending" query:"pending" validate:"required"`
-	Entries   [][]byte           `json:"entries,omitempty" form:"entries" query:"entries" validate:"required"`
-	extraData []byte
+	fieldsSet     []bool
+	Name          string                 `json:"name,omitempty" form:"name" query:"name" validate:"required"`
+	Type          protocol.ChainType     `json:"type,omitempty" form:"type" query:"type" validate:"required"`
+	Count         uint64                 `json:"count,omitempty" form:"count" query:"count" validate:"required"`
+	Pending       [][]byte               `json:"pending,omitempty" form:"pending" query:"pending" validate:"required"`
+	Entries       [][]byte               `json:"entries,omitempty" form:"entries" query:"entries" validate:"required"`
+	HashAlgorithm protocol.HashAlgorithm `json:"hashAlgorithm,omitempty" form:"hashAlgorithm" query:"hashAlgorithm"`
+	extraData     []byte
 }
 
 type Header struct {
@@ -55,6 +58,13 @@ type Header struct {
 	extraData []byte
 }
 
+type KeyValue struct {
+	fieldsSet []bool
+	KeyHash   [32]byte `json:"keyHash,omitempty" form:"keyHash" query:"keyHash" validate:"required"`
+	Value     []byte   `json:"value,omitempty" form:"value" query:"value" validate:"required"`
+	extraData []byte
+}
+
 type Signature struct {
 	fieldsSet []bool
 	Txid      *url.TxID          `json:"txid,omitempty" form:"txid" query:"txid" validate:"required"`
@@ -117,6 +127,12 @@ func (v *Account) Copy() *Account {
 	if v.Url != nil {
 		u.Url = v.Url
 	}
+	u.KeyValues = make([]*KeyValue, len(v.KeyValues))
+	for i, v := range v.KeyValues {
+		if v != nil {
+			u.KeyValues[i] = (v).Copy()
+		}
+	}
 
 	return u
 }
@@ -137,6 +153,7 @@ func (v *Chain) Copy() *Chain {
 	for i, v := range v.Entries {
 		u.Entries[i] = encoding.BytesCopy(v)
 	}
+	u.HashAlgorithm = v.HashAlgorithm
 
 	return u
 }
@@ -155,6 +172,17 @@ func (v *Header) Copy() *Header {
 
 func (v *Header) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *KeyValue) Copy() *KeyValue {
+	u := new(KeyValue)
+
+	u.KeyHash = v.KeyHash
+	u.Value = encoding.BytesCopy(v.Value)
+
+	return u
+}
+
+func (v *KeyValue) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *Signature) Copy() *Signature {
 	u := new(Signature)
 
@@ -274,6 +302,14 @@ func (v *Account) Equal(u *Account) bool {
 	case !((v.Url).Equal(u.Url)):
 		return false
 	}
+	if len(v.KeyValues) != len(u.KeyValues) {
+		return false
+	}
+	for i := range v.KeyValues {
+		if !((v.KeyValues[i]).Equal(u.KeyValues[i])) {
+			return false
+		}
+	}
 
 	return true
 }
@@ -304,6 +340,9 @@ func (v *Chain) Equal(u *Chain) bool {
 			return false
 		}
 	}
+	if !(v.HashAlgorithm == u.HashAlgorithm) {
+		return false
+	}
 
 	return true
 }
@@ -322,6 +361,17 @@ func (v *Header) Equal(u *Header) bool {
 	return true
 }
 
+func (v *KeyValue) Equal(u *KeyValue) bool {
+	if !(v.KeyHash == u.KeyHash) {
+		return false
+	}
+	if !(bytes.Equal(v.Value, u.Value)) {
+		return false
+	}
+
+	return true
+}
+
 func (v *Signature) Equal(u *Signature) bool {
 	switch {
 	case v.Txid == u.Txid:
@@ -423,6 +473,7 @@ var fieldNames_Account = []string{
 	3: "Pending",
 	4: "Directory",
 	5: "Url",
+	6: "KeyValues",
 }
 
 func (v *Account) MarshalBinary() ([]byte, error) {
@@ -450,6 +501,11 @@ func (v *Account) MarshalBinary() ([]byte, error) {
 	if !(v.Url == nil) {
 		writer.WriteUrl(5, v.Url)
 	}
+	if !(len(v.KeyValues) == 0) {
+		for _, v := range v.KeyValues {
+			writer.WriteValue(6, v.MarshalBinary)
+		}
+	}
 
 	_, _, err := writer.Reset(fieldNames_Account)
 	if err != nil {
@@ -487,6 +543,11 @@ func (v *Account) IsValid() error {
 	} else if v.Url == nil {
 		errs = append(errs, "field Url is not set")
 	}
+	if len(v.fieldsSet) > 6 && !v.fieldsSet[6] {
+		errs = append(errs, "field KeyValues is missing")
+	} else if len(v.KeyValues) == 0 {
+		errs = append(errs, "field KeyValues is not set")
+	}
 
 	switch len(errs) {
 	case 0:
@@ -504,6 +565,7 @@ var fieldNames_Chain = []string{
 	3: "Count",
 	4: "Pending",
 	5: "Entries",
+	6: "HashAlgorithm",
 }
 
 func (v *Chain) MarshalBinary() ([]byte, error) {
@@ -529,6 +591,9 @@ func (v *Chain) MarshalBinary() ([]byte, error) {
 			writer.WriteBytes(5, v)
 		}
 	}
+	if !(v.HashAlgorithm == 0) {
+		writer.WriteEnum(6, v.HashAlgorithm)
+	}
 
 	_, _, err := writer.Reset(fieldNames_Chain)
 	if err != nil {
@@ -634,6 +699,54 @@ func (v *Header) IsValid() error {
 	}
 }
 
+var fieldNames_KeyValue = []string{
+	1: "KeyHash",
+	2: "Value",
+}
+
+func (v *KeyValue) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.KeyHash == ([32]byte{})) {
+		writer.WriteHash(1, &v.KeyHash)
+	}
+	if !(len(v.Value) == 0) {
+		writer.WriteBytes(2, v.Value)
+	}
+
+	_, _, err := writer.Reset(fieldNames_KeyValue)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *KeyValue) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field KeyHash is missing")
+	} else if v.KeyHash == ([32]byte{}) {
+		errs = append(errs, "field KeyHash is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Value is missing")
+	} else if len(v.Value) == 0 {
+		errs = append(errs, "field Value is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_Signature = []string{
 	1: "Txid",
 	2: "Signature",
@@ -920,6 +1033,13 @@ func (v *Account) UnmarshalBinaryFrom(rd io.Reader) error {
 	if x, ok := reader.ReadUrl(5); ok {
 		v.Url = x
 	}
+	for {
+		if x := new(KeyValue); reader.ReadValue(6, x.UnmarshalBinary) {
+			v.KeyValues = append(v.KeyValues, x)
+		} else {
+			break
+		}
+	}
 
 	seen, err := reader.Reset(fieldNames_Account)
 	if err != nil {
@@ -963,6 +1083,9 @@ func (v *Chain) UnmarshalBinaryFrom(rd io.Reader) error {
 			break
 		}
 	}
+	if x := new(protocol.HashAlgorithm); reader.ReadEnum(6, x) {
+		v.HashAlgorithm = *x
+	}
 
 	seen, err := reader.Reset(fieldNames_Chain)
 	if err != nil {
@@ -1005,6 +1128,32 @@ func (v *Header) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *KeyValue) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *KeyValue) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadHash(1); ok {
+		v.KeyHash = *x
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.Value = x
+	}
+
+	seen, err := reader.Reset(fieldNames_KeyValue)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *Signature) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -1162,22 +1311,25 @@ func (v *Account) MarshalJSON() ([]byte, error) {
 		Pending   encoding.JsonList[*url.TxID]                 `json:"pending,omitempty"`
 		Directory encoding.JsonList[*url.URL]                  `json:"directory,omitempty"`
 		Url       *url.URL                                     `json:"url,omitempty"`
+		KeyValues encoding.JsonList[*KeyValue]                 `json:"keyValues,omitempty"`
 	}{}
 	u.Main = encoding.JsonUnmarshalWith[protocol.Account]{Value: v.Main, Func: protocol.UnmarshalAccountJSON}
 	u.Chains = v.Chains
 	u.Pending = v.Pending
 	u.Directory = v.Directory
 	u.Url = v.Url
+	u.KeyValues = v.KeyValues
 	return json.Marshal(&u)
 }
 
 func (v *Chain) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Name    string                     `json:"name,omitempty"`
-		Type    protocol.ChainType         `json:"type,omitempty"`
-		Count   uint64                     `json:"count,omitempty"`
-		Pending encoding.JsonList[*string] `json:"pending,omitempty"`
-		Entries encoding.JsonList[*string] `json:"entries,omitempty"`
+		Name          string                     `json:"name,omitempty"`
+		Type          protocol.ChainType         `json:"type,omitempty"`
+		Count         uint64                     `json:"count,omitempty"`
+		Pending       encoding.JsonList[*string] `json:"pending,omitempty"`
+		Entries       encoding.JsonList[*string] `json:"entries,omitempty"`
+		HashAlgorithm protocol.HashAlgorithm     `json:"hashAlgorithm,omitempty"`
 	}{}
 	u.Name = v.Name
 	u.Type = v.Type
@@ -1190,6 +1342,7 @@ func (v *Chain) MarshalJSON() ([]byte, error) {
 	for i, x := range v.Entries {
 		u.Entries[i] = encoding.BytesToJSON(x)
 	}
+	u.HashAlgorithm = v.HashAlgorithm
 	return json.Marshal(&u)
 }
 
@@ -1205,6 +1358,16 @@ func (v *Header) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *KeyValue) MarshalJSON() ([]byte, error) {
+	u := struct {
+		KeyHash string  `json:"keyHash,omitempty"`
+		Value   *string `json:"value,omitempty"`
+	}{}
+	u.KeyHash = encoding.ChainToJSON(v.KeyHash)
+	u.Value = encoding.BytesToJSON(v.Value)
+	return json.Marshal(&u)
+}
+
 func (v *Signature) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Txid      *url.TxID                                      `json:"txid,omitempty"`
@@ -1262,12 +1425,14 @@ func (v *Account) UnmarshalJSON(data []byte) error {
 		Pending   encoding.JsonList[*url.TxID]                 `json:"pending,omitempty"`
 		Directory encoding.JsonList[*url.URL]                  `json:"directory,omitempty"`
 		Url       *url.URL                                     `json:"url,omitempty"`
+		KeyValues encoding.JsonList[*KeyValue]                 `json:"keyValues,omitempty"`
 	}{}
 	u.Main = encoding.JsonUnmarshalWith[protocol.Account]{Value: v.Main, Func: protocol.UnmarshalAccountJSON}
 	u.Chains = v.Chains
 	u.Pending = v.Pending
 	u.Directory = v.Directory
 	u.Url = v.Url
+	u.KeyValues = v.KeyValues
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -1277,16 +1442,18 @@ func (v *Account) UnmarshalJSON(data []byte) error {
 	v.Pending = u.Pending
 	v.Directory = u.Directory
 	v.Url = u.Url
+	v.KeyValues = u.KeyValues
 	return nil
 }
 
 func (v *Chain) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Name    string                     `json:"name,omitempty"`
-		Type    protocol.ChainType         `json:"type,omitempty"`
-		Count   uint64                     `json:"count,omitempty"`
-		Pending encoding.JsonList[*string] `json:"pending,omitempty"`
-		Entries encoding.JsonList[*string] `json:"entries,omitempty"`
+		Name          string                     `json:"name,omitempty"`
+		Type          protocol.ChainType         `json:"type,omitempty"`
+		Count         uint64                     `json:"count,omitempty"`
+		Pending       encoding.JsonList[*string] `json:"pending,omitempty"`
+		Entries       encoding.JsonList[*string] `json:"entries,omitempty"`
+		HashAlgorithm protocol.HashAlgorithm     `json:"hashAlgorithm,omitempty"`
 	}{}
 	u.Name = v.Name
 	u.Type = v.Type
@@ -1299,6 +1466,7 @@ func (v *Chain) UnmarshalJSON(data []byte) error {
 	for i, x := range v.Entries {
 		u.Entries[i] = encoding.BytesToJSON(x)
 	}
+	u.HashAlgorithm = v.HashAlgorithm
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -1321,6 +1489,7 @@ func (v *Chain) UnmarshalJSON(data []byte) error {
 			v.Entries[i] = x
 		}
 	}
+	v.HashAlgorithm = u.HashAlgorithm
 	return nil
 }
 
@@ -1346,6 +1515,29 @@ func (v *Header) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *KeyValue) UnmarshalJSON(data []byte) error {
+	u := struct {
+		KeyHash string  `json:"keyHash,omitempty"`
+		Value   *string `json:"value,omitempty"`
+	}{}
+	u.KeyHash = encoding.ChainToJSON(v.KeyHash)
+	u.Value = encoding.BytesToJSON(v.Value)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.ChainFromJSON(u.KeyHash); err != nil {
+		return fmt.Errorf("error decoding KeyHash: %w", err)
+	} else {
+		v.KeyHash = x
+	}
+	if x, err := encoding.BytesFromJSON(u.Value); err != nil {
+		return fmt.Errorf("error decoding Value: %w", err)
+	} else {
+		v.Value = x
+	}
+	return nil
+}
+
 func (v *Signature) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Txid      *url.TxID                                      `json:"txid,omitempty"`
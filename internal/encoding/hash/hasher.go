@@ -131,7 +131,7 @@ func (h Hasher) Receipt(start, anchor int) *managed.Receipt {
 	r.Anchor = h[start]
 
 	// Build the receipt
-	err := r.BuildReceiptWith(h.getIntermediate, managed.Sha256, anchorState)
+	err := r.BuildReceiptWith(h.getIntermediate, anchorState)
 	if err != nil {
 		// The data is static and in memory so there should never be an error
 		panic(err)
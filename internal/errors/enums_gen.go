@@ -59,6 +59,9 @@ const StatusIncompleteChain Status = 414
 // StatusInsufficientBalance means the account balance is insufficient to satisfy the request.
 const StatusInsufficientBalance Status = 415
 
+// StatusTooManyPending means the principal or signer has too many pending transactions.
+const StatusTooManyPending Status = 429
+
 // StatusInternalError means an internal error occured.
 const StatusInternalError Status = 500
 
@@ -78,7 +81,7 @@ func (v Status) GetEnumValue() uint64 { return uint64(v) }
 func (v *Status) SetEnumValue(id uint64) bool {
 	u := Status(id)
 	switch u {
-	case StatusOK, StatusDelivered, StatusPending, StatusRemote, StatusWrongPartition, StatusBadRequest, StatusUnauthenticated, StatusInsufficientCredits, StatusUnauthorized, StatusNotFound, StatusNotAllowed, StatusConflict, StatusBadSignerVersion, StatusBadTimestamp, StatusBadUrlLength, StatusIncompleteChain, StatusInsufficientBalance, StatusInternalError, StatusUnknownError, StatusEncodingError, StatusFatalError:
+	case StatusOK, StatusDelivered, StatusPending, StatusRemote, StatusWrongPartition, StatusBadRequest, StatusUnauthenticated, StatusInsufficientCredits, StatusUnauthorized, StatusNotFound, StatusNotAllowed, StatusConflict, StatusBadSignerVersion, StatusBadTimestamp, StatusBadUrlLength, StatusIncompleteChain, StatusInsufficientBalance, StatusTooManyPending, StatusInternalError, StatusUnknownError, StatusEncodingError, StatusFatalError:
 		*v = u
 		return true
 	default:
@@ -123,6 +126,8 @@ func (v Status) String() string {
 		return "incompleteChain"
 	case StatusInsufficientBalance:
 		return "insufficientBalance"
+	case StatusTooManyPending:
+		return "tooManyPending"
 	case StatusInternalError:
 		return "internalError"
 	case StatusUnknownError:
@@ -173,6 +178,8 @@ func StatusByName(name string) (Status, bool) {
 		return StatusIncompleteChain, true
 	case "insufficientbalance":
 		return StatusInsufficientBalance, true
+	case "toomanypending":
+		return StatusTooManyPending, true
 	case "internalerror":
 		return StatusInternalError, true
 	case "unknownerror":
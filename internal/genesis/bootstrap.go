@@ -231,6 +231,7 @@ func (b *bootstrap) Validate(st *chain.StateManager, tx *chain.Delivery) (protoc
 	b.createAnchorPool()
 	b.createOperatorBook()
 	b.createEvidenceChain()
+	b.maybeCreateExternalAnchors()
 	b.maybeCreateAcme()
 	b.maybeCreateFaucet()
 
@@ -338,6 +339,18 @@ func (b *bootstrap) createEvidenceChain() {
 	b.urls = append(b.urls, da.Url)
 }
 
+func (b *bootstrap) maybeCreateExternalAnchors() {
+	if b.NetworkType != config.Directory {
+		return
+	}
+
+	// Create the data account that records external anchors of major blocks
+	da := new(protocol.DataAccount)
+	da.Url = b.partition.JoinPath(protocol.ExternalAnchors)
+	da.AddAuthority(b.localAuthority)
+	b.WriteRecords(da)
+}
+
 func (b *bootstrap) maybeCreateAcme() {
 	if !b.shouldCreate(protocol.AcmeUrl()) {
 		return
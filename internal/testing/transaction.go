@@ -54,6 +54,11 @@ func (tb TransactionBuilder) WithPrincipal(origin *url.URL) TransactionBuilder {
 	return tb
 }
 
+func (tb TransactionBuilder) WithSponsor(sponsor *url.URL) TransactionBuilder {
+	tb.Transaction[0].Header.Sponsor = sponsor
+	return tb
+}
+
 func (tb TransactionBuilder) WithDelegator(delegator *url.URL) TransactionBuilder {
 	tb.signer.AddDelegator(delegator)
 	return tb
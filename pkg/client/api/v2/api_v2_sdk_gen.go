@@ -22,6 +22,18 @@ func (c *Client) Describe(ctx context.Context) (*api.DescriptionResponse, error)
 	return &resp, nil
 }
 
+// EstimateFee estimates the fee for a transaction and the signatures that will be submitted with it.
+func (c *Client) EstimateFee(ctx context.Context, req *api.EstimateFeeRequest) (*api.EstimateFeeResponse, error) {
+	var resp api.EstimateFeeResponse
+
+	err := c.RequestAPIv2(ctx, "estimate-fee", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 // Execute submits a transaction.
 func (c *Client) Execute(ctx context.Context, req *api.TxRequest) (*api.TxResponse, error) {
 	var resp api.TxResponse
@@ -362,7 +374,6 @@ func (c *Client) QueryMinorBlocks(ctx context.Context, req *api.MinorBlocksQuery
 	return &resp, nil
 }
 
-//
 // WARNING: EXPERIMENTAL!
 func (c *Client) QuerySynth(ctx context.Context, req *api.SyntheticTransactionRequest) (*api.TransactionQueryResponse, error) {
 	var resp api.TransactionQueryResponse
@@ -411,6 +422,18 @@ func (c *Client) QueryTxLocal(ctx context.Context, req *api.TxnQuery) (*api.Tran
 	return &resp, nil
 }
 
+// Simulate executes a transaction against a discarded copy of the state and returns the fee, produced transactions, and account changes.
+func (c *Client) Simulate(ctx context.Context, req *api.ExecuteRequest) (*protocol.SimulateResponse, error) {
+	var resp protocol.SimulateResponse
+
+	err := c.RequestAPIv2(ctx, "simulate", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 // Status queries the status of the node.
 func (c *Client) Status(ctx context.Context) (*api.StatusResponse, error) {
 	var req struct{}
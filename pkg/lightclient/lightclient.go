@@ -0,0 +1,295 @@
+// Package lightclient verifies account states and transactions against
+// directory network anchors, without trusting the node that served them.
+//
+// A light client is seeded with directory anchors the caller trusts. Each
+// directory anchor vouches for the root chain and state tree (BPT) of the
+// directory network, and carries receipts that prove the root chain anchors of
+// the partitions it received anchors from. Account state receipts are verified
+// against a trusted state tree anchor and transaction receipts are verified
+// against a trusted root chain anchor.
+package lightclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2/query"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+// ErrInvalidReceipt is returned when a receipt is malformed, does not prove
+// the given value, or does not evaluate to its anchor.
+var ErrInvalidReceipt = errors.New("invalid receipt")
+
+// ErrUntrustedAnchor is returned when a receipt is valid but its anchor is not
+// a trusted anchor. The caller may add more recent directory anchors and try
+// again.
+var ErrUntrustedAnchor = errors.New("receipt anchor is not trusted")
+
+// Anchor is a trusted root chain or state tree anchor of a partition.
+type Anchor struct {
+	// Partition is the URL of the partition.
+	Partition *url.URL
+	// MinorBlock is the partition's minor block index.
+	MinorBlock uint64
+	// MajorBlock is the partition's major block index, or zero.
+	MajorBlock uint64
+	// DirectoryBlock is the minor block index of the directory anchor that
+	// vouches for the anchor.
+	DirectoryBlock uint64
+	// RootChainAnchor is the anchor of the partition's root chain.
+	RootChainAnchor [32]byte
+	// StateTreeAnchor is the root of the partition's BPT.
+	StateTreeAnchor [32]byte
+}
+
+// Client tracks trusted directory anchors and verifies receipts against them.
+// It is safe for concurrent use.
+type Client struct {
+	mu            sync.RWMutex
+	rootChain     map[[32]byte]*Anchor
+	stateTree     map[[32]byte]*Anchor
+	latest        map[string]*Anchor
+	lastDirectory uint64
+	network       *protocol.NetworkDefinition
+	globals       *protocol.NetworkGlobals
+}
+
+// New returns a light client with no trusted anchors.
+func New() *Client {
+	c := new(Client)
+	c.rootChain = map[[32]byte]*Anchor{}
+	c.stateTree = map[[32]byte]*Anchor{}
+	c.latest = map[string]*Anchor{}
+	return c
+}
+
+// AddDirectoryAnchor trusts a directory anchor, and the partition anchors it
+// proves. The caller is responsible for establishing that the directory
+// anchor is authentic, for example by verifying the signatures of the
+// transaction that delivered it.
+func (c *Client) AddDirectoryAnchor(anchor *protocol.DirectoryAnchor) error {
+	err := verifyDirectoryAnchor(anchor)
+	if err != nil {
+		return err
+	}
+
+	c.addVerified(anchor)
+	return nil
+}
+
+// verifyDirectoryAnchor verifies the receipts of the directory anchor.
+func verifyDirectoryAnchor(anchor *protocol.DirectoryAnchor) error {
+	if anchor.Source == nil {
+		return fmt.Errorf("directory anchor is missing the source")
+	}
+	if !protocol.IsDnUrl(anchor.Source) {
+		return fmt.Errorf("directory anchor source %v is not the directory network", anchor.Source)
+	}
+
+	// Verify every receipt before trusting anything
+	for i, receipt := range anchor.Receipts {
+		if receipt.Anchor == nil || receipt.RootChainReceipt == nil {
+			return fmt.Errorf("receipt %d is incomplete: %w", i, ErrInvalidReceipt)
+		}
+		r := receipt.RootChainReceipt
+		if !bytes.Equal(r.Start, receipt.Anchor.RootChainAnchor[:]) {
+			return fmt.Errorf("receipt %d does not start from the anchor of %v: %w", i, receipt.Anchor.Source, ErrInvalidReceipt)
+		}
+		if !bytes.Equal(r.Anchor, anchor.RootChainAnchor[:]) {
+			return fmt.Errorf("receipt %d does not end at the directory anchor: %w", i, ErrInvalidReceipt)
+		}
+		if !r.Validate() {
+			return fmt.Errorf("receipt %d does not validate: %w", i, ErrInvalidReceipt)
+		}
+	}
+	return nil
+}
+
+// addVerified trusts a directory anchor whose receipts have been verified.
+func (c *Client) addVerified(anchor *protocol.DirectoryAnchor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.add(&anchor.PartitionAnchor, anchor.MinorBlockIndex)
+	for _, receipt := range anchor.Receipts {
+		c.add(receipt.Anchor, anchor.MinorBlockIndex)
+	}
+	if anchor.MinorBlockIndex > c.lastDirectory {
+		c.lastDirectory = anchor.MinorBlockIndex
+	}
+}
+
+// AddDirectoryAnchorTransaction trusts the directory anchor delivered by a
+// transaction. See AddDirectoryAnchor.
+func (c *Client) AddDirectoryAnchorTransaction(txn *protocol.Transaction) error {
+	anchor, ok := txn.Body.(*protocol.DirectoryAnchor)
+	if !ok {
+		return fmt.Errorf("transaction is a %v, not a directory anchor", txn.Body.Type())
+	}
+	return c.AddDirectoryAnchor(anchor)
+}
+
+func (c *Client) add(partition *protocol.PartitionAnchor, directoryBlock uint64) {
+	anchor := &Anchor{
+		Partition:       partition.Source,
+		MinorBlock:      partition.MinorBlockIndex,
+		MajorBlock:      partition.MajorBlockIndex,
+		DirectoryBlock:  directoryBlock,
+		RootChainAnchor: partition.RootChainAnchor,
+		StateTreeAnchor: partition.StateTreeAnchor,
+	}
+	c.rootChain[anchor.RootChainAnchor] = anchor
+	c.stateTree[anchor.StateTreeAnchor] = anchor
+
+	name := partitionKey(partition.Source)
+	if latest, ok := c.latest[name]; !ok || latest.MinorBlock < anchor.MinorBlock {
+		c.latest[name] = anchor
+	}
+}
+
+// LatestAnchor returns the most recent trusted anchor of the partition, or
+// nil.
+func (c *Client) LatestAnchor(partition *url.URL) *Anchor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.latest[partitionKey(partition)]
+}
+
+// LatestDirectoryBlock returns the minor block index of the most recent
+// trusted directory anchor.
+func (c *Client) LatestDirectoryBlock() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastDirectory
+}
+
+// VerifyAccount verifies that the receipt proves the account's state is
+// included in the state tree of a trusted anchor, and returns that anchor.
+func (c *Client) VerifyAccount(account protocol.Account, receipt *managed.Receipt) (*Anchor, error) {
+	data, err := account.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal account: %w", err)
+	}
+
+	// The account state receipt starts from the hash of the account's main
+	// state
+	hash := sha256.Sum256(data)
+	if receipt == nil || !bytes.Equal(receipt.Start, hash[:]) {
+		return nil, fmt.Errorf("receipt does not prove the state of %v: %w", account.GetUrl(), ErrInvalidReceipt)
+	}
+
+	return c.verify(receipt, c.stateTree)
+}
+
+// VerifyTransaction verifies that the receipt proves the transaction is
+// included in the root chain of a trusted anchor, and returns that anchor.
+func (c *Client) VerifyTransaction(txn *protocol.Transaction, receipt *managed.Receipt) (*Anchor, error) {
+	if receipt == nil || !bytes.Equal(receipt.Start, txn.GetHash()) {
+		return nil, fmt.Errorf("receipt does not prove transaction %X: %w", txn.GetHash()[:4], ErrInvalidReceipt)
+	}
+
+	return c.verify(receipt, c.rootChain)
+}
+
+// VerifyReceipt verifies that the receipt evaluates to a trusted root chain
+// or state tree anchor, and returns that anchor. Unlike VerifyAccount and
+// VerifyTransaction, it does not check what the receipt proves.
+func (c *Client) VerifyReceipt(receipt *managed.Receipt) (*Anchor, error) {
+	anchor, err := c.verify(receipt, c.rootChain)
+	if !errors.Is(err, ErrUntrustedAnchor) {
+		return anchor, err
+	}
+	return c.verify(receipt, c.stateTree)
+}
+
+func (c *Client) verify(receipt *managed.Receipt, anchors map[[32]byte]*Anchor) (*Anchor, error) {
+	if receipt == nil || !receipt.Validate() {
+		return nil, fmt.Errorf("receipt does not validate: %w", ErrInvalidReceipt)
+	}
+	if len(receipt.Anchor) != 32 {
+		return nil, fmt.Errorf("receipt anchor is not a hash: %w", ErrInvalidReceipt)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	anchor, ok := anchors[*(*[32]byte)(receipt.Anchor)]
+	if !ok {
+		return nil, fmt.Errorf("%X: %w", receipt.Anchor[:4], ErrUntrustedAnchor)
+	}
+	return anchor, nil
+}
+
+// VerifyAccountResponse verifies the account and receipt of a query response.
+// The query must have been made with Prove set.
+func (c *Client) VerifyAccountResponse(res *client.ChainQueryResponse) (protocol.Account, *Anchor, error) {
+	data, err := json.Marshal(res.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	account, err := protocol.UnmarshalAccountJSON(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("response does not contain an account: %w", err)
+	}
+
+	receipt, err := generalReceipt(res.Receipt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	anchor, err := c.VerifyAccount(account, receipt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return account, anchor, nil
+}
+
+// VerifyTransactionResponse verifies the transaction of a query response
+// against any of its receipts. The query must have been made with Prove set.
+func (c *Client) VerifyTransactionResponse(res *client.TransactionQueryResponse) (*Anchor, error) {
+	if res.Transaction == nil {
+		return nil, fmt.Errorf("response does not contain a transaction")
+	}
+	if len(res.Receipts) == 0 {
+		return nil, fmt.Errorf("response does not include a receipt")
+	}
+
+	var lastErr error
+	for _, r := range res.Receipts {
+		receipt, err := generalReceipt(&r.GeneralReceipt)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		anchor, err := c.VerifyTransaction(res.Transaction, receipt)
+		if err == nil {
+			return anchor, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func generalReceipt(r *query.GeneralReceipt) (*managed.Receipt, error) {
+	if r == nil {
+		return nil, fmt.Errorf("response does not include a receipt")
+	}
+	if r.Error != "" {
+		return nil, fmt.Errorf("node failed to build the receipt: %s", r.Error)
+	}
+	return &r.Proof, nil
+}
+
+func partitionKey(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
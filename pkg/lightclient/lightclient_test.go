@@ -0,0 +1,112 @@
+package lightclient_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/encoding/hash"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/lightclient"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+	"gitlab.com/accumulatenetwork/accumulate/smt/pmt"
+)
+
+func fakeHash(s string) [32]byte {
+	return sha256.Sum256([]byte(s))
+}
+
+// merkleReceipt returns a receipt for the entry and the root of a Merkle tree
+// of the entries.
+func merkleReceipt(entries [][32]byte, index int) (*managed.Receipt, [32]byte) {
+	var h hash.Hasher
+	for i := range entries {
+		h.AddHash(&entries[i])
+	}
+	return h.Receipt(index, len(entries)-1), *(*[32]byte)(h.MerkleHash())
+}
+
+func TestVerify(t *testing.T) {
+	// Build the account state receipt, the same way the database does
+	account := new(protocol.LiteTokenAccount)
+	account.Url = url.MustParse("acc://bbbb/ACME")
+	account.TokenUrl = protocol.AcmeUrl()
+	account.Balance = *big.NewInt(1234)
+	data, err := account.MarshalBinary()
+	require.NoError(t, err)
+	stateReceipt, stateHash := merkleReceipt([][32]byte{sha256.Sum256(data), fakeHash("directory"), fakeHash("chains"), fakeHash("pending")}, 0)
+
+	bpt := pmt.NewBPTManager(nil).Bpt
+	bpt.Insert(account.Url.AccountID32(), stateHash)
+	bpt.Insert(fakeHash("other key"), fakeHash("other value"))
+	bpt.Insert(fakeHash("another key"), fakeHash("another value"))
+	require.NoError(t, bpt.Update())
+	accountReceipt, err := stateReceipt.Combine(bpt.GetReceipt(account.Url.AccountID32()))
+	require.NoError(t, err)
+
+	// Build the transaction receipt from the BVN's root chain
+	txn := new(protocol.Transaction)
+	txn.Header.Principal = account.Url
+	txn.Body = &protocol.SendTokens{To: []*protocol.TokenRecipient{{Url: url.MustParse("bob/tokens"), Amount: *big.NewInt(1)}}}
+	txnReceipt, bvnRoot := merkleReceipt([][32]byte{fakeHash("a"), *(*[32]byte)(txn.GetHash()), fakeHash("b")}, 1)
+
+	// Build the directory anchor that vouches for the BVN's anchor
+	bvnAnchor := new(protocol.PartitionAnchor)
+	bvnAnchor.Source = protocol.PartitionUrl("BVN0")
+	bvnAnchor.MinorBlockIndex = 10
+	bvnAnchor.RootChainAnchor = bvnRoot
+	bvnAnchor.StateTreeAnchor = bpt.RootHash
+	dnReceipt, dnRoot := merkleReceipt([][32]byte{fakeHash("c"), bvnRoot, fakeHash("d"), fakeHash("e")}, 1)
+
+	anchor := new(protocol.DirectoryAnchor)
+	anchor.Source = protocol.DnUrl()
+	anchor.MinorBlockIndex = 5
+	anchor.RootChainAnchor = dnRoot
+	anchor.StateTreeAnchor = fakeHash("dn state")
+	anchor.Receipts = []*protocol.PartitionAnchorReceipt{{Anchor: bvnAnchor, RootChainReceipt: dnReceipt}}
+
+	c := lightclient.New()
+
+	// Nothing is trusted yet
+	_, err = c.VerifyAccount(account, accountReceipt)
+	require.True(t, errors.Is(err, lightclient.ErrUntrustedAnchor), "%v", err)
+	_, err = c.VerifyTransaction(txn, txnReceipt)
+	require.True(t, errors.Is(err, lightclient.ErrUntrustedAnchor), "%v", err)
+
+	// A directory anchor with a bad receipt is rejected
+	bad := anchor.Copy()
+	bad.RootChainAnchor = fakeHash("wrong")
+	require.Error(t, c.AddDirectoryAnchor(bad))
+
+	require.NoError(t, c.AddDirectoryAnchor(anchor))
+	require.Equal(t, uint64(5), c.LatestDirectoryBlock())
+	require.Equal(t, uint64(10), c.LatestAnchor(protocol.PartitionUrl("BVN0")).MinorBlock)
+
+	a, err := c.VerifyAccount(account, accountReceipt)
+	require.NoError(t, err)
+	require.True(t, a.Partition.Equal(protocol.PartitionUrl("BVN0")))
+	require.Equal(t, uint64(5), a.DirectoryBlock)
+
+	a, err = c.VerifyTransaction(txn, txnReceipt)
+	require.NoError(t, err)
+	require.Equal(t, bvnRoot, a.RootChainAnchor)
+
+	// A modified account or transaction does not verify
+	account.Balance = *big.NewInt(9999)
+	_, err = c.VerifyAccount(account, accountReceipt)
+	require.True(t, errors.Is(err, lightclient.ErrInvalidReceipt), "%v", err)
+
+	changed := &protocol.Transaction{Header: txn.Header, Body: txn.Body}
+	changed.Header.Memo = "changed"
+	_, err = c.VerifyTransaction(changed, txnReceipt)
+	require.True(t, errors.Is(err, lightclient.ErrInvalidReceipt), "%v", err)
+
+	// A receipt with a tampered path does not verify
+	tampered := txnReceipt.Copy()
+	tampered.Entries[0].Hash = make([]byte, 32)
+	_, err = c.VerifyTransaction(txn, tampered)
+	require.True(t, errors.Is(err, lightclient.ErrInvalidReceipt), "%v", err)
+}
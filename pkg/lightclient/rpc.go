@@ -0,0 +1,168 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	stdlog "log"
+	"net/http"
+	"os"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+// JSON-RPC error codes
+const (
+	ErrCodeValidation jsonrpc2.ErrorCode = -33100 - iota
+	ErrCodeInvalidReceipt
+	ErrCodeUntrustedAnchor
+	ErrCodeNotFound
+)
+
+// AnchorResponse is a trusted anchor returned by the JSON-RPC API.
+type AnchorResponse struct {
+	Partition       *url.URL `json:"partition"`
+	MinorBlock      uint64   `json:"minorBlock"`
+	MajorBlock      uint64   `json:"majorBlock,omitempty"`
+	DirectoryBlock  uint64   `json:"directoryBlock"`
+	RootChainAnchor string   `json:"rootChainAnchor"`
+	StateTreeAnchor string   `json:"stateTreeAnchor"`
+}
+
+// StatusResponse is the status of the light client.
+type StatusResponse struct {
+	DirectoryBlock  uint64 `json:"directoryBlock"`
+	NetworkName     string `json:"networkName,omitempty"`
+	NetworkVersion  uint64 `json:"networkVersion"`
+	ValidatorCount  int    `json:"validatorCount"`
+	ActiveDirectory int    `json:"activeDirectoryValidators"`
+}
+
+// VerifyRequest is the request of the verify methods. Account is only used by
+// verify-account and Transaction is only used by verify-transaction.
+type VerifyRequest struct {
+	Receipt     *managed.Receipt      `json:"receipt"`
+	Account     json.RawMessage       `json:"account,omitempty"`
+	Transaction *protocol.Transaction `json:"transaction,omitempty"`
+}
+
+// NewHandler returns an HTTP handler that serves the light client's JSON-RPC
+// API. The methods are:
+//
+//   - status returns the latest trusted directory block and validator set.
+//   - latest-anchor returns the latest trusted anchor of a partition.
+//   - verify-receipt verifies that a receipt is rooted in a trusted anchor.
+//   - verify-account verifies an account state receipt.
+//   - verify-transaction verifies a transaction receipt.
+func NewHandler(c *Client) http.Handler {
+	methods := jsonrpc2.MethodMap{
+		"status":             c.rpcStatus,
+		"latest-anchor":      c.rpcLatestAnchor,
+		"verify-receipt":     c.rpcVerifyReceipt,
+		"verify-account":     c.rpcVerifyAccount,
+		"verify-transaction": c.rpcVerifyTransaction,
+	}
+	return jsonrpc2.HTTPRequestHandler(methods, stdlog.New(os.Stdout, "", 0))
+}
+
+func (c *Client) rpcStatus(context.Context, json.RawMessage) interface{} {
+	res := new(StatusResponse)
+	res.DirectoryBlock = c.LatestDirectoryBlock()
+	network, _ := c.Validators()
+	if network != nil {
+		res.NetworkName = network.NetworkName
+		res.NetworkVersion = network.Version
+		res.ValidatorCount = len(network.Validators)
+		for _, v := range network.Validators {
+			if v.IsActiveOn(protocol.Directory) {
+				res.ActiveDirectory++
+			}
+		}
+	}
+	return res
+}
+
+func (c *Client) rpcLatestAnchor(_ context.Context, params json.RawMessage) interface{} {
+	var req struct {
+		Partition *url.URL `json:"partition"`
+	}
+	err := json.Unmarshal(params, &req)
+	if err != nil || req.Partition == nil {
+		return jsonrpc2.NewError(ErrCodeValidation, "Validation Error", "a partition URL is required")
+	}
+
+	anchor := c.LatestAnchor(req.Partition)
+	if anchor == nil {
+		return jsonrpc2.NewError(ErrCodeNotFound, "Not Found", "no trusted anchor for "+req.Partition.String())
+	}
+	return anchorResponse(anchor)
+}
+
+func (c *Client) rpcVerifyReceipt(_ context.Context, params json.RawMessage) interface{} {
+	req, err := parseVerifyRequest(params)
+	if err != nil {
+		return err
+	}
+	return verifyResponse(c.VerifyReceipt(req.Receipt))
+}
+
+func (c *Client) rpcVerifyAccount(_ context.Context, params json.RawMessage) interface{} {
+	req, err := parseVerifyRequest(params)
+	if err != nil {
+		return err
+	}
+	account, err := protocol.UnmarshalAccountJSON(req.Account)
+	if err != nil {
+		return jsonrpc2.NewError(ErrCodeValidation, "Validation Error", err)
+	}
+	return verifyResponse(c.VerifyAccount(account, req.Receipt))
+}
+
+func (c *Client) rpcVerifyTransaction(_ context.Context, params json.RawMessage) interface{} {
+	req, err := parseVerifyRequest(params)
+	if err != nil {
+		return err
+	}
+	if req.Transaction == nil {
+		return jsonrpc2.NewError(ErrCodeValidation, "Validation Error", "a transaction is required")
+	}
+	return verifyResponse(c.VerifyTransaction(req.Transaction, req.Receipt))
+}
+
+func parseVerifyRequest(params json.RawMessage) (*VerifyRequest, error) {
+	req := new(VerifyRequest)
+	err := json.Unmarshal(params, req)
+	if err != nil {
+		return nil, jsonrpc2.NewError(ErrCodeValidation, "Validation Error", err)
+	}
+	if req.Receipt == nil {
+		return nil, jsonrpc2.NewError(ErrCodeValidation, "Validation Error", "a receipt is required")
+	}
+	return req, nil
+}
+
+func verifyResponse(anchor *Anchor, err error) interface{} {
+	switch {
+	case err == nil:
+		return anchorResponse(anchor)
+	case errors.Is(err, ErrUntrustedAnchor):
+		return jsonrpc2.NewError(ErrCodeUntrustedAnchor, "Untrusted Anchor", err.Error())
+	default:
+		return jsonrpc2.NewError(ErrCodeInvalidReceipt, "Invalid Receipt", err.Error())
+	}
+}
+
+func anchorResponse(anchor *Anchor) *AnchorResponse {
+	return &AnchorResponse{
+		Partition:       anchor.Partition,
+		MinorBlock:      anchor.MinorBlock,
+		MajorBlock:      anchor.MajorBlock,
+		DirectoryBlock:  anchor.DirectoryBlock,
+		RootChainAnchor: hex.EncodeToString(anchor.RootChainAnchor[:]),
+		StateTreeAnchor: hex.EncodeToString(anchor.StateTreeAnchor[:]),
+	}
+}
@@ -0,0 +1,240 @@
+package lightclient
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage"
+)
+
+// Store persists the trusted directory anchor history and validator set of a
+// light client, so the client can resume where it left off. Only anchors that
+// have been verified may be added to the store, since they are trusted without
+// being verified again when the store is loaded.
+type Store struct {
+	db storage.KeyValueStore
+}
+
+var storeKey = storage.MakeKey("LightClient")
+
+// NewStore returns a store backed by the key-value store.
+func NewStore(db storage.KeyValueStore) *Store {
+	return &Store{db: db}
+}
+
+// AnchorCount returns the number of directory anchors in the store.
+func (s *Store) AnchorCount() (uint64, error) {
+	batch := s.db.Begin(false)
+	defer batch.Discard()
+	return getUint(batch, storeKey.Append("AnchorCount"))
+}
+
+// NextBlock returns the next directory block to sync from.
+func (s *Store) NextBlock() (uint64, error) {
+	batch := s.db.Begin(false)
+	defer batch.Discard()
+	return getUint(batch, storeKey.Append("NextBlock"))
+}
+
+// Anchor returns the directory anchor at the given index of the history.
+func (s *Store) Anchor(index uint64) (*protocol.DirectoryAnchor, error) {
+	batch := s.db.Begin(false)
+	defer batch.Discard()
+
+	data, err := batch.Get(storeKey.Append("Anchor", index))
+	if err != nil {
+		return nil, fmt.Errorf("load anchor %d: %w", index, err)
+	}
+	anchor := new(protocol.DirectoryAnchor)
+	err = anchor.UnmarshalBinary(data)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal anchor %d: %w", index, err)
+	}
+	return anchor, nil
+}
+
+// AddAnchor appends a directory anchor to the history.
+func (s *Store) AddAnchor(anchor *protocol.DirectoryAnchor) error {
+	batch := s.db.Begin(true)
+	defer batch.Discard()
+	err := addAnchor(batch, anchor)
+	if err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// SetNextBlock records the next directory block to sync from.
+func (s *Store) SetNextBlock(block uint64) error {
+	batch := s.db.Begin(true)
+	defer batch.Discard()
+	err := putUint(batch, storeKey.Append("NextBlock"), block)
+	if err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// Save appends a directory anchor to the history, if anchor is not nil, and
+// records the validator set in effect after the anchor and the next block to
+// sync from, in a single batch. Writing them together ensures an interrupted
+// sync cannot leave an anchor in the store without the validator set updates
+// it pushed.
+func (s *Store) Save(anchor *protocol.DirectoryAnchor, network *protocol.NetworkDefinition, globals *protocol.NetworkGlobals, nextBlock uint64) error {
+	batch := s.db.Begin(true)
+	defer batch.Discard()
+
+	if anchor != nil {
+		err := addAnchor(batch, anchor)
+		if err != nil {
+			return err
+		}
+	}
+	err := putValidators(batch, network, globals)
+	if err != nil {
+		return err
+	}
+	err = putUint(batch, storeKey.Append("NextBlock"), nextBlock)
+	if err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// Checkpoint returns the stored validator set, the next block, and the latest
+// directory anchor, which can be used to bootstrap another client. It returns
+// nil if the store has not been initialized.
+func (s *Store) Checkpoint() (*Checkpoint, error) {
+	network, globals, err := s.Validators()
+	if err != nil || network == nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{Network: network, Globals: globals}
+	checkpoint.NextBlock, err = s.NextBlock()
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.AnchorCount()
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		checkpoint.Anchor, err = s.Anchor(count - 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return checkpoint, nil
+}
+
+// Validators returns the stored network definition and network globals. Both
+// are nil if the store has not been initialized.
+func (s *Store) Validators() (*protocol.NetworkDefinition, *protocol.NetworkGlobals, error) {
+	batch := s.db.Begin(false)
+	defer batch.Discard()
+
+	network := new(protocol.NetworkDefinition)
+	globals := new(protocol.NetworkGlobals)
+	for name, value := range map[string]interface{ UnmarshalBinary([]byte) error }{"Network": network, "Globals": globals} {
+		data, err := batch.Get(storeKey.Append(name))
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return nil, nil, nil
+		case err != nil:
+			return nil, nil, fmt.Errorf("load %s: %w", name, err)
+		}
+		err = value.UnmarshalBinary(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unmarshal %s: %w", name, err)
+		}
+	}
+	return network, globals, nil
+}
+
+// SetValidators stores the network definition and network globals.
+func (s *Store) SetValidators(network *protocol.NetworkDefinition, globals *protocol.NetworkGlobals) error {
+	batch := s.db.Begin(true)
+	defer batch.Discard()
+	err := putValidators(batch, network, globals)
+	if err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// Load adds the stored directory anchors and validator set to the client. The
+// anchors were verified before they were stored, so they are not verified
+// again.
+func (s *Store) Load(c *Client) error {
+	network, globals, err := s.Validators()
+	if err != nil {
+		return err
+	}
+	c.SetValidators(network, globals)
+
+	count, err := s.AnchorCount()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < count; i++ {
+		anchor, err := s.Anchor(i)
+		if err != nil {
+			return err
+		}
+		c.addVerified(anchor)
+	}
+	return nil
+}
+
+func addAnchor(batch storage.KeyValueTxn, anchor *protocol.DirectoryAnchor) error {
+	count, err := getUint(batch, storeKey.Append("AnchorCount"))
+	if err != nil {
+		return err
+	}
+	data, err := anchor.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal anchor: %w", err)
+	}
+	err = batch.Put(storeKey.Append("Anchor", count), data)
+	if err != nil {
+		return err
+	}
+	return putUint(batch, storeKey.Append("AnchorCount"), count+1)
+}
+
+func putValidators(batch storage.KeyValueTxn, network *protocol.NetworkDefinition, globals *protocol.NetworkGlobals) error {
+	networkData, err := network.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal network definition: %w", err)
+	}
+	globalsData, err := globals.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal network globals: %w", err)
+	}
+	return batch.PutAll(map[storage.Key][]byte{
+		storeKey.Append("Network"): networkData,
+		storeKey.Append("Globals"): globalsData,
+	})
+}
+
+func getUint(batch storage.KeyValueTxn, key storage.Key) (uint64, error) {
+	data, err := batch.Get(key)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return 0, nil
+	case err != nil:
+		return 0, err
+	case len(data) != 8:
+		return 0, fmt.Errorf("invalid value for %v", key)
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+func putUint(batch storage.KeyValueTxn, key storage.Key, v uint64) error {
+	var data [8]byte
+	binary.BigEndian.PutUint64(data[:], v)
+	return batch.Put(key, data[:])
+}
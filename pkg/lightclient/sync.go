@@ -0,0 +1,225 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AccumulateNetwork/jsonrpc2/v15"
+	"github.com/tendermint/tendermint/libs/log"
+	apiv2 "gitlab.com/accumulatenetwork/accumulate/internal/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2/query"
+	"gitlab.com/accumulatenetwork/accumulate/internal/logging"
+	client "gitlab.com/accumulatenetwork/accumulate/pkg/client/api/v2"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// Syncer follows the directory network's blocks, verifies the directory
+// anchors it produces, and adds them to a light client. Validator set updates
+// pushed by the anchors are applied to the client, so later anchors are
+// verified against the current validator set.
+type Syncer struct {
+	Client *Client
+	Store  *Store
+	Node   *client.Client
+	Logger log.Logger
+
+	// PollInterval is how often the syncer checks for new blocks once it
+	// has caught up.
+	PollInterval time.Duration
+}
+
+// syncPageSize is the number of blocks requested at a time.
+const syncPageSize = 50
+
+// Checkpoint is a state of the directory network the caller trusts. It is
+// used to bootstrap a syncer whose store is empty.
+type Checkpoint struct {
+	// Network is the network definition in effect at NextBlock.
+	Network *protocol.NetworkDefinition `json:"network"`
+	// Globals are the network globals in effect at NextBlock.
+	Globals *protocol.NetworkGlobals `json:"globals"`
+	// NextBlock is the directory block to start syncing from.
+	NextBlock uint64 `json:"nextBlock,omitempty"`
+	// Anchor is a directory anchor to trust, if any.
+	Anchor *protocol.DirectoryAnchor `json:"anchor,omitempty"`
+}
+
+// ErrNoCheckpoint is returned by Bootstrap if the store is empty and the
+// caller did not provide a trusted checkpoint.
+var ErrNoCheckpoint = errors.New("a trusted validator set or checkpoint is required")
+
+// Bootstrap loads the client's state from the store. If the store is empty,
+// the client is initialized from the trusted checkpoint, which is required in
+// that case. The checkpoint is ignored if the store is not empty.
+func (s *Syncer) Bootstrap(trusted *Checkpoint) error {
+	err := s.Store.Load(s.Client)
+	if err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+
+	network, globals := s.Client.Validators()
+	if network != nil && globals != nil {
+		return nil
+	}
+
+	if trusted == nil || trusted.Network == nil || trusted.Globals == nil {
+		return ErrNoCheckpoint
+	}
+
+	if trusted.Anchor != nil {
+		err = verifyDirectoryAnchor(trusted.Anchor)
+		if err != nil {
+			return fmt.Errorf("checkpoint anchor: %w", err)
+		}
+	}
+	err = s.Store.Save(trusted.Anchor, trusted.Network, trusted.Globals, trusted.NextBlock)
+	if err != nil {
+		return err
+	}
+
+	s.logger().Info("Bootstrapped from a trusted checkpoint", "network", trusted.Network.NetworkName, "version", trusted.Network.Version, "validators", len(trusted.Network.Validators), "next-block", trusted.NextBlock)
+	if trusted.Anchor != nil {
+		s.Client.addVerified(trusted.Anchor)
+	}
+	s.Client.SetValidators(trusted.Network, trusted.Globals)
+	return nil
+}
+
+// Run syncs until the context is canceled. Errors are logged and retried
+// after the poll interval.
+func (s *Syncer) Run(ctx context.Context) {
+	interval := s.PollInterval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := s.Sync(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger().Error("Sync failed", "error", err)
+				}
+				break
+			}
+			if n < syncPageSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync processes the next page of directory blocks and returns the number of
+// blocks it processed.
+func (s *Syncer) Sync(ctx context.Context) (int, error) {
+	next, err := s.Store.NextBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	req := new(client.MinorBlocksQuery)
+	req.Url = protocol.DnUrl()
+	req.Start = next
+	req.Count = syncPageSize
+	req.TxFetchMode = query.TxFetchModeExpand
+	req.BlockFilterMode = query.BlockFilterModeExcludeNone
+	res, err := s.Node.QueryMinorBlocks(ctx, req)
+	var rpcErr jsonrpc2.Error
+	switch {
+	case err == nil:
+	case errors.As(err, &rpcErr) && rpcErr.Code == apiv2.ErrCodeNotFound:
+		// The syncer has caught up
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("query blocks %d: %w", next, err)
+	}
+
+	for i, item := range res.Items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return 0, err
+		}
+		block := new(client.MinorQueryResponse)
+		err = json.Unmarshal(data, block)
+		if err != nil {
+			return 0, fmt.Errorf("invalid block: %w", err)
+		}
+
+		for _, txn := range block.Transactions {
+			err = s.addAnchor(txn, next+uint64(i))
+			if err != nil {
+				return 0, fmt.Errorf("block %d: %w", block.BlockIndex, err)
+			}
+		}
+	}
+
+	next += uint64(len(res.Items))
+	err = s.Store.SetNextBlock(next)
+	if err != nil {
+		return 0, err
+	}
+	return len(res.Items), nil
+}
+
+// addAnchor verifies and adds the directory anchor of the transaction, if it
+// is one. The anchor is stored along with the validator set that results from
+// its updates and the block being synced, so that a sync interrupted after the
+// anchor is stored resumes from that block with the updated validator set.
+func (s *Syncer) addAnchor(res *client.TransactionQueryResponse, block uint64) error {
+	if res.Transaction == nil {
+		return nil
+	}
+	anchor, ok := res.Transaction.Body.(*protocol.DirectoryAnchor)
+	if !ok || !protocol.IsDnUrl(anchor.Source) {
+		return nil
+	}
+
+	// Skip anchors that were added before a failed or interrupted sync
+	if anchor.MinorBlockIndex <= s.Client.LatestDirectoryBlock() {
+		return nil
+	}
+
+	err := s.Client.VerifyDirectoryAnchorSignatures(res.Transaction, res.Signatures)
+	if err != nil {
+		return fmt.Errorf("directory anchor %d: %w", anchor.MinorBlockIndex, err)
+	}
+	err = verifyDirectoryAnchor(anchor)
+	if err != nil {
+		return fmt.Errorf("directory anchor %d: %w", anchor.MinorBlockIndex, err)
+	}
+	network, globals, err := s.Client.applyUpdates(anchor.Updates)
+	if err != nil {
+		return fmt.Errorf("directory anchor %d: %w", anchor.MinorBlockIndex, err)
+	}
+
+	err = s.Store.Save(anchor, network, globals, block)
+	if err != nil {
+		return err
+	}
+
+	s.Client.addVerified(anchor)
+	if len(anchor.Updates) == 0 {
+		return nil
+	}
+	s.Client.SetValidators(network, globals)
+	s.logger().Info("Updated the validator set", "version", network.Version, "validators", len(network.Validators), "directory-block", anchor.MinorBlockIndex)
+	return nil
+}
+
+func (s *Syncer) logger() log.Logger {
+	if s.Logger == nil {
+		return logging.NullLogger{}
+	}
+	return s.Logger
+}
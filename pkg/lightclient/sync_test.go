@@ -0,0 +1,300 @@
+package lightclient_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/block/simulator"
+	"gitlab.com/accumulatenetwork/accumulate/internal/build"
+	acctesting "gitlab.com/accumulatenetwork/accumulate/internal/testing"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/client/signing"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/lightclient"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage/memory"
+)
+
+func signAnchor(txn *protocol.Transaction, keys ...ed25519.PrivateKey) []protocol.Signature {
+	var signatures []protocol.Signature
+	for _, key := range keys {
+		sig := new(protocol.ED25519Signature)
+		sig.PublicKey = key[32:]
+		sig.Signer = protocol.DnUrl().JoinPath(protocol.Network)
+		sig.Timestamp = 1
+		protocol.SignED25519(sig, key, nil, txn.GetHash())
+		signatures = append(signatures, sig)
+	}
+	return signatures
+}
+
+func TestDirectoryAnchorSignatures(t *testing.T) {
+	var keys []ed25519.PrivateKey
+	network := new(protocol.NetworkDefinition)
+	network.Version = 1
+	for i := 0; i < 3; i++ {
+		seed := fakeHash(string(rune('a' + i)))
+		key := ed25519.NewKeyFromSeed(seed[:])
+		keys = append(keys, key)
+		network.AddValidator(key[32:], protocol.Directory, true)
+	}
+	globals := new(protocol.NetworkGlobals)
+	globals.ValidatorAcceptThreshold.Set(2, 3)
+
+	anchor := new(protocol.DirectoryAnchor)
+	anchor.Source = protocol.DnUrl()
+	anchor.MinorBlockIndex = 1
+	txn := new(protocol.Transaction)
+	txn.Header.Principal = protocol.DnUrl().JoinPath(protocol.AnchorPool)
+	txn.Body = anchor
+
+	c := lightclient.New()
+	require.Error(t, c.VerifyDirectoryAnchorSignatures(txn, signAnchor(txn, keys...)), "The validator set is not known")
+	c.SetValidators(network, globals)
+
+	// Two of three validators are required
+	err := c.VerifyDirectoryAnchorSignatures(txn, signAnchor(txn, keys[0], keys[0]))
+	require.True(t, errors.Is(err, lightclient.ErrInsufficientSignatures), "%v", err)
+	require.NoError(t, c.VerifyDirectoryAnchorSignatures(txn, signAnchor(txn, keys[0], keys[2])))
+
+	// A key that is not a validator does not count
+	seed := fakeHash("other")
+	other := ed25519.NewKeyFromSeed(seed[:])
+	err = c.VerifyDirectoryAnchorSignatures(txn, signAnchor(txn, keys[0], other))
+	require.True(t, errors.Is(err, lightclient.ErrInsufficientSignatures), "%v", err)
+
+	// Remove two validators with a pushed update
+	updated := network.Copy()
+	updated.Version = 2
+	updated.RemoveValidator(keys[1][32:])
+	updated.RemoveValidator(keys[2][32:])
+	data, err := updated.MarshalBinary()
+	require.NoError(t, err)
+	update := protocol.NetworkAccountUpdate{
+		Name: protocol.Network,
+		Body: &protocol.WriteData{Entry: &protocol.AccumulateDataEntry{Data: [][]byte{data}}, WriteToState: true},
+	}
+	require.NoError(t, c.ApplyUpdates([]protocol.NetworkAccountUpdate{update}))
+	err = c.VerifyDirectoryAnchorSignatures(txn, signAnchor(txn, keys[1], keys[2]))
+	require.True(t, errors.Is(err, lightclient.ErrInsufficientSignatures), "%v", err)
+	require.NoError(t, c.VerifyDirectoryAnchorSignatures(txn, signAnchor(txn, keys[0])))
+
+	// The version of the network definition must increase
+	require.Error(t, c.ApplyUpdates([]protocol.NetworkAccountUpdate{update}))
+}
+
+func TestStore(t *testing.T) {
+	store := lightclient.NewStore(memory.New(nil))
+
+	network := new(protocol.NetworkDefinition)
+	network.NetworkName = "Test"
+	network.Version = 3
+	globals := new(protocol.NetworkGlobals)
+	globals.ValidatorAcceptThreshold.Set(2, 3)
+	require.NoError(t, store.SetValidators(network, globals))
+
+	for i := uint64(1); i <= 3; i++ {
+		anchor := new(protocol.DirectoryAnchor)
+		anchor.Source = protocol.DnUrl()
+		anchor.MinorBlockIndex = i * 10
+		anchor.RootChainAnchor = fakeHash(string(rune('a' + i)))
+		require.NoError(t, store.AddAnchor(anchor))
+	}
+	require.NoError(t, store.SetNextBlock(31))
+
+	// Load the history into a new client
+	c := lightclient.New()
+	require.NoError(t, store.Load(c))
+	require.Equal(t, uint64(30), c.LatestDirectoryBlock())
+	latest := c.LatestAnchor(protocol.DnUrl())
+	require.NotNil(t, latest)
+	require.Equal(t, fakeHash("d"), latest.RootChainAnchor)
+
+	gotNetwork, gotGlobals := c.Validators()
+	require.True(t, network.Equal(gotNetwork))
+	require.True(t, globals.Equal(gotGlobals))
+
+	next, err := store.NextBlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(31), next)
+}
+
+func TestBootstrap(t *testing.T) {
+	store := lightclient.NewStore(memory.New(nil))
+	syncer := &lightclient.Syncer{Client: lightclient.New(), Store: store}
+
+	// An empty store requires a trusted checkpoint
+	require.ErrorIs(t, syncer.Bootstrap(nil), lightclient.ErrNoCheckpoint)
+
+	network := new(protocol.NetworkDefinition)
+	network.Version = 1
+	globals := new(protocol.NetworkGlobals)
+	globals.ValidatorAcceptThreshold.Set(2, 3)
+	anchor := new(protocol.DirectoryAnchor)
+	anchor.Source = protocol.DnUrl()
+	anchor.MinorBlockIndex = 10
+	require.NoError(t, syncer.Bootstrap(&lightclient.Checkpoint{Network: network, Globals: globals, NextBlock: 11, Anchor: anchor}))
+	require.Equal(t, uint64(10), syncer.Client.LatestDirectoryBlock())
+
+	// The checkpoint of the store can bootstrap another client
+	checkpoint, err := store.Checkpoint()
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), checkpoint.NextBlock)
+	require.True(t, network.Equal(checkpoint.Network))
+	require.True(t, anchor.Equal(checkpoint.Anchor))
+
+	// Once the store is initialized the checkpoint is ignored
+	other := &lightclient.Syncer{Client: lightclient.New(), Store: store}
+	require.NoError(t, other.Bootstrap(nil))
+	gotNetwork, _ := other.Client.Validators()
+	require.True(t, network.Equal(gotNetwork))
+}
+
+func TestSync(t *testing.T) {
+	sim := simulator.New(t, 1)
+	sim.InitFromGenesis()
+	dn := sim.Partition(protocol.Directory)
+
+	// Trust the validator set the network was created with
+	globals := dn.Executor.ActiveGlobals_TESTONLY()
+	checkpoint := &lightclient.Checkpoint{Network: globals.Network, Globals: globals.Globals}
+	store := lightclient.NewStore(memory.New(nil))
+	syncer := &lightclient.Syncer{Client: lightclient.New(), Store: store, Node: dn.API}
+	require.NoError(t, syncer.Bootstrap(checkpoint))
+
+	// Sync the blocks produced by the network
+	sim.ExecuteBlocks(10)
+	for {
+		n, err := syncer.Sync(context.Background())
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+
+	count, err := store.AnchorCount()
+	require.NoError(t, err)
+	require.NotZero(t, count, "the directory anchors are synced")
+	require.NotZero(t, syncer.Client.LatestDirectoryBlock())
+	require.NotNil(t, syncer.Client.LatestAnchor(protocol.DnUrl()))
+	require.NotNil(t, syncer.Client.LatestAnchor(protocol.PartitionUrl("BVN0")), "the anchors of the BVN are proven by the directory anchors")
+
+	// A restarted client resumes from the store
+	restarted := &lightclient.Syncer{Client: lightclient.New(), Store: store, Node: dn.API}
+	require.NoError(t, restarted.Bootstrap(nil))
+	require.Equal(t, syncer.Client.LatestDirectoryBlock(), restarted.Client.LatestDirectoryBlock())
+	n, err := restarted.Sync(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// Anchors signed by validators the client does not trust are rejected
+	untrusted := checkpoint.Network.Copy()
+	untrusted.Validators = nil
+	seed := fakeHash("untrusted")
+	untrusted.AddValidator(ed25519.NewKeyFromSeed(seed[:])[32:], protocol.Directory, true)
+	bad := &lightclient.Syncer{Client: lightclient.New(), Store: lightclient.NewStore(memory.New(nil)), Node: dn.API}
+	require.NoError(t, bad.Bootstrap(&lightclient.Checkpoint{Network: untrusted, Globals: checkpoint.Globals}))
+	_, err = bad.Sync(context.Background())
+	require.ErrorIs(t, err, lightclient.ErrInsufficientSignatures)
+}
+
+var errCrash = errors.New("crash")
+
+// crashingStore fails every commit after the first n, as if the process
+// crashed after n commits.
+type crashingStore struct {
+	storage.KeyValueStore
+	n int
+}
+
+type crashingTxn struct {
+	storage.KeyValueTxn
+	store *crashingStore
+}
+
+func (s *crashingStore) Begin(writable bool) storage.KeyValueTxn {
+	return &crashingTxn{s.KeyValueStore.Begin(writable), s}
+}
+
+func (t *crashingTxn) Commit() error {
+	if t.store.n <= 0 {
+		return errCrash
+	}
+	t.store.n--
+	return t.KeyValueTxn.Commit()
+}
+
+func syncAll(syncer *lightclient.Syncer) error {
+	for {
+		n, err := syncer.Sync(context.Background())
+		if err != nil || n == 0 {
+			return err
+		}
+	}
+}
+
+func TestSync_Crash(t *testing.T) {
+	sim := simulator.New(t, 1)
+	sim.InitFromGenesis()
+	dn := sim.Partition(protocol.Directory)
+	globals := dn.Executor.ActiveGlobals_TESTONLY()
+	checkpoint := &lightclient.Checkpoint{Network: globals.Network.Copy(), Globals: globals.Globals.Copy()}
+
+	// Update the validator set, which is pushed to the light client by a
+	// directory anchor
+	sim.ExecuteBlocks(5)
+	operators := dn.Executor.Describe.OperatorsPage()
+	page := simulator.GetAccount[*protocol.KeyPage](sim, operators)
+	_, entry, ok := page.EntryByKey(dn.Executor.Key[32:])
+	require.True(t, ok)
+	timestamp := entry.GetLastUsedOn()
+	signer := new(signing.Builder).
+		SetType(protocol.SignatureTypeED25519).
+		UseSimpleHash().
+		SetPrivateKey(dn.Executor.Key).
+		SetUrl(operators).
+		SetVersion(page.Version).
+		SetTimestampWithVar(&timestamp)
+	validator := acctesting.GenerateKey("validator")
+	env, err := build.AddValidator(dn.Executor.ActiveGlobals_TESTONLY().Copy(), len(page.Keys), validator[32:], sim.Partitions[1].Id, true, signer)
+	require.NoError(t, err)
+	sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(env)...)
+	sim.ExecuteBlocks(10)
+	version := dn.Executor.ActiveGlobals_TESTONLY().Network.Version
+	require.Greater(t, version, checkpoint.Network.Version)
+
+	// Sync without crashing, counting the commits
+	counter := &crashingStore{KeyValueStore: memory.New(nil), n: 1 << 30}
+	expected := &lightclient.Syncer{Client: lightclient.New(), Store: lightclient.NewStore(counter), Node: dn.API}
+	require.NoError(t, expected.Bootstrap(checkpoint))
+	require.NoError(t, syncAll(expected))
+	network, _ := expected.Client.Validators()
+	require.Equal(t, version, network.Version, "the validator set update is synced")
+	commits := 1<<30 - counter.n
+
+	// Crash after each commit, then restart and sync
+	for n := 1; n < commits; n++ {
+		db := memory.New(nil)
+		crashing := &lightclient.Syncer{Client: lightclient.New(), Store: lightclient.NewStore(&crashingStore{KeyValueStore: db, n: n}), Node: dn.API}
+		require.NoError(t, crashing.Bootstrap(checkpoint))
+		require.ErrorIs(t, syncAll(crashing), errCrash)
+
+		restarted := &lightclient.Syncer{Client: lightclient.New(), Store: lightclient.NewStore(db), Node: dn.API}
+		require.NoError(t, restarted.Bootstrap(nil))
+		require.NoError(t, syncAll(restarted), "crash after %d commits", n)
+		network, globals := restarted.Client.Validators()
+		require.Equal(t, version, network.Version, "crash after %d commits", n)
+		require.True(t, checkpoint.Globals.Equal(globals), "crash after %d commits", n)
+		require.Equal(t, expected.Client.LatestDirectoryBlock(), restarted.Client.LatestDirectoryBlock(), "crash after %d commits", n)
+
+		stored, err := lightclient.NewStore(db).Checkpoint()
+		require.NoError(t, err)
+		require.Equal(t, version, stored.Network.Version, "crash after %d commits", n)
+	}
+}
+
+func delivered(status *protocol.TransactionStatus) bool {
+	return status.Delivered()
+}
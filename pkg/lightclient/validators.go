@@ -0,0 +1,141 @@
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// ErrInsufficientSignatures is returned when a directory anchor is not signed
+// by enough active validators of the directory network.
+var ErrInsufficientSignatures = errors.New("insufficient validator signatures")
+
+// SetValidators trusts the network definition and the network globals. The
+// active directory validators of the network definition and the validator
+// threshold of the globals are used to verify the signatures of directory
+// anchors. Either may be nil to keep the current value.
+func (c *Client) SetValidators(network *protocol.NetworkDefinition, globals *protocol.NetworkGlobals) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if network != nil {
+		c.network = network
+	}
+	if globals != nil {
+		c.globals = globals
+	}
+}
+
+// Validators returns the trusted network definition and network globals.
+func (c *Client) Validators() (*protocol.NetworkDefinition, *protocol.NetworkGlobals) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.network, c.globals
+}
+
+// VerifyDirectoryAnchorSignatures verifies that the directory anchor
+// transaction is signed by at least the threshold of active directory
+// validators.
+func (c *Client) VerifyDirectoryAnchorSignatures(txn *protocol.Transaction, signatures []protocol.Signature) error {
+	if _, ok := txn.Body.(*protocol.DirectoryAnchor); !ok {
+		return fmt.Errorf("transaction is a %v, not a directory anchor", txn.Body.Type())
+	}
+
+	c.mu.RLock()
+	network, globals := c.network, c.globals
+	c.mu.RUnlock()
+	if network == nil || globals == nil {
+		return fmt.Errorf("the validator set is not known")
+	}
+
+	var active int
+	for _, v := range network.Validators {
+		if v.IsActiveOn(protocol.Directory) {
+			active++
+		}
+	}
+	threshold := globals.ValidatorAcceptThreshold.Threshold(active)
+
+	// Count each validator once, no matter how many times it signed
+	signed := map[[32]byte]bool{}
+	for _, sig := range signatures {
+		sig, ok := sig.(protocol.KeySignature)
+		if !ok || len(sig.GetPublicKeyHash()) != 32 {
+			continue
+		}
+		_, validator, ok := network.ValidatorByHash(sig.GetPublicKeyHash())
+		if !ok || !validator.IsActiveOn(protocol.Directory) {
+			continue
+		}
+		if !sig.Verify(nil, txn.GetHash()) {
+			continue
+		}
+		signed[*(*[32]byte)(sig.GetPublicKeyHash())] = true
+	}
+
+	if uint64(len(signed)) < threshold || threshold == 0 {
+		return fmt.Errorf("signed by %d of %d active validators, %d required: %w", len(signed), active, threshold, ErrInsufficientSignatures)
+	}
+	return nil
+}
+
+// ApplyUpdates applies updates to the network definition and network globals
+// that are pushed by a directory anchor. Updates of other accounts are
+// ignored.
+func (c *Client) ApplyUpdates(updates []protocol.NetworkAccountUpdate) error {
+	network, globals, err := c.applyUpdates(updates)
+	if err != nil {
+		return err
+	}
+	c.SetValidators(network, globals)
+	return nil
+}
+
+// applyUpdates returns the network definition and network globals that result
+// from applying the updates, without changing the client.
+func (c *Client) applyUpdates(updates []protocol.NetworkAccountUpdate) (*protocol.NetworkDefinition, *protocol.NetworkGlobals, error) {
+	network, globals := c.Validators()
+	for _, update := range updates {
+		var entry protocol.DataEntry
+		switch body := update.Body.(type) {
+		case *protocol.WriteData:
+			entry = body.Entry
+		case *protocol.SystemWriteData:
+			entry = body.Entry
+		default:
+			continue
+		}
+
+		switch update.Name {
+		case protocol.Network:
+			updated := new(protocol.NetworkDefinition)
+			err := unmarshalEntry(entry, updated)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid network definition update: %w", err)
+			}
+			if network != nil && updated.Version <= network.Version {
+				return nil, nil, fmt.Errorf("invalid network definition update: version must increase: %d <= %d", updated.Version, network.Version)
+			}
+			network = updated
+
+		case protocol.Globals:
+			updated := new(protocol.NetworkGlobals)
+			err := unmarshalEntry(entry, updated)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid network globals update: %w", err)
+			}
+			globals = updated
+		}
+	}
+	return network, globals, nil
+}
+
+func unmarshalEntry(entry protocol.DataEntry, value interface{ UnmarshalBinary([]byte) error }) error {
+	if entry == nil {
+		return fmt.Errorf("entry is missing")
+	}
+	if len(entry.GetData()) != 1 {
+		return fmt.Errorf("want 1 record, got %d", len(entry.GetData()))
+	}
+	return value.UnmarshalBinary(entry.GetData()[0])
+}
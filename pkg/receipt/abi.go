@@ -0,0 +1,92 @@
+package receipt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+// EncodeABI encodes the receipt as the Ethereum ABI encoding of
+//
+//	(bytes32 start, bytes32 anchor, (bytes32 hash, bool right)[] entries)
+//
+// A contract decodes it with abi.decode and verifies it by hashing start with
+// each entry using sha256, abi.encodePacked(hash, start) or
+// abi.encodePacked(start, hash) if right is set, and comparing the result to
+// anchor. The EVM's native hash is Keccak-256, which chains do not use, and
+// SHA-256 is the only hash of a chain that the EVM provides, as a precompiled
+// contract. So every entry of the receipt must use SHA-256.
+func EncodeABI(r *managed.Receipt) ([]byte, error) {
+	if len(r.Start) != 32 || len(r.Anchor) != 32 {
+		return nil, fmt.Errorf("%w: start and anchor must be 32 bytes", ErrInvalidProof)
+	}
+
+	b := make([]byte, 4*32, 4*32+2*32*len(r.Entries))
+	copy(b[0:], r.Start)
+	copy(b[32:], r.Anchor)
+	putUint(b[64:], 3*32) // Offset of entries
+	putUint(b[96:], uint64(len(r.Entries)))
+	for i, entry := range r.Entries {
+		if entry.HashAlgorithm != managed.HashAlgorithmSHA256 {
+			return nil, fmt.Errorf("entry %d: %v is not supported by the EVM", i, entry.HashAlgorithm)
+		}
+		if len(entry.Hash) != 32 {
+			return nil, fmt.Errorf("%w: entry %d: hash must be 32 bytes", ErrInvalidProof, i)
+		}
+
+		var word [2 * 32]byte
+		copy(word[:], entry.Hash)
+		if entry.Right {
+			word[len(word)-1] = 1
+		}
+		b = append(b, word[:]...)
+	}
+	return b, nil
+}
+
+// DecodeABI decodes a receipt encoded by EncodeABI. The receipt must be
+// validated by the caller.
+func DecodeABI(b []byte) (*managed.Receipt, error) {
+	if len(b) < 4*32 || len(b)%32 != 0 {
+		return nil, fmt.Errorf("%w: invalid length %d", ErrInvalidProof, len(b))
+	}
+	if offset, ok := getUint(b[64:]); !ok || offset != 3*32 {
+		return nil, fmt.Errorf("%w: invalid offset of entries", ErrInvalidProof)
+	}
+	count, ok := getUint(b[96:])
+	if !ok || count != uint64(len(b)-4*32)/(2*32) || len(b)%(2*32) != 0 {
+		return nil, fmt.Errorf("%w: invalid number of entries", ErrInvalidProof)
+	}
+
+	r := new(managed.Receipt)
+	r.Start = append([]byte{}, b[0:32]...)
+	r.Anchor = append([]byte{}, b[32:64]...)
+	for i, b := 0, b[4*32:]; len(b) > 0; i, b = i+1, b[2*32:] {
+		right, ok := getUint(b[32:])
+		if !ok || right > 1 {
+			return nil, fmt.Errorf("%w: entry %d: invalid bool", ErrInvalidProof, i)
+		}
+		r.Entries = append(r.Entries, &managed.ReceiptEntry{
+			Hash:  append([]byte{}, b[:32]...),
+			Right: right == 1,
+		})
+	}
+	return r, nil
+}
+
+// putUint writes v as a uint256 word.
+func putUint(b []byte, v uint64) {
+	binary.BigEndian.PutUint64(b[24:32], v)
+}
+
+// getUint reads a uint256 word. It returns false if the value does not fit in
+// a uint64.
+func getUint(b []byte) (uint64, bool) {
+	for _, b := range b[:24] {
+		if b != 0 {
+			return 0, false
+		}
+	}
+	return binary.BigEndian.Uint64(b[24:32]), true
+}
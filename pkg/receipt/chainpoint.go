@@ -0,0 +1,198 @@
+// Package receipt converts Merkle receipts to formats understood by external
+// verifiers.
+//
+// A receipt proving a transaction or account all the way to a directory anchor
+// is a chain of receipts (account chain, partition root chain, directory
+// anchor), combined with managed.CombineReceipts. The combined receipt can be
+// exported as a Chainpoint v3 style JSON proof, for Chainpoint tooling, or ABI
+// encoded, for verification by an Ethereum contract.
+package receipt
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+// ChainpointContext is the JSON-LD context of a Chainpoint v3 proof.
+const ChainpointContext = "https://w3id.org/chainpoint/v3"
+
+// ChainpointAnchorType is the anchor type of proofs anchored in Accumulate.
+const ChainpointAnchorType = "acc"
+
+// ErrInvalidProof is returned when an exported proof is malformed.
+var ErrInvalidProof = errors.New("invalid proof")
+
+// Chainpoint is a Chainpoint v3 style proof.
+//
+// Chainpoint proofs are a list of operations applied to the hash. The
+// operations of a receipt entry are appending (r) or prepending (l) the
+// entry's hash followed by hashing with the entry's algorithm. SHA-256 is
+// named sha-256 as in Chainpoint, and BLAKE3, which Chainpoint does not
+// define, is named blake3. The last operation lists the anchors, whose
+// expected value is the anchor of the receipt.
+type Chainpoint struct {
+	Context  string              `json:"@context"`
+	Type     string              `json:"type"`
+	Hash     string              `json:"hash"`
+	Branches []*ChainpointBranch `json:"branches"`
+}
+
+// ChainpointBranch is a branch of a Chainpoint proof.
+type ChainpointBranch struct {
+	Label    string              `json:"label,omitempty"`
+	Ops      []*ChainpointOp     `json:"ops"`
+	Branches []*ChainpointBranch `json:"branches,omitempty"`
+}
+
+// ChainpointOp is an operation of a Chainpoint proof. Exactly one field is
+// set.
+type ChainpointOp struct {
+	L       string              `json:"l,omitempty"`
+	R       string              `json:"r,omitempty"`
+	Op      string              `json:"op,omitempty"`
+	Anchors []*ChainpointAnchor `json:"anchors,omitempty"`
+}
+
+// ChainpointAnchor is an anchor of a Chainpoint proof.
+type ChainpointAnchor struct {
+	Type          string   `json:"type"`
+	AnchorID      string   `json:"anchor_id"`
+	ExpectedValue string   `json:"expected_value,omitempty"`
+	URIs          []string `json:"uris,omitempty"`
+}
+
+// chainpointOps maps hash algorithms to the names of Chainpoint operations.
+var chainpointOps = map[managed.HashAlgorithm]string{
+	managed.HashAlgorithmSHA256: "sha-256",
+	managed.HashAlgorithmBLAKE3: "blake3",
+}
+
+// NewChainpoint converts the receipt to a Chainpoint proof. The proof has a
+// single branch, anchored by the receipt's anchor. The anchor ID is the
+// anchor; the caller may replace it, for example with the directory block the
+// anchor was produced in.
+func NewChainpoint(r *managed.Receipt) (*Chainpoint, error) {
+	if len(r.Start) != 32 || len(r.Anchor) != 32 {
+		return nil, fmt.Errorf("%w: start and anchor must be 32 bytes", ErrInvalidProof)
+	}
+
+	branch := new(ChainpointBranch)
+	branch.Label = "accumulate"
+	for i, entry := range r.Entries {
+		op, ok := chainpointOps[entry.HashAlgorithm]
+		if !ok {
+			return nil, fmt.Errorf("entry %d: unsupported hash algorithm %v", i, entry.HashAlgorithm)
+		}
+		if entry.Right {
+			branch.Ops = append(branch.Ops, &ChainpointOp{R: hex.EncodeToString(entry.Hash)})
+		} else {
+			branch.Ops = append(branch.Ops, &ChainpointOp{L: hex.EncodeToString(entry.Hash)})
+		}
+		branch.Ops = append(branch.Ops, &ChainpointOp{Op: op})
+	}
+
+	anchor := hex.EncodeToString(r.Anchor)
+	branch.Ops = append(branch.Ops, &ChainpointOp{Anchors: []*ChainpointAnchor{{
+		Type:          ChainpointAnchorType,
+		AnchorID:      anchor,
+		ExpectedValue: anchor,
+	}}})
+
+	c := new(Chainpoint)
+	c.Context = ChainpointContext
+	c.Type = "Chainpoint"
+	c.Hash = hex.EncodeToString(r.Start)
+	c.Branches = []*ChainpointBranch{branch}
+	return c, nil
+}
+
+// Receipt converts the first branch of the proof back to a receipt. The
+// receipt must be validated by the caller.
+func (c *Chainpoint) Receipt() (*managed.Receipt, error) {
+	if len(c.Branches) == 0 {
+		return nil, fmt.Errorf("%w: no branches", ErrInvalidProof)
+	}
+
+	r := new(managed.Receipt)
+	var err error
+	r.Start, err = decodeHash(c.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: hash: %v", ErrInvalidProof, err)
+	}
+
+	// Every entry is an l or r operation followed by a hash operation
+	var entry *managed.ReceiptEntry
+	for i, op := range c.Branches[0].Ops {
+		switch {
+		case op.L != "" || op.R != "":
+			if entry != nil || op.L != "" && op.R != "" {
+				return nil, fmt.Errorf("%w: op %d: expected a hash operation", ErrInvalidProof, i)
+			}
+			entry = new(managed.ReceiptEntry)
+			entry.Right = op.R != ""
+			entry.Hash, err = decodeHash(op.L + op.R)
+			if err != nil {
+				return nil, fmt.Errorf("%w: op %d: %v", ErrInvalidProof, i, err)
+			}
+
+		case op.Op != "":
+			if entry == nil {
+				return nil, fmt.Errorf("%w: op %d: expected l or r", ErrInvalidProof, i)
+			}
+			entry.HashAlgorithm, err = hashAlgorithm(op.Op)
+			if err != nil {
+				return nil, fmt.Errorf("%w: op %d: %v", ErrInvalidProof, i, err)
+			}
+			r.Entries = append(r.Entries, entry)
+			entry = nil
+
+		case len(op.Anchors) > 0:
+			if entry != nil || r.Anchor != nil {
+				return nil, fmt.Errorf("%w: op %d: unexpected anchors", ErrInvalidProof, i)
+			}
+			for _, anchor := range op.Anchors {
+				if anchor.Type != ChainpointAnchorType {
+					continue
+				}
+				r.Anchor, err = decodeHash(anchor.ExpectedValue)
+				if err != nil {
+					return nil, fmt.Errorf("%w: op %d: %v", ErrInvalidProof, i, err)
+				}
+				break
+			}
+
+		default:
+			return nil, fmt.Errorf("%w: op %d is empty", ErrInvalidProof, i)
+		}
+	}
+	if entry != nil {
+		return nil, fmt.Errorf("%w: missing hash operation", ErrInvalidProof)
+	}
+	if r.Anchor == nil {
+		return nil, fmt.Errorf("%w: missing %s anchor", ErrInvalidProof, ChainpointAnchorType)
+	}
+	return r, nil
+}
+
+func hashAlgorithm(op string) (managed.HashAlgorithm, error) {
+	for alg, name := range chainpointOps {
+		if name == op {
+			return alg, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported operation %q", op)
+}
+
+func decodeHash(s string) ([]byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 32 {
+		return nil, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	return b, nil
+}
@@ -0,0 +1,132 @@
+package receipt_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/receipt"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+func hash(s string) []byte {
+	h := sha256.Sum256([]byte(s))
+	return h[:]
+}
+
+// testReceipt builds a valid receipt combining two receipts, like a chain
+// receipt combined with an anchor receipt.
+func testReceipt(alg managed.HashAlgorithm) *managed.Receipt {
+	r1 := new(managed.Receipt)
+	r1.Start = hash("start")
+	r1.Entries = []*managed.ReceiptEntry{
+		{Hash: hash("a"), Right: true, HashAlgorithm: alg},
+		{Hash: hash("b"), Right: false, HashAlgorithm: alg},
+	}
+	r1.Anchor = r1.Start
+	for _, e := range r1.Entries {
+		r1.Anchor = e.Apply(r1.Anchor)
+	}
+
+	r2 := new(managed.Receipt)
+	r2.Start = r1.Anchor
+	r2.Entries = []*managed.ReceiptEntry{{Hash: hash("c"), Right: true}}
+	r2.Anchor = r2.Entries[0].Apply(r2.Start)
+
+	r, err := managed.CombineReceipts(r1, r2)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestChainpoint(t *testing.T) {
+	for _, alg := range []managed.HashAlgorithm{managed.HashAlgorithmSHA256, managed.HashAlgorithmBLAKE3} {
+		t.Run(alg.String(), func(t *testing.T) {
+			r := testReceipt(alg)
+			require.True(t, r.Validate())
+
+			c, err := receipt.NewChainpoint(r)
+			require.NoError(t, err)
+			ops := c.Branches[0].Ops
+			require.Len(t, ops, 2*len(r.Entries)+1)
+			require.Equal(t, "sha-256", ops[5].Op)
+
+			// The proof survives JSON
+			data, err := json.Marshal(c)
+			require.NoError(t, err)
+			c2 := new(receipt.Chainpoint)
+			require.NoError(t, json.Unmarshal(data, c2))
+			require.Equal(t, receipt.ChainpointContext, c2.Context)
+
+			r2, err := c2.Receipt()
+			require.NoError(t, err)
+			require.True(t, r2.Validate())
+			require.Equal(t, r.Anchor, r2.Anchor)
+			require.Equal(t, r.Start, r2.Start)
+		})
+	}
+}
+
+func TestChainpointInvalid(t *testing.T) {
+	c, err := receipt.NewChainpoint(testReceipt(managed.HashAlgorithmSHA256))
+	require.NoError(t, err)
+
+	// An unknown operation
+	bad := *c.Branches[0]
+	bad.Ops = append([]*receipt.ChainpointOp{}, bad.Ops...)
+	bad.Ops[1] = &receipt.ChainpointOp{Op: "sha-512"}
+	_, err = (&receipt.Chainpoint{Hash: c.Hash, Branches: []*receipt.ChainpointBranch{&bad}}).Receipt()
+	require.True(t, errors.Is(err, receipt.ErrInvalidProof), "%v", err)
+
+	// A missing anchor
+	bad.Ops = c.Branches[0].Ops[:len(c.Branches[0].Ops)-1]
+	_, err = (&receipt.Chainpoint{Hash: c.Hash, Branches: []*receipt.ChainpointBranch{&bad}}).Receipt()
+	require.True(t, errors.Is(err, receipt.ErrInvalidProof), "%v", err)
+
+	// A tampered hash produces a receipt that does not validate
+	bad.Ops = append([]*receipt.ChainpointOp{}, c.Branches[0].Ops...)
+	bad.Ops[0] = &receipt.ChainpointOp{R: c.Hash}
+	r, err := (&receipt.Chainpoint{Hash: c.Hash, Branches: []*receipt.ChainpointBranch{&bad}}).Receipt()
+	require.NoError(t, err)
+	require.False(t, r.Validate())
+}
+
+func TestABI(t *testing.T) {
+	r := testReceipt(managed.HashAlgorithmSHA256)
+	b, err := receipt.EncodeABI(r)
+	require.NoError(t, err)
+	require.Len(t, b, 4*32+2*32*len(r.Entries))
+
+	// Verify the encoding as a contract would
+	require.Equal(t, r.Start, b[:32])
+	require.Equal(t, r.Anchor, b[32:64])
+	require.Equal(t, byte(96), b[95])
+	require.Equal(t, byte(len(r.Entries)), b[127])
+	hash := b[:32]
+	for entry := b[128:]; len(entry) > 0; entry = entry[64:] {
+		var h [32]byte
+		if entry[63] == 1 {
+			h = sha256.Sum256(append(append([]byte{}, hash...), entry[:32]...))
+		} else {
+			h = sha256.Sum256(append(append([]byte{}, entry[:32]...), hash...))
+		}
+		hash = h[:]
+	}
+	require.Equal(t, r.Anchor, hash)
+
+	r2, err := receipt.DecodeABI(b)
+	require.NoError(t, err)
+	require.True(t, r2.Validate())
+	require.True(t, r.Contains(r2))
+
+	// Truncated data is rejected
+	_, err = receipt.DecodeABI(b[:len(b)-32])
+	require.True(t, errors.Is(err, receipt.ErrInvalidProof), "%v", err)
+
+	// BLAKE3 cannot be verified by the EVM
+	_, err = receipt.EncodeABI(testReceipt(managed.HashAlgorithmBLAKE3))
+	require.Error(t, err)
+}
@@ -29,15 +29,17 @@ func (a *SystemLedger) GetUrl() *url.URL    { return a.Url }
 func (a *BlockLedger) GetUrl() *url.URL     { return a.Url }
 func (a *KeyBook) GetUrl() *url.URL         { return a.Url }
 func (a *KeyPage) GetUrl() *url.URL         { return a.Url }
+func (a *KeyValueAccount) GetUrl() *url.URL { return a.Url }
 func (a *TokenAccount) GetUrl() *url.URL    { return a.Url }
 func (a *TokenIssuer) GetUrl() *url.URL     { return a.Url }
 func (a *SyntheticLedger) GetUrl() *url.URL { return a.Url }
 
-func (a *ADI) GetAuth() *AccountAuth          { return &a.AccountAuth }
-func (a *DataAccount) GetAuth() *AccountAuth  { return &a.AccountAuth }
-func (a *KeyBook) GetAuth() *AccountAuth      { return &a.AccountAuth }
-func (a *TokenAccount) GetAuth() *AccountAuth { return &a.AccountAuth }
-func (a *TokenIssuer) GetAuth() *AccountAuth  { return &a.AccountAuth }
+func (a *ADI) GetAuth() *AccountAuth             { return &a.AccountAuth }
+func (a *DataAccount) GetAuth() *AccountAuth     { return &a.AccountAuth }
+func (a *KeyBook) GetAuth() *AccountAuth         { return &a.AccountAuth }
+func (a *KeyValueAccount) GetAuth() *AccountAuth { return &a.AccountAuth }
+func (a *TokenAccount) GetAuth() *AccountAuth    { return &a.AccountAuth }
+func (a *TokenIssuer) GetAuth() *AccountAuth     { return &a.AccountAuth }
 
 // KeyBook is a backwards compatability shim for the API
 func (a *KeyPage) KeyBook() *url.URL {
@@ -68,6 +68,9 @@ const AccountTypeLiteIdentity AccountType = 15
 // AccountTypeSyntheticLedger is a ledger that tracks the status of produced and received synthetic transactions.
 const AccountTypeSyntheticLedger AccountType = 16
 
+// AccountTypeKeyValueAccount is an ADI account that stores values by key, committed in a Merkle tree.
+const AccountTypeKeyValueAccount AccountType = 17
+
 // AllowedTransactionBitUpdateKeyPage is the offset of the UpdateKeyPage bit.
 const AllowedTransactionBitUpdateKeyPage AllowedTransactionBit = 1
 
@@ -245,6 +248,15 @@ const TransactionTypeUpdateAccountAuth TransactionType = 21
 // TransactionTypeUpdateKey update key for existing keys.
 const TransactionTypeUpdateKey TransactionType = 22
 
+// TransactionTypeCreateKeyValueAccount creates an ADI key/value account.
+const TransactionTypeCreateKeyValueAccount TransactionType = 23
+
+// TransactionTypeSetKeyValue sets the value of a key of a key/value account.
+const TransactionTypeSetKeyValue TransactionType = 24
+
+// TransactionTypeDeleteKeyValue deletes the value of a key of a key/value account.
+const TransactionTypeDeleteKeyValue TransactionType = 25
+
 // TransactionTypeRemote is used to sign a remote transaction.
 const TransactionTypeRemote TransactionType = 48
 
@@ -369,7 +381,7 @@ func (v AccountType) GetEnumValue() uint64 { return uint64(v) }
 func (v *AccountType) SetEnumValue(id uint64) bool {
 	u := AccountType(id)
 	switch u {
-	case AccountTypeUnknown, AccountTypeAnchorLedger, AccountTypeIdentity, AccountTypeTokenIssuer, AccountTypeTokenAccount, AccountTypeLiteTokenAccount, AccountTypeBlockLedger, AccountTypeKeyPage, AccountTypeKeyBook, AccountTypeDataAccount, AccountTypeLiteDataAccount, AccountTypeUnknownSigner, AccountTypeSystemLedger, AccountTypeLiteIdentity, AccountTypeSyntheticLedger:
+	case AccountTypeUnknown, AccountTypeAnchorLedger, AccountTypeIdentity, AccountTypeTokenIssuer, AccountTypeTokenAccount, AccountTypeLiteTokenAccount, AccountTypeBlockLedger, AccountTypeKeyPage, AccountTypeKeyBook, AccountTypeDataAccount, AccountTypeLiteDataAccount, AccountTypeUnknownSigner, AccountTypeSystemLedger, AccountTypeLiteIdentity, AccountTypeSyntheticLedger, AccountTypeKeyValueAccount:
 		*v = u
 		return true
 	default:
@@ -410,6 +422,8 @@ func (v AccountType) String() string {
 		return "liteIdentity"
 	case AccountTypeSyntheticLedger:
 		return "syntheticLedger"
+	case AccountTypeKeyValueAccount:
+		return "keyValueAccount"
 	default:
 		return fmt.Sprintf("AccountType:%d", v)
 	}
@@ -450,6 +464,8 @@ func AccountTypeByName(name string) (AccountType, bool) {
 		return AccountTypeLiteIdentity, true
 	case "syntheticledger":
 		return AccountTypeSyntheticLedger, true
+	case "keyvalueaccount":
+		return AccountTypeKeyValueAccount, true
 	default:
 		return 0, false
 	}
@@ -1115,7 +1131,7 @@ func (v TransactionType) GetEnumValue() uint64 { return uint64(v) }
 func (v *TransactionType) SetEnumValue(id uint64) bool {
 	u := TransactionType(id)
 	switch u {
-	case TransactionTypeUnknown, TransactionTypeCreateIdentity, TransactionTypeCreateTokenAccount, TransactionTypeSendTokens, TransactionTypeCreateDataAccount, TransactionTypeWriteData, TransactionTypeWriteDataTo, TransactionTypeAcmeFaucet, TransactionTypeCreateToken, TransactionTypeIssueTokens, TransactionTypeBurnTokens, TransactionTypeCreateLiteTokenAccount, TransactionTypeCreateKeyPage, TransactionTypeCreateKeyBook, TransactionTypeAddCredits, TransactionTypeUpdateKeyPage, TransactionTypeLockAccount, TransactionTypeUpdateAccountAuth, TransactionTypeUpdateKey, TransactionTypeRemote, TransactionTypeSyntheticCreateIdentity, TransactionTypeSyntheticWriteData, TransactionTypeSyntheticDepositTokens, TransactionTypeSyntheticDepositCredits, TransactionTypeSyntheticBurnTokens, TransactionTypeSyntheticForwardTransaction, TransactionTypeSystemGenesis, TransactionTypeDirectoryAnchor, TransactionTypeBlockValidatorAnchor, TransactionTypeSystemWriteData:
+	case TransactionTypeUnknown, TransactionTypeCreateIdentity, TransactionTypeCreateTokenAccount, TransactionTypeSendTokens, TransactionTypeCreateDataAccount, TransactionTypeWriteData, TransactionTypeWriteDataTo, TransactionTypeAcmeFaucet, TransactionTypeCreateToken, TransactionTypeIssueTokens, TransactionTypeBurnTokens, TransactionTypeCreateLiteTokenAccount, TransactionTypeCreateKeyPage, TransactionTypeCreateKeyBook, TransactionTypeAddCredits, TransactionTypeUpdateKeyPage, TransactionTypeLockAccount, TransactionTypeUpdateAccountAuth, TransactionTypeUpdateKey, TransactionTypeCreateKeyValueAccount, TransactionTypeSetKeyValue, TransactionTypeDeleteKeyValue, TransactionTypeRemote, TransactionTypeSyntheticCreateIdentity, TransactionTypeSyntheticWriteData, TransactionTypeSyntheticDepositTokens, TransactionTypeSyntheticDepositCredits, TransactionTypeSyntheticBurnTokens, TransactionTypeSyntheticForwardTransaction, TransactionTypeSystemGenesis, TransactionTypeDirectoryAnchor, TransactionTypeBlockValidatorAnchor, TransactionTypeSystemWriteData:
 		*v = u
 		return true
 	default:
@@ -1164,6 +1180,12 @@ func (v TransactionType) String() string {
 		return "updateAccountAuth"
 	case TransactionTypeUpdateKey:
 		return "updateKey"
+	case TransactionTypeCreateKeyValueAccount:
+		return "createKeyValueAccount"
+	case TransactionTypeSetKeyValue:
+		return "setKeyValue"
+	case TransactionTypeDeleteKeyValue:
+		return "deleteKeyValue"
 	case TransactionTypeRemote:
 		return "remote"
 	case TransactionTypeSyntheticCreateIdentity:
@@ -1232,6 +1254,12 @@ func TransactionTypeByName(name string) (TransactionType, bool) {
 		return TransactionTypeUpdateAccountAuth, true
 	case "updatekey":
 		return TransactionTypeUpdateKey, true
+	case "createkeyvalueaccount":
+		return TransactionTypeCreateKeyValueAccount, true
+	case "setkeyvalue":
+		return TransactionTypeSetKeyValue, true
+	case "deletekeyvalue":
+		return TransactionTypeDeleteKeyValue, true
 	case "remote":
 		return TransactionTypeRemote, true
 	case "signPending":
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"encoding"
+	"math/big"
 	"strings"
 
 	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
@@ -14,6 +15,28 @@ func (n Fee) AsUInt64() uint64            { return uint64(n) }
 func (n Fee) GetEnumValue() uint64        { return uint64(n) }
 func (n *Fee) SetEnumValue(v uint64) bool { *n = Fee(v); return true }
 
+// AsAcme returns the amount of ACME, in ACME precision, needed to buy the fee
+// in credits at the given oracle price, rounded up. AsAcme returns zero if the
+// oracle is zero.
+func (n Fee) AsAcme(oracle uint64) *big.Int {
+	if oracle == 0 {
+		return new(big.Int)
+	}
+
+	// ACME = credits * oracle precision * ACME precision / (credit units per
+	// dollar * oracle)
+	acme := new(big.Int).SetUint64(n.AsUInt64())
+	acme.Mul(acme, big.NewInt(AcmeOraclePrecision))
+	acme.Mul(acme, big.NewInt(AcmePrecision))
+	div := new(big.Int).SetUint64(oracle)
+	div.Mul(div, big.NewInt(CreditUnitsPerFiatUnit))
+
+	// Round up
+	acme.Add(acme, div)
+	acme.Sub(acme, big.NewInt(1))
+	return acme.Div(acme, div)
+}
+
 const (
 	// FeeFailedMaximum $0.01
 	FeeFailedMaximum Fee = 100
@@ -107,6 +130,61 @@ func (s *FeeSchedule) ComputeSignatureFee(sig Signature) (Fee, error) {
 	return fee, nil
 }
 
+// ComputeSignerFee computes the fee charged to the signer of a signature of the
+// transaction. Signatures from partitions are free.
+//
+// The initiator of a transaction that is not sponsored pays the transaction fee
+// in place of the base signature fee. The first signature from the sponsor of a
+// sponsored transaction pays the transaction fee in addition to its signature
+// fee, or in place of the base signature fee if it is also the initiator. If
+// sponsorPaid is true, the sponsor has already paid the transaction fee.
+//
+// Every other signature pays its signature fee.
+func (s *FeeSchedule) ComputeSignerFee(txn *Transaction, sig Signature, isInitiator, sponsorPaid bool) (Fee, error) {
+	// Don't charge fees for internal administrative functions
+	signer := sig.GetSigner()
+	_, isBvn := ParsePartitionUrl(signer)
+	if isBvn || IsDnUrl(signer) {
+		return 0, nil
+	}
+
+	// Compute the signature fee
+	fee, err := s.ComputeSignatureFee(sig)
+	if err != nil {
+		return 0, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	// Only charge the transaction fee for the initial signature of an
+	// unsponsored transaction, or the sponsor's first signature
+	var payTxnFee bool
+	switch {
+	case txn.IsSponsor(signer):
+		payTxnFee = !sponsorPaid
+	case txn.Header.Sponsor != nil:
+		payTxnFee = false
+	default:
+		payTxnFee = isInitiator
+	}
+	if !payTxnFee {
+		return fee, nil
+	}
+
+	// Add the transaction fee
+	txnFee, err := s.ComputeTransactionFee(txn)
+	if err != nil {
+		return 0, errors.Wrap(errors.StatusUnknownError, err)
+	}
+
+	// The sponsor pays for its own signature in addition to the transaction
+	if !isInitiator {
+		return fee + txnFee, nil
+	}
+
+	// Subtract the base signature fee, but not the oversize surcharge if there is one
+	fee += txnFee - FeeSignature
+	return fee, nil
+}
+
 func (s *FeeSchedule) ComputeTransactionFee(tx *Transaction) (Fee, error) {
 	// Do not charge fees for the DN or BVNs
 	if _, ok := ParsePartitionUrl(tx.Header.Principal); ok {
@@ -156,7 +234,8 @@ func (s *FeeSchedule) ComputeTransactionFee(tx *Transaction) (Fee, error) {
 		}
 
 	case *CreateTokenAccount,
-		*CreateDataAccount:
+		*CreateDataAccount,
+		*CreateKeyValueAccount:
 		fee = FeeCreateAccount + FeeData*Fee(count-1)
 
 	case *SendTokens:
@@ -196,6 +275,11 @@ func (s *FeeSchedule) ComputeTransactionFee(tx *Transaction) (Fee, error) {
 	case *WriteDataTo:
 		fee = FeeData * Fee(count)
 
+	case *SetKeyValue:
+		fee = FeeData * Fee(count)
+	case *DeleteKeyValue:
+		fee = FeeGeneralSmall + FeeData*Fee(count-1)
+
 	case *AddCredits,
 		*AcmeFaucet:
 		fee = 0
@@ -185,3 +185,57 @@ func TestMultiOutputRefund(t *testing.T) {
 	// Verify
 	require.GreaterOrEqual(t, fee2, fee1)
 }
+
+func TestComputeSignerFee(t *testing.T) {
+	s := new(FeeSchedule)
+	txn := new(Transaction)
+	txn.Header.Principal = AccountUrl("alice")
+	txn.Body = &CreateTokenAccount{Url: AccountUrl("alice", "tokens"), TokenUrl: AcmeUrl()}
+	txnFee, err := s.ComputeTransactionFee(txn)
+	require.NoError(t, err)
+
+	alice := &ED25519Signature{Signer: AccountUrl("alice", "book", "1"), PublicKey: make([]byte, 32), Signature: make([]byte, 64)}
+	bob := &ED25519Signature{Signer: AccountUrl("bob", "book", "1"), PublicKey: make([]byte, 32), Signature: make([]byte, 64)}
+	sigFee, err := s.ComputeSignatureFee(alice)
+	require.NoError(t, err)
+
+	fee := func(sig Signature, isInitiator, sponsorPaid bool) Fee {
+		t.Helper()
+		fee, err := s.ComputeSignerFee(txn, sig, isInitiator, sponsorPaid)
+		require.NoError(t, err)
+		return fee
+	}
+
+	// The initiator of an unsponsored transaction pays the transaction fee in
+	// place of the base signature fee
+	require.Equal(t, sigFee+txnFee-FeeSignature, fee(alice, true, false))
+	require.Equal(t, sigFee, fee(alice, false, false))
+
+	// The sponsor pays the transaction fee once, and the initiator of a
+	// sponsored transaction only pays its signature fee
+	txn.Header.Sponsor = AccountUrl("bob", "book", "1")
+	require.Equal(t, sigFee, fee(alice, true, false))
+	require.Equal(t, sigFee+txnFee, fee(bob, false, false))
+	require.Equal(t, sigFee, fee(bob, false, true))
+	require.Equal(t, sigFee+txnFee-FeeSignature, fee(bob, true, false))
+
+	// Partitions do not pay
+	require.Zero(t, fee(&ED25519Signature{Signer: DnUrl().JoinPath(Operators, "1"), PublicKey: make([]byte, 32), Signature: make([]byte, 64)}, true, false))
+}
+
+func TestFeeAsAcme(t *testing.T) {
+	cases := []struct {
+		Fee    Fee
+		Oracle uint64
+		Acme   int64
+	}{
+		{1, 0, 0},
+		{1, 500, 2e5},     // 0.01 credits at $0.05 = 0.002 ACME
+		{1, 3, 33333334},  // Rounded up
+		{1e9, 500, 2e14},  // Overflows uint64 if multiplied directly
+		{1e12, 5e6, 2e13}, // Large fee, high price
+	}
+	for _, c := range cases {
+		require.Equal(t, big.NewInt(c.Acme).String(), c.Fee.AsAcme(c.Oracle).String(), "fee %d at %d", c.Fee, c.Oracle)
+	}
+}
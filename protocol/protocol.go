@@ -60,6 +60,10 @@ const (
 	// Globals is the path to the Directory network's Mutable Protocol costants data account
 	Globals = "globals"
 
+	// ExternalAnchors is the path to the Directory network's data account that
+	// records where major blocks have been anchored externally
+	ExternalAnchors = "external-anchors"
+
 	// MainChain is the main transaction chain of a record.
 	MainChain = "main"
 
@@ -74,6 +78,9 @@ const (
 
 	//AccountUrlMaxLength is the maximum size allowed for accumulate adi urls
 	AccountUrlMaxLength = 500
+
+	// KeyValueKeyMaxLength is the maximum size of a key of a key/value account
+	KeyValueKeyMaxLength = 256
 )
 
 //AcmeSupplyLimit set at 500,000,000.00000000 million acme (external units)
@@ -99,6 +99,22 @@ func (s *TransactionStatus) FindSigners(authority *url.URL) []Signer {
 	return s.Signers[i:j]
 }
 
+// IsSponsor returns true if the signer is the sponsor of the transaction. A
+// lite token account signs as its lite identity.
+func (t *Transaction) IsSponsor(signer *url.URL) bool {
+	sponsor := t.Header.Sponsor
+	if sponsor == nil || signer == nil {
+		return false
+	}
+	if key, _, _ := ParseLiteTokenAddress(sponsor); key != nil {
+		sponsor = sponsor.RootIdentity()
+	}
+	if key, _, _ := ParseLiteTokenAddress(signer); key != nil {
+		signer = signer.RootIdentity()
+	}
+	return sponsor.Equal(signer)
+}
+
 // IsUser returns true if the transaction type is user.
 func (t TransactionType) IsUser() bool {
 	return TransactionTypeUnknown < t && t.GetEnumValue() <= TransactionMaxUser.GetEnumValue()
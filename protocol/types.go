@@ -17,6 +17,12 @@ const ChainTypeTransaction = managed.ChainTypeTransaction
 const ChainTypeAnchor = managed.ChainTypeAnchor
 const ChainTypeIndex = managed.ChainTypeIndex
 
+// HashAlgorithm is the algorithm used to combine the hashes of a chain.
+type HashAlgorithm = managed.HashAlgorithm
+
+const HashAlgorithmSHA256 = managed.HashAlgorithmSHA256
+const HashAlgorithmBLAKE3 = managed.HashAlgorithmBLAKE3
+
 // BookType is the type of a key book.
 type BookType uint64
 
@@ -33,6 +33,17 @@ type AccountAuth struct {
 	extraData   []byte
 }
 
+type AccountChange struct {
+	Url *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	// Before is the state of the account before the transaction, or nil if it did not exist.
+	Before Account `json:"before,omitempty" form:"before" query:"before" validate:"required"`
+	// After is the state of the account after the transaction.
+	After            Account       `json:"after,omitempty" form:"after" query:"after" validate:"required"`
+	DirectoryAdded   []*url.URL    `json:"directoryAdded,omitempty" form:"directoryAdded" query:"directoryAdded" validate:"required"`
+	DirectoryRemoved []*url.URL    `json:"directoryRemoved,omitempty" form:"directoryRemoved" query:"directoryRemoved" validate:"required"`
+	Chains           []ChainChange `json:"chains,omitempty" form:"chains" query:"chains" validate:"required"`
+}
+
 type AccumulateDataEntry struct {
 	fieldsSet []bool
 	Data      [][]byte `json:"data,omitempty" form:"data" query:"data" validate:"required"`
@@ -168,11 +179,20 @@ type BurnTokens struct {
 	extraData []byte
 }
 
+type ChainChange struct {
+	Name string `json:"name,omitempty" form:"name" query:"name" validate:"required"`
+	// Before is the height of the chain before the transaction.
+	Before uint64 `json:"before,omitempty" form:"before" query:"before" validate:"required"`
+	// After is the height of the chain after the transaction.
+	After uint64 `json:"after,omitempty" form:"after" query:"after" validate:"required"`
+}
+
 type ChainMetadata struct {
-	fieldsSet []bool
-	Name      string    `json:"name,omitempty" form:"name" query:"name" validate:"required"`
-	Type      ChainType `json:"type,omitempty" form:"type" query:"type" validate:"required"`
-	extraData []byte
+	fieldsSet     []bool
+	Name          string        `json:"name,omitempty" form:"name" query:"name" validate:"required"`
+	Type          ChainType     `json:"type,omitempty" form:"type" query:"type" validate:"required"`
+	HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty" form:"hashAlgorithm" query:"hashAlgorithm"`
+	extraData     []byte
 }
 
 type ChainParams struct {
@@ -187,7 +207,9 @@ type CreateDataAccount struct {
 	Url       *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
 	// Authorities is a list of authorities to add to the authority set.
 	Authorities []*url.URL `json:"authorities,omitempty" form:"authorities" query:"authorities"`
-	extraData   []byte
+	// HashAlgorithm is the algorithm used to combine the hashes of the account's data chains.
+	HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty" form:"hashAlgorithm" query:"hashAlgorithm"`
+	extraData     []byte
 }
 
 type CreateIdentity struct {
@@ -216,6 +238,14 @@ type CreateKeyPage struct {
 	extraData []byte
 }
 
+type CreateKeyValueAccount struct {
+	fieldsSet []bool
+	Url       *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	// Authorities is a list of authorities to add to the authority set.
+	Authorities []*url.URL `json:"authorities,omitempty" form:"authorities" query:"authorities"`
+	extraData   []byte
+}
+
 type CreateLiteTokenAccount struct {
 	fieldsSet []bool
 	extraData []byte
@@ -260,6 +290,12 @@ type DelegatedSignature struct {
 	extraData []byte
 }
 
+type DeleteKeyValue struct {
+	fieldsSet []bool
+	Key       []byte `json:"key,omitempty" form:"key" query:"key" validate:"required"`
+	extraData []byte
+}
+
 type DirectoryAnchor struct {
 	fieldsSet []bool
 	PartitionAnchor
@@ -325,6 +361,23 @@ type Envelope struct {
 	extraData   []byte
 }
 
+// ExternalAnchor records the anchor of a directory major block in an external system.
+type ExternalAnchor struct {
+	fieldsSet []bool
+	// MajorBlockIndex is the index of the major block.
+	MajorBlockIndex uint64 `json:"majorBlockIndex,omitempty" form:"majorBlockIndex" query:"majorBlockIndex" validate:"required"`
+	// MinorBlockIndex is the index of the minor block that opened the major block.
+	MinorBlockIndex uint64 `json:"minorBlockIndex,omitempty" form:"minorBlockIndex" query:"minorBlockIndex" validate:"required"`
+	RootChainIndex  uint64 `json:"rootChainIndex,omitempty" form:"rootChainIndex" query:"rootChainIndex" validate:"required"`
+	// RootChainAnchor is the anchor of the directory's root chain.
+	RootChainAnchor [32]byte `json:"rootChainAnchor,omitempty" form:"rootChainAnchor" query:"rootChainAnchor" validate:"required"`
+	// StateTreeAnchor is the root of the directory's BPT.
+	StateTreeAnchor [32]byte `json:"stateTreeAnchor,omitempty" form:"stateTreeAnchor" query:"stateTreeAnchor" validate:"required"`
+	// Reference identifies the external record of the anchor.
+	Reference string `json:"reference,omitempty" form:"reference" query:"reference" validate:"required"`
+	extraData []byte
+}
+
 type FactomDataEntry struct {
 	AccountId [32]byte `json:"accountId,omitempty" form:"accountId" query:"accountId" validate:"required"`
 	Data      []byte   `json:"data,omitempty" form:"data" query:"data" validate:"required"`
@@ -419,6 +472,13 @@ type KeySpecParams struct {
 	extraData []byte
 }
 
+type KeyValueAccount struct {
+	fieldsSet []bool
+	Url       *url.URL `json:"url,omitempty" form:"url" query:"url" validate:"required"`
+	AccountAuth
+	extraData []byte
+}
+
 type LegacyED25519Signature struct {
 	fieldsSet       []bool
 	Timestamp       uint64   `json:"timestamp,omitempty" form:"timestamp" query:"timestamp" validate:"required"`
@@ -498,7 +558,9 @@ type NetworkGlobals struct {
 	// AnchorEmptyBlocks controls whether an anchor is sent for a block if the block contains no transactions other than a directory anchor.
 	AnchorEmptyBlocks bool         `json:"anchorEmptyBlocks,omitempty" form:"anchorEmptyBlocks" query:"anchorEmptyBlocks" validate:"required"`
 	FeeSchedule       *FeeSchedule `json:"feeSchedule,omitempty" form:"feeSchedule" query:"feeSchedule" validate:"required"`
-	extraData         []byte
+	// EnableExternalAnchors enables recording the anchors of major blocks so they can be anchored externally. Every node must support external anchors before it is enabled.
+	EnableExternalAnchors bool `json:"enableExternalAnchors,omitempty" form:"enableExternalAnchors" query:"enableExternalAnchors"`
+	extraData             []byte
 }
 
 type Object struct {
@@ -647,6 +709,13 @@ type SendTokens struct {
 	extraData []byte
 }
 
+type SetKeyValue struct {
+	fieldsSet []bool
+	Key       []byte `json:"key,omitempty" form:"key" query:"key" validate:"required"`
+	Value     []byte `json:"value,omitempty" form:"value" query:"value" validate:"required"`
+	extraData []byte
+}
+
 type SetThresholdKeyPageOperation struct {
 	fieldsSet []bool
 	Threshold uint64 `json:"threshold,omitempty" form:"threshold" query:"threshold" validate:"required"`
@@ -660,7 +729,19 @@ type SignatureSet struct {
 	Signer          *url.URL    `json:"signer,omitempty" form:"signer" query:"signer" validate:"required"`
 	TransactionHash [32]byte    `json:"transactionHash,omitempty" form:"transactionHash" query:"transactionHash"`
 	Signatures      []Signature `json:"signatures,omitempty" form:"signatures" query:"signatures" validate:"required"`
-	extraData       []byte
+	// Fees are the fees the signer's partition charged for each of the signatures, when the set is forwarded.
+	Fees      []uint64 `json:"fees,omitempty" form:"fees" query:"fees"`
+	extraData []byte
+}
+
+type SimulateResponse struct {
+	Results []*TransactionStatus `json:"results,omitempty" form:"results" query:"results" validate:"required"`
+	// Fee is the number of credits the transactions and signatures would cost.
+	Fee Fee `json:"fee,omitempty" form:"fee" query:"fee" validate:"required"`
+	// Produced lists the synthetic transactions that would be produced.
+	Produced []*Transaction `json:"produced,omitempty" form:"produced" query:"produced" validate:"required"`
+	// Changes lists the accounts that would be modified.
+	Changes []*AccountChange `json:"changes,omitempty" form:"changes" query:"changes" validate:"required"`
 }
 
 type SyntheticBurnTokens struct {
@@ -822,6 +903,8 @@ type TransactionHeader struct {
 	Initiator [32]byte `json:"initiator,omitempty" form:"initiator" query:"initiator" validate:"required"`
 	Memo      string   `json:"memo,omitempty" form:"memo" query:"memo"`
 	Metadata  []byte   `json:"metadata,omitempty" form:"metadata" query:"metadata"`
+	// Sponsor is the signer that pays the fee for the transaction. A sponsored transaction is not executed until the sponsor has signed it.
+	Sponsor   *url.URL `json:"sponsor,omitempty" form:"sponsor" query:"sponsor"`
 	extraData []byte
 }
 
@@ -854,7 +937,9 @@ type TransactionStatus struct {
 	// GotDirectoryReceipt indicates if a receipt has been received from the DN.
 	GotDirectoryReceipt bool `json:"gotDirectoryReceipt,omitempty" form:"gotDirectoryReceipt" query:"gotDirectoryReceipt" validate:"required"`
 	// Proof is the proof of the transaction.
-	Proof     *managed.Receipt `json:"proof,omitempty" form:"proof" query:"proof" validate:"required"`
+	Proof *managed.Receipt `json:"proof,omitempty" form:"proof" query:"proof" validate:"required"`
+	// Refunds lists the credit refunds issued for the transaction.
+	Refunds   []*url.TxID `json:"refunds,omitempty" form:"refunds" query:"refunds" validate:"required"`
 	extraData []byte
 }
 
@@ -985,6 +1070,8 @@ func (*CreateKeyBook) Type() TransactionType { return TransactionTypeCreateKeyBo
 
 func (*CreateKeyPage) Type() TransactionType { return TransactionTypeCreateKeyPage }
 
+func (*CreateKeyValueAccount) Type() TransactionType { return TransactionTypeCreateKeyValueAccount }
+
 func (*CreateLiteTokenAccount) Type() TransactionType { return TransactionTypeCreateLiteTokenAccount }
 
 func (*CreateToken) Type() TransactionType { return TransactionTypeCreateToken }
@@ -995,6 +1082,8 @@ func (*DataAccount) Type() AccountType { return AccountTypeDataAccount }
 
 func (*DelegatedSignature) Type() SignatureType { return SignatureTypeDelegated }
 
+func (*DeleteKeyValue) Type() TransactionType { return TransactionTypeDeleteKeyValue }
+
 func (*DirectoryAnchor) Type() TransactionType { return TransactionTypeDirectoryAnchor }
 
 func (*DisableAccountAuthOperation) Type() AccountAuthOperationType {
@@ -1021,6 +1110,8 @@ func (*KeyBook) Type() AccountType { return AccountTypeKeyBook }
 
 func (*KeyPage) Type() AccountType { return AccountTypeKeyPage }
 
+func (*KeyValueAccount) Type() AccountType { return AccountTypeKeyValueAccount }
+
 func (*LegacyED25519Signature) Type() SignatureType { return SignatureTypeLegacyED25519 }
 
 func (*LiteDataAccount) Type() AccountType { return AccountTypeLiteDataAccount }
@@ -1049,6 +1140,8 @@ func (*RemoveKeyOperation) Type() KeyPageOperationType { return KeyPageOperation
 
 func (*SendTokens) Type() TransactionType { return TransactionTypeSendTokens }
 
+func (*SetKeyValue) Type() TransactionType { return TransactionTypeSetKeyValue }
+
 func (*SetThresholdKeyPageOperation) Type() KeyPageOperationType {
 	return KeyPageOperationTypeSetThreshold
 }
@@ -1364,6 +1457,7 @@ func (v *ChainMetadata) Copy() *ChainMetadata {
 
 	u.Name = v.Name
 	u.Type = v.Type
+	u.HashAlgorithm = v.HashAlgorithm
 
 	return u
 }
@@ -1393,6 +1487,7 @@ func (v *CreateDataAccount) Copy() *CreateDataAccount {
 			u.Authorities[i] = v
 		}
 	}
+	u.HashAlgorithm = v.HashAlgorithm
 
 	return u
 }
@@ -1455,6 +1550,24 @@ func (v *CreateKeyPage) Copy() *CreateKeyPage {
 
 func (v *CreateKeyPage) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *CreateKeyValueAccount) Copy() *CreateKeyValueAccount {
+	u := new(CreateKeyValueAccount)
+
+	if v.Url != nil {
+		u.Url = v.Url
+	}
+	u.Authorities = make([]*url.URL, len(v.Authorities))
+	for i, v := range v.Authorities {
+		if v != nil {
+			u.Authorities[i] = v
+		}
+	}
+
+	return u
+}
+
+func (v *CreateKeyValueAccount) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *CreateLiteTokenAccount) Copy() *CreateLiteTokenAccount {
 	u := new(CreateLiteTokenAccount)
 
@@ -1544,6 +1657,16 @@ func (v *DelegatedSignature) Copy() *DelegatedSignature {
 
 func (v *DelegatedSignature) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *DeleteKeyValue) Copy() *DeleteKeyValue {
+	u := new(DeleteKeyValue)
+
+	u.Key = encoding.BytesCopy(v.Key)
+
+	return u
+}
+
+func (v *DeleteKeyValue) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *DirectoryAnchor) Copy() *DirectoryAnchor {
 	u := new(DirectoryAnchor)
 
@@ -1656,6 +1779,21 @@ func (v *Envelope) Copy() *Envelope {
 
 func (v *Envelope) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *ExternalAnchor) Copy() *ExternalAnchor {
+	u := new(ExternalAnchor)
+
+	u.MajorBlockIndex = v.MajorBlockIndex
+	u.MinorBlockIndex = v.MinorBlockIndex
+	u.RootChainIndex = v.RootChainIndex
+	u.RootChainAnchor = v.RootChainAnchor
+	u.StateTreeAnchor = v.StateTreeAnchor
+	u.Reference = v.Reference
+
+	return u
+}
+
+func (v *ExternalAnchor) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *FactomDataEntry) Copy() *FactomDataEntry {
 	u := new(FactomDataEntry)
 
@@ -1811,6 +1949,19 @@ func (v *KeySpecParams) Copy() *KeySpecParams {
 
 func (v *KeySpecParams) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *KeyValueAccount) Copy() *KeyValueAccount {
+	u := new(KeyValueAccount)
+
+	if v.Url != nil {
+		u.Url = v.Url
+	}
+	u.AccountAuth = *v.AccountAuth.Copy()
+
+	return u
+}
+
+func (v *KeyValueAccount) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *LegacyED25519Signature) Copy() *LegacyED25519Signature {
 	u := new(LegacyED25519Signature)
 
@@ -1939,6 +2090,7 @@ func (v *NetworkGlobals) Copy() *NetworkGlobals {
 	if v.FeeSchedule != nil {
 		u.FeeSchedule = (v.FeeSchedule).Copy()
 	}
+	u.EnableExternalAnchors = v.EnableExternalAnchors
 
 	return u
 }
@@ -2176,6 +2328,17 @@ func (v *SendTokens) Copy() *SendTokens {
 
 func (v *SendTokens) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *SetKeyValue) Copy() *SetKeyValue {
+	u := new(SetKeyValue)
+
+	u.Key = encoding.BytesCopy(v.Key)
+	u.Value = encoding.BytesCopy(v.Value)
+
+	return u
+}
+
+func (v *SetKeyValue) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *SetThresholdKeyPageOperation) Copy() *SetThresholdKeyPageOperation {
 	u := new(SetThresholdKeyPageOperation)
 
@@ -2200,6 +2363,10 @@ func (v *SignatureSet) Copy() *SignatureSet {
 			u.Signatures[i] = (v).CopyAsInterface().(Signature)
 		}
 	}
+	u.Fees = make([]uint64, len(v.Fees))
+	for i, v := range v.Fees {
+		u.Fees[i] = v
+	}
 
 	return u
 }
@@ -2482,6 +2649,9 @@ func (v *TransactionHeader) Copy() *TransactionHeader {
 	u.Initiator = v.Initiator
 	u.Memo = v.Memo
 	u.Metadata = encoding.BytesCopy(v.Metadata)
+	if v.Sponsor != nil {
+		u.Sponsor = v.Sponsor
+	}
 
 	return u
 }
@@ -2541,6 +2711,12 @@ func (v *TransactionStatus) Copy() *TransactionStatus {
 	if v.Proof != nil {
 		u.Proof = (v.Proof).Copy()
 	}
+	u.Refunds = make([]*url.TxID, len(v.Refunds))
+	for i, v := range v.Refunds {
+		if v != nil {
+			u.Refunds[i] = v
+		}
+	}
 
 	return u
 }
@@ -3059,6 +3235,9 @@ func (v *ChainMetadata) Equal(u *ChainMetadata) bool {
 	if !(v.Type == u.Type) {
 		return false
 	}
+	if !(v.HashAlgorithm == u.HashAlgorithm) {
+		return false
+	}
 
 	return true
 }
@@ -3091,6 +3270,9 @@ func (v *CreateDataAccount) Equal(u *CreateDataAccount) bool {
 			return false
 		}
 	}
+	if !(v.HashAlgorithm == u.HashAlgorithm) {
+		return false
+	}
 
 	return true
 }
@@ -3164,6 +3346,27 @@ func (v *CreateKeyPage) Equal(u *CreateKeyPage) bool {
 	return true
 }
 
+func (v *CreateKeyValueAccount) Equal(u *CreateKeyValueAccount) bool {
+	switch {
+	case v.Url == u.Url:
+		// equal
+	case v.Url == nil || u.Url == nil:
+		return false
+	case !((v.Url).Equal(u.Url)):
+		return false
+	}
+	if len(v.Authorities) != len(u.Authorities) {
+		return false
+	}
+	for i := range v.Authorities {
+		if !((v.Authorities[i]).Equal(u.Authorities[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (v *CreateLiteTokenAccount) Equal(u *CreateLiteTokenAccount) bool {
 
 	return true
@@ -3284,6 +3487,14 @@ func (v *DelegatedSignature) Equal(u *DelegatedSignature) bool {
 	return true
 }
 
+func (v *DeleteKeyValue) Equal(u *DeleteKeyValue) bool {
+	if !(bytes.Equal(v.Key, u.Key)) {
+		return false
+	}
+
+	return true
+}
+
 func (v *DirectoryAnchor) Equal(u *DirectoryAnchor) bool {
 	if !v.PartitionAnchor.Equal(&u.PartitionAnchor) {
 		return false
@@ -3431,6 +3642,29 @@ func (v *Envelope) Equal(u *Envelope) bool {
 	return true
 }
 
+func (v *ExternalAnchor) Equal(u *ExternalAnchor) bool {
+	if !(v.MajorBlockIndex == u.MajorBlockIndex) {
+		return false
+	}
+	if !(v.MinorBlockIndex == u.MinorBlockIndex) {
+		return false
+	}
+	if !(v.RootChainIndex == u.RootChainIndex) {
+		return false
+	}
+	if !(v.RootChainAnchor == u.RootChainAnchor) {
+		return false
+	}
+	if !(v.StateTreeAnchor == u.StateTreeAnchor) {
+		return false
+	}
+	if !(v.Reference == u.Reference) {
+		return false
+	}
+
+	return true
+}
+
 func (v *FactomDataEntry) Equal(u *FactomDataEntry) bool {
 	if !(v.AccountId == u.AccountId) {
 		return false
@@ -3635,6 +3869,22 @@ func (v *KeySpecParams) Equal(u *KeySpecParams) bool {
 	return true
 }
 
+func (v *KeyValueAccount) Equal(u *KeyValueAccount) bool {
+	switch {
+	case v.Url == u.Url:
+		// equal
+	case v.Url == nil || u.Url == nil:
+		return false
+	case !((v.Url).Equal(u.Url)):
+		return false
+	}
+	if !v.AccountAuth.Equal(&u.AccountAuth) {
+		return false
+	}
+
+	return true
+}
+
 func (v *LegacyED25519Signature) Equal(u *LegacyED25519Signature) bool {
 	if !(v.Timestamp == u.Timestamp) {
 		return false
@@ -3803,6 +4053,9 @@ func (v *NetworkGlobals) Equal(u *NetworkGlobals) bool {
 	case !((v.FeeSchedule).Equal(u.FeeSchedule)):
 		return false
 	}
+	if !(v.EnableExternalAnchors == u.EnableExternalAnchors) {
+		return false
+	}
 
 	return true
 }
@@ -4100,6 +4353,17 @@ func (v *SendTokens) Equal(u *SendTokens) bool {
 	return true
 }
 
+func (v *SetKeyValue) Equal(u *SetKeyValue) bool {
+	if !(bytes.Equal(v.Key, u.Key)) {
+		return false
+	}
+	if !(bytes.Equal(v.Value, u.Value)) {
+		return false
+	}
+
+	return true
+}
+
 func (v *SetThresholdKeyPageOperation) Equal(u *SetThresholdKeyPageOperation) bool {
 	if !(v.Threshold == u.Threshold) {
 		return false
@@ -4131,6 +4395,14 @@ func (v *SignatureSet) Equal(u *SignatureSet) bool {
 			return false
 		}
 	}
+	if len(v.Fees) != len(u.Fees) {
+		return false
+	}
+	for i := range v.Fees {
+		if !(v.Fees[i] == u.Fees[i]) {
+			return false
+		}
+	}
 
 	return true
 }
@@ -4505,6 +4777,14 @@ func (v *TransactionHeader) Equal(u *TransactionHeader) bool {
 	if !(bytes.Equal(v.Metadata, u.Metadata)) {
 		return false
 	}
+	switch {
+	case v.Sponsor == u.Sponsor:
+		// equal
+	case v.Sponsor == nil || u.Sponsor == nil:
+		return false
+	case !((v.Sponsor).Equal(u.Sponsor)):
+		return false
+	}
 
 	return true
 }
@@ -4602,6 +4882,14 @@ func (v *TransactionStatus) Equal(u *TransactionStatus) bool {
 	case !((v.Proof).Equal(u.Proof)):
 		return false
 	}
+	if len(v.Refunds) != len(u.Refunds) {
+		return false
+	}
+	for i := range v.Refunds {
+		if !((v.Refunds[i]).Equal(u.Refunds[i])) {
+			return false
+		}
+	}
 
 	return true
 }
@@ -5827,6 +6115,7 @@ func (v *BurnTokens) IsValid() error {
 var fieldNames_ChainMetadata = []string{
 	1: "Name",
 	2: "Type",
+	3: "HashAlgorithm",
 }
 
 func (v *ChainMetadata) MarshalBinary() ([]byte, error) {
@@ -5839,6 +6128,9 @@ func (v *ChainMetadata) MarshalBinary() ([]byte, error) {
 	if !(v.Type == 0) {
 		writer.WriteEnum(2, v.Type)
 	}
+	if !(v.HashAlgorithm == 0) {
+		writer.WriteEnum(3, v.HashAlgorithm)
+	}
 
 	_, _, err := writer.Reset(fieldNames_ChainMetadata)
 	if err != nil {
@@ -5924,6 +6216,7 @@ var fieldNames_CreateDataAccount = []string{
 	1: "Type",
 	2: "Url",
 	3: "Authorities",
+	4: "HashAlgorithm",
 }
 
 func (v *CreateDataAccount) MarshalBinary() ([]byte, error) {
@@ -5939,6 +6232,9 @@ func (v *CreateDataAccount) MarshalBinary() ([]byte, error) {
 			writer.WriteUrl(3, v)
 		}
 	}
+	if !(v.HashAlgorithm == 0) {
+		writer.WriteEnum(4, v.HashAlgorithm)
+	}
 
 	_, _, err := writer.Reset(fieldNames_CreateDataAccount)
 	if err != nil {
@@ -6133,6 +6429,56 @@ func (v *CreateKeyPage) IsValid() error {
 	}
 }
 
+var fieldNames_CreateKeyValueAccount = []string{
+	1: "Type",
+	2: "Url",
+	3: "Authorities",
+}
+
+func (v *CreateKeyValueAccount) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	writer.WriteEnum(1, v.Type())
+	if !(v.Url == nil) {
+		writer.WriteUrl(2, v.Url)
+	}
+	if !(len(v.Authorities) == 0) {
+		for _, v := range v.Authorities {
+			writer.WriteUrl(3, v)
+		}
+	}
+
+	_, _, err := writer.Reset(fieldNames_CreateKeyValueAccount)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *CreateKeyValueAccount) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Type is missing")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Url is missing")
+	} else if v.Url == nil {
+		errs = append(errs, "field Url is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_CreateLiteTokenAccount = []string{
 	1: "Type",
 }
@@ -6413,29 +6759,73 @@ func (v *DelegatedSignature) IsValid() error {
 	}
 }
 
-var fieldNames_DirectoryAnchor = []string{
+var fieldNames_DeleteKeyValue = []string{
 	1: "Type",
-	2: "PartitionAnchor",
-	3: "Updates",
-	4: "Receipts",
-	5: "MakeMajorBlock",
-	6: "MakeMajorBlockTime",
+	2: "Key",
 }
 
-func (v *DirectoryAnchor) MarshalBinary() ([]byte, error) {
+func (v *DeleteKeyValue) MarshalBinary() ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	writer := encoding.NewWriter(buffer)
 
 	writer.WriteEnum(1, v.Type())
-	writer.WriteValue(2, v.PartitionAnchor.MarshalBinary)
-	if !(len(v.Updates) == 0) {
-		for _, v := range v.Updates {
-			writer.WriteValue(3, v.MarshalBinary)
-		}
+	if !(len(v.Key) == 0) {
+		writer.WriteBytes(2, v.Key)
 	}
-	if !(len(v.Receipts) == 0) {
-		for _, v := range v.Receipts {
-			writer.WriteValue(4, v.MarshalBinary)
+
+	_, _, err := writer.Reset(fieldNames_DeleteKeyValue)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *DeleteKeyValue) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Type is missing")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Key is missing")
+	} else if len(v.Key) == 0 {
+		errs = append(errs, "field Key is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_DirectoryAnchor = []string{
+	1: "Type",
+	2: "PartitionAnchor",
+	3: "Updates",
+	4: "Receipts",
+	5: "MakeMajorBlock",
+	6: "MakeMajorBlockTime",
+}
+
+func (v *DirectoryAnchor) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	writer.WriteEnum(1, v.Type())
+	writer.WriteValue(2, v.PartitionAnchor.MarshalBinary)
+	if !(len(v.Updates) == 0) {
+		for _, v := range v.Updates {
+			writer.WriteValue(3, v.MarshalBinary)
+		}
+	}
+	if !(len(v.Receipts) == 0) {
+		for _, v := range v.Receipts {
+			writer.WriteValue(4, v.MarshalBinary)
 		}
 	}
 	if !(v.MakeMajorBlock == 0) {
@@ -6833,6 +7223,90 @@ func (v *Envelope) IsValid() error {
 	}
 }
 
+var fieldNames_ExternalAnchor = []string{
+	1: "MajorBlockIndex",
+	2: "MinorBlockIndex",
+	3: "RootChainIndex",
+	4: "RootChainAnchor",
+	5: "StateTreeAnchor",
+	6: "Reference",
+}
+
+func (v *ExternalAnchor) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.MajorBlockIndex == 0) {
+		writer.WriteUint(1, v.MajorBlockIndex)
+	}
+	if !(v.MinorBlockIndex == 0) {
+		writer.WriteUint(2, v.MinorBlockIndex)
+	}
+	if !(v.RootChainIndex == 0) {
+		writer.WriteUint(3, v.RootChainIndex)
+	}
+	if !(v.RootChainAnchor == ([32]byte{})) {
+		writer.WriteHash(4, &v.RootChainAnchor)
+	}
+	if !(v.StateTreeAnchor == ([32]byte{})) {
+		writer.WriteHash(5, &v.StateTreeAnchor)
+	}
+	if !(len(v.Reference) == 0) {
+		writer.WriteString(6, v.Reference)
+	}
+
+	_, _, err := writer.Reset(fieldNames_ExternalAnchor)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *ExternalAnchor) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field MajorBlockIndex is missing")
+	} else if v.MajorBlockIndex == 0 {
+		errs = append(errs, "field MajorBlockIndex is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field MinorBlockIndex is missing")
+	} else if v.MinorBlockIndex == 0 {
+		errs = append(errs, "field MinorBlockIndex is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field RootChainIndex is missing")
+	} else if v.RootChainIndex == 0 {
+		errs = append(errs, "field RootChainIndex is not set")
+	}
+	if len(v.fieldsSet) > 4 && !v.fieldsSet[4] {
+		errs = append(errs, "field RootChainAnchor is missing")
+	} else if v.RootChainAnchor == ([32]byte{}) {
+		errs = append(errs, "field RootChainAnchor is not set")
+	}
+	if len(v.fieldsSet) > 5 && !v.fieldsSet[5] {
+		errs = append(errs, "field StateTreeAnchor is missing")
+	} else if v.StateTreeAnchor == ([32]byte{}) {
+		errs = append(errs, "field StateTreeAnchor is not set")
+	}
+	if len(v.fieldsSet) > 6 && !v.fieldsSet[6] {
+		errs = append(errs, "field Reference is missing")
+	} else if len(v.Reference) == 0 {
+		errs = append(errs, "field Reference is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_FactomDataEntryWrapper = []string{
 	1: "Type",
 	2: "FactomDataEntry",
@@ -7386,6 +7860,55 @@ func (v *KeySpecParams) IsValid() error {
 	}
 }
 
+var fieldNames_KeyValueAccount = []string{
+	1: "Type",
+	2: "Url",
+	3: "AccountAuth",
+}
+
+func (v *KeyValueAccount) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	writer.WriteEnum(1, v.Type())
+	if !(v.Url == nil) {
+		writer.WriteUrl(2, v.Url)
+	}
+	writer.WriteValue(3, v.AccountAuth.MarshalBinary)
+
+	_, _, err := writer.Reset(fieldNames_KeyValueAccount)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *KeyValueAccount) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Type is missing")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Url is missing")
+	} else if v.Url == nil {
+		errs = append(errs, "field Url is not set")
+	}
+	if err := v.AccountAuth.IsValid(); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_LegacyED25519Signature = []string{
 	1: "Type",
 	2: "Timestamp",
@@ -7867,6 +8390,7 @@ var fieldNames_NetworkGlobals = []string{
 	3: "MajorBlockSchedule",
 	4: "AnchorEmptyBlocks",
 	5: "FeeSchedule",
+	6: "EnableExternalAnchors",
 }
 
 func (v *NetworkGlobals) MarshalBinary() ([]byte, error) {
@@ -7888,6 +8412,9 @@ func (v *NetworkGlobals) MarshalBinary() ([]byte, error) {
 	if !(v.FeeSchedule == nil) {
 		writer.WriteValue(5, v.FeeSchedule.MarshalBinary)
 	}
+	if !(!v.EnableExternalAnchors) {
+		writer.WriteBool(6, v.EnableExternalAnchors)
+	}
 
 	_, _, err := writer.Reset(fieldNames_NetworkGlobals)
 	if err != nil {
@@ -8840,6 +9367,59 @@ func (v *SendTokens) IsValid() error {
 	}
 }
 
+var fieldNames_SetKeyValue = []string{
+	1: "Type",
+	2: "Key",
+	3: "Value",
+}
+
+func (v *SetKeyValue) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	writer.WriteEnum(1, v.Type())
+	if !(len(v.Key) == 0) {
+		writer.WriteBytes(2, v.Key)
+	}
+	if !(len(v.Value) == 0) {
+		writer.WriteBytes(3, v.Value)
+	}
+
+	_, _, err := writer.Reset(fieldNames_SetKeyValue)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *SetKeyValue) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Type is missing")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Key is missing")
+	} else if len(v.Key) == 0 {
+		errs = append(errs, "field Key is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field Value is missing")
+	} else if len(v.Value) == 0 {
+		errs = append(errs, "field Value is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_SetThresholdKeyPageOperation = []string{
 	1: "Type",
 	2: "Threshold",
@@ -8890,6 +9470,7 @@ var fieldNames_SignatureSet = []string{
 	3: "Signer",
 	4: "TransactionHash",
 	5: "Signatures",
+	6: "Fees",
 }
 
 func (v *SignatureSet) MarshalBinary() ([]byte, error) {
@@ -8911,6 +9492,11 @@ func (v *SignatureSet) MarshalBinary() ([]byte, error) {
 			writer.WriteValue(5, v.MarshalBinary)
 		}
 	}
+	if !(len(v.Fees) == 0) {
+		for _, v := range v.Fees {
+			writer.WriteUint(6, v)
+		}
+	}
 
 	_, _, err := writer.Reset(fieldNames_SignatureSet)
 	if err != nil {
@@ -9975,6 +10561,7 @@ var fieldNames_TransactionHeader = []string{
 	2: "Initiator",
 	3: "Memo",
 	4: "Metadata",
+	5: "Sponsor",
 }
 
 func (v *TransactionHeader) MarshalBinary() ([]byte, error) {
@@ -9993,6 +10580,9 @@ func (v *TransactionHeader) MarshalBinary() ([]byte, error) {
 	if !(len(v.Metadata) == 0) {
 		writer.WriteBytes(4, v.Metadata)
 	}
+	if !(v.Sponsor == nil) {
+		writer.WriteUrl(5, v.Sponsor)
+	}
 
 	_, _, err := writer.Reset(fieldNames_TransactionHeader)
 	if err != nil {
@@ -10081,6 +10671,7 @@ var fieldNames_TransactionStatus = []string{
 	11: "SequenceNumber",
 	12: "GotDirectoryReceipt",
 	13: "Proof",
+	14: "Refunds",
 }
 
 func (v *TransactionStatus) MarshalBinary() ([]byte, error) {
@@ -10130,6 +10721,11 @@ func (v *TransactionStatus) MarshalBinary() ([]byte, error) {
 	if !(v.Proof == nil) {
 		writer.WriteValue(13, v.Proof.MarshalBinary)
 	}
+	if !(len(v.Refunds) == 0) {
+		for _, v := range v.Refunds {
+			writer.WriteTxid(14, v)
+		}
+	}
 
 	_, _, err := writer.Reset(fieldNames_TransactionStatus)
 	if err != nil {
@@ -10207,6 +10803,11 @@ func (v *TransactionStatus) IsValid() error {
 	} else if v.Proof == nil {
 		errs = append(errs, "field Proof is not set")
 	}
+	if len(v.fieldsSet) > 14 && !v.fieldsSet[14] {
+		errs = append(errs, "field Refunds is missing")
+	} else if len(v.Refunds) == 0 {
+		errs = append(errs, "field Refunds is not set")
+	}
 
 	switch len(errs) {
 	case 0:
@@ -11502,6 +12103,9 @@ func (v *ChainMetadata) UnmarshalBinaryFrom(rd io.Reader) error {
 	if x := new(ChainType); reader.ReadEnum(2, x) {
 		v.Type = *x
 	}
+	if x := new(HashAlgorithm); reader.ReadEnum(3, x) {
+		v.HashAlgorithm = *x
+	}
 
 	seen, err := reader.Reset(fieldNames_ChainMetadata)
 	if err != nil {
@@ -11565,6 +12169,9 @@ func (v *CreateDataAccount) UnmarshalBinaryFrom(rd io.Reader) error {
 			break
 		}
 	}
+	if x := new(HashAlgorithm); reader.ReadEnum(4, x) {
+		v.HashAlgorithm = *x
+	}
 
 	seen, err := reader.Reset(fieldNames_CreateDataAccount)
 	if err != nil {
@@ -11695,6 +12302,43 @@ func (v *CreateKeyPage) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *CreateKeyValueAccount) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *CreateKeyValueAccount) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	var vType TransactionType
+	if x := new(TransactionType); reader.ReadEnum(1, x) {
+		vType = *x
+	}
+	if !(v.Type() == vType) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), vType)
+	}
+	if x, ok := reader.ReadUrl(2); ok {
+		v.Url = x
+	}
+	for {
+		if x, ok := reader.ReadUrl(3); ok {
+			v.Authorities = append(v.Authorities, x)
+		} else {
+			break
+		}
+	}
+
+	seen, err := reader.Reset(fieldNames_CreateKeyValueAccount)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *CreateLiteTokenAccount) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -11889,11 +12533,11 @@ func (v *DelegatedSignature) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
-func (v *DirectoryAnchor) UnmarshalBinary(data []byte) error {
+func (v *DeleteKeyValue) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
 
-func (v *DirectoryAnchor) UnmarshalBinaryFrom(rd io.Reader) error {
+func (v *DeleteKeyValue) UnmarshalBinaryFrom(rd io.Reader) error {
 	reader := encoding.NewReader(rd)
 
 	var vType TransactionType
@@ -11903,20 +12547,50 @@ func (v *DirectoryAnchor) UnmarshalBinaryFrom(rd io.Reader) error {
 	if !(v.Type() == vType) {
 		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), vType)
 	}
-	reader.ReadValue(2, v.PartitionAnchor.UnmarshalBinary)
-	for {
-		if x := new(NetworkAccountUpdate); reader.ReadValue(3, x.UnmarshalBinary) {
-			v.Updates = append(v.Updates, *x)
-		} else {
-			break
-		}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.Key = x
 	}
-	for {
-		if x := new(PartitionAnchorReceipt); reader.ReadValue(4, x.UnmarshalBinary) {
-			v.Receipts = append(v.Receipts, x)
-		} else {
-			break
-		}
+
+	seen, err := reader.Reset(fieldNames_DeleteKeyValue)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *DirectoryAnchor) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *DirectoryAnchor) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	var vType TransactionType
+	if x := new(TransactionType); reader.ReadEnum(1, x) {
+		vType = *x
+	}
+	if !(v.Type() == vType) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), vType)
+	}
+	reader.ReadValue(2, v.PartitionAnchor.UnmarshalBinary)
+	for {
+		if x := new(NetworkAccountUpdate); reader.ReadValue(3, x.UnmarshalBinary) {
+			v.Updates = append(v.Updates, *x)
+		} else {
+			break
+		}
+	}
+	for {
+		if x := new(PartitionAnchorReceipt); reader.ReadValue(4, x.UnmarshalBinary) {
+			v.Receipts = append(v.Receipts, x)
+		} else {
+			break
+		}
 	}
 	if x, ok := reader.ReadUint(5); ok {
 		v.MakeMajorBlock = x
@@ -12162,6 +12836,44 @@ func (v *Envelope) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *ExternalAnchor) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *ExternalAnchor) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadUint(1); ok {
+		v.MajorBlockIndex = x
+	}
+	if x, ok := reader.ReadUint(2); ok {
+		v.MinorBlockIndex = x
+	}
+	if x, ok := reader.ReadUint(3); ok {
+		v.RootChainIndex = x
+	}
+	if x, ok := reader.ReadHash(4); ok {
+		v.RootChainAnchor = *x
+	}
+	if x, ok := reader.ReadHash(5); ok {
+		v.StateTreeAnchor = *x
+	}
+	if x, ok := reader.ReadString(6); ok {
+		v.Reference = x
+	}
+
+	seen, err := reader.Reset(fieldNames_ExternalAnchor)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *FactomDataEntryWrapper) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -12475,6 +13187,37 @@ func (v *KeySpecParams) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *KeyValueAccount) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *KeyValueAccount) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	var vType AccountType
+	if x := new(AccountType); reader.ReadEnum(1, x) {
+		vType = *x
+	}
+	if !(v.Type() == vType) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), vType)
+	}
+	if x, ok := reader.ReadUrl(2); ok {
+		v.Url = x
+	}
+	reader.ReadValue(3, v.AccountAuth.UnmarshalBinary)
+
+	seen, err := reader.Reset(fieldNames_KeyValueAccount)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *LegacyED25519Signature) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -12776,6 +13519,9 @@ func (v *NetworkGlobals) UnmarshalBinaryFrom(rd io.Reader) error {
 	if x := new(FeeSchedule); reader.ReadValue(5, x.UnmarshalBinary) {
 		v.FeeSchedule = x
 	}
+	if x, ok := reader.ReadBool(6); ok {
+		v.EnableExternalAnchors = x
+	}
 
 	seen, err := reader.Reset(fieldNames_NetworkGlobals)
 	if err != nil {
@@ -13327,6 +14073,39 @@ func (v *SendTokens) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *SetKeyValue) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *SetKeyValue) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	var vType TransactionType
+	if x := new(TransactionType); reader.ReadEnum(1, x) {
+		vType = *x
+	}
+	if !(v.Type() == vType) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), vType)
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.Key = x
+	}
+	if x, ok := reader.ReadBytes(3); ok {
+		v.Value = x
+	}
+
+	seen, err := reader.Reset(fieldNames_SetKeyValue)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *SetThresholdKeyPageOperation) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -13392,6 +14171,13 @@ func (v *SignatureSet) UnmarshalBinaryFrom(rd io.Reader) error {
 			break
 		}
 	}
+	for {
+		if x, ok := reader.ReadUint(6); ok {
+			v.Fees = append(v.Fees, x)
+		} else {
+			break
+		}
+	}
 
 	seen, err := reader.Reset(fieldNames_SignatureSet)
 	if err != nil {
@@ -14037,6 +14823,9 @@ func (v *TransactionHeader) UnmarshalBinaryFrom(rd io.Reader) error {
 	if x, ok := reader.ReadBytes(4); ok {
 		v.Metadata = x
 	}
+	if x, ok := reader.ReadUrl(5); ok {
+		v.Sponsor = x
+	}
 
 	seen, err := reader.Reset(fieldNames_TransactionHeader)
 	if err != nil {
@@ -14140,6 +14929,13 @@ func (v *TransactionStatus) UnmarshalBinaryFrom(rd io.Reader) error {
 	if x := new(managed.Receipt); reader.ReadValue(13, x.UnmarshalBinary) {
 		v.Proof = x
 	}
+	for {
+		if x, ok := reader.ReadTxid(14); ok {
+			v.Refunds = append(v.Refunds, x)
+		} else {
+			break
+		}
+	}
 
 	seen, err := reader.Reset(fieldNames_TransactionStatus)
 	if err != nil {
@@ -14620,6 +15416,24 @@ func (v *AccountAuth) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *AccountChange) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Url              *url.URL                            `json:"url,omitempty"`
+		Before           encoding.JsonUnmarshalWith[Account] `json:"before,omitempty"`
+		After            encoding.JsonUnmarshalWith[Account] `json:"after,omitempty"`
+		DirectoryAdded   encoding.JsonList[*url.URL]         `json:"directoryAdded,omitempty"`
+		DirectoryRemoved encoding.JsonList[*url.URL]         `json:"directoryRemoved,omitempty"`
+		Chains           encoding.JsonList[ChainChange]      `json:"chains,omitempty"`
+	}{}
+	u.Url = v.Url
+	u.Before = encoding.JsonUnmarshalWith[Account]{Value: v.Before, Func: UnmarshalAccountJSON}
+	u.After = encoding.JsonUnmarshalWith[Account]{Value: v.After, Func: UnmarshalAccountJSON}
+	u.DirectoryAdded = v.DirectoryAdded
+	u.DirectoryRemoved = v.DirectoryRemoved
+	u.Chains = v.Chains
+	return json.Marshal(&u)
+}
+
 func (v *AccumulateDataEntry) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Type DataEntryType              `json:"type"`
@@ -14711,16 +15525,18 @@ func (v *AnchorLedger) MarshalJSON() ([]byte, error) {
 
 func (v *AnchorMetadata) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Name        string    `json:"name,omitempty"`
-		Type        ChainType `json:"type,omitempty"`
-		Account     *url.URL  `json:"account,omitempty"`
-		Index       uint64    `json:"index,omitempty"`
-		SourceIndex uint64    `json:"sourceIndex,omitempty"`
-		SourceBlock uint64    `json:"sourceBlock,omitempty"`
-		Entry       *string   `json:"entry,omitempty"`
+		Name          string        `json:"name,omitempty"`
+		Type          ChainType     `json:"type,omitempty"`
+		HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty"`
+		Account       *url.URL      `json:"account,omitempty"`
+		Index         uint64        `json:"index,omitempty"`
+		SourceIndex   uint64        `json:"sourceIndex,omitempty"`
+		SourceBlock   uint64        `json:"sourceBlock,omitempty"`
+		Entry         *string       `json:"entry,omitempty"`
 	}{}
 	u.Name = v.ChainMetadata.Name
 	u.Type = v.ChainMetadata.Type
+	u.HashAlgorithm = v.ChainMetadata.HashAlgorithm
 	u.Account = v.Account
 	u.Index = v.Index
 	u.SourceIndex = v.SourceIndex
@@ -14835,13 +15651,15 @@ func (v *ChainParams) MarshalJSON() ([]byte, error) {
 
 func (v *CreateDataAccount) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Type        TransactionType             `json:"type"`
-		Url         *url.URL                    `json:"url,omitempty"`
-		Authorities encoding.JsonList[*url.URL] `json:"authorities,omitempty"`
+		Type          TransactionType             `json:"type"`
+		Url           *url.URL                    `json:"url,omitempty"`
+		Authorities   encoding.JsonList[*url.URL] `json:"authorities,omitempty"`
+		HashAlgorithm HashAlgorithm               `json:"hashAlgorithm,omitempty"`
 	}{}
 	u.Type = v.Type()
 	u.Url = v.Url
 	u.Authorities = v.Authorities
+	u.HashAlgorithm = v.HashAlgorithm
 	return json.Marshal(&u)
 }
 
@@ -14885,6 +15703,18 @@ func (v *CreateKeyPage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *CreateKeyValueAccount) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Type        TransactionType             `json:"type"`
+		Url         *url.URL                    `json:"url,omitempty"`
+		Authorities encoding.JsonList[*url.URL] `json:"authorities,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Url = v.Url
+	u.Authorities = v.Authorities
+	return json.Marshal(&u)
+}
+
 func (v *CreateLiteTokenAccount) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Type TransactionType `json:"type"`
@@ -14955,6 +15785,16 @@ func (v *DelegatedSignature) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *DeleteKeyValue) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Type TransactionType `json:"type"`
+		Key  *string         `json:"key,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Key = encoding.BytesToJSON(v.Key)
+	return json.Marshal(&u)
+}
+
 func (v *DirectoryAnchor) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Type               TransactionType                               `json:"type"`
@@ -15069,6 +15909,24 @@ func (v *Envelope) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *ExternalAnchor) MarshalJSON() ([]byte, error) {
+	u := struct {
+		MajorBlockIndex uint64 `json:"majorBlockIndex,omitempty"`
+		MinorBlockIndex uint64 `json:"minorBlockIndex,omitempty"`
+		RootChainIndex  uint64 `json:"rootChainIndex,omitempty"`
+		RootChainAnchor string `json:"rootChainAnchor,omitempty"`
+		StateTreeAnchor string `json:"stateTreeAnchor,omitempty"`
+		Reference       string `json:"reference,omitempty"`
+	}{}
+	u.MajorBlockIndex = v.MajorBlockIndex
+	u.MinorBlockIndex = v.MinorBlockIndex
+	u.RootChainIndex = v.RootChainIndex
+	u.RootChainAnchor = encoding.ChainToJSON(v.RootChainAnchor)
+	u.StateTreeAnchor = encoding.ChainToJSON(v.StateTreeAnchor)
+	u.Reference = v.Reference
+	return json.Marshal(&u)
+}
+
 func (v *FactomDataEntry) MarshalJSON() ([]byte, error) {
 	u := struct {
 		AccountId string                     `json:"accountId,omitempty"`
@@ -15205,6 +16063,18 @@ func (v *KeySpecParams) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *KeyValueAccount) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Type        AccountType                       `json:"type"`
+		Url         *url.URL                          `json:"url,omitempty"`
+		Authorities encoding.JsonList[AuthorityEntry] `json:"authorities,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Url = v.Url
+	u.Authorities = v.AccountAuth.Authorities
+	return json.Marshal(&u)
+}
+
 func (v *LegacyED25519Signature) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Type            SignatureType `json:"type"`
@@ -15469,6 +16339,18 @@ func (v *SendTokens) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *SetKeyValue) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Type  TransactionType `json:"type"`
+		Key   *string         `json:"key,omitempty"`
+		Value *string         `json:"value,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Key = encoding.BytesToJSON(v.Key)
+	u.Value = encoding.BytesToJSON(v.Value)
+	return json.Marshal(&u)
+}
+
 func (v *SetThresholdKeyPageOperation) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Type      KeyPageOperationType `json:"type"`
@@ -15486,12 +16368,28 @@ func (v *SignatureSet) MarshalJSON() ([]byte, error) {
 		Signer          *url.URL                                  `json:"signer,omitempty"`
 		TransactionHash string                                    `json:"transactionHash,omitempty"`
 		Signatures      encoding.JsonUnmarshalListWith[Signature] `json:"signatures,omitempty"`
+		Fees            encoding.JsonList[uint64]                 `json:"fees,omitempty"`
 	}{}
 	u.Type = v.Type()
 	u.Vote = v.Vote
 	u.Signer = v.Signer
 	u.TransactionHash = encoding.ChainToJSON(v.TransactionHash)
 	u.Signatures = encoding.JsonUnmarshalListWith[Signature]{Value: v.Signatures, Func: UnmarshalSignatureJSON}
+	u.Fees = v.Fees
+	return json.Marshal(&u)
+}
+
+func (v *SimulateResponse) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Results  encoding.JsonList[*TransactionStatus] `json:"results,omitempty"`
+		Fee      Fee                                   `json:"fee,omitempty"`
+		Produced encoding.JsonList[*Transaction]       `json:"produced,omitempty"`
+		Changes  encoding.JsonList[*AccountChange]     `json:"changes,omitempty"`
+	}{}
+	u.Results = v.Results
+	u.Fee = v.Fee
+	u.Produced = v.Produced
+	u.Changes = v.Changes
 	return json.Marshal(&u)
 }
 
@@ -15743,11 +16641,13 @@ func (v *TransactionHeader) MarshalJSON() ([]byte, error) {
 		Initiator string   `json:"initiator,omitempty"`
 		Memo      string   `json:"memo,omitempty"`
 		Metadata  *string  `json:"metadata,omitempty"`
+		Sponsor   *url.URL `json:"sponsor,omitempty"`
 	}{}
 	u.Principal = v.Principal
 	u.Initiator = encoding.ChainToJSON(v.Initiator)
 	u.Memo = v.Memo
 	u.Metadata = encoding.BytesToJSON(v.Metadata)
+	u.Sponsor = v.Sponsor
 	return json.Marshal(&u)
 }
 
@@ -15779,6 +16679,7 @@ func (v *TransactionStatus) MarshalJSON() ([]byte, error) {
 		SequenceNumber      uint64                                        `json:"sequenceNumber,omitempty"`
 		GotDirectoryReceipt bool                                          `json:"gotDirectoryReceipt,omitempty"`
 		Proof               *managed.Receipt                              `json:"proof,omitempty"`
+		Refunds             encoding.JsonList[*url.TxID]                  `json:"refunds,omitempty"`
 	}{}
 	u.TxID = v.TxID
 	u.Code = v.Code
@@ -15801,6 +16702,7 @@ func (v *TransactionStatus) MarshalJSON() ([]byte, error) {
 	u.SequenceNumber = v.SequenceNumber
 	u.GotDirectoryReceipt = v.GotDirectoryReceipt
 	u.Proof = v.Proof
+	u.Refunds = v.Refunds
 	return json.Marshal(&u)
 }
 
@@ -15974,6 +16876,35 @@ func (v *AccountAuth) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *AccountChange) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Url              *url.URL                            `json:"url,omitempty"`
+		Before           encoding.JsonUnmarshalWith[Account] `json:"before,omitempty"`
+		After            encoding.JsonUnmarshalWith[Account] `json:"after,omitempty"`
+		DirectoryAdded   encoding.JsonList[*url.URL]         `json:"directoryAdded,omitempty"`
+		DirectoryRemoved encoding.JsonList[*url.URL]         `json:"directoryRemoved,omitempty"`
+		Chains           encoding.JsonList[ChainChange]      `json:"chains,omitempty"`
+	}{}
+	u.Url = v.Url
+	u.Before = encoding.JsonUnmarshalWith[Account]{Value: v.Before, Func: UnmarshalAccountJSON}
+	u.After = encoding.JsonUnmarshalWith[Account]{Value: v.After, Func: UnmarshalAccountJSON}
+	u.DirectoryAdded = v.DirectoryAdded
+	u.DirectoryRemoved = v.DirectoryRemoved
+	u.Chains = v.Chains
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Url = u.Url
+	v.Before = u.Before.Value
+
+	v.After = u.After.Value
+
+	v.DirectoryAdded = u.DirectoryAdded
+	v.DirectoryRemoved = u.DirectoryRemoved
+	v.Chains = u.Chains
+	return nil
+}
+
 func (v *AccumulateDataEntry) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Type DataEntryType              `json:"type"`
@@ -16137,16 +17068,18 @@ func (v *AnchorLedger) UnmarshalJSON(data []byte) error {
 
 func (v *AnchorMetadata) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Name        string    `json:"name,omitempty"`
-		Type        ChainType `json:"type,omitempty"`
-		Account     *url.URL  `json:"account,omitempty"`
-		Index       uint64    `json:"index,omitempty"`
-		SourceIndex uint64    `json:"sourceIndex,omitempty"`
-		SourceBlock uint64    `json:"sourceBlock,omitempty"`
-		Entry       *string   `json:"entry,omitempty"`
+		Name          string        `json:"name,omitempty"`
+		Type          ChainType     `json:"type,omitempty"`
+		HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty"`
+		Account       *url.URL      `json:"account,omitempty"`
+		Index         uint64        `json:"index,omitempty"`
+		SourceIndex   uint64        `json:"sourceIndex,omitempty"`
+		SourceBlock   uint64        `json:"sourceBlock,omitempty"`
+		Entry         *string       `json:"entry,omitempty"`
 	}{}
 	u.Name = v.ChainMetadata.Name
 	u.Type = v.ChainMetadata.Type
+	u.HashAlgorithm = v.ChainMetadata.HashAlgorithm
 	u.Account = v.Account
 	u.Index = v.Index
 	u.SourceIndex = v.SourceIndex
@@ -16157,6 +17090,7 @@ func (v *AnchorMetadata) UnmarshalJSON(data []byte) error {
 	}
 	v.ChainMetadata.Name = u.Name
 	v.ChainMetadata.Type = u.Type
+	v.ChainMetadata.HashAlgorithm = u.HashAlgorithm
 	v.Account = u.Account
 	v.Index = u.Index
 	v.SourceIndex = u.SourceIndex
@@ -16381,13 +17315,15 @@ func (v *ChainParams) UnmarshalJSON(data []byte) error {
 
 func (v *CreateDataAccount) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Type        TransactionType             `json:"type"`
-		Url         *url.URL                    `json:"url,omitempty"`
-		Authorities encoding.JsonList[*url.URL] `json:"authorities,omitempty"`
+		Type          TransactionType             `json:"type"`
+		Url           *url.URL                    `json:"url,omitempty"`
+		Authorities   encoding.JsonList[*url.URL] `json:"authorities,omitempty"`
+		HashAlgorithm HashAlgorithm               `json:"hashAlgorithm,omitempty"`
 	}{}
 	u.Type = v.Type()
 	u.Url = v.Url
 	u.Authorities = v.Authorities
+	u.HashAlgorithm = v.HashAlgorithm
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -16396,6 +17332,7 @@ func (v *CreateDataAccount) UnmarshalJSON(data []byte) error {
 	}
 	v.Url = u.Url
 	v.Authorities = u.Authorities
+	v.HashAlgorithm = u.HashAlgorithm
 	return nil
 }
 
@@ -16473,6 +17410,26 @@ func (v *CreateKeyPage) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *CreateKeyValueAccount) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Type        TransactionType             `json:"type"`
+		Url         *url.URL                    `json:"url,omitempty"`
+		Authorities encoding.JsonList[*url.URL] `json:"authorities,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Url = v.Url
+	u.Authorities = v.Authorities
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if !(v.Type() == u.Type) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), u.Type)
+	}
+	v.Url = u.Url
+	v.Authorities = u.Authorities
+	return nil
+}
+
 func (v *CreateLiteTokenAccount) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Type TransactionType `json:"type"`
@@ -16594,6 +17551,27 @@ func (v *DelegatedSignature) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *DeleteKeyValue) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Type TransactionType `json:"type"`
+		Key  *string         `json:"key,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Key = encoding.BytesToJSON(v.Key)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if !(v.Type() == u.Type) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), u.Type)
+	}
+	if x, err := encoding.BytesFromJSON(u.Key); err != nil {
+		return fmt.Errorf("error decoding Key: %w", err)
+	} else {
+		v.Key = x
+	}
+	return nil
+}
+
 func (v *DirectoryAnchor) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Type               TransactionType                               `json:"type"`
@@ -16816,6 +17794,41 @@ func (v *Envelope) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *ExternalAnchor) UnmarshalJSON(data []byte) error {
+	u := struct {
+		MajorBlockIndex uint64 `json:"majorBlockIndex,omitempty"`
+		MinorBlockIndex uint64 `json:"minorBlockIndex,omitempty"`
+		RootChainIndex  uint64 `json:"rootChainIndex,omitempty"`
+		RootChainAnchor string `json:"rootChainAnchor,omitempty"`
+		StateTreeAnchor string `json:"stateTreeAnchor,omitempty"`
+		Reference       string `json:"reference,omitempty"`
+	}{}
+	u.MajorBlockIndex = v.MajorBlockIndex
+	u.MinorBlockIndex = v.MinorBlockIndex
+	u.RootChainIndex = v.RootChainIndex
+	u.RootChainAnchor = encoding.ChainToJSON(v.RootChainAnchor)
+	u.StateTreeAnchor = encoding.ChainToJSON(v.StateTreeAnchor)
+	u.Reference = v.Reference
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.MajorBlockIndex = u.MajorBlockIndex
+	v.MinorBlockIndex = u.MinorBlockIndex
+	v.RootChainIndex = u.RootChainIndex
+	if x, err := encoding.ChainFromJSON(u.RootChainAnchor); err != nil {
+		return fmt.Errorf("error decoding RootChainAnchor: %w", err)
+	} else {
+		v.RootChainAnchor = x
+	}
+	if x, err := encoding.ChainFromJSON(u.StateTreeAnchor); err != nil {
+		return fmt.Errorf("error decoding StateTreeAnchor: %w", err)
+	} else {
+		v.StateTreeAnchor = x
+	}
+	v.Reference = u.Reference
+	return nil
+}
+
 func (v *FactomDataEntry) UnmarshalJSON(data []byte) error {
 	u := struct {
 		AccountId string                     `json:"accountId,omitempty"`
@@ -17086,6 +18099,26 @@ func (v *KeySpecParams) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *KeyValueAccount) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Type        AccountType                       `json:"type"`
+		Url         *url.URL                          `json:"url,omitempty"`
+		Authorities encoding.JsonList[AuthorityEntry] `json:"authorities,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Url = v.Url
+	u.Authorities = v.AccountAuth.Authorities
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if !(v.Type() == u.Type) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), u.Type)
+	}
+	v.Url = u.Url
+	v.AccountAuth.Authorities = u.Authorities
+	return nil
+}
+
 func (v *LegacyED25519Signature) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Type            SignatureType `json:"type"`
@@ -17570,6 +18603,34 @@ func (v *SendTokens) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *SetKeyValue) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Type  TransactionType `json:"type"`
+		Key   *string         `json:"key,omitempty"`
+		Value *string         `json:"value,omitempty"`
+	}{}
+	u.Type = v.Type()
+	u.Key = encoding.BytesToJSON(v.Key)
+	u.Value = encoding.BytesToJSON(v.Value)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if !(v.Type() == u.Type) {
+		return fmt.Errorf("field Type: not equal: want %v, got %v", v.Type(), u.Type)
+	}
+	if x, err := encoding.BytesFromJSON(u.Key); err != nil {
+		return fmt.Errorf("error decoding Key: %w", err)
+	} else {
+		v.Key = x
+	}
+	if x, err := encoding.BytesFromJSON(u.Value); err != nil {
+		return fmt.Errorf("error decoding Value: %w", err)
+	} else {
+		v.Value = x
+	}
+	return nil
+}
+
 func (v *SetThresholdKeyPageOperation) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Type      KeyPageOperationType `json:"type"`
@@ -17594,12 +18655,14 @@ func (v *SignatureSet) UnmarshalJSON(data []byte) error {
 		Signer          *url.URL                                  `json:"signer,omitempty"`
 		TransactionHash string                                    `json:"transactionHash,omitempty"`
 		Signatures      encoding.JsonUnmarshalListWith[Signature] `json:"signatures,omitempty"`
+		Fees            encoding.JsonList[uint64]                 `json:"fees,omitempty"`
 	}{}
 	u.Type = v.Type()
 	u.Vote = v.Vote
 	u.Signer = v.Signer
 	u.TransactionHash = encoding.ChainToJSON(v.TransactionHash)
 	u.Signatures = encoding.JsonUnmarshalListWith[Signature]{Value: v.Signatures, Func: UnmarshalSignatureJSON}
+	u.Fees = v.Fees
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -17617,6 +18680,28 @@ func (v *SignatureSet) UnmarshalJSON(data []byte) error {
 	for i, x := range u.Signatures.Value {
 		v.Signatures[i] = x
 	}
+	v.Fees = u.Fees
+	return nil
+}
+
+func (v *SimulateResponse) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Results  encoding.JsonList[*TransactionStatus] `json:"results,omitempty"`
+		Fee      Fee                                   `json:"fee,omitempty"`
+		Produced encoding.JsonList[*Transaction]       `json:"produced,omitempty"`
+		Changes  encoding.JsonList[*AccountChange]     `json:"changes,omitempty"`
+	}{}
+	u.Results = v.Results
+	u.Fee = v.Fee
+	u.Produced = v.Produced
+	u.Changes = v.Changes
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Results = u.Results
+	v.Fee = u.Fee
+	v.Produced = u.Produced
+	v.Changes = u.Changes
 	return nil
 }
 
@@ -18047,11 +19132,13 @@ func (v *TransactionHeader) UnmarshalJSON(data []byte) error {
 		Initiator string   `json:"initiator,omitempty"`
 		Memo      string   `json:"memo,omitempty"`
 		Metadata  *string  `json:"metadata,omitempty"`
+		Sponsor   *url.URL `json:"sponsor,omitempty"`
 	}{}
 	u.Principal = v.Principal
 	u.Initiator = encoding.ChainToJSON(v.Initiator)
 	u.Memo = v.Memo
 	u.Metadata = encoding.BytesToJSON(v.Metadata)
+	u.Sponsor = v.Sponsor
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -18067,6 +19154,7 @@ func (v *TransactionHeader) UnmarshalJSON(data []byte) error {
 	} else {
 		v.Metadata = x
 	}
+	v.Sponsor = u.Sponsor
 	return nil
 }
 
@@ -18102,6 +19190,7 @@ func (v *TransactionStatus) UnmarshalJSON(data []byte) error {
 		SequenceNumber      uint64                                        `json:"sequenceNumber,omitempty"`
 		GotDirectoryReceipt bool                                          `json:"gotDirectoryReceipt,omitempty"`
 		Proof               *managed.Receipt                              `json:"proof,omitempty"`
+		Refunds             encoding.JsonList[*url.TxID]                  `json:"refunds,omitempty"`
 	}{}
 	u.TxID = v.TxID
 	u.Code = v.Code
@@ -18124,6 +19213,7 @@ func (v *TransactionStatus) UnmarshalJSON(data []byte) error {
 	u.SequenceNumber = v.SequenceNumber
 	u.GotDirectoryReceipt = v.GotDirectoryReceipt
 	u.Proof = v.Proof
+	u.Refunds = v.Refunds
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -18151,6 +19241,7 @@ func (v *TransactionStatus) UnmarshalJSON(data []byte) error {
 	v.SequenceNumber = u.SequenceNumber
 	v.GotDirectoryReceipt = u.GotDirectoryReceipt
 	v.Proof = u.Proof
+	v.Refunds = u.Refunds
 	return nil
 }
 
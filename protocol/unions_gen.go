@@ -26,6 +26,8 @@ func NewAccount(typ AccountType) (Account, error) {
 		return new(KeyBook), nil
 	case AccountTypeKeyPage:
 		return new(KeyPage), nil
+	case AccountTypeKeyValueAccount:
+		return new(KeyValueAccount), nil
 	case AccountTypeLiteDataAccount:
 		return new(LiteDataAccount), nil
 	case AccountTypeLiteIdentity:
@@ -73,6 +75,9 @@ func EqualAccount(a, b Account) bool {
 	case *KeyPage:
 		b, ok := b.(*KeyPage)
 		return ok && a.Equal(b)
+	case *KeyValueAccount:
+		b, ok := b.(*KeyValueAccount)
+		return ok && a.Equal(b)
 	case *LiteDataAccount:
 		b, ok := b.(*LiteDataAccount)
 		return ok && a.Equal(b)
@@ -327,12 +332,16 @@ func NewTransactionBody(typ TransactionType) (TransactionBody, error) {
 		return new(CreateKeyBook), nil
 	case TransactionTypeCreateKeyPage:
 		return new(CreateKeyPage), nil
+	case TransactionTypeCreateKeyValueAccount:
+		return new(CreateKeyValueAccount), nil
 	case TransactionTypeCreateLiteTokenAccount:
 		return new(CreateLiteTokenAccount), nil
 	case TransactionTypeCreateToken:
 		return new(CreateToken), nil
 	case TransactionTypeCreateTokenAccount:
 		return new(CreateTokenAccount), nil
+	case TransactionTypeDeleteKeyValue:
+		return new(DeleteKeyValue), nil
 	case TransactionTypeDirectoryAnchor:
 		return new(DirectoryAnchor), nil
 	case TransactionTypeIssueTokens:
@@ -343,6 +352,8 @@ func NewTransactionBody(typ TransactionType) (TransactionBody, error) {
 		return new(RemoteTransaction), nil
 	case TransactionTypeSendTokens:
 		return new(SendTokens), nil
+	case TransactionTypeSetKeyValue:
+		return new(SetKeyValue), nil
 	case TransactionTypeSyntheticBurnTokens:
 		return new(SyntheticBurnTokens), nil
 	case TransactionTypeSyntheticCreateIdentity:
@@ -404,6 +415,9 @@ func EqualTransactionBody(a, b TransactionBody) bool {
 	case *CreateKeyPage:
 		b, ok := b.(*CreateKeyPage)
 		return ok && a.Equal(b)
+	case *CreateKeyValueAccount:
+		b, ok := b.(*CreateKeyValueAccount)
+		return ok && a.Equal(b)
 	case *CreateLiteTokenAccount:
 		b, ok := b.(*CreateLiteTokenAccount)
 		return ok && a.Equal(b)
@@ -413,6 +427,9 @@ func EqualTransactionBody(a, b TransactionBody) bool {
 	case *CreateTokenAccount:
 		b, ok := b.(*CreateTokenAccount)
 		return ok && a.Equal(b)
+	case *DeleteKeyValue:
+		b, ok := b.(*DeleteKeyValue)
+		return ok && a.Equal(b)
 	case *DirectoryAnchor:
 		b, ok := b.(*DirectoryAnchor)
 		return ok && a.Equal(b)
@@ -428,6 +445,9 @@ func EqualTransactionBody(a, b TransactionBody) bool {
 	case *SendTokens:
 		b, ok := b.(*SendTokens)
 		return ok && a.Equal(b)
+	case *SetKeyValue:
+		b, ok := b.(*SetKeyValue)
+		return ok && a.Equal(b)
 	case *SyntheticBurnTokens:
 		b, ok := b.(*SyntheticBurnTokens)
 		return ok && a.Equal(b)
@@ -20,6 +20,12 @@ const ChainTypeAnchor ChainType = 2
 // ChainTypeIndex indexes other chains.
 const ChainTypeIndex ChainType = 4
 
+// HashAlgorithmSHA256 combines hashes with SHA-256.
+const HashAlgorithmSHA256 HashAlgorithm = 0
+
+// HashAlgorithmBLAKE3 combines hashes with BLAKE3.
+const HashAlgorithmBLAKE3 HashAlgorithm = 1
+
 // GetEnumValue returns the value of the Chain Type
 func (v ChainType) GetEnumValue() uint64 { return uint64(v) }
 
@@ -87,3 +93,63 @@ func (v *ChainType) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// GetEnumValue returns the value of the Hash Algorithm
+func (v HashAlgorithm) GetEnumValue() uint64 { return uint64(v) }
+
+// SetEnumValue sets the value. SetEnumValue returns false if the value is invalid.
+func (v *HashAlgorithm) SetEnumValue(id uint64) bool {
+	u := HashAlgorithm(id)
+	switch u {
+	case HashAlgorithmSHA256, HashAlgorithmBLAKE3:
+		*v = u
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the name of the Hash Algorithm.
+func (v HashAlgorithm) String() string {
+	switch v {
+	case HashAlgorithmSHA256:
+		return "sha256"
+	case HashAlgorithmBLAKE3:
+		return "blake3"
+	default:
+		return fmt.Sprintf("HashAlgorithm:%d", v)
+	}
+}
+
+// HashAlgorithmByName returns the named Hash Algorithm.
+func HashAlgorithmByName(name string) (HashAlgorithm, bool) {
+	switch strings.ToLower(name) {
+	case "sha256":
+		return HashAlgorithmSHA256, true
+	case "blake3":
+		return HashAlgorithmBLAKE3, true
+	default:
+		return 0, false
+	}
+}
+
+// MarshalJSON marshals the Hash Algorithm to JSON as a string.
+func (v HashAlgorithm) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON unmarshals the Hash Algorithm from JSON as a string.
+func (v *HashAlgorithm) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	*v, ok = HashAlgorithmByName(s)
+	if !ok || strings.ContainsRune(v.String(), ':') {
+		return fmt.Errorf("invalid Hash Algorithm %q", s)
+	}
+	return nil
+}
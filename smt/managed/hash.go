@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"math/bits"
 
+	"github.com/zeebo/blake3"
 	"gitlab.com/accumulatenetwork/accumulate/internal/encoding"
 )
 
@@ -59,6 +60,23 @@ func Sha256(b []byte) Hash {
 	return h[:]
 }
 
+func Blake3(b []byte) Hash {
+	h := blake3.Sum256(b)
+	return h[:]
+}
+
+// Func returns the hash function of the algorithm, or nil if the algorithm is
+// not known.
+func (a HashAlgorithm) Func() HashFunc {
+	switch a {
+	case HashAlgorithmSHA256:
+		return Sha256
+	case HashAlgorithmBLAKE3:
+		return Blake3
+	}
+	return nil
+}
+
 func (h Hash) BinarySize() int {
 	return encoding.BytesBinarySize(h)
 }
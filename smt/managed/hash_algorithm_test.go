@@ -0,0 +1,115 @@
+package managed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/smt/common"
+)
+
+func TestHashAlgorithm(t *testing.T) {
+	var rh common.RandHash
+	store := begin()
+	m1 := testChain(store, 2, "blake3")
+	m1.SetHashAlgorithm(HashAlgorithmBLAKE3)
+	m2 := testChain(store, 2, "sha256")
+
+	// Anchor a BLAKE3 chain into a SHA-256 chain
+	const count = 20
+	for i := 0; i < count; i++ {
+		require.NoError(t, m1.AddHash(rh.NextList(), false))
+		head, err := m1.Head().Get()
+		require.NoError(t, err)
+		require.NoError(t, m2.AddHash(head.GetMDRoot(), false))
+	}
+
+	// The chain's root is built with BLAKE3
+	head, err := m1.Head().Get()
+	require.NoError(t, err)
+	require.Equal(t, HashAlgorithmBLAKE3, head.HashAlgorithm)
+	expected := new(MerkleState)
+	for _, h := range rh.List {
+		expected.AddToMerkleTree(h)
+	}
+	require.NotEqual(t, expected.GetMDRoot(), head.GetMDRoot())
+	expected = new(MerkleState)
+	expected.HashAlgorithm = HashAlgorithmBLAKE3
+	for _, h := range rh.List {
+		expected.AddToMerkleTree(h)
+	}
+	require.Equal(t, expected.GetMDRoot(), head.GetMDRoot())
+
+	// The head and states keep the hash algorithm when they are reloaded
+	require.NoError(t, store.Store.Commit())
+	for i := int64(0); i < count; i++ {
+		state, err := m1.GetAnyState(i)
+		require.NoError(t, err)
+		require.Equal(t, HashAlgorithmBLAKE3, state.HashAlgorithm)
+	}
+
+	for i := int64(0); i < count; i++ {
+		for j := i; j < count; j++ {
+			r1, err := GetReceipt(m1, rh.List[i], rh.List[j])
+			require.NoError(t, err)
+			require.True(t, r1.Validate(), "receipt %d %d", i, j)
+			for _, e := range r1.Entries {
+				require.Equal(t, HashAlgorithmBLAKE3, e.HashAlgorithm)
+			}
+
+			// The receipt survives serialization
+			data, err := r1.MarshalBinary()
+			require.NoError(t, err)
+			r := new(Receipt)
+			require.NoError(t, r.UnmarshalBinary(data))
+			require.True(t, r.Validate())
+
+			// Combining receipts with different algorithms works
+			anchor, err := m2.Get(j)
+			require.NoError(t, err)
+			r2, err := GetReceipt(m2, anchor, anchor)
+			require.NoError(t, err)
+			r3, err := r1.Combine(r2)
+			require.NoError(t, err)
+			require.True(t, r3.Validate(), "combined receipt %d %d", i, j)
+
+			// Applying the wrong algorithm fails
+			if len(r1.Entries) > 0 {
+				r := r1.Copy()
+				r.Entries[0].HashAlgorithm = HashAlgorithmSHA256
+				require.False(t, r.Validate())
+				r.Entries[0].HashAlgorithm = 99
+				require.False(t, r.Validate())
+			}
+		}
+	}
+
+	mr, err := GetMultiReceipt(m1, []int64{1, 7, 12}, count-1)
+	require.NoError(t, err)
+	require.Equal(t, HashAlgorithmBLAKE3, mr.HashAlgorithm)
+	require.True(t, mr.Validate())
+	mr.HashAlgorithm = HashAlgorithmSHA256
+	require.False(t, mr.Validate())
+}
+
+func TestMerkleStateHashAlgorithm(t *testing.T) {
+	var rh common.RandHash
+	ms := new(MerkleState)
+	for i := 0; i < 5; i++ {
+		ms.AddToMerkleTree(rh.NextList())
+	}
+
+	// The encoding of a SHA-256 state does not include the algorithm
+	sha, err := ms.Marshal()
+	require.NoError(t, err)
+	ms.HashAlgorithm = HashAlgorithmBLAKE3
+	blake, err := ms.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, sha, blake[:len(sha)])
+
+	ms2 := new(MerkleState)
+	require.NoError(t, ms2.UnMarshal(sha))
+	require.Equal(t, HashAlgorithmSHA256, ms2.HashAlgorithm)
+	require.NoError(t, ms2.UnMarshal(blake))
+	require.Equal(t, HashAlgorithmBLAKE3, ms2.HashAlgorithm)
+	require.True(t, ms.Equal(ms2))
+}
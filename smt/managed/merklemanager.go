@@ -19,6 +19,7 @@ func NewChain(logger log.Logger, store record.Store, key record.Key, markPower i
 	c.store = store
 	c.key = key
 	c.typ = typ
+	c.hashAlgorithm = typ.DefaultHashAlgorithm()
 
 	// TODO markFreq = 1 << markPower?
 
@@ -44,6 +45,15 @@ func NewChain(logger log.Logger, store record.Store, key record.Key, markPower i
 func (c *Chain) Name() string    { return c.name }
 func (c *Chain) Type() ChainType { return c.typ }
 
+// HashAlgorithm returns the algorithm used to combine the hashes of a new
+// chain. Once a hash is added, the chain's head records the algorithm.
+func (c *Chain) HashAlgorithm() HashAlgorithm { return c.hashAlgorithm }
+
+// SetHashAlgorithm sets the algorithm used to combine the hashes of a new
+// chain. SetHashAlgorithm does not change the algorithm of a chain that has
+// hashes.
+func (c *Chain) SetHashAlgorithm(alg HashAlgorithm) { c.hashAlgorithm = alg }
+
 // AddHash adds a Hash to the Chain controlled by the ChainManager. If unique is
 // true, the hash will not be added if it is already in the chain.
 func (m *MerkleManager) AddHash(hash Hash, unique bool) error {
@@ -52,6 +62,10 @@ func (m *MerkleManager) AddHash(hash Hash, unique bool) error {
 		return err
 	}
 
+	if head.Count == 0 { //                     The first hash fixes the hash algorithm of the chain
+		head.HashAlgorithm = m.hashAlgorithm
+	}
+
 	hash = hash.Copy()                       // Just to make sure hash doesn't get changed
 	_, err = m.ElementIndex(hash).Get()      // See if this element is a duplicate
 	if errors.Is(err, storage.ErrNotFound) { // So only if the hash is not yet added to the Merkle Tree
@@ -113,10 +127,10 @@ func (m *MerkleManager) GetState(element int64) *MerkleState {
 	head, err := m.Head().Get()
 	if err == nil && head.Count == 0 {
 		ms := new(MerkleState)
+		ms.HashAlgorithm = m.hashAlgorithm
 		if eHash, err := m.Get(element); err != nil {
 			ms.AddToMerkleTree(eHash)
 		}
-		ms.InitSha256()
 		return ms
 	}
 
@@ -132,9 +146,7 @@ func (m *MerkleManager) GetState(element int64) *MerkleState {
 // state even if one isn't stored for a particular element.
 func (m *MerkleManager) GetAnyState(element int64) (ms *MerkleState, err error) {
 	if element == -1 { //                                A need exists for the state before adding the first element
-		ms = new(MerkleState) //                         In that case, just allocate a MerkleState
-		ms.InitSha256()       //                         Initialize its hash function
-		return ms, nil        //                         And all is golden
+		return m.newState() //                           In that case, just allocate a MerkleState
 	}
 	if ms = m.GetState(element); ms != nil { //          Shoot for broke. Return a state if it is in the db
 		return ms, nil
@@ -148,8 +160,10 @@ func (m *MerkleManager) GetAnyState(element int64) (ms *MerkleState, err error)
 	MIPrev := element&(^m.markMask) - 1 //               Calculate the index of the prior markpoint
 	cState := m.GetState(MIPrev)        //               Use state at the prior mark point to compute what we need
 	if MIPrev < 0 {
-		cState = new(MerkleState)
-		cState.InitSha256()
+		cState, err = m.newState()
+		if err != nil {
+			return nil, err
+		}
 	}
 	if cState == nil { //                                Should be in the database.
 		return nil, errors.New( //                        Report error if it isn't in the database'
@@ -180,6 +194,21 @@ func (m *MerkleManager) GetAnyState(element int64) (ms *MerkleState, err error)
 	return cState, nil
 }
 
+// newState
+// Allocate an empty MerkleState that combines hashes the same way as the chain
+func (m *MerkleManager) newState() (*MerkleState, error) {
+	head, err := m.Head().Get()
+	if err != nil {
+		return nil, err
+	}
+	ms := new(MerkleState)
+	ms.HashAlgorithm = head.HashAlgorithm
+	if head.Count == 0 {
+		ms.HashAlgorithm = m.hashAlgorithm
+	}
+	return ms, nil
+}
+
 // Get the nth leaf node
 func (m *MerkleManager) Get(element int64) (Hash, error) {
 	return m.Element(uint64(element)).Get()
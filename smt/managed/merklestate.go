@@ -25,9 +25,10 @@ import (
 // Interestingly, the state of building such a Merkle Tree looks just like counting in binary.  And the
 // higher order bits set will correspond to where the binary roots must be kept in a Merkle state.
 type MerkleState struct {
-	Count    int64          // Count of hashes added to the Merkle tree
-	Pending  SparseHashList // Array of hashes that represent the left edge of the Merkle tree
-	HashList HashList       // List of Hashes in the order added to the chain
+	Count         int64          // Count of hashes added to the Merkle tree
+	Pending       SparseHashList // Array of hashes that represent the left edge of the Merkle tree
+	HashList      HashList       // List of Hashes in the order added to the chain
+	HashAlgorithm HashAlgorithm  // Algorithm used to combine hashes
 }
 
 // String
@@ -132,6 +133,11 @@ func (m *MerkleState) Equal(m2 *MerkleState) (isEqual bool) {
 		return false
 	}
 
+	// The hashes must be combined the same way
+	if m.HashAlgorithm != m2.HashAlgorithm {
+		return false
+	}
+
 	for i, v := range m.Pending { // First check if all non nil elements of m.Pending == m2.Pending
 		if v == nil && len(m2.Pending) <= i { // If m1 has trailing nils where m mas nils, that's okay
 			continue
@@ -182,13 +188,19 @@ func (m *MerkleState) Marshal() (MSBytes []byte, err error) {
 	// Write out the hash list (never returns an error)
 	b, _ = m.HashList.MarshalBinary()
 	MSBytes = append(MSBytes, b...)
+
+	// Write out the hash algorithm, unless it is the default, so states
+	// written before hash algorithms were introduced are unchanged
+	if m.HashAlgorithm != HashAlgorithmSHA256 {
+		MSBytes = append(MSBytes, encoding.UvarintMarshalBinary(m.HashAlgorithm.GetEnumValue())...)
+	}
 	return MSBytes, nil
 }
 
 // UnMarshal
 // Take the state of an MSMarshal instance defined by MSBytes, and set all the values
-// in this instance of MSMarshal to the state defined by MSBytes.  It is assumed that the
-// hash function has been set by the caller.
+// in this instance of MSMarshal to the state defined by MSBytes.  If MSBytes does
+// not include a hash algorithm, the algorithm is SHA-256.
 func (m *MerkleState) UnMarshal(MSBytes []byte) (err error) {
 	// Unmarshal the Count
 	m.Count, err = encoding.VarintUnmarshalBinary(MSBytes)
@@ -209,6 +221,19 @@ func (m *MerkleState) UnMarshal(MSBytes []byte) (err error) {
 	if err != nil {
 		return err
 	}
+	MSBytes = MSBytes[m.HashList.BinarySize():]
+
+	// Unmarshal the hash algorithm, if there is one
+	m.HashAlgorithm = HashAlgorithmSHA256
+	if len(MSBytes) > 0 {
+		v, err := encoding.UvarintUnmarshalBinary(MSBytes)
+		if err != nil {
+			return err
+		}
+		if !m.HashAlgorithm.SetEnumValue(v) {
+			return fmt.Errorf("unknown hash algorithm %d", v)
+		}
+	}
 
 	// Make a copy to avoid weird memory bugs
 	for i, h := range m.Pending {
@@ -233,8 +258,18 @@ func GetSha256() func(data []byte) Hash {
 
 // InitSha256
 // Set the hashing function of this Merkle State to Sha256
-// TODO: Actually update the library to be able to use various hash algorithms
 func (m *MerkleState) InitSha256() {
+	m.HashAlgorithm = HashAlgorithmSHA256
+}
+
+// hash
+// Returns the function used to combine the hashes of this Merkle State
+func (m *MerkleState) hash() HashFunc {
+	hf := m.HashAlgorithm.Func()
+	if hf == nil {
+		panic(fmt.Errorf("unknown hash algorithm %v", m.HashAlgorithm))
+	}
+	return hf
 }
 
 // AddToMerkleTree
@@ -250,7 +285,7 @@ func (m *MerkleState) AddToMerkleTree(hash_ []byte) {
 			m.Pending[i] = hash //               And put the Hash there if one is found
 			return              //          Mission complete, so return
 		}
-		hash = Hash(v).Combine(Sha256, hash) // If this slot isn't empty, combine the hash with the slot
+		hash = Hash(v).Combine(m.hash(), hash) // If this slot isn't empty, combine the hash with the slot
 		m.Pending[i] = nil                   //   and carry the result to the next (clearing this one)
 	}
 }
@@ -273,7 +308,7 @@ func (m *MerkleState) GetMDRoot() (MDRoot Hash) {
 		if MDRoot == nil { // Pick up the first hash in m.MerkleState no matter what.
 			MDRoot = Hash(v).Copy() // If a nil is assigned over a nil, no harm no foul.  Fewer cases to test this way.
 		} else if v != nil { // If MDRoot isn't nil and v isn't nil, combine them.
-			MDRoot = Hash(v).Combine(Sha256, MDRoot) // v is on the left, MDRoot candidate is on the right, for a new MDRoot
+			MDRoot = Hash(v).Combine(m.hash(), MDRoot) // v is on the left, MDRoot candidate is on the right, for a new MDRoot
 		}
 	}
 	// Drop out with a MDRoot unless m.MerkleState is zero length, in which case return a nil (correct)
@@ -312,7 +347,7 @@ func (m *MerkleState) GetIntermediate(hash Hash, height int64) (left, right Hash
 			right = hash.Copy()     //
 			return left, right, nil // return them
 		}
-		hash = Hash(v).Combine(Sha256, hash) // If this slot isn't empty, combine the hash with the slot
+		hash = Hash(v).Combine(m.hash(), hash) // If this slot isn't empty, combine the hash with the slot
 	}
 	return nil, nil, fmt.Errorf("no values found at height %d", height)
 }
@@ -0,0 +1,229 @@
+package managed
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// MultiReceipt
+// A MultiReceipt proves that a set of elements, not necessarily contiguous,
+// are part of a Merkle Tree at the given anchor.  Rather than one receipt per
+// element, a MultiReceipt holds the elements and the minimum set of
+// intermediate hashes needed to compute the anchor, so hashes shared by the
+// paths of multiple elements are only included once.
+//
+// The Merkle Tree at the anchor is a list of perfect binary trees, one per
+// entry in the pending list of the anchor's MerkleState.  Each element is
+// hashed up its tree, taking a sibling from Hashes whenever the sibling is not
+// computed from another element.  Trees with no elements are taken from Hashes
+// as a whole.  Finally the tree roots are combined the same way as
+// MerkleState.GetMDRoot.  Hashes are combined with the hash algorithm of the
+// chain, which is recorded in the MultiReceipt.
+
+// GetMultiReceipt
+// Given a merkle tree, a set of element indices, and an anchor index, produce a
+// proof that the elements were used to derive the DAG at the anchor.
+func GetMultiReceipt(manager *MerkleManager, indices []int64, anchorIndex int64) (*MultiReceipt, error) {
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no elements to prove")
+	}
+
+	head, err := manager.Head().Get()
+	if err != nil {
+		return nil, err
+	}
+	if anchorIndex < 0 || anchorIndex >= head.Count {
+		return nil, fmt.Errorf("anchor %d is out of range for SMT length %d", anchorIndex, head.Count)
+	}
+
+	sorted := append([]int64{}, indices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	r := new(MultiReceipt)
+	r.EndIndex = anchorIndex
+	r.HashAlgorithm = head.HashAlgorithm
+	for i, index := range sorted {
+		if i > 0 && index == sorted[i-1] {
+			continue // Ignore duplicates
+		}
+		if index < 0 || index > anchorIndex {
+			return nil, fmt.Errorf("element %d is not at or before the anchor %d", index, anchorIndex)
+		}
+		hash, err := manager.Get(index)
+		if err != nil {
+			return nil, err
+		}
+		r.Elements = append(r.Elements, &MultiReceiptElement{Index: index, Hash: hash.Copy()})
+	}
+
+	r.End, err = manager.Get(anchorIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute the anchor, recording every hash that is not computed from the
+	// elements
+	anchor, err := r.compute(func(start, height int64) (Hash, error) {
+		hash, err := manager.getSubtreeHash(start, height, head.hash())
+		if err != nil {
+			return nil, err
+		}
+		r.Hashes = append(r.Hashes, hash.Copy())
+		return hash, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Anchor = anchor
+	return r, nil
+}
+
+// getSubtreeHash returns the root of the perfect binary tree of the given
+// height whose first element is at start, combining hashes with hf.  Start
+// must be a multiple of 2^height.
+func (m *MerkleManager) getSubtreeHash(start, height int64, hf HashFunc) (Hash, error) {
+	if height == 0 {
+		return m.Get(start)
+	}
+
+	// The last element of the tree combines the roots of its two halves
+	left, right, err := m.GetIntermediate(start+1<<height-1, height)
+	if err != nil {
+		return nil, err
+	}
+	return left.Combine(hf, right), nil
+}
+
+// Validate
+// Take a MultiReceipt and validate that the elements and hashes progress to
+// the Merkle Dag Root hash (MDRoot) in the receipt
+func (r *MultiReceipt) Validate() bool {
+	if len(r.Elements) == 0 {
+		return false
+	}
+	for i, e := range r.Elements {
+		if e == nil || len(e.Hash) != 32 {
+			return false
+		}
+		if i > 0 && e.Index <= r.Elements[i-1].Index { // Elements must be sorted and unique
+			return false
+		}
+	}
+
+	var next int
+	anchor, err := r.compute(func(start, height int64) (Hash, error) {
+		if next >= len(r.Hashes) {
+			return nil, fmt.Errorf("missing hash for height %d at %d", height, start)
+		}
+		hash := r.Hashes[next]
+		next++
+		return hash, nil
+	})
+	if err != nil || next != len(r.Hashes) { // Every hash must be used
+		return false
+	}
+	return bytes.Equal(anchor, r.Anchor)
+}
+
+// Included
+// Tests an entry for inclusion at the given index in the MultiReceipt.  Note
+// that this only checks the element list; the receipt must also be validated.
+func (r *MultiReceipt) Included(index int64, entry []byte) bool {
+	i := sort.Search(len(r.Elements), func(i int) bool { return r.Elements[i].Index >= index })
+	return i < len(r.Elements) && r.Elements[i].Index == index && bytes.Equal(r.Elements[i].Hash, entry)
+}
+
+// compute calculates the anchor from the elements. Whenever a hash cannot be
+// computed from the elements, compute calls getHash for the root of the
+// perfect binary tree of the given height whose first element is at start.
+// The order of the calls defines the order of MultiReceipt.Hashes.
+func (r *MultiReceipt) compute(getHash func(start, height int64) (Hash, error)) (Hash, error) {
+	if r.EndIndex < 0 {
+		return nil, fmt.Errorf("invalid anchor index %d", r.EndIndex)
+	}
+	hf := r.HashAlgorithm.Func()
+	if hf == nil {
+		return nil, fmt.Errorf("unknown hash algorithm %v", r.HashAlgorithm)
+	}
+
+	// Walk the trees from the first (tallest) to the last
+	count := r.EndIndex + 1
+	var roots []Hash
+	var start int64
+	elements := r.Elements
+	for height := int64(62); height >= 0; height-- {
+		size := int64(1) << height
+		if count&size == 0 {
+			continue
+		}
+
+		// Collect the elements within this tree
+		var nodes []*MultiReceiptElement
+		for len(elements) > 0 && elements[0].Index < start+size {
+			if elements[0].Index < start {
+				return nil, fmt.Errorf("element %d is out of order", elements[0].Index)
+			}
+			nodes = append(nodes, &MultiReceiptElement{Index: elements[0].Index - start, Hash: elements[0].Hash})
+			elements = elements[1:]
+		}
+
+		var root Hash
+		var err error
+		if len(nodes) == 0 {
+			root, err = getHash(start, height)
+		} else {
+			root, err = computeSubtree(nodes, start, height, hf, getHash)
+		}
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+		start += size
+	}
+	if len(elements) > 0 {
+		return nil, fmt.Errorf("element %d is after the anchor %d", elements[0].Index, r.EndIndex)
+	}
+
+	// Combine the roots, shortest first, as MerkleState.GetMDRoot does
+	anchor := roots[len(roots)-1]
+	for i := len(roots) - 2; i >= 0; i-- {
+		anchor = roots[i].Combine(hf, anchor)
+	}
+	return anchor, nil
+}
+
+// computeSubtree hashes the nodes, given by their position within the tree,
+// up to the root of the perfect binary tree of the given height whose first
+// element is at start.
+func computeSubtree(nodes []*MultiReceiptElement, start, height int64, hf HashFunc, getHash func(start, height int64) (Hash, error)) (Hash, error) {
+	for level := int64(0); level < height; level++ {
+		var parents []*MultiReceiptElement
+		for i := 0; i < len(nodes); i++ {
+			node := nodes[i]
+			var left, right Hash
+			switch {
+			case node.Index&1 == 1: // A right node whose left sibling is not known
+				sibling, err := getHash(start+(node.Index-1)<<level, level)
+				if err != nil {
+					return nil, err
+				}
+				left, right = sibling, node.Hash
+
+			case i+1 < len(nodes) && nodes[i+1].Index == node.Index+1: // Both siblings are known
+				left, right = node.Hash, nodes[i+1].Hash
+				i++
+
+			default: // A left node whose right sibling is not known
+				sibling, err := getHash(start+(node.Index+1)<<level, level)
+				if err != nil {
+					return nil, err
+				}
+				left, right = node.Hash, sibling
+			}
+			parents = append(parents, &MultiReceiptElement{Index: node.Index >> 1, Hash: Hash(left).Combine(hf, right)})
+		}
+		nodes = parents
+	}
+	return nodes[0].Hash, nil
+}
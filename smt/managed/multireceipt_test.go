@@ -0,0 +1,112 @@
+package managed
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/smt/common"
+)
+
+func TestMultiReceipt(t *testing.T) {
+	var rh common.RandHash
+	manager := testChain(begin(), 2, 1)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, manager.AddHash(rh.NextList(), false))
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for anchor := int64(0); anchor < 100; anchor++ {
+		// Pick a random set of elements at or before the anchor
+		indices := []int64{anchor}
+		for i := random.Intn(8); i > 0; i-- {
+			indices = append(indices, random.Int63n(anchor+1))
+		}
+
+		r, err := GetMultiReceipt(manager, indices, anchor)
+		require.NoError(t, err)
+		require.True(t, r.Validate(), "anchor %d, elements %v", anchor, indices)
+
+		// The anchor must match the receipt of every element
+		for _, index := range indices {
+			require.True(t, r.Included(index, rh.List[index]))
+			single, err := GetReceipt(manager, rh.List[index], rh.List[anchor])
+			require.NoError(t, err)
+			require.Equal(t, single.Anchor, r.Anchor)
+		}
+
+		// The receipt survives serialization
+		data, err := r.MarshalBinary()
+		require.NoError(t, err)
+		r2 := new(MultiReceipt)
+		require.NoError(t, r2.UnmarshalBinary(data))
+		require.True(t, r2.Validate())
+		require.True(t, r.Equal(r2))
+	}
+}
+
+func TestMultiReceiptShared(t *testing.T) {
+	var rh common.RandHash
+	manager := testChain(begin(), 2, 1)
+	for i := 0; i < 64; i++ {
+		require.NoError(t, manager.AddHash(rh.NextList(), false))
+	}
+
+	// Proving every element requires no hashes
+	var all []int64
+	for i := int64(0); i < 64; i++ {
+		all = append(all, i)
+	}
+	r, err := GetMultiReceipt(manager, all, 63)
+	require.NoError(t, err)
+	require.True(t, r.Validate())
+	require.Empty(t, r.Hashes)
+
+	// Siblings share the path above them
+	r, err = GetMultiReceipt(manager, []int64{10, 11}, 63)
+	require.NoError(t, err)
+	require.True(t, r.Validate())
+	require.Len(t, r.Hashes, 5)
+}
+
+func TestMultiReceiptTampered(t *testing.T) {
+	var rh common.RandHash
+	manager := testChain(begin(), 2, 1)
+	for i := 0; i < 37; i++ {
+		require.NoError(t, manager.AddHash(rh.NextList(), false))
+	}
+
+	r, err := GetMultiReceipt(manager, []int64{3, 17, 30}, 36)
+	require.NoError(t, err)
+	require.True(t, r.Validate())
+
+	// Changing an element fails
+	bad := r.Copy()
+	bad.Elements[1].Hash[0] ^= 1
+	require.False(t, bad.Validate())
+
+	// Changing an index fails
+	bad = r.Copy()
+	bad.Elements[1].Index = 18
+	require.False(t, bad.Validate())
+
+	// Changing, dropping, or adding a hash fails
+	bad = r.Copy()
+	bad.Hashes[0][0] ^= 1
+	require.False(t, bad.Validate())
+	bad = r.Copy()
+	bad.Hashes = bad.Hashes[1:]
+	require.False(t, bad.Validate())
+	bad = r.Copy()
+	bad.Hashes = append(bad.Hashes, rh.Next())
+	require.False(t, bad.Validate())
+
+	// Duplicate elements fail
+	bad = r.Copy()
+	bad.Elements = append(bad.Elements[:2], bad.Elements[1:]...)
+	require.False(t, bad.Validate())
+
+	// Elements after the anchor are rejected
+	_, err = GetMultiReceipt(manager, []int64{3, 37}, 36)
+	require.Error(t, err)
+}
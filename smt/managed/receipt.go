@@ -20,22 +20,27 @@ func (r *Receipt) String() string {
 		r := "L"
 		if v.Right {
 			r = "R"
-			working = Sha256(append(working[:], v.Hash[:]...))
-		} else {
-			working = Sha256(append(v.Hash[:], working[:]...))
 		}
+		working = v.Apply(working)
 		b.WriteString(fmt.Sprintf(" %10d Apply %s %x working: %x \n", i, r, v.Hash, working))
 	}
 	return b.String()
 }
 
+// Apply
+// Combine the hash with the entry's hash, using the entry's hash algorithm.
+// Returns nil if the hash algorithm is not known.
 func (n *ReceiptEntry) Apply(hash Hash) Hash {
+	hf := n.HashAlgorithm.Func()
+	if hf == nil {
+		return nil
+	}
 	if n.Right {
 		// If this hash comes from the right, apply it that way
-		return hash.Combine(Sha256, n.Hash)
+		return hash.Combine(hf, n.Hash)
 	}
 	// If this hash comes from the left, apply it that way
-	return Hash(n.Hash).Combine(Sha256, hash)
+	return Hash(n.Hash).Combine(hf, hash)
 }
 
 // Validate
@@ -46,6 +51,9 @@ func (r *Receipt) Validate() bool {
 	// Now apply all the path hashes to the MDRoot
 	for _, node := range r.Entries {
 		MDRoot = node.Apply(MDRoot)
+		if MDRoot == nil { // The hash algorithm is not known
+			return false
+		}
 	}
 	// In the end, MDRoot should be the same hash the receipt expects.
 	return Hash(MDRoot).Equal(r.Anchor)
@@ -61,6 +69,9 @@ func (r *Receipt) Contains(other *Receipt) bool {
 			return false
 		}
 		hashSelf = r.Entries[posSelf].Apply(hashSelf)
+		if hashSelf == nil {
+			return false
+		}
 		posSelf++
 	}
 
@@ -72,7 +83,7 @@ func (r *Receipt) Contains(other *Receipt) bool {
 		hashSelf = r.Entries[posSelf].Apply(hashSelf)
 		hashOther = entry.Apply(hashOther)
 		posSelf++
-		if !bytes.Equal(hashSelf, hashOther) {
+		if hashSelf == nil || !bytes.Equal(hashSelf, hashOther) {
 			return false
 		}
 	}
@@ -163,12 +174,18 @@ func GetReceipt(manager *MerkleManager, element Hash, anchor Hash) (r *Receipt,
 func (r *Receipt) BuildReceipt() error {
 	state, _ := r.manager.GetAnyState(r.EndIndex) // Get the state at the Anchor Index
 	state.Trim()                                  // If Pending has any trailing nils, remove them.
-	return r.BuildReceiptWith(r.manager.GetIntermediate, Sha256, state)
+	return r.BuildReceiptWith(r.manager.GetIntermediate, state)
 }
 
 type GetIntermediateFunc func(element, height int64) (l, r Hash, err error)
 
-func (r *Receipt) BuildReceiptWith(getIntermediate GetIntermediateFunc, hashFunc HashFunc, anchorState *MerkleState) error {
+// BuildReceiptWith
+// Builds the receipt using the given function to get intermediate hashes.
+// Hashes are combined with the hash algorithm of the anchor state, which is
+// recorded in each entry of the receipt.
+func (r *Receipt) BuildReceiptWith(getIntermediate GetIntermediateFunc, anchorState *MerkleState) error {
+	alg := anchorState.HashAlgorithm
+	hashFunc := anchorState.hash()
 	height := int64(1) // Start the height at 1, because the element isn't part
 	r.Anchor = r.Start // of the nodes collected.  To begin with, the element is the Merkle Dag Root
 	stay := true       // stay represents the fact that the proof is already in this column
@@ -195,9 +212,9 @@ func (r *Receipt) BuildReceiptWith(getIntermediate GetIntermediateFunc, hashFunc
 			}
 			r.Anchor = lHash.Combine(hashFunc, rHash) // We don't have to calculate the MDRoot, but it
 			if stay {                                 //   helps debugging.  Check if still in column
-				r.Entries = append(r.Entries, &ReceiptEntry{Hash: lHash, Right: false}) // If so, combine from left
+				r.Entries = append(r.Entries, &ReceiptEntry{Hash: lHash, Right: false, HashAlgorithm: alg}) // If so, combine from left
 			} else { //                                                     Otherwise
-				r.Entries = append(r.Entries, &ReceiptEntry{Hash: rHash, Right: true}) //  combine from right
+				r.Entries = append(r.Entries, &ReceiptEntry{Hash: rHash, Right: true, HashAlgorithm: alg}) //  combine from right
 			}
 			stay = true // By default assume a stay in the column
 			height++    // and increment the height.
@@ -236,12 +253,12 @@ func (r *Receipt) BuildReceiptWith(getIntermediate GetIntermediateFunc, hashFunc
 		}
 		if stay { //                                                     If in the same column
 			if int64(i) >= height { //                                    And the proof is at this hight or higher
-				r.Entries = append(r.Entries, &ReceiptEntry{Hash: v, Right: false}) // Add to the receipt
+				r.Entries = append(r.Entries, &ReceiptEntry{Hash: v, Right: false, HashAlgorithm: alg}) // Add to the receipt
 			}
 			continue
 		}
-		r.Entries = append(r.Entries, &ReceiptEntry{Hash: lastIH, Right: true}) // First time in this column, so add to receipt
-		stay = true                                                             // Indicate processing the same column now.
+		r.Entries = append(r.Entries, &ReceiptEntry{Hash: lastIH, Right: true, HashAlgorithm: alg}) // First time in this column, so add to receipt
+		stay = true                                                                                 // Indicate processing the same column now.
 
 	}
 	r.Anchor = intermediateHash // The Merkle Dag Root is the last intermediate Hash produced.
@@ -6,3 +6,16 @@ package managed
 
 // ChainType is the type of a chain belonging to an account.
 type ChainType uint64
+
+// HashAlgorithm is the algorithm used to combine the hashes of a Merkle tree.
+// Only byte-oriented hashes are supported. SNARK-friendly hashes such as
+// Poseidon operate on field elements, so they would need a field encoding of
+// the entries and are not implemented.
+type HashAlgorithm uint64
+
+// DefaultHashAlgorithm returns the hash algorithm of a new chain of the type.
+// Every chain uses SHA-256 unless its owner chooses otherwise; the database
+// decides which chains may.
+func (t ChainType) DefaultHashAlgorithm() HashAlgorithm {
+	return HashAlgorithmSHA256
+}
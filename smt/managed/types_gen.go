@@ -15,6 +15,29 @@ import (
 	"gitlab.com/accumulatenetwork/accumulate/internal/encoding"
 )
 
+type MultiReceipt struct {
+	fieldsSet []bool
+	// Elements are the entries for which we want a proof, sorted by index.
+	Elements []*MultiReceiptElement `json:"elements,omitempty" form:"elements" query:"elements" validate:"required"`
+	// End is the entry at the index where the anchor was created.
+	End      []byte `json:"end,omitempty" form:"end" query:"end" validate:"required"`
+	EndIndex int64  `json:"endIndex,omitempty" form:"endIndex" query:"endIndex" validate:"required"`
+	// Anchor is the root expected once all elements and hashes are combined.
+	Anchor []byte `json:"anchor,omitempty" form:"anchor" query:"anchor" validate:"required"`
+	// Hashes are the intermediate hashes needed to compute the anchor, in the order they are used.
+	Hashes [][]byte `json:"hashes,omitempty" form:"hashes" query:"hashes" validate:"required"`
+	// HashAlgorithm is the algorithm used to combine the hashes.
+	HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty" form:"hashAlgorithm" query:"hashAlgorithm"`
+	extraData     []byte
+}
+
+type MultiReceiptElement struct {
+	fieldsSet []bool
+	Index     int64  `json:"index,omitempty" form:"index" query:"index" validate:"required"`
+	Hash      []byte `json:"hash,omitempty" form:"hash" query:"hash" validate:"required"`
+	extraData []byte
+}
+
 type Receipt struct {
 	fieldsSet []bool
 	// Start is the entry for which we want a proof.
@@ -35,7 +58,9 @@ type ReceiptEntry struct {
 	fieldsSet []bool
 	Right     bool   `json:"right,omitempty" form:"right" query:"right" validate:"required"`
 	Hash      []byte `json:"hash,omitempty" form:"hash" query:"hash" validate:"required"`
-	extraData []byte
+	// HashAlgorithm is the algorithm used to combine the hashes.
+	HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty" form:"hashAlgorithm" query:"hashAlgorithm"`
+	extraData     []byte
 }
 
 type ReceiptList struct {
@@ -48,6 +73,40 @@ type ReceiptList struct {
 	extraData        []byte
 }
 
+func (v *MultiReceipt) Copy() *MultiReceipt {
+	u := new(MultiReceipt)
+
+	u.Elements = make([]*MultiReceiptElement, len(v.Elements))
+	for i, v := range v.Elements {
+		if v != nil {
+			u.Elements[i] = (v).Copy()
+		}
+	}
+	u.End = encoding.BytesCopy(v.End)
+	u.EndIndex = v.EndIndex
+	u.Anchor = encoding.BytesCopy(v.Anchor)
+	u.Hashes = make([][]byte, len(v.Hashes))
+	for i, v := range v.Hashes {
+		u.Hashes[i] = encoding.BytesCopy(v)
+	}
+	u.HashAlgorithm = v.HashAlgorithm
+
+	return u
+}
+
+func (v *MultiReceipt) CopyAsInterface() interface{} { return v.Copy() }
+
+func (v *MultiReceiptElement) Copy() *MultiReceiptElement {
+	u := new(MultiReceiptElement)
+
+	u.Index = v.Index
+	u.Hash = encoding.BytesCopy(v.Hash)
+
+	return u
+}
+
+func (v *MultiReceiptElement) CopyAsInterface() interface{} { return v.Copy() }
+
 func (v *Receipt) Copy() *Receipt {
 	u := new(Receipt)
 
@@ -73,6 +132,7 @@ func (v *ReceiptEntry) Copy() *ReceiptEntry {
 
 	u.Right = v.Right
 	u.Hash = encoding.BytesCopy(v.Hash)
+	u.HashAlgorithm = v.HashAlgorithm
 
 	return u
 }
@@ -101,6 +161,50 @@ func (v *ReceiptList) Copy() *ReceiptList {
 
 func (v *ReceiptList) CopyAsInterface() interface{} { return v.Copy() }
 
+func (v *MultiReceipt) Equal(u *MultiReceipt) bool {
+	if len(v.Elements) != len(u.Elements) {
+		return false
+	}
+	for i := range v.Elements {
+		if !((v.Elements[i]).Equal(u.Elements[i])) {
+			return false
+		}
+	}
+	if !(bytes.Equal(v.End, u.End)) {
+		return false
+	}
+	if !(v.EndIndex == u.EndIndex) {
+		return false
+	}
+	if !(bytes.Equal(v.Anchor, u.Anchor)) {
+		return false
+	}
+	if len(v.Hashes) != len(u.Hashes) {
+		return false
+	}
+	for i := range v.Hashes {
+		if !(bytes.Equal(v.Hashes[i], u.Hashes[i])) {
+			return false
+		}
+	}
+	if !(v.HashAlgorithm == u.HashAlgorithm) {
+		return false
+	}
+
+	return true
+}
+
+func (v *MultiReceiptElement) Equal(u *MultiReceiptElement) bool {
+	if !(v.Index == u.Index) {
+		return false
+	}
+	if !(bytes.Equal(v.Hash, u.Hash)) {
+		return false
+	}
+
+	return true
+}
+
 func (v *Receipt) Equal(u *Receipt) bool {
 	if !(bytes.Equal(v.Start, u.Start)) {
 		return false
@@ -136,6 +240,9 @@ func (v *ReceiptEntry) Equal(u *ReceiptEntry) bool {
 	if !(bytes.Equal(v.Hash, u.Hash)) {
 		return false
 	}
+	if !(v.HashAlgorithm == u.HashAlgorithm) {
+		return false
+	}
 
 	return true
 }
@@ -177,6 +284,137 @@ func (v *ReceiptList) Equal(u *ReceiptList) bool {
 	return true
 }
 
+var fieldNames_MultiReceipt = []string{
+	1: "Elements",
+	2: "End",
+	3: "EndIndex",
+	4: "Anchor",
+	5: "Hashes",
+	6: "HashAlgorithm",
+}
+
+func (v *MultiReceipt) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(len(v.Elements) == 0) {
+		for _, v := range v.Elements {
+			writer.WriteValue(1, v.MarshalBinary)
+		}
+	}
+	if !(len(v.End) == 0) {
+		writer.WriteBytes(2, v.End)
+	}
+	if !(v.EndIndex == 0) {
+		writer.WriteInt(3, v.EndIndex)
+	}
+	if !(len(v.Anchor) == 0) {
+		writer.WriteBytes(4, v.Anchor)
+	}
+	if !(len(v.Hashes) == 0) {
+		for _, v := range v.Hashes {
+			writer.WriteBytes(5, v)
+		}
+	}
+	if !(v.HashAlgorithm == 0) {
+		writer.WriteEnum(6, v.HashAlgorithm)
+	}
+
+	_, _, err := writer.Reset(fieldNames_MultiReceipt)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *MultiReceipt) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Elements is missing")
+	} else if len(v.Elements) == 0 {
+		errs = append(errs, "field Elements is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field End is missing")
+	} else if len(v.End) == 0 {
+		errs = append(errs, "field End is not set")
+	}
+	if len(v.fieldsSet) > 3 && !v.fieldsSet[3] {
+		errs = append(errs, "field EndIndex is missing")
+	} else if v.EndIndex == 0 {
+		errs = append(errs, "field EndIndex is not set")
+	}
+	if len(v.fieldsSet) > 4 && !v.fieldsSet[4] {
+		errs = append(errs, "field Anchor is missing")
+	} else if len(v.Anchor) == 0 {
+		errs = append(errs, "field Anchor is not set")
+	}
+	if len(v.fieldsSet) > 5 && !v.fieldsSet[5] {
+		errs = append(errs, "field Hashes is missing")
+	} else if len(v.Hashes) == 0 {
+		errs = append(errs, "field Hashes is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+var fieldNames_MultiReceiptElement = []string{
+	1: "Index",
+	2: "Hash",
+}
+
+func (v *MultiReceiptElement) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := encoding.NewWriter(buffer)
+
+	if !(v.Index == 0) {
+		writer.WriteInt(1, v.Index)
+	}
+	if !(len(v.Hash) == 0) {
+		writer.WriteBytes(2, v.Hash)
+	}
+
+	_, _, err := writer.Reset(fieldNames_MultiReceiptElement)
+	if err != nil {
+		return nil, encoding.Error{E: err}
+	}
+	buffer.Write(v.extraData)
+	return buffer.Bytes(), nil
+}
+
+func (v *MultiReceiptElement) IsValid() error {
+	var errs []string
+
+	if len(v.fieldsSet) > 1 && !v.fieldsSet[1] {
+		errs = append(errs, "field Index is missing")
+	} else if v.Index == 0 {
+		errs = append(errs, "field Index is not set")
+	}
+	if len(v.fieldsSet) > 2 && !v.fieldsSet[2] {
+		errs = append(errs, "field Hash is missing")
+	} else if len(v.Hash) == 0 {
+		errs = append(errs, "field Hash is not set")
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(errs[0])
+	default:
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
 var fieldNames_Receipt = []string{
 	1: "Start",
 	2: "StartIndex",
@@ -266,6 +504,7 @@ func (v *Receipt) IsValid() error {
 var fieldNames_ReceiptEntry = []string{
 	1: "Right",
 	2: "Hash",
+	3: "HashAlgorithm",
 }
 
 func (v *ReceiptEntry) MarshalBinary() ([]byte, error) {
@@ -278,6 +517,9 @@ func (v *ReceiptEntry) MarshalBinary() ([]byte, error) {
 	if !(len(v.Hash) == 0) {
 		writer.WriteBytes(2, v.Hash)
 	}
+	if !(v.HashAlgorithm == 0) {
+		writer.WriteEnum(3, v.HashAlgorithm)
+	}
 
 	_, _, err := writer.Reset(fieldNames_ReceiptEntry)
 	if err != nil {
@@ -379,6 +621,78 @@ func (v *ReceiptList) IsValid() error {
 	}
 }
 
+func (v *MultiReceipt) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *MultiReceipt) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	for {
+		if x := new(MultiReceiptElement); reader.ReadValue(1, x.UnmarshalBinary) {
+			v.Elements = append(v.Elements, x)
+		} else {
+			break
+		}
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.End = x
+	}
+	if x, ok := reader.ReadInt(3); ok {
+		v.EndIndex = x
+	}
+	if x, ok := reader.ReadBytes(4); ok {
+		v.Anchor = x
+	}
+	for {
+		if x, ok := reader.ReadBytes(5); ok {
+			v.Hashes = append(v.Hashes, x)
+		} else {
+			break
+		}
+	}
+	if x := new(HashAlgorithm); reader.ReadEnum(6, x) {
+		v.HashAlgorithm = *x
+	}
+
+	seen, err := reader.Reset(fieldNames_MultiReceipt)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
+func (v *MultiReceiptElement) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
+}
+
+func (v *MultiReceiptElement) UnmarshalBinaryFrom(rd io.Reader) error {
+	reader := encoding.NewReader(rd)
+
+	if x, ok := reader.ReadInt(1); ok {
+		v.Index = x
+	}
+	if x, ok := reader.ReadBytes(2); ok {
+		v.Hash = x
+	}
+
+	seen, err := reader.Reset(fieldNames_MultiReceiptElement)
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	v.fieldsSet = seen
+	v.extraData, err = reader.ReadAll()
+	if err != nil {
+		return encoding.Error{E: err}
+	}
+	return nil
+}
+
 func (v *Receipt) UnmarshalBinary(data []byte) error {
 	return v.UnmarshalBinaryFrom(bytes.NewReader(data))
 }
@@ -434,6 +748,9 @@ func (v *ReceiptEntry) UnmarshalBinaryFrom(rd io.Reader) error {
 	if x, ok := reader.ReadBytes(2); ok {
 		v.Hash = x
 	}
+	if x := new(HashAlgorithm); reader.ReadEnum(3, x) {
+		v.HashAlgorithm = *x
+	}
 
 	seen, err := reader.Reset(fieldNames_ReceiptEntry)
 	if err != nil {
@@ -483,6 +800,37 @@ func (v *ReceiptList) UnmarshalBinaryFrom(rd io.Reader) error {
 	return nil
 }
 
+func (v *MultiReceipt) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Elements      encoding.JsonList[*MultiReceiptElement] `json:"elements,omitempty"`
+		End           *string                                 `json:"end,omitempty"`
+		EndIndex      int64                                   `json:"endIndex,omitempty"`
+		Anchor        *string                                 `json:"anchor,omitempty"`
+		Hashes        encoding.JsonList[*string]              `json:"hashes,omitempty"`
+		HashAlgorithm HashAlgorithm                           `json:"hashAlgorithm,omitempty"`
+	}{}
+	u.Elements = v.Elements
+	u.End = encoding.BytesToJSON(v.End)
+	u.EndIndex = v.EndIndex
+	u.Anchor = encoding.BytesToJSON(v.Anchor)
+	u.Hashes = make(encoding.JsonList[*string], len(v.Hashes))
+	for i, x := range v.Hashes {
+		u.Hashes[i] = encoding.BytesToJSON(x)
+	}
+	u.HashAlgorithm = v.HashAlgorithm
+	return json.Marshal(&u)
+}
+
+func (v *MultiReceiptElement) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Index int64   `json:"index,omitempty"`
+		Hash  *string `json:"hash,omitempty"`
+	}{}
+	u.Index = v.Index
+	u.Hash = encoding.BytesToJSON(v.Hash)
+	return json.Marshal(&u)
+}
+
 func (v *Receipt) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Start      *string                          `json:"start,omitempty"`
@@ -503,11 +851,13 @@ func (v *Receipt) MarshalJSON() ([]byte, error) {
 
 func (v *ReceiptEntry) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Right bool    `json:"right,omitempty"`
-		Hash  *string `json:"hash,omitempty"`
+		Right         bool          `json:"right,omitempty"`
+		Hash          *string       `json:"hash,omitempty"`
+		HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty"`
 	}{}
 	u.Right = v.Right
 	u.Hash = encoding.BytesToJSON(v.Hash)
+	u.HashAlgorithm = v.HashAlgorithm
 	return json.Marshal(&u)
 }
 
@@ -528,6 +878,70 @@ func (v *ReceiptList) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *MultiReceipt) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Elements      encoding.JsonList[*MultiReceiptElement] `json:"elements,omitempty"`
+		End           *string                                 `json:"end,omitempty"`
+		EndIndex      int64                                   `json:"endIndex,omitempty"`
+		Anchor        *string                                 `json:"anchor,omitempty"`
+		Hashes        encoding.JsonList[*string]              `json:"hashes,omitempty"`
+		HashAlgorithm HashAlgorithm                           `json:"hashAlgorithm,omitempty"`
+	}{}
+	u.Elements = v.Elements
+	u.End = encoding.BytesToJSON(v.End)
+	u.EndIndex = v.EndIndex
+	u.Anchor = encoding.BytesToJSON(v.Anchor)
+	u.Hashes = make(encoding.JsonList[*string], len(v.Hashes))
+	for i, x := range v.Hashes {
+		u.Hashes[i] = encoding.BytesToJSON(x)
+	}
+	u.HashAlgorithm = v.HashAlgorithm
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Elements = u.Elements
+	if x, err := encoding.BytesFromJSON(u.End); err != nil {
+		return fmt.Errorf("error decoding End: %w", err)
+	} else {
+		v.End = x
+	}
+	v.EndIndex = u.EndIndex
+	if x, err := encoding.BytesFromJSON(u.Anchor); err != nil {
+		return fmt.Errorf("error decoding Anchor: %w", err)
+	} else {
+		v.Anchor = x
+	}
+	v.Hashes = make([][]byte, len(u.Hashes))
+	for i, x := range u.Hashes {
+		if x, err := encoding.BytesFromJSON(x); err != nil {
+			return fmt.Errorf("error decoding Hashes: %w", err)
+		} else {
+			v.Hashes[i] = x
+		}
+	}
+	v.HashAlgorithm = u.HashAlgorithm
+	return nil
+}
+
+func (v *MultiReceiptElement) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Index int64   `json:"index,omitempty"`
+		Hash  *string `json:"hash,omitempty"`
+	}{}
+	u.Index = v.Index
+	u.Hash = encoding.BytesToJSON(v.Hash)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Index = u.Index
+	if x, err := encoding.BytesFromJSON(u.Hash); err != nil {
+		return fmt.Errorf("error decoding Hash: %w", err)
+	} else {
+		v.Hash = x
+	}
+	return nil
+}
+
 func (v *Receipt) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Start      *string                          `json:"start,omitempty"`
@@ -569,11 +983,13 @@ func (v *Receipt) UnmarshalJSON(data []byte) error {
 
 func (v *ReceiptEntry) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Right bool    `json:"right,omitempty"`
-		Hash  *string `json:"hash,omitempty"`
+		Right         bool          `json:"right,omitempty"`
+		Hash          *string       `json:"hash,omitempty"`
+		HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty"`
 	}{}
 	u.Right = v.Right
 	u.Hash = encoding.BytesToJSON(v.Hash)
+	u.HashAlgorithm = v.HashAlgorithm
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -583,6 +999,7 @@ func (v *ReceiptEntry) UnmarshalJSON(data []byte) error {
 	} else {
 		v.Hash = x
 	}
+	v.HashAlgorithm = u.HashAlgorithm
 	return nil
 }
 
@@ -0,0 +1,123 @@
+package managed
+
+import (
+	"bytes"
+
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage"
+)
+
+// Verify recomputes the Merkle state of the chain from its elements and
+// compares the result with the stored element indexes, mark point states, and
+// head. Verify calls report for every stored record that does not match and,
+// if visit is not nil, calls visit with the recomputed state after each
+// element.
+//
+// The elements are the source of truth. If fix is true, Verify overwrites
+// every record that does not match with the recomputed value. The caller must
+// commit the changes.
+func (m *MerkleManager) Verify(fix bool, visit func(*MerkleState) error, report func(error)) error {
+	head, err := m.Head().Get()
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "load head: %w", err)
+	}
+
+	state := new(MerkleState)
+	state.HashAlgorithm = head.HashAlgorithm
+	for i := int64(0); i < head.Count; i++ {
+		hash, err := m.Element(uint64(i)).Get()
+		if err != nil {
+			return errors.Format(errors.StatusUnknownError, "load element %d: %w", i, err)
+		}
+
+		// The index of a hash is the index of its first occurrence. Since
+		// elements are visited in order, an earlier occurrence has already
+		// been checked (and fixed).
+		index, err := m.ElementIndex(hash).Get()
+		switch {
+		case err == nil:
+			if index <= uint64(i) {
+				other, err := m.Element(index).Get()
+				if err == nil && bytes.Equal(other, hash) {
+					break
+				}
+			}
+			report(errors.Format(errors.StatusConflict, "element %d: index of %X is %d", i, hash[:4], index))
+			if fix {
+				err = m.ElementIndex(hash).Put(uint64(i))
+			}
+		case errors.Is(err, storage.ErrNotFound):
+			report(errors.Format(errors.StatusNotFound, "element %d: index of %X is missing", i, hash[:4]))
+			if fix {
+				err = m.ElementIndex(hash).Put(uint64(i))
+			} else {
+				err = nil
+			}
+		}
+		if err != nil {
+			return errors.Format(errors.StatusUnknownError, "element %d: index of %X: %w", i, hash[:4], err)
+		}
+
+		// Build the state the same way AddHash does
+		switch (state.Count + 1) & m.markMask {
+		case 0:
+			state.AddToMerkleTree(hash)
+			err = m.verifyMark(fix, uint64(i), state, report)
+			if err != nil {
+				return err
+			}
+		case 1:
+			state.HashList = state.HashList[:0]
+			fallthrough
+		default:
+			state.AddToMerkleTree(hash)
+		}
+
+		if visit == nil {
+			continue
+		}
+		err = visit(state)
+		if err != nil {
+			return errors.Wrap(errors.StatusUnknownError, err)
+		}
+	}
+
+	if head.Equal(state) {
+		return nil
+	}
+	report(errors.Format(errors.StatusConflict, "head does not match: want height %d and root %X, got height %d and root %X", state.Count, state.GetMDRoot(), head.Count, head.GetMDRoot()))
+	if !fix {
+		return nil
+	}
+	err = m.Head().Put(state)
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "store head: %w", err)
+	}
+	return nil
+}
+
+// verifyMark compares the state stored at a mark point with the recomputed
+// state.
+func (m *MerkleManager) verifyMark(fix bool, element uint64, state *MerkleState, report func(error)) error {
+	stored, err := m.States(element).Get()
+	switch {
+	case err == nil:
+		if stored.Equal(state) {
+			return nil
+		}
+		report(errors.Format(errors.StatusConflict, "mark point %d does not match", element))
+	case errors.Is(err, storage.ErrNotFound):
+		report(errors.Format(errors.StatusNotFound, "mark point %d is missing", element))
+	default:
+		return errors.Format(errors.StatusUnknownError, "load mark point %d: %w", element, err)
+	}
+
+	if !fix {
+		return nil
+	}
+	err = m.States(element).Put(state.Copy())
+	if err != nil {
+		return errors.Format(errors.StatusUnknownError, "store mark point %d: %w", element, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package managed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/smt/common"
+)
+
+func TestVerify(t *testing.T) {
+	var rh common.RandHash
+	store := begin()
+	m := testChain(store, 2)
+	var hashes [][]byte
+	for i := 0; i < 25; i++ {
+		hashes = append(hashes, rh.Next())
+		require.NoError(t, m.AddHash(hashes[i], false))
+	}
+	require.NoError(t, m.AddHash(hashes[3], false)) // Duplicate
+	require.NoError(t, m.Commit())
+
+	verify := func(fix bool) []error {
+		var errs []error
+		var count int64
+		require.NoError(t, m.Verify(fix, func(ms *MerkleState) error {
+			count++
+			require.Equal(t, count, ms.Count)
+			return nil
+		}, func(err error) { errs = append(errs, err) }))
+		require.Equal(t, int64(26), count)
+		return errs
+	}
+
+	// An intact chain is consistent
+	require.Empty(t, verify(false))
+
+	// Corrupt a mark point, an element index, and the head
+	bad, err := m.States(7).Get()
+	require.NoError(t, err)
+	bad = bad.Copy()
+	bad.Pending[0] = rh.Next()
+	require.NoError(t, m.States(7).Put(bad))
+	require.NoError(t, m.ElementIndex(hashes[3]).Put(25))
+	require.NoError(t, m.ElementIndex(hashes[10]).Put(11))
+	head, err := m.Head().Get()
+	require.NoError(t, err)
+	head = head.Copy()
+	head.HashList[0] = rh.Next()
+	require.NoError(t, m.Head().Put(head))
+	require.NoError(t, m.Commit())
+
+	// Verify reports every corrupt record
+	require.Len(t, verify(false), 4)
+
+	// Fixing the chain restores it
+	require.Len(t, verify(true), 4)
+	require.NoError(t, m.Commit())
+	require.Empty(t, verify(false))
+
+	index, err := m.GetElementIndex(hashes[3])
+	require.NoError(t, err)
+	require.Equal(t, int64(3), index)
+}
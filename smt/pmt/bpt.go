@@ -3,7 +3,9 @@ package pmt
 import (
 	"bytes"
 	"crypto/sha256"
+	"runtime"
 	"sort"
+	"sync"
 )
 
 // BPT
@@ -22,6 +24,7 @@ type BPT struct {
 	Power     int                   // Power
 	Mask      int                   // Mask used to detect Byte Block boundaries
 	Manager   *Manager              // Pointer to the manager for access to the database
+	Workers   int                   // Goroutines used by Update; zero uses GOMAXPROCS, one updates sequentially
 }
 
 // GetRoot
@@ -250,6 +253,43 @@ func (b *BPT) Insert(key, hash [32]byte) { //          The location of a value i
 	b.insertAtNode(b.GetRoot(), key, hash) //          in that location is the hash.  We start at byte 0, lowest
 } //                                                   significant bit. (which is masked with a 1)
 
+// Delete
+// Removes the value of the key from the BPT.  A node that is left with a single
+// value is replaced by that value, so the BPT is the same as if the key had
+// never been inserted.  Delete returns false if the key is not in the BPT.
+func (b *BPT) Delete(key [32]byte) bool {
+	node, entry, found := b.Get(b.GetRoot(), key) //   Find the node holding the value
+	if !found {
+		return false
+	}
+	*entry = nil  //                                   Remove the value
+	b.Dirty(node) //                                   and mark the node as dirty
+
+	for node.Parent != nil { //                        Collapse nodes up the tree, but never the root
+		var value *Value
+		switch {
+		case node.Left == nil:
+			value, _ = node.Right.(*Value)
+		case node.Right == nil:
+			value, _ = node.Left.(*Value)
+		}
+		if value == nil { //                           A node with two entries, or with a node, stays
+			break
+		}
+
+		parent := node.Parent //                       Replace the node with its value
+		if parent.Left == Entry(node) {
+			parent.Left = value
+		} else {
+			parent.Right = value
+		}
+		b.Clean(node)
+		b.Dirty(parent)
+		node = parent
+	}
+	return true
+}
+
 // GetHash
 // Makes the code just a bit more simple.  Checks for nils
 func GetHash(e Entry) []byte {
@@ -271,6 +311,8 @@ func GetNodeHash(n *BptNode) {
 		copy(n.Hash[:], L) //                       Just use L.  No hash required
 	case R != nil: //                               Just have R.  Again, just use R.
 		copy(n.Hash[:], R) //                       No Hash Required
+	case n.Height == 0: //                          The root is empty if every value was deleted
+		n.Hash = [32]byte{}
 	default: //                                     The fourth condition never happens, and bad if it does.
 		panic("dead nodes should not exist") //     This is a node without a child somewhere up the tree.
 	}
@@ -279,6 +321,36 @@ func GetNodeHash(n *BptNode) {
 // Update the Patricia Tree hashes with the values from the
 // updates since the last update
 func (b *BPT) Update() error {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var err error
+	if workers == 1 {
+		err = b.updateSequential()
+	} else {
+		err = b.updateParallel(workers)
+	}
+	if err != nil {
+		return err
+	}
+
+	if b.Manager != nil { //                                Root doesn't get flushed (has no parent)
+		b.Manager.Bpt.RootHash = b.Manager.Bpt.Root.Hash
+		err := b.Manager.FlushNode(b.GetRoot()) //          So flush it special
+		if err != nil {
+			return err
+		}
+	} //
+	b.RootHash = b.GetRoot().Hash //                        Set the root hash (so we don't have to load Root)
+	return nil
+}
+
+// updateSequential
+// Hash the dirty nodes one height at a time, from the highest height down to
+// the root
+func (b *BPT) updateSequential() error {
 	for len(b.DirtyMap) > 0 { //                            While the DirtyMap has nodes to process
 		dirtyList := b.GetDirtyList() //                    Get the Dirty List. Note sorted by height, High to low
 
@@ -298,17 +370,137 @@ func (b *BPT) Update() error {
 			b.Dirty(n.Parent) //                            The Parent is dirty cause it must consider this new state
 		}
 	}
-	if b.Manager != nil { //                                Root doesn't get flushed (has no parent)
-		b.Manager.Bpt.RootHash = b.Manager.Bpt.Root.Hash
-		err := b.Manager.FlushNode(b.GetRoot()) //          So flush it special
+	return nil
+}
+
+// updateParallel
+// Hash the dirty nodes using a pool of workers.  The dirty nodes and their
+// parents form a tree under the root.  The dirty subtrees that start at the
+// first Byte Block boundary below the root are independent of each other, so
+// the workers hash those, then the nodes above the boundary are hashed.  Each
+// node is hashed from the same children as updateSequential would use, so the
+// hashes are identical.
+//
+// The workers marshal the Byte Blocks of the subtrees, but the Byte Blocks are
+// written after all the hashes are computed, since the database is not safe
+// for concurrent use.
+func (b *BPT) updateParallel(workers int) error {
+	if len(b.DirtyMap) == 0 {
+		return nil
+	}
+
+	// Every parent of a dirty node must be hashed, so add them to the dirty
+	// map.  Collect the roots of the subtrees as we go.
+	split := b.Mask + 1
+	var subtrees []*BptNode
+	dirty := make([]*BptNode, 0, len(b.DirtyMap))
+	for _, n := range b.DirtyMap {
+		dirty = append(dirty, n)
+		if n.Height == split {
+			subtrees = append(subtrees, n)
+		}
+	}
+	for _, n := range dirty {
+		for p := n.Parent; p != nil && !b.IsDirty(p); p = p.Parent {
+			b.Dirty(p)
+			if p.Height == split {
+				subtrees = append(subtrees, p)
+			}
+		}
+	}
+
+	// Hash the subtrees.  The dirty map is only read by the workers.
+	borders := make([][]*BptNode, len(subtrees))
+	blocks := make([][][]byte, len(subtrees))
+	work := make(chan int)
+	var failed interface{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	if workers > len(subtrees) {
+		workers = len(subtrees)
+	}
+	marshal := func(border []*BptNode) (blocks [][]byte) { // Marshalling is safe, unlike writing to the database
+		if b.Manager == nil {
+			return nil
+		}
+		for _, n := range border {
+			blocks = append(blocks, b.MarshalByteBlock(n))
+		}
+		return blocks
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil { // Hand a panic over to the caller
+					mu.Lock()
+					failed = r
+					mu.Unlock()
+					for range work { // Drain the remaining subtrees
+					}
+				}
+			}()
+			for i := range work {
+				b.hashDirty(subtrees[i], -1, &borders[i])
+				blocks[i] = marshal(borders[i])
+			}
+		}()
+	}
+	for i := range subtrees {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	if failed != nil {
+		panic(failed)
+	}
+
+	// Hash the nodes above the subtrees
+	var top []*BptNode
+	b.hashDirty(b.GetRoot(), split, &top)
+	b.DirtyMap = make(map[[32]byte]*BptNode)
+
+	if b.Manager == nil {
+		return nil
+	}
+	for i, border := range borders {
+		for j, n := range border {
+			err := b.Manager.putByteBlock(n, blocks[i][j])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, n := range top {
+		err := b.Manager.FlushNode(n)
 		if err != nil {
 			return err
 		}
-	} //
-	b.RootHash = b.GetRoot().Hash //                        Set the root hash (so we don't have to load Root)
+	}
 	return nil
 }
 
+// hashDirty
+// Recursively hash the dirty children of a node, then the node itself,
+// stopping at nodes at the skip height.  Byte Block border nodes are added
+// to the border list so they can be flushed.
+func (b *BPT) hashDirty(n *BptNode, skip int, border *[]*BptNode) {
+	if n.Height == skip { //                                The subtree was hashed by a worker
+		return
+	}
+	if left, ok := n.Left.(*BptNode); ok && b.IsDirty(left) {
+		b.hashDirty(left, skip, border)
+	}
+	if right, ok := n.Right.(*BptNode); ok && b.IsDirty(right) {
+		b.hashDirty(right, skip, border)
+	}
+	GetNodeHash(n)
+	if n.Height&b.Mask == 0 {
+		*border = append(*border, n)
+	}
+}
+
 func (b *BPT) EnsureRootHash() {
 	n := b.GetRoot()      //                       Get the Root node
 	L := GetHash(n.Left)  //                       Get the Left Branch
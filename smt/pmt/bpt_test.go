@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gitlab.com/accumulatenetwork/accumulate/smt/common"
 	. "gitlab.com/accumulatenetwork/accumulate/smt/pmt"
+	"gitlab.com/accumulatenetwork/accumulate/smt/storage/memory"
 )
 
 const defaultNodeCnt = 1000
@@ -438,6 +439,74 @@ func BenchmarkBPT_Update2(b *testing.B) {
 	// BUG This benchmark does not depend on b.N
 }
 
+// TestUpdateParallel
+// The parallel update must produce the same hashes and Byte Blocks as the
+// sequential update
+func TestUpdateParallel(t *testing.T) {
+	sequential := memory.New(nil)
+	parallel := memory.New(nil)
+	bpt1 := NewBPTManager(sequential.Begin(true)).Bpt
+	bpt2 := NewBPTManager(parallel.Begin(true)).Bpt
+	bpt1.Workers = 1
+	bpt2.Workers = 8
+
+	var rh common.RandHash
+	var keys [][32]byte
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 1000; j++ { // Add new keys
+			key, hash := rh.NextA(), rh.NextA()
+			keys = append(keys, key)
+			bpt1.Insert(key, hash)
+			bpt2.Insert(key, hash)
+		}
+		for j := 0; j < 100; j++ { // And update some old ones
+			key, hash := keys[rand.Intn(len(keys))], rh.NextA()
+			bpt1.Insert(key, hash)
+			bpt2.Insert(key, hash)
+		}
+		require.NoError(t, bpt1.Update())
+		require.NoError(t, bpt2.Update())
+		require.Equal(t, bpt1.RootHash, bpt2.RootHash)
+		require.Empty(t, bpt2.DirtyMap)
+	}
+
+	require.NoError(t, bpt1.Manager.DBManager.Commit())
+	require.NoError(t, bpt2.Manager.DBManager.Commit())
+	require.Equal(t, sequential.Export(), parallel.Export())
+}
+
+// benchmarkUpdate
+// Measures updating a BPT of 100,000 entries after changing the given number
+// of entries, as at the end of a block
+func benchmarkUpdate(b *testing.B, workers, changes int) {
+	bpt := NewBPTManager(nil).Bpt
+	bpt.Workers = workers
+	var rh common.RandHash
+	var keys [][32]byte
+	for i := 0; i < 100000; i++ {
+		key := rh.NextA()
+		keys = append(keys, key)
+		bpt.Insert(key, rh.NextA())
+	}
+	require.NoError(b, bpt.Update())
+
+	rnd := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for j := 0; j < changes; j++ {
+			bpt.Insert(keys[rnd.Intn(len(keys))], rh.NextA())
+		}
+		b.StartTimer()
+		require.NoError(b, bpt.Update())
+	}
+}
+
+func BenchmarkBPT_UpdateSequential100(b *testing.B)   { benchmarkUpdate(b, 1, 100) }
+func BenchmarkBPT_UpdateParallel100(b *testing.B)     { benchmarkUpdate(b, 0, 100) }
+func BenchmarkBPT_UpdateSequential10000(b *testing.B) { benchmarkUpdate(b, 1, 10000) }
+func BenchmarkBPT_UpdateParallel10000(b *testing.B)   { benchmarkUpdate(b, 0, 10000) }
+
 func TestNodeKey(t *testing.T) {
 	r := common.RandHash{}
 	h := r.NextA()
@@ -455,3 +524,61 @@ func TestNodeKey(t *testing.T) {
 
 	}
 }
+
+func TestDelete(t *testing.T) {
+	var rh common.RandHash
+	var keys, hashes [][32]byte
+	for i := 0; i < 1000; i++ {
+		keys = append(keys, rh.NextA())
+		hashes = append(hashes, rh.NextA())
+	}
+
+	// Build the expected tree from every other key
+	expected := NewBPTManager(nil).Bpt
+	for i := 0; i < len(keys); i += 2 {
+		expected.Insert(keys[i], hashes[i])
+	}
+	require.NoError(t, expected.Update())
+
+	// Insert every key, persist the tree, then delete the odd keys from a
+	// reloaded tree
+	store := memory.NewDB()
+	batch := store.Begin(true)
+	bpt := NewBPTManager(batch)
+	for i := range keys {
+		bpt.InsertKV(keys[i], hashes[i])
+	}
+	require.NoError(t, bpt.Bpt.Update())
+	require.NoError(t, batch.Commit())
+
+	batch = store.Begin(true)
+	bpt = NewBPTManager(batch)
+	for i := 1; i < len(keys); i += 2 {
+		require.True(t, bpt.DeleteKV(keys[i]))
+	}
+	require.False(t, bpt.DeleteKV(keys[1]), "Deleting a missing key does nothing")
+	require.NoError(t, bpt.Bpt.Update())
+	require.NoError(t, batch.Commit())
+
+	// The tree is the same as if the keys had never been inserted
+	require.Equal(t, expected.RootHash, bpt.GetRootHash())
+	batch = store.Begin(false)
+	bpt = NewBPTManager(batch)
+	require.Equal(t, expected.RootHash, bpt.GetRootHash())
+	values, _ := bpt.Bpt.GetRange(FirstPossibleBptKey, len(keys))
+	require.Len(t, values, len(keys)/2)
+	for i := 0; i < len(keys); i += 2 {
+		receipt := bpt.Bpt.GetReceipt(keys[i])
+		require.NotNil(t, receipt)
+		require.True(t, receipt.Validate())
+	}
+
+	// Deleting every key empties the tree
+	batch = store.Begin(true)
+	bpt = NewBPTManager(batch)
+	for i := 0; i < len(keys); i += 2 {
+		require.True(t, bpt.DeleteKV(keys[i]))
+	}
+	require.NoError(t, bpt.Bpt.Update())
+	require.Equal(t, [32]byte{}, bpt.GetRootHash())
+}
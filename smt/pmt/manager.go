@@ -62,18 +62,24 @@ func (m *Manager) LoadNode(node *BptNode) {
 // Flushes the Byte Block to disk
 func (m *Manager) FlushNode(node *BptNode) error { //   Flush a Byte Block
 	if node.Height&7 == 0 {
-		data := m.Bpt.MarshalByteBlock(node)                       //
-		err := m.DBManager.Put(kBpt.Append(node.NodeKey[:]), data) //
+		return m.putByteBlock(node, m.Bpt.MarshalByteBlock(node))
+	}
+	return nil
+}
+
+// putByteBlock
+// Writes a marshalled Byte Block to disk
+func (m *Manager) putByteBlock(node *BptNode, data []byte) error {
+	err := m.DBManager.Put(kBpt.Append(node.NodeKey[:]), data) //
+	if err != nil {
+		return err
+	}
+	if node.Height == 0 {
+		data = m.Bpt.Marshal()
+		err = m.DBManager.Put(kBptRoot, data)
 		if err != nil {
 			return err
 		}
-		if node.Height == 0 {
-			data = m.Bpt.Marshal()
-			err = m.DBManager.Put(kBptRoot, data)
-			if err != nil {
-				return err
-			}
-		}
 	}
 	return nil
 }
@@ -83,3 +89,9 @@ func (m *Manager) FlushNode(node *BptNode) error { //   Flush a Byte Block
 func (m *Manager) InsertKV(key, value [32]byte) {
 	m.Bpt.Insert(key, value)
 }
+
+// DeleteKV
+// Delete the Key Value from the Bpt
+func (m *Manager) DeleteKV(key [32]byte) bool {
+	return m.Bpt.Delete(key)
+}
@@ -76,3 +76,98 @@ func TestMinorBlock_Expand(t *testing.T) {
 	}
 	require.NotEmpty(t, anchors)
 }
+
+func TestSimulate(t *testing.T) {
+	var timestamp uint64
+
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	lite := acctesting.GenerateKey(t.Name(), "Lite")
+	liteUrl := acctesting.AcmeLiteAddressStdPriv(lite)
+	batch := sim.PartitionFor(liteUrl).Database.Begin(true)
+	require.NoError(t, acctesting.CreateLiteTokenAccountWithCredits(batch, tmed25519.PrivKey(lite), AcmeFaucetAmount, 1e9))
+	require.NoError(t, batch.Commit())
+
+	alice := AccountUrl("alice")
+	aliceKey := acctesting.GenerateKey(t.Name(), alice)
+	keyHash := sha256.Sum256(aliceKey[32:])
+
+	// Simulate
+	req := new(api.ExecuteRequest)
+	req.Envelope = acctesting.NewTransaction().
+		WithPrincipal(liteUrl.RootIdentity()).
+		WithTimestampVar(&timestamp).
+		WithSigner(liteUrl.RootIdentity(), 1).
+		WithBody(&CreateIdentity{
+			Url:        alice,
+			KeyHash:    keyHash[:],
+			KeyBookUrl: alice.JoinPath("book"),
+		}).
+		Initiate(SignatureTypeLegacyED25519, lite).
+		Build()
+	res, err := sim.PartitionFor(liteUrl).API.Simulate(context.Background(), req)
+	require.NoError(t, err)
+
+	// Verify the fee was reported and a synthetic transaction was produced
+	require.NotZero(t, res.Fee)
+	require.NotEmpty(t, res.Produced)
+	require.NotEmpty(t, res.Changes)
+
+	// Verify the fee matches the credits debited from the signer
+	var debited uint64
+	for _, change := range res.Changes {
+		before, ok1 := change.Before.(*LiteIdentity)
+		after, ok2 := change.After.(*LiteIdentity)
+		if ok1 && ok2 {
+			debited = before.CreditBalance - after.CreditBalance
+		}
+	}
+	require.Equal(t, res.Fee.AsUInt64(), debited)
+
+	// Verify nothing was committed
+	_, err = sim.PartitionFor(liteUrl).API.QueryTx(context.Background(), &api.TxnQuery{Txid: req.Envelope.Transaction[0].GetHash()})
+	require.Error(t, err)
+}
+
+func TestSimulate_Sponsored(t *testing.T) {
+	var timestamp uint64
+	alice := AccountUrl("alice")
+	bob := AccountUrl("bob")
+	aliceKey := acctesting.GenerateKey(alice)
+	bobKey := acctesting.GenerateKey(bob)
+
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	sim.SetRouteFor(alice, "BVN1")
+	sim.SetRouteFor(bob, "BVN1")
+	sim.CreateIdentity(alice, aliceKey[32:])
+	sim.CreateIdentity(bob, bobKey[32:])
+	updateAccount(sim, alice.JoinPath("book", "1"), func(p *KeyPage) { p.CreditBalance = 1e9 })
+	updateAccount(sim, bob.JoinPath("book", "1"), func(p *KeyPage) { p.CreditBalance = 1e9 })
+
+	// Simulate a transaction Bob sponsors, which reads whether the sponsor
+	// has paid when computing the fee
+	req := new(api.ExecuteRequest)
+	req.Envelope = acctesting.NewTransaction().
+		WithPrincipal(alice).
+		WithSponsor(bob.JoinPath("book", "1")).
+		WithTimestampVar(&timestamp).
+		WithSigner(alice.JoinPath("book", "1"), 1).
+		WithBody(&CreateTokenAccount{Url: alice.JoinPath("tokens"), TokenUrl: AcmeUrl()}).
+		Initiate(SignatureTypeED25519, aliceKey).
+		WithSigner(bob.JoinPath("book", "1"), 1).
+		Sign(SignatureTypeED25519, bobKey).
+		Build()
+	res, err := sim.PartitionFor(alice).API.Simulate(context.Background(), req)
+	require.NoError(t, err)
+	for _, r := range res.Results {
+		require.False(t, r.Failed(), "%v", r.Error)
+	}
+
+	// The sponsor pays the transaction fee
+	require.Equal(t, FeeCreateAccount+FeeSignature+FeeSignature, res.Fee)
+}
@@ -0,0 +1,258 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/api/v2/query"
+	"gitlab.com/accumulatenetwork/accumulate/internal/block/simulator"
+	"gitlab.com/accumulatenetwork/accumulate/internal/core"
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	acctesting "gitlab.com/accumulatenetwork/accumulate/internal/testing"
+	. "gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// recordingAnchorSink records the anchors it is given. It verifies that the
+// block that recorded an anchor has been committed before it is given the
+// anchor.
+type recordingAnchorSink struct {
+	t       *testing.T
+	db      database.Beginner
+	mu      sync.Mutex
+	anchors []*ExternalAnchor
+}
+
+func (s *recordingAnchorSink) Anchor(_ context.Context, anchor *ExternalAnchor) (string, error) {
+	batch := s.db.Begin(false)
+	defer batch.Discard()
+	recorded, err := batch.SystemData(Directory).ExternalAnchor(anchor.MajorBlockIndex).Get()
+	if err != nil {
+		s.t.Errorf("Anchor of major block %d was given to the sink before it was committed: %v", anchor.MajorBlockIndex, err)
+		return "", err
+	}
+	if !recorded.Equal(anchor) {
+		s.t.Errorf("Anchor of major block %d does not match the recorded anchor", anchor.MajorBlockIndex)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.anchors = append(s.anchors, anchor.Copy())
+	return fmt.Sprintf("test://anchor/%d", anchor.MajorBlockIndex), nil
+}
+
+func (s *recordingAnchorSink) Anchors() []*ExternalAnchor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.anchors
+}
+
+// setupExternalAnchors initializes a simulator and executes blocks until the
+// first major block has been opened and, if external anchors are enabled, its
+// anchor recorded.
+func setupExternalAnchors(t *testing.T, enable bool, beforeMajorBlock func(*simulator.Simulator)) *simulator.Simulator {
+	acctesting.SkipLong(t)
+
+	globals := new(core.GlobalValues)
+	globals.Globals = new(NetworkGlobals)
+	globals.Globals.MajorBlockSchedule = "*/5 * * * *" // Every 5 minutes (300 minor blocks)
+	globals.Globals.EnableExternalAnchors = enable
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesisWith(globals)
+
+	dn := sim.Partition(Directory)
+	nextMajorBlock := dn.Executor.MajorBlockScheduler.GetNextMajorBlockTime(simulator.GenesisTime)
+	count := int(nextMajorBlock.Sub(simulator.GenesisTime) / time.Second)
+	sim.ExecuteBlocks(count - GenesisBlock - 1)
+	if beforeMajorBlock != nil {
+		beforeMajorBlock(sim)
+	}
+
+	// Open the major block. The anchor is recorded when the block is
+	// finalized, at the start of the next block.
+	sim.ExecuteBlocks(2)
+	return sim
+}
+
+func getExternalAnchor(t *testing.T, sim *simulator.Simulator, major uint64) *ExternalAnchor {
+	t.Helper()
+	var anchor *ExternalAnchor
+	dn := sim.Partition(Directory)
+	require.NoError(t, dn.Database.View(func(batch *database.Batch) error {
+		var err error
+		anchor, err = batch.SystemData(Directory).ExternalAnchor(major).Get()
+		return err
+	}))
+	return anchor
+}
+
+func TestExternalAnchor(t *testing.T) {
+	var sink *recordingAnchorSink
+	sim := setupExternalAnchors(t, true, func(sim *simulator.Simulator) {
+		dn := sim.Partition(Directory)
+		sink = &recordingAnchorSink{t: t, db: dn.Database}
+		dn.Executor.AnchorSink = sink
+	})
+	dn := sim.Partition(Directory)
+	bvn1 := sim.Partition(sim.Partitions[2].Id)
+
+	// The anchor of the major block is recorded and given to the sink
+	recorded := getExternalAnchor(t, sim, 1)
+	require.Empty(t, recorded.Reference)
+	require.Len(t, sink.Anchors(), 1)
+	require.True(t, recorded.Equal(sink.Anchors()[0]))
+
+	// The node initiates a record of the reference, which needs the signature
+	// of another operator
+	sim.ExecuteBlocks(2)
+	var pending []*Transaction
+	require.NoError(t, dn.Database.View(func(batch *database.Batch) error {
+		ids, err := batch.Account(dn.Executor.Describe.NodeUrl(ExternalAnchors)).Pending().Get()
+		require.NoError(t, err)
+		for _, id := range ids {
+			hash := id.Hash()
+			state, err := batch.Transaction(hash[:]).Main().Get()
+			require.NoError(t, err)
+			pending = append(pending, state.Transaction)
+		}
+		return nil
+	}))
+	require.Len(t, pending, 1)
+	require.Empty(t, getExternalAnchor(t, sim, 1).Reference)
+
+	page := simulator.GetAccount[*KeyPage](sim, dn.Executor.Describe.OperatorsPage())
+	sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithTransaction(pending[0]).
+			WithSigner(page.Url, page.Version).
+			WithCurrentTimestamp().
+			Sign(SignatureTypeED25519, bvn1.Executor.Key).
+			Build(),
+	)...)
+
+	// The reference is recorded
+	recorded = getExternalAnchor(t, sim, 1)
+	require.Equal(t, "test://anchor/1", recorded.Reference)
+	require.Len(t, sink.Anchors(), 1, "The anchor is only given to the sink once")
+
+	// The anchor can be queried by its root
+	u := dn.Executor.Describe.NodeUrl(ExternalAnchors).WithFragment(fmt.Sprintf("external-anchor/%x", recorded.RootChainAnchor))
+	resp := simulator.QueryUrl[*externalAnchorResponse](sim, u, false)
+	require.Equal(t, "externalAnchor", resp.Type)
+	require.True(t, recorded.Equal(resp.Data.Anchor))
+	require.Equal(t, pending[0].GetHash(), resp.Data.Transaction[:])
+}
+
+type externalAnchorResponse struct {
+	Type string                        `json:"type"`
+	Data *query.ResponseExternalAnchor `json:"data"`
+}
+
+func TestExternalAnchor_CoSign(t *testing.T) {
+	sim := setupExternalAnchors(t, true, nil)
+	dn := sim.Partition(Directory)
+	bvn1 := sim.Partition(sim.Partitions[2].Id)
+
+	// Another operator initiates a record of the reference
+	anchor := getExternalAnchor(t, sim, 1)
+	anchor.Reference = "test://anchor/1"
+	data, err := anchor.MarshalBinary()
+	require.NoError(t, err)
+
+	page := simulator.GetAccount[*KeyPage](sim, dn.Executor.Describe.OperatorsPage())
+	envs := sim.MustSubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithPrincipal(dn.Executor.Describe.NodeUrl(ExternalAnchors)).
+			WithSigner(page.Url, page.Version).
+			WithCurrentTimestamp().
+			WithBody(&WriteData{Entry: &AccumulateDataEntry{Data: [][]byte{data}}}).
+			Initiate(SignatureTypeED25519, bvn1.Executor.Key).
+			Build(),
+	)
+
+	// The node signs it once it sees it
+	sim.WaitForTransactions(delivered, envs...)
+	require.Equal(t, anchor.Reference, getExternalAnchor(t, sim, 1).Reference)
+}
+
+func TestExternalAnchor_Mismatch(t *testing.T) {
+	sim := setupExternalAnchors(t, true, nil)
+	dn := sim.Partition(Directory)
+	bvn1 := sim.Partition(sim.Partitions[2].Id)
+
+	// Record a reference for an anchor that does not match
+	anchor := getExternalAnchor(t, sim, 1)
+	anchor.RootChainAnchor[0]++
+	anchor.Reference = "test://anchor/1"
+	data, err := anchor.MarshalBinary()
+	require.NoError(t, err)
+
+	page := simulator.GetAccount[*KeyPage](sim, dn.Executor.Describe.OperatorsPage())
+	st, err := sim.SubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithPrincipal(dn.Executor.Describe.NodeUrl(ExternalAnchors)).
+			WithSigner(page.Url, page.Version).
+			WithCurrentTimestamp().
+			WithBody(&WriteData{Entry: &AccumulateDataEntry{Data: [][]byte{data}}}).
+			Initiate(SignatureTypeED25519, dn.Executor.Key).
+			Sign(SignatureTypeED25519, bvn1.Executor.Key).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	// The record fails and no reference is recorded
+	require.Len(t, st, 1)
+	require.NotNil(t, st[0].Error)
+	require.Equal(t, errors.StatusBadRequest, st[0].Code)
+	require.Contains(t, st[0].Error.Message, "does not match the anchor of major block 1")
+	require.Empty(t, getExternalAnchor(t, sim, 1).Reference)
+}
+
+func TestExternalAnchor_Migration(t *testing.T) {
+	// Remove the external anchors account, as if the network was created
+	// before it existed
+	sim := setupExternalAnchors(t, true, func(sim *simulator.Simulator) {
+		dn := sim.Partition(Directory)
+		require.NoError(t, dn.Database.Update(func(batch *database.Batch) error {
+			return batch.DeleteAccountState_TESTONLY(dn.Executor.Describe.NodeUrl(ExternalAnchors))
+		}))
+	})
+
+	// The account is created when the major block is recorded
+	dn := sim.Partition(Directory)
+	account := simulator.GetAccount[*DataAccount](sim, dn.Executor.Describe.NodeUrl(ExternalAnchors))
+	require.Len(t, account.Authorities, 1)
+	require.True(t, dn.Executor.Describe.Operators().Equal(account.Authorities[0].Url))
+	require.NotNil(t, getExternalAnchor(t, sim, 1))
+}
+
+func TestExternalAnchor_Disabled(t *testing.T) {
+	// Remove the external anchors account, as if the network was created
+	// before it existed
+	var sink *recordingAnchorSink
+	sim := setupExternalAnchors(t, false, func(sim *simulator.Simulator) {
+		dn := sim.Partition(Directory)
+		sink = &recordingAnchorSink{t: t, db: dn.Database}
+		dn.Executor.AnchorSink = sink
+		require.NoError(t, dn.Database.Update(func(batch *database.Batch) error {
+			return batch.DeleteAccountState_TESTONLY(dn.Executor.Describe.NodeUrl(ExternalAnchors))
+		}))
+	})
+	sim.ExecuteBlocks(2)
+
+	// Until the network enables external anchors, the major block is not
+	// recorded or anchored and the account is not created
+	dn := sim.Partition(Directory)
+	require.NoError(t, dn.Database.View(func(batch *database.Batch) error {
+		_, err := batch.SystemData(Directory).ExternalAnchor(1).Get()
+		require.ErrorIs(t, err, errors.StatusNotFound)
+		_, err = batch.Account(dn.Executor.Describe.NodeUrl(ExternalAnchors)).GetState()
+		require.ErrorIs(t, err, errors.StatusNotFound)
+		return nil
+	}))
+	require.Empty(t, sink.Anchors())
+}
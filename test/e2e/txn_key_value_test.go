@@ -0,0 +1,162 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/block/simulator"
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/errors"
+	acctesting "gitlab.com/accumulatenetwork/accumulate/internal/testing"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	. "gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func setupKeyValue(t *testing.T, timestamp *uint64) (*simulator.Simulator, *url.URL, []byte) {
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	// Setup accounts
+	alice := url.MustParse("alice")
+	aliceKey := acctesting.GenerateKey(alice)
+	sim.CreateIdentity(alice, aliceKey[32:])
+	updateAccount(sim, alice.JoinPath("book", "1"), func(page *KeyPage) { page.CreditBalance = 1e9 })
+
+	// Create the key/value account
+	sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithPrincipal(alice).
+			WithSigner(alice.JoinPath("book", "1"), 1).
+			WithTimestampVar(timestamp).
+			WithBody(&CreateKeyValueAccount{Url: alice.JoinPath("kv")}).
+			Initiate(SignatureTypeED25519, aliceKey).
+			Build(),
+	)...)
+
+	return sim, alice, aliceKey
+}
+
+func getKeyValue(t *testing.T, sim *simulator.Simulator, account *url.URL, key string) ([]byte, error) {
+	t.Helper()
+	var value []byte
+	err := sim.PartitionFor(account).Database.View(func(batch *database.Batch) error {
+		var err error
+		value, err = batch.Account(account).GetKeyValue([]byte(key))
+		return err
+	})
+	return value, err
+}
+
+func countKeyValues(t *testing.T, sim *simulator.Simulator, account *url.URL) int {
+	t.Helper()
+	var count int
+	require.NoError(t, sim.PartitionFor(account).Database.View(func(batch *database.Batch) error {
+		return batch.Account(account).VisitKeyValues(func([32]byte, []byte) error { count++; return nil })
+	}))
+	return count
+}
+
+func TestCreateKeyValueAccount(t *testing.T) {
+	var timestamp uint64
+	sim, alice, _ := setupKeyValue(t, &timestamp)
+
+	// Check the result
+	account := simulator.GetAccount[*KeyValueAccount](sim, alice.JoinPath("kv"))
+	require.Len(t, account.Authorities, 1)
+	require.True(t, alice.JoinPath("book").Equal(account.Authorities[0].Url))
+	require.Zero(t, countKeyValues(t, sim, alice.JoinPath("kv")))
+}
+
+func TestSetKeyValue(t *testing.T) {
+	var timestamp uint64
+	sim, alice, aliceKey := setupKeyValue(t, &timestamp)
+	kv := alice.JoinPath("kv")
+
+	// Set values
+	for _, s := range []string{"foo", "bar"} {
+		sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(
+			acctesting.NewTransaction().
+				WithPrincipal(kv).
+				WithSigner(alice.JoinPath("book", "1"), 1).
+				WithTimestampVar(&timestamp).
+				WithBody(&SetKeyValue{Key: []byte(s), Value: []byte(s + "-value")}).
+				Initiate(SignatureTypeED25519, aliceKey).
+				Build(),
+		)...)
+	}
+
+	// Check the result
+	value, err := getKeyValue(t, sim, kv, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "foo-value", string(value))
+	require.Equal(t, 2, countKeyValues(t, sim, kv))
+
+	// The value can be proven to the BPT root
+	require.NoError(t, sim.PartitionFor(kv).Database.View(func(batch *database.Batch) error {
+		receipt, err := batch.Account(kv).KeyValueReceipt([]byte("foo"))
+		require.NoError(t, err)
+		require.True(t, receipt.Validate())
+		require.Equal(t, batch.BptRoot(), []byte(receipt.Anchor))
+		return nil
+	}))
+
+	// Cannot set a value on an account that is not a key/value account
+	_, err = sim.SubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithPrincipal(alice).
+			WithSigner(alice.JoinPath("book", "1"), 1).
+			WithTimestampVar(&timestamp).
+			WithBody(&SetKeyValue{Key: []byte("foo"), Value: []byte("bar")}).
+			Initiate(SignatureTypeED25519, aliceKey).
+			Build(),
+	)
+	require.ErrorIs(t, err, errors.StatusBadRequest)
+}
+
+func TestDeleteKeyValue(t *testing.T) {
+	var timestamp uint64
+	sim, alice, aliceKey := setupKeyValue(t, &timestamp)
+	kv := alice.JoinPath("kv")
+
+	// Set values
+	for _, s := range []string{"foo", "bar"} {
+		sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(
+			acctesting.NewTransaction().
+				WithPrincipal(kv).
+				WithSigner(alice.JoinPath("book", "1"), 1).
+				WithTimestampVar(&timestamp).
+				WithBody(&SetKeyValue{Key: []byte(s), Value: []byte(s + "-value")}).
+				Initiate(SignatureTypeED25519, aliceKey).
+				Build(),
+		)...)
+	}
+
+	// Delete a value
+	sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithPrincipal(kv).
+			WithSigner(alice.JoinPath("book", "1"), 1).
+			WithTimestampVar(&timestamp).
+			WithBody(&DeleteKeyValue{Key: []byte("foo")}).
+			Initiate(SignatureTypeED25519, aliceKey).
+			Build(),
+	)...)
+
+	// Check the result
+	_, err := getKeyValue(t, sim, kv, "foo")
+	require.ErrorIs(t, err, errors.StatusNotFound)
+	require.Equal(t, 1, countKeyValues(t, sim, kv))
+
+	// Cannot delete a value that does not exist
+	_, err = sim.SubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithPrincipal(kv).
+			WithSigner(alice.JoinPath("book", "1"), 1).
+			WithTimestampVar(&timestamp).
+			WithBody(&DeleteKeyValue{Key: []byte("foo")}).
+			Initiate(SignatureTypeED25519, aliceKey).
+			Build(),
+	)
+	require.ErrorIs(t, err, errors.StatusNotFound)
+}
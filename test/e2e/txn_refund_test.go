@@ -3,6 +3,7 @@ package e2e
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -125,6 +126,12 @@ func TestRefundFailedUserTransaction_Local(t *testing.T) {
 	require.NotNil(t, refund.Transaction)
 	require.IsType(t, (*SyntheticDepositCredits)(nil), refund.Transaction.Body)
 	require.Equal(t, alice.JoinPath("book", "1").ShortString(), refund.Transaction.Header.Principal.ShortString())
+	require.True(t, refund.Transaction.Body.(*SyntheticDepositCredits).IsRefund)
+
+	// The refund is linked to the transaction
+	txnStatus := simulator.GetTxnState[*TransactionStatus](sim, status[0].TxID, (*database.Transaction).Status)
+	require.Len(t, txnStatus.Refunds, 1)
+	require.Equal(t, produced[0].String(), txnStatus.Refunds[0].String())
 }
 
 func TestRefundFailedUserTransaction_Remote(t *testing.T) {
@@ -173,4 +180,100 @@ func TestRefundFailedUserTransaction_Remote(t *testing.T) {
 	require.NotNil(t, refund.Transaction)
 	require.IsType(t, (*SyntheticDepositCredits)(nil), refund.Transaction.Body)
 	require.Equal(t, alice.JoinPath("book", "1").ShortString(), refund.Transaction.Header.Principal.ShortString())
+	require.True(t, refund.Transaction.Body.(*SyntheticDepositCredits).IsRefund)
+
+	// The refund is linked to the transaction
+	txnStatus := simulator.GetTxnState[*TransactionStatus](sim, status[0].TxID, (*database.Transaction).Status)
+	require.Len(t, txnStatus.Refunds, 1)
+	require.Equal(t, produced[0].String(), txnStatus.Refunds[0].String())
+}
+
+func TestRefundLateSignature(t *testing.T) {
+	var timestamp uint64
+	alice := AccountUrl("alice")
+	// A long name makes the signatures of Bob's keys cost more than the base
+	// signature fee, so a late signature is refunded the difference
+	bob := AccountUrl("bob" + strings.Repeat("b", 120))
+	bobKey1, bobKey2, bobKey3 := acctesting.GenerateKey(bob, 1), acctesting.GenerateKey(bob, 2), acctesting.GenerateKey(bob, 3)
+	recipient := acctesting.AcmeLiteAddressStdPriv(acctesting.GenerateKey("recipient"))
+
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	// Alice's token account is governed by Bob's book, which is on another
+	// partition and needs one of its three keys
+	sim.SetRouteFor(alice, "BVN1")
+	sim.SetRouteFor(bob, "BVN2")
+	sim.CreateIdentity(alice, acctesting.GenerateKey(alice)[32:])
+	sim.CreateIdentity(bob, bobKey1[32:], bobKey2[32:], bobKey3[32:])
+	updateAccount(sim, bob.JoinPath("book", "1"), func(p *KeyPage) { p.CreditBalance = 1e9 })
+	sim.CreateAccount(&TokenAccount{
+		Url:         alice.JoinPath("tokens"),
+		TokenUrl:    AcmeUrl(),
+		Balance:     *big.NewInt(1e12),
+		AccountAuth: AccountAuth{Authorities: []AuthorityEntry{{Url: bob.JoinPath("book")}}},
+	})
+
+	// The first key's signature executes the transaction
+	env := acctesting.NewTransaction().
+		WithPrincipal(alice.JoinPath("tokens")).
+		WithSigner(bob.JoinPath("book", "1"), 1).
+		WithTimestampVar(&timestamp).
+		WithBody(&SendTokens{To: []*TokenRecipient{{Url: recipient, Amount: *big.NewInt(1)}}}).
+		Initiate(SignatureTypeED25519, bobKey1).
+		Build()
+	txn := env.Transaction[0]
+	sim.MustSubmitAndExecuteBlock(env)
+	sim.WaitForTransactionFlow(delivered, txn.GetHash())
+	sim.ExecuteBlocks(10)
+	paid := 1e9 - simulator.GetAccount[*KeyPage](sim, bob.JoinPath("book", "1")).CreditBalance
+
+	// Sign with the other keys after the transaction has executed
+	getStatus := func() *TransactionStatus {
+		var status *TransactionStatus
+		require.NoError(t, sim.PartitionFor(alice).Database.View(func(batch *database.Batch) error {
+			var err error
+			status, err = batch.Transaction(txn.GetHash()).GetStatus()
+			return err
+		}))
+		return status
+	}
+	for i, key := range [][]byte{bobKey2, bobKey3} {
+		env := acctesting.NewTransaction().
+			WithTransaction(txn).
+			WithSigner(bob.JoinPath("book", "1"), 1).
+			WithTimestampVar(&timestamp).
+			Sign(SignatureTypeED25519, key).
+			Build()
+		fee, err := new(FeeSchedule).ComputeSignatureFee(env.Signatures[0])
+		require.NoError(t, err)
+		require.Greater(t, fee, FeeSignature)
+		sim.MustSubmitAndExecuteBlock(env)
+		sim.ExecuteBlocks(10)
+
+		// The refund is linked to the transaction
+		status := getStatus()
+		require.Len(t, status.Refunds, i+1)
+		h := status.Refunds[i].Hash()
+		refund, _, _ := sim.WaitForTransaction(delivered, h[:], 50)
+		deposit, ok := refund.Body.(*SyntheticDepositCredits)
+		require.True(t, ok)
+		require.True(t, deposit.IsRefund)
+		require.Equal(t, int(fee-FeeSignature), int(deposit.Amount))
+		require.True(t, bob.JoinPath("book", "1").Equal(refund.Header.Principal))
+
+		// The signer pays the fee, but everything but the base signature
+		// fee is refunded, once
+		paid += FeeSignature.AsUInt64()
+		require.Equal(t, int(1e9-paid), int(simulator.GetAccount[*KeyPage](sim, bob.JoinPath("book", "1")).CreditBalance))
+	}
+
+	// Each late signature is recorded as refunded
+	require.NoError(t, sim.PartitionFor(alice).Database.View(func(batch *database.Batch) error {
+		refunded, err := batch.Transaction(txn.GetHash()).RefundedSignatures().Get()
+		require.NoError(t, err)
+		require.Len(t, refunded, 2)
+		return nil
+	}))
 }
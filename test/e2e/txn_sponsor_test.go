@@ -0,0 +1,143 @@
+package e2e
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/block/simulator"
+	acctesting "gitlab.com/accumulatenetwork/accumulate/internal/testing"
+	. "gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func TestSponsoredTransaction(t *testing.T) {
+	var timestamp uint64
+	alice := AccountUrl("alice")
+	bob := AccountUrl("bob")
+	aliceKey := acctesting.GenerateKey(alice)
+	bobKey := acctesting.GenerateKey(bob)
+
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	sim.SetRouteFor(alice, "BVN1")
+	sim.SetRouteFor(bob, "BVN2")
+	sim.CreateIdentity(alice, aliceKey[32:])
+	sim.CreateIdentity(bob, bobKey[32:])
+	updateAccount(sim, alice.JoinPath("book", "1"), func(p *KeyPage) { p.CreditBalance = 1e9 })
+	updateAccount(sim, bob.JoinPath("book", "1"), func(p *KeyPage) { p.CreditBalance = 1e9 })
+
+	// Bob sponsors the transaction
+	env := acctesting.NewTransaction().
+		WithPrincipal(alice).
+		WithSponsor(bob.JoinPath("book", "1")).
+		WithTimestampVar(&timestamp).
+		WithSigner(alice.JoinPath("book", "1"), 1).
+		WithBody(&CreateTokenAccount{Url: alice.JoinPath("tokens"), TokenUrl: AcmeUrl()}).
+		Initiate(SignatureTypeED25519, aliceKey).
+		Build()
+	sim.MustSubmitAndExecuteBlock(env)
+
+	// The transaction is pending until the sponsor signs
+	sim.WaitForTransactionFlow(pending, env.Transaction[0].GetHash())
+
+	env = acctesting.NewTransaction().
+		WithTransaction(env.Transaction[0]).
+		WithSigner(bob.JoinPath("book", "1"), 1).
+		WithTimestampVar(&timestamp).
+		Sign(SignatureTypeED25519, bobKey).
+		Build()
+	sim.MustSubmitAndExecuteBlock(env)
+	sim.WaitForTransactionFlow(delivered, env.Transaction[0].GetHash())
+
+	// Verify the account was created, Bob paid the fee, and Alice's signature
+	// fee was refunded
+	_ = simulator.GetAccount[*TokenAccount](sim, alice.JoinPath("tokens"))
+	require.Equal(t, uint64(1e9), simulator.GetAccount[*KeyPage](sim, alice.JoinPath("book", "1")).CreditBalance)
+	require.Equal(t, uint64(1e9-FeeCreateAccount-FeeSignature), simulator.GetAccount[*KeyPage](sim, bob.JoinPath("book", "1")).CreditBalance)
+}
+
+func TestSponsoredTransaction_Unsigned(t *testing.T) {
+	var timestamp uint64
+	alice := AccountUrl("alice")
+	bob := AccountUrl("bob")
+	aliceKey := acctesting.GenerateKey(alice)
+	bobKey := acctesting.GenerateKey(bob)
+
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	sim.SetRouteFor(alice, "BVN1")
+	sim.SetRouteFor(bob, "BVN2")
+	sim.CreateIdentity(alice, aliceKey[32:])
+	sim.CreateIdentity(bob, bobKey[32:])
+	updateAccount(sim, alice.JoinPath("book", "1"), func(p *KeyPage) { p.CreditBalance = 1e9 })
+
+	// Alice pays for her signature even if Bob never signs
+	env := acctesting.NewTransaction().
+		WithPrincipal(alice).
+		WithSponsor(bob.JoinPath("book", "1")).
+		WithTimestampVar(&timestamp).
+		WithSigner(alice.JoinPath("book", "1"), 1).
+		WithBody(&CreateTokenAccount{Url: alice.JoinPath("tokens"), TokenUrl: AcmeUrl()}).
+		Initiate(SignatureTypeED25519, aliceKey).
+		Build()
+	sim.MustSubmitAndExecuteBlock(env)
+	sim.WaitForTransactionFlow(pending, env.Transaction[0].GetHash())
+
+	require.Equal(t, uint64(1e9-FeeSignature), simulator.GetAccount[*KeyPage](sim, alice.JoinPath("book", "1")).CreditBalance)
+}
+
+func TestSponsoredTransaction_MultipleKeys(t *testing.T) {
+	var timestamp uint64
+	alice := AccountUrl("alice")
+	bob := AccountUrl("bob")
+	aliceKey := acctesting.GenerateKey(alice)
+	bobKey1 := acctesting.GenerateKey(bob, 1)
+	bobKey2 := acctesting.GenerateKey(bob, 2)
+
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	sim.SetRouteFor(alice, "BVN1")
+	sim.SetRouteFor(bob, "BVN2")
+	sim.CreateIdentity(alice, aliceKey[32:])
+	sim.CreateIdentity(bob, bobKey1[32:])
+	updateAccount(sim, alice.JoinPath("book", "1"), func(p *KeyPage) { p.CreditBalance = 1e9 })
+	updateAccount(sim, bob.JoinPath("book", "1"), func(p *KeyPage) {
+		hash := sha256.Sum256(bobKey2[32:])
+		p.AddKeySpec(&KeySpec{PublicKeyHash: hash[:]})
+		p.AcceptThreshold = 2
+		p.CreditBalance = 1e9
+	})
+
+	env := acctesting.NewTransaction().
+		WithPrincipal(alice).
+		WithSponsor(bob.JoinPath("book", "1")).
+		WithTimestampVar(&timestamp).
+		WithSigner(alice.JoinPath("book", "1"), 1).
+		WithBody(&CreateTokenAccount{Url: alice.JoinPath("tokens"), TokenUrl: AcmeUrl()}).
+		Initiate(SignatureTypeED25519, aliceKey).
+		Build()
+	sim.MustSubmitAndExecuteBlock(env)
+	sim.WaitForTransactionFlow(pending, env.Transaction[0].GetHash())
+
+	// Both of Bob's keys sign
+	for _, key := range [][]byte{bobKey1, bobKey2} {
+		sim.MustSubmitAndExecuteBlock(acctesting.NewTransaction().
+			WithTransaction(env.Transaction[0]).
+			WithSigner(bob.JoinPath("book", "1"), 1).
+			WithTimestampVar(&timestamp).
+			Sign(SignatureTypeED25519, key).
+			Build())
+	}
+	sim.WaitForTransactionFlow(delivered, env.Transaction[0].GetHash())
+
+	// Bob only paid the transaction fee once
+	_ = simulator.GetAccount[*TokenAccount](sim, alice.JoinPath("tokens"))
+	require.Equal(t, uint64(1e9), simulator.GetAccount[*KeyPage](sim, alice.JoinPath("book", "1")).CreditBalance)
+	require.Equal(t, uint64(1e9-FeeCreateAccount-2*FeeSignature), simulator.GetAccount[*KeyPage](sim, bob.JoinPath("book", "1")).CreditBalance)
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"gitlab.com/accumulatenetwork/accumulate/internal/block/simulator"
 	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/indexing"
 	acctesting "gitlab.com/accumulatenetwork/accumulate/internal/testing"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
 	. "gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
 )
 
 func TestWriteData_ToState(t *testing.T) {
@@ -291,3 +293,112 @@ func TestWriteData_LiteFactomEntryHash(t *testing.T) {
 		return nil
 	})
 }
+
+func TestWriteData_Blake3DataAccount(t *testing.T) {
+	var timestamp uint64
+
+	// Initialize
+	sim := simulator.New(t, 3)
+	sim.InitFromGenesis()
+
+	// Setup accounts
+	alice := url.MustParse("alice")
+	aliceKey := acctesting.GenerateKey(alice)
+	sim.CreateIdentity(alice, aliceKey[32:])
+	updateAccount(sim, alice.JoinPath("book", "1"), func(page *KeyPage) { page.CreditBalance = 1e9 })
+	sim.CreateAccount(&TokenAccount{Url: alice.JoinPath("tokens"), TokenUrl: AcmeUrl()})
+
+	// Create a data account that uses BLAKE3
+	sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(
+		acctesting.NewTransaction().
+			WithPrincipal(alice).
+			WithSigner(alice.JoinPath("book", "1"), 1).
+			WithTimestampVar(&timestamp).
+			WithBody(&CreateDataAccount{Url: alice.JoinPath("data"), HashAlgorithm: HashAlgorithmBLAKE3}).
+			Initiate(SignatureTypeED25519, aliceKey).
+			Build(),
+	)...)
+
+	// Only the data chains of a data account can use BLAKE3
+	x := sim.PartitionFor(alice)
+	_ = x.Database.Update(func(batch *database.Batch) error {
+		require.Error(t, batch.Account(alice.JoinPath("data")).SignatureChain().SetHashAlgorithm(HashAlgorithmBLAKE3))
+		require.Error(t, batch.Account(alice.JoinPath("tokens")).MainChain().SetHashAlgorithm(HashAlgorithmBLAKE3))
+		require.Error(t, batch.Account(x.Executor.Describe.Ledger()).RootChain().SetHashAlgorithm(HashAlgorithmBLAKE3))
+		return nil
+	})
+
+	// Write scratch data
+	var envs []*Envelope
+	for _, data := range []string{"foo", "bar", "baz"} {
+		envs = append(envs, acctesting.NewTransaction().
+			WithPrincipal(alice.JoinPath("data")).
+			WithSigner(alice.JoinPath("book", "1"), 1).
+			WithTimestampVar(&timestamp).
+			WithBody(&WriteData{
+				Entry:   &AccumulateDataEntry{Data: [][]byte{[]byte(data)}},
+				Scratch: true,
+			}).
+			Initiate(SignatureTypeED25519, aliceKey).
+			Build())
+	}
+	_, txns := sim.WaitForTransactions(delivered, sim.MustSubmitAndExecuteBlock(envs...)...)
+
+	// The scratch chain is built with BLAKE3
+	_ = x.Database.View(func(batch *database.Batch) error {
+		record := batch.Account(alice.JoinPath("data")).ScratchChain()
+		require.Equal(t, HashAlgorithmBLAKE3, record.HashAlgorithm())
+		chain, err := record.Get()
+		require.NoError(t, err)
+		require.Equal(t, int64(len(txns)), chain.Height())
+
+		expected := new(managed.MerkleState)
+		expected.HashAlgorithm = HashAlgorithmBLAKE3
+		for _, txn := range txns {
+			expected.AddToMerkleTree(txn.GetHash())
+		}
+		require.Equal(t, []byte(expected.GetMDRoot()), chain.Anchor())
+
+		// The main chain also uses BLAKE3 but the signature chain does not
+		require.Equal(t, HashAlgorithmBLAKE3, batch.Account(alice.JoinPath("data")).MainChain().HashAlgorithm())
+		require.Equal(t, HashAlgorithmSHA256, batch.Account(alice.JoinPath("data")).SignatureChain().HashAlgorithm())
+		return nil
+	})
+
+	// The receipt of each transaction proves it with BLAKE3 up to the scratch
+	// chain's anchor, and with SHA-256 from there to the root chain's anchor.
+	// The simulator's clock is too far in the past for the API to return
+	// scratch transactions, so build the receipts the way the API does.
+	for _, txn := range txns {
+		var receipt *managed.Receipt
+		_ = x.Database.View(func(batch *database.Batch) error {
+			entries, err := indexing.TransactionChain(batch, txn.GetHash()).Get()
+			require.NoError(t, err)
+			for _, entry := range entries {
+				if entry.Chain != "scratch" {
+					continue
+				}
+				_, receipt, err = indexing.ReceiptForChainEntry(&x.Executor.Describe, batch, batch.Account(entry.Account), txn.GetHash(), entry)
+				require.NoError(t, err)
+			}
+			require.NotNil(t, receipt, "the transaction is not on the scratch chain")
+			return nil
+		})
+		require.Equal(t, txn.GetHash(), []byte(receipt.Start))
+		require.True(t, receipt.Validate())
+
+		algs := map[HashAlgorithm]bool{}
+		for _, entry := range receipt.Entries {
+			algs[entry.HashAlgorithm] = true
+		}
+		require.True(t, algs[HashAlgorithmBLAKE3], "the receipt does not use BLAKE3")
+		require.True(t, algs[HashAlgorithmSHA256], "the receipt does not use SHA-256")
+
+		// The receipt does not validate with SHA-256 alone
+		forged := receipt.Copy()
+		for _, entry := range forged.Entries {
+			entry.HashAlgorithm = HashAlgorithmSHA256
+		}
+		require.False(t, forged.Validate())
+	}
+}
@@ -76,6 +76,7 @@ func newNode(s *Simulator, p *Partition, node int, init *accumulated.NodeInit) (
 		TxMaxWaitTime: time.Hour,
 		Database:      n,
 		Key:           init.PrivValKey,
+		Simulator:     n.executor,
 	})
 	if err != nil {
 		return nil, errors.Wrap(errors.StatusUnknownError, err)
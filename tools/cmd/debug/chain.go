@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/logging"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+var chainCmd = &cobra.Command{
+	Use:   "chain",
+	Short: "Analyze chains",
+}
+
+var chainVerifyCmd = &cobra.Command{
+	Use:   "verify <database>",
+	Short: "Verify the chains of every account in a Badger database",
+	Long: `Verify the chains of every account in a Badger database.
+
+For every chain of every account, including index chains, verify recomputes
+the Merkle state from the chain's entries and compares it with the stored head,
+mark points, and element indexes. Verify also checks each account's hash
+against the BPT, and checks the anchor of each indexed chain against the
+partition's root chain.
+
+With --fix, verify rebuilds the head, mark points, and element indexes from the
+chain's entries. Each chain is fixed and committed separately. Fixing a head
+changes the account's BPT entry, so a node with a fixed database may disagree
+with the rest of the network.`,
+	Args: cobra.ExactArgs(1),
+	Run:  verifyChains,
+}
+
+var chainVerifyFlag = struct {
+	Fix bool
+}{}
+
+func init() {
+	cmd.AddCommand(chainCmd)
+	chainCmd.AddCommand(chainVerifyCmd)
+	chainVerifyCmd.Flags().BoolVar(&chainVerifyFlag.Fix, "fix", false, "Rebuild records that do not match from the chain entries")
+}
+
+func verifyChains(_ *cobra.Command, args []string) {
+	db, err := database.OpenBadger(args[0], logging.NullLogger{})
+	checkf(err, "open database")
+	defer db.Close()
+
+	// Chains are verified and fixed in their own batches, so the batch that
+	// visits the accounts is only read from
+	batch := db.Begin(false)
+	defer batch.Discard()
+
+	// Find the partition's root chain
+	var ledger *url.URL
+	err = batch.VisitAccounts(func(account *database.Account) error {
+		u := account.Url()
+		partition, ok := protocol.ParsePartitionUrl(u.RootIdentity())
+		if ok && u.Equal(protocol.PartitionUrl(partition).JoinPath(protocol.Ledger)) {
+			ledger = u
+		}
+		return nil
+	})
+	checkf(err, "find the system ledger")
+	var rootChain *database.Chain
+	if ledger == nil {
+		fmt.Println("Cannot find the system ledger, skipping anchor checks")
+	} else {
+		rootChain, err = batch.Account(ledger).RootChain().Get()
+		checkf(err, "load the root chain")
+	}
+
+	var accounts, chains, problems, fixed int
+	err = batch.VisitAccounts(func(account *database.Account) error {
+		accounts++
+		report := func(name string) func(error) {
+			return func(err error) {
+				problems++
+				if name == "" {
+					fmt.Printf("%v: %v\n", account.Url(), err)
+				} else {
+					fmt.Printf("%v#chain/%s: %v\n", account.Url(), name, err)
+				}
+			}
+		}
+
+		// Check the account against the BPT before fixing anything
+		receipt, err := account.BptReceipt()
+		if err != nil {
+			report("")(fmt.Errorf("load BPT entry: %w", err))
+		} else if err = account.VerifyHash(receipt.Start); err != nil {
+			report("")(fmt.Errorf("BPT entry does not match: %w", err))
+		}
+
+		chainMetas, err := account.Chains().Get()
+		if err != nil {
+			return fmt.Errorf("load chains of %v: %w", account.Url(), err)
+		}
+		names := map[string]bool{}
+		for _, meta := range chainMetas {
+			names[meta.Name] = true
+		}
+
+		// An index chain is not always listed, so verify the index chain of
+		// every chain that has one
+		for _, meta := range chainMetas {
+			if meta.Type == managed.ChainTypeIndex || names[meta.Name+"-index"] {
+				continue
+			}
+			chain, err := account.ChainByName(meta.Name)
+			if err != nil {
+				continue // Reported below
+			}
+			index, err := chain.Index().Get()
+			if err != nil {
+				report(meta.Name + "-index")(err)
+			} else if index.Height() > 0 {
+				names[meta.Name+"-index"] = true
+				chainMetas = append(chainMetas, &protocol.ChainMetadata{Name: meta.Name + "-index", Type: managed.ChainTypeIndex})
+			}
+		}
+
+		for _, meta := range chainMetas {
+			chains++
+			chain, err := account.ChainByName(meta.Name)
+			if err != nil {
+				report(meta.Name)(err)
+				continue
+			}
+
+			var anchors map[uint64]uint64
+			if meta.Type != managed.ChainTypeIndex && names[meta.Name+"-index"] {
+				anchors, err = chainAnchors(chain)
+			}
+			if err != nil {
+				report(meta.Name)(err)
+			}
+
+			ok, err := verifyChain(db, account.Url(), meta.Name, func(state *managed.MerkleState) error {
+				index, ok := anchors[uint64(state.Count-1)]
+				if !ok || rootChain == nil {
+					return nil
+				}
+				anchor, err := rootChain.Entry(int64(index))
+				if err != nil {
+					report(meta.Name)(fmt.Errorf("load root chain entry %d: %w", index, err))
+				} else if !bytes.Equal(anchor, state.GetMDRoot()) {
+					report(meta.Name)(fmt.Errorf("anchor of entry %d does not match root chain entry %d", state.Count-1, index))
+				}
+				return nil
+			}, report(meta.Name))
+			if err != nil {
+				report(meta.Name)(err)
+			}
+			if ok {
+				fixed++
+			}
+		}
+		return nil
+	})
+	checkf(err, "verify chains")
+
+	fmt.Printf("Verified %d chains of %d accounts, found %d problems\n", chains, accounts, problems)
+	if fixed > 0 {
+		fmt.Printf("Fixed %d chains\n", fixed)
+	}
+}
+
+// verifyChain verifies a chain of an account and, with --fix, commits the
+// fixes. Each chain is fixed in its own batch so that fixing a large database
+// does not exceed the size limit of a Badger transaction. verifyChain returns
+// true if it fixed the chain.
+func verifyChain(db *database.Database, account *url.URL, name string, visit func(*managed.MerkleState) error, report func(error)) (bool, error) {
+	batch := db.Begin(chainVerifyFlag.Fix)
+	defer batch.Discard()
+
+	chain, err := batch.Account(account).ChainByName(name)
+	if err != nil {
+		return false, err
+	}
+
+	var problems int
+	err = chain.Verify(chainVerifyFlag.Fix, visit, func(err error) {
+		problems++
+		report(err)
+	})
+	if err != nil {
+		return false, err
+	}
+	if !chainVerifyFlag.Fix || problems == 0 {
+		return false, nil
+	}
+
+	err = batch.Commit()
+	if err != nil {
+		return false, fmt.Errorf("commit fixes: %w", err)
+	}
+	return true, nil
+}
+
+// chainAnchors reads the index chain of the chain and returns the root chain
+// index of the anchor for each anchored height.
+func chainAnchors(chain *database.Chain2) (map[uint64]uint64, error) {
+	index, err := chain.Index().Get()
+	if err != nil {
+		return nil, fmt.Errorf("load index chain: %w", err)
+	}
+
+	anchors := map[uint64]uint64{}
+	for i := int64(0); i < index.Height(); i++ {
+		entry := new(protocol.IndexEntry)
+		err = index.EntryAs(i, entry)
+		if err != nil {
+			return nil, fmt.Errorf("load index chain entry %d: %w", i, err)
+		}
+
+		// Only entries of chains anchored into the root chain have a block
+		// index without a block time
+		if entry.BlockIndex > 0 && entry.BlockTime == nil {
+			anchors[entry.Source] = entry.Anchor
+		}
+	}
+	return anchors, nil
+}
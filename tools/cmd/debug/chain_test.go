@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/accumulatenetwork/accumulate/internal/database"
+	"gitlab.com/accumulatenetwork/accumulate/internal/logging"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+	"gitlab.com/accumulatenetwork/accumulate/smt/common"
+	"gitlab.com/accumulatenetwork/accumulate/smt/managed"
+)
+
+func TestVerifyChains(t *testing.T) {
+	dir := t.TempDir()
+	db, err := database.OpenBadger(dir, logging.NullLogger{})
+	require.NoError(t, err)
+
+	// Create an account with a main chain and an index chain
+	u := protocol.AccountUrl("foo")
+	var rh common.RandHash
+	batch := db.Begin(true)
+	account := batch.Account(u)
+	adi := &protocol.ADI{Url: u}
+	adi.AddAuthority(u.JoinPath("book"))
+	require.NoError(t, account.PutState(adi))
+	main, err := account.MainChain().Get()
+	require.NoError(t, err)
+	index, err := account.MainChain().Index().Get()
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, main.AddEntry(rh.Next(), false))
+		data, err := (&protocol.IndexEntry{Source: uint64(i)}).MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, index.AddEntry(data, false))
+	}
+	require.NoError(t, batch.Commit())
+
+	// Corrupt the heads of both chains
+	batch = db.Begin(true)
+	for _, chain := range []*database.Chain2{batch.Account(u).MainChain(), batch.Account(u).MainChain().Index()} {
+		c, err := chain.Get()
+		require.NoError(t, err)
+		head := c.CurrentState().Copy()
+		head.HashList[0] = rh.Next()
+		require.NoError(t, c.RestoreHead(head))
+	}
+
+	// Index chains are not always listed
+	require.NoError(t, batch.Account(u).Chains().Remove(&protocol.ChainMetadata{Name: "main-index"}))
+	require.NoError(t, batch.Commit())
+	require.NoError(t, db.Close())
+
+	verify := func() []error {
+		db, err := database.OpenBadger(dir, logging.NullLogger{})
+		require.NoError(t, err)
+		defer db.Close()
+		batch := db.Begin(false)
+		defer batch.Discard()
+
+		var errs []error
+		for _, chain := range []*database.Chain2{batch.Account(u).MainChain(), batch.Account(u).MainChain().Index()} {
+			require.NoError(t, chain.Verify(false, func(*managed.MerkleState) error { return nil }, func(err error) { errs = append(errs, err) }))
+		}
+		return errs
+	}
+	require.Len(t, verify(), 2)
+
+	// Fixing the database rebuilds both heads
+	chainVerifyFlag.Fix = true
+	defer func() { chainVerifyFlag.Fix = false }()
+	verifyChains(nil, []string{dir})
+	require.Empty(t, verify())
+}
@@ -15,8 +15,9 @@ var (
 )
 
 type TApi struct {
-	Package string
-	Methods []*TMethod
+	Package        string
+	Methods        []*TMethod
+	HasPermissions bool
 }
 
 type TMethod struct {
@@ -38,6 +39,9 @@ func convert(api typegen.API, subPkgName string) *TApi {
 		if tm.Call == "" {
 			tm.Call = name
 		}
+		if tm.Permission != "" {
+			tapi.HasPermissions = true
+		}
 		tapi.Methods = append(tapi.Methods, tm)
 	}
 
@@ -233,6 +233,7 @@ type Method struct {
 	RouteParam   string   `yaml:"route-param"`
 	CallParams   []string `yaml:"call-params"`
 	Validate     []string `yaml:"validate"`
+	Permission   string
 }
 
 // Enum is an enumeration with a set of values.